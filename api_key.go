@@ -17,6 +17,50 @@ var (
 	ErrAPIKeyMaxLimit = errors.New("you can only have a maximum of 15 active api keys")
 )
 
+// APIKeyUsageTrackingInterval limits how often the last used details of a
+// key are written so busy integrations do not cause a write on every request
+const APIKeyUsageTrackingInterval = time.Minute
+
+// apiKeyScopes are the permissions that can be granted to an api key.
+// Managing billing, members and other api keys is left to humans
+var apiKeyScopes = []Permission{
+	PermissionIngestWrite,
+	PermissionWorkspaceRead,
+	PermissionPreferencesRead,
+	PermissionIntegrationsRead,
+	PermissionUpdatesRead,
+	PermissionUpdatesWrite,
+	PermissionUpdatesSend,
+	PermissionContactsRead,
+	PermissionContactsWrite,
+	PermissionDecksRead,
+	PermissionDecksWrite,
+	PermissionDashboardsRead,
+	PermissionDashboardsWrite,
+	PermissionPipelinesRead,
+	PermissionPipelinesWrite,
+	PermissionTeamRead,
+	PermissionAuditLogsRead,
+}
+
+// APIKeyScopes returns every permission that can be granted to an api key
+func APIKeyScopes() []Permission {
+	scopes := make([]Permission, len(apiKeyScopes))
+	copy(scopes, apiKeyScopes)
+	return scopes
+}
+
+// IsValidAPIKeyScope reports if the permission can be granted to an api key
+func IsValidAPIKeyScope(p Permission) bool {
+	for _, scope := range apiKeyScopes {
+		if scope == p {
+			return true
+		}
+	}
+
+	return false
+}
+
 type APIKey struct {
 	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
 	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
@@ -25,6 +69,11 @@ type APIKey struct {
 	Value       string    `json:"-"`
 	KeyName     string    `json:"key_name,omitempty"`
 
+	Scopes []Permission `bun:"type:jsonb" json:"scopes,omitempty"`
+
+	LastUsedAt *time.Time `bun:",nullzero" json:"last_used_at,omitempty"`
+	LastUsedIP string     `bun:",nullzero" json:"last_used_ip,omitempty"`
+
 	ExpiresAt *time.Time `bun:",nullzero" json:"expires_at,omitempty"`
 
 	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
@@ -36,6 +85,42 @@ type APIKey struct {
 
 func (a *APIKey) IsRevoked() bool { return a.ExpiresAt != nil }
 
+// IsExpired reports if a revoked key can no longer be used. Keys can be
+// revoked with a grace period so they keep working until then
+func (a *APIKey) IsExpired() bool {
+	return a.ExpiresAt != nil && a.ExpiresAt.Before(time.Now())
+}
+
+// Can reports if the key has been granted all of the provided permissions
+func (a *APIKey) Can(permissions ...Permission) bool {
+	for _, permission := range permissions {
+		var granted bool
+
+		for _, scope := range a.Scopes {
+			if scope == permission {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ShouldTrackUsage reports if the last used details need to be updated for a
+// request from ip at the given time
+func (a *APIKey) ShouldTrackUsage(ip string, now time.Time) bool {
+	if a.LastUsedAt == nil || a.LastUsedIP != ip {
+		return true
+	}
+
+	return now.Sub(*a.LastUsedAt) >= APIKeyUsageTrackingInterval
+}
+
 // ENUM(immediate,day,week)
 type RevocationType string
 
@@ -55,6 +140,7 @@ type APIKeyRepository interface {
 	List(context.Context, uuid.UUID) ([]APIKey, error)
 	Fetch(context.Context, FetchAPIKeyOptions) (*APIKey, error)
 	FetchByValue(context.Context, string) (*APIKey, error)
+	UpdateLastUsed(context.Context, *APIKey) error
 }
 
 func HashKey(secret, val string) string {
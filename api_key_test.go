@@ -0,0 +1,59 @@
+package malak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey_Can(t *testing.T) {
+
+	key := &APIKey{
+		Scopes: []Permission{
+			PermissionContactsRead,
+			PermissionDecksRead,
+		},
+	}
+
+	require.True(t, key.Can(PermissionContactsRead))
+	require.True(t, key.Can(PermissionContactsRead, PermissionDecksRead))
+	require.False(t, key.Can(PermissionContactsWrite))
+	require.False(t, key.Can(PermissionContactsRead, PermissionUpdatesWrite))
+
+	require.False(t, (&APIKey{}).Can(PermissionIngestWrite))
+}
+
+func TestIsValidAPIKeyScope(t *testing.T) {
+	require.True(t, IsValidAPIKeyScope(PermissionIngestWrite))
+	require.True(t, IsValidAPIKeyScope(PermissionUpdatesWrite))
+	require.False(t, IsValidAPIKeyScope(PermissionAPIKeysManage))
+	require.False(t, IsValidAPIKeyScope(PermissionBillingManage))
+	require.False(t, IsValidAPIKeyScope(PermissionTeamManage))
+	require.False(t, IsValidAPIKeyScope(Permission("oops:write")))
+}
+
+func TestAPIKey_IsExpired(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	require.False(t, (&APIKey{}).IsExpired())
+	require.False(t, (&APIKey{ExpiresAt: &future}).IsExpired())
+	require.True(t, (&APIKey{ExpiresAt: &past}).IsExpired())
+}
+
+func TestAPIKey_ShouldTrackUsage(t *testing.T) {
+	now := time.Now()
+	recently := now.Add(-time.Second * 10)
+
+	require.True(t, (&APIKey{}).ShouldTrackUsage("10.0.0.1", now))
+
+	key := &APIKey{
+		LastUsedAt: &recently,
+		LastUsedIP: "10.0.0.1",
+	}
+
+	require.False(t, key.ShouldTrackUsage("10.0.0.1", now))
+	require.True(t, key.ShouldTrackUsage("10.0.0.2", now))
+	require.True(t, key.ShouldTrackUsage("10.0.0.1", now.Add(APIKeyUsageTrackingInterval)))
+}
@@ -0,0 +1,73 @@
+package malak
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ENUM(user,api_key)
+type AuditLogActorType string
+
+// ENUM(
+// api_key_created,api_key_revoked,
+// deck_deleted,deck_preferences_updated,
+// update_sent,
+// dashboard_link_generated,dashboard_link_revoked,
+// team_member_invited,team_member_role_updated,team_member_removed,
+// webhook_created,webhook_updated,webhook_deleted,
+// suppression_deleted,
+// update_link_generated,update_link_revoked,update_visibility_updated,
+// recurring_schedule_created,
+// update_rescheduled,update_schedule_cancelled,
+// workspace_template_created,workspace_template_updated,workspace_template_deleted,
+// update_revision_restored,
+// update_review_requested,update_approved,update_changes_requested,
+// sending_domain_created,sending_domain_verified,sending_domain_deleted)
+type AuditLogAction string
+
+// AuditLogMetadata holds extra details about the action. e.g the
+// revocation strategy of an api key
+type AuditLogMetadata map[string]string
+
+// AuditLog is an append only record of security and data relevant
+// actions carried out within a workspace
+type AuditLog struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+
+	ActorType AuditLogActorType `json:"actor_type"`
+	// ActorID is either the id of the user or the api key
+	ActorID uuid.UUID `json:"actor_id"`
+
+	Action          AuditLogAction   `json:"action"`
+	EntityType      EntityType       `json:"entity_type"`
+	EntityReference string           `json:"entity_reference"`
+	Metadata        AuditLogMetadata `bun:"type:jsonb" json:"metadata"`
+
+	IPAddress string `json:"ip_address"`
+	RequestID string `json:"request_id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+
+	bun.BaseModel `bun:"table:audit_logs" json:"-"`
+}
+
+type ListAuditLogOptions struct {
+	Paginator   Paginator
+	WorkspaceID uuid.UUID
+
+	// optional filters
+	ActorID    uuid.UUID
+	ActorType  AuditLogActorType
+	EntityType EntityType
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditLogRepository interface {
+	Create(context.Context, *AuditLog) error
+	List(context.Context, ListAuditLogOptions) ([]AuditLog, int64, error)
+}
@@ -2,6 +2,8 @@ package malak
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/ayinke-llc/hermes"
@@ -34,3 +36,61 @@ func NewEmailVerification(u *User) (*EmailVerification, error) {
 type EmailVerificationRepository interface {
 	Create(context.Context, *EmailVerification) error
 }
+
+var (
+	ErrPasswordResetNotFound = MalakError("password reset token not found")
+)
+
+// How long a password reset link stays valid for
+const PasswordResetExpiration = time.Hour
+
+// HashToken returns the value single use tokens sent by email are stored
+// as. A leaked database row cannot be used to take over the account
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type PasswordReset struct {
+	// Token is only available when the reset is created. Just the hash is
+	// stored
+	Token     string    `bun:"-" json:"-"`
+	TokenHash string    `json:"-"`
+	ID        uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+
+	bun.BaseModel `bun:"table:password_resets" json:"-"`
+}
+
+func NewPasswordReset(u *User) (*PasswordReset, error) {
+	val, err := hermes.Random(40)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordReset{
+		Token:     val,
+		TokenHash: HashToken(val),
+		UserID:    u.ID,
+		ExpiresAt: time.Now().Add(PasswordResetExpiration),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (p *PasswordReset) IsExpired() bool { return time.Now().After(p.ExpiresAt) }
+
+type ResetPasswordOptions struct {
+	Reset *PasswordReset
+	// already hashed
+	Password string
+}
+
+type PasswordResetRepository interface {
+	Create(context.Context, *PasswordReset) error
+	Get(context.Context, string) (*PasswordReset, error)
+	// Reset updates the user's password and removes the token so it
+	// cannot be used again
+	Reset(context.Context, *ResetPasswordOptions) error
+}
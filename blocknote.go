@@ -3,6 +3,7 @@ package malak
 import (
 	"fmt"
 	"html"
+	"net/url"
 	"strings"
 
 	"github.com/google/uuid"
@@ -118,6 +119,22 @@ type Link struct {
 
 func (Link) inlineContent() {}
 
+// ENUM(latest,mom_delta)
+type InlineMetricValue string
+
+// InlineMetric is a value of an integration chart written in the text of a
+// block. It is resolved when the update is rendered so sent updates carry
+// the numbers at send time
+type InlineMetric struct {
+	Type  string `json:"type"`
+	Props struct {
+		ChartReference string            `json:"chartReference"`
+		Value          InlineMetricValue `json:"value"`
+	} `json:"props"`
+}
+
+func (InlineMetric) inlineContent() {}
+
 type Styles struct {
 	Bold            bool   `json:"bold"`
 	Italic          bool   `json:"italic"`
@@ -129,22 +146,28 @@ type Styles struct {
 
 type BlockContents []Block
 
-func (bc BlockContents) HTML(workspaceID uuid.UUID, renderer ChartRenderer) string {
+// LinkRewriter changes the href of links before they are written to the
+// html. It is used to route clicks through the api so they can be tracked
+type LinkRewriter func(href string) string
+
+// HTML converts the blocks to html. rewriter can be nil if links should be
+// left as is
+func (bc BlockContents) HTML(workspaceID uuid.UUID, renderer ChartRenderer, rewriter LinkRewriter) string {
 	var html strings.Builder
 	for _, block := range bc {
-		html.WriteString(convertBlockToHTML(block, workspaceID, renderer))
+		html.WriteString(convertBlockToHTML(block, workspaceID, renderer, rewriter))
 	}
 	return html.String()
 }
 
-func convertBlockToHTML(block Block, workspaceID uuid.UUID, renderer ChartRenderer) string {
+func convertBlockToHTML(block Block, workspaceID uuid.UUID, renderer ChartRenderer, rewriter LinkRewriter) string {
 	var s strings.Builder
 	style := getStyleString(block.Props)
 
 	switch block.Type {
 	case "heading":
 		level, _ := block.Props["level"].(float64)
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf("<h%d style='%s'>%s</h%d>", int(level), style, content, int(level)))
 	case "paragraph":
 		extra := []string{
@@ -152,15 +175,15 @@ func convertBlockToHTML(block Block, workspaceID uuid.UUID, renderer ChartRender
 			"line-height: 24px;",
 			"margin: 16px;",
 		}
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf("<p style='%s'>%s</p>", strings.Join(append(extra, style), " "), content))
 	case "alert":
 		alertType, _ := block.Props["type"].(string)
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf(`<div class="alert" data-alert-type="%s">%s</div>`, alertType, content))
 	case "dashboard":
 		selectedItem, _ := block.Props["selectedItem"].(string)
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf(`<div class="dashboard" data-selected-item="%s">%s</div>`, selectedItem, content))
 	case "chart":
 		selectedChart, _ := block.Props["selectedChart"].(string)
@@ -176,14 +199,14 @@ func convertBlockToHTML(block Block, workspaceID uuid.UUID, renderer ChartRender
 			s.WriteString(fmt.Sprintf(`<a href='%s' target="_blank"><img src='%s' alt='%s' style="display: block; width: %s; max-width: 600px; height: auto;"></a>`, chartKey, chartKey, "Chart image", "100%"))
 		}
 	case "numberedListItem", "bulletListItem":
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf("<li style='%s'>%s</li>", style, content))
 	case "checkListItem":
 		checked := ""
 		if isChecked, ok := block.Props["checked"].(bool); ok && isChecked {
 			checked = " checked"
 		}
-		content := getSimpleContent(block.Content)
+		content := getSimpleContent(block.Content, workspaceID, renderer, rewriter)
 		s.WriteString(fmt.Sprintf("<li style='%s'><input type='checkbox'%s>%s</li>", style, checked, content))
 	case "image":
 		url, _ := block.Props["url"].(string)
@@ -201,20 +224,30 @@ func convertBlockToHTML(block Block, workspaceID uuid.UUID, renderer ChartRender
 
 	if len(block.Children) > 0 {
 		for _, child := range block.Children {
-			s.WriteString(convertBlockToHTML(child, workspaceID, renderer))
+			s.WriteString(convertBlockToHTML(child, workspaceID, renderer, rewriter))
 		}
 	}
 
 	return s.String()
 }
 
-func getSimpleContent(content interface{}) string {
+func getSimpleContent(content interface{}, workspaceID uuid.UUID,
+	renderer ChartRenderer, rewriter LinkRewriter) string {
 	var result strings.Builder
 
 	switch v := content.(type) {
 	case []interface{}:
 		for _, item := range v {
 			if m, ok := item.(map[string]interface{}); ok {
+				switch t, _ := m["type"].(string); t {
+				case "link":
+					result.WriteString(getLinkContent(m, workspaceID, renderer, rewriter))
+					continue
+				case "metric":
+					result.WriteString(getMetricContent(m, workspaceID, renderer))
+					continue
+				}
+
 				text, _ := m["text"].(string)
 				styles, _ := m["styles"].(map[string]interface{})
 				result.WriteString(applyInlineStyles(text, styles))
@@ -228,6 +261,61 @@ func getSimpleContent(content interface{}) string {
 	return result.String()
 }
 
+func getLinkContent(link map[string]interface{}, workspaceID uuid.UUID,
+	renderer ChartRenderer, rewriter LinkRewriter) string {
+	href, _ := link["href"].(string)
+	text := getSimpleContent(link["content"], workspaceID, renderer, nil)
+
+	if text == "" {
+		text = html.EscapeString(href)
+	}
+
+	if rewriter != nil && isTrackableLink(href) {
+		href = rewriter(href)
+	}
+
+	return fmt.Sprintf(`<a href='%s' target="_blank" style='color: #556cd6;'>%s</a>`, html.EscapeString(href), text)
+}
+
+func inlineMetricProps(metric map[string]interface{}) (string, InlineMetricValue) {
+	props, _ := metric["props"].(map[string]interface{})
+	chartReference, _ := props["chartReference"].(string)
+	value, _ := props["value"].(string)
+
+	metricValue, err := ParseInlineMetricValue(value)
+	if err != nil {
+		metricValue = InlineMetricValueLatest
+	}
+
+	return chartReference, metricValue
+}
+
+func getMetricContent(metric map[string]interface{}, workspaceID uuid.UUID, renderer ChartRenderer) string {
+	chartReference, metricValue := inlineMetricProps(metric)
+
+	if chartReference == "" || renderer == nil {
+		return `<span class="metric-error">N/A</span>`
+	}
+
+	text, err := renderer.RenderMetric(workspaceID, chartReference, metricValue)
+	if err != nil || text == "" {
+		return `<span class="metric-error">N/A</span>`
+	}
+
+	return fmt.Sprintf("<span class='metric' style='font-weight: bold;'>%s</span>", html.EscapeString(text))
+}
+
+// isTrackableLink reports if the link opens a web page. mailto and other
+// schemes cannot go through a redirect
+func isTrackableLink(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
 func applyInlineStyles(text string, styles map[string]interface{}) string {
 	if len(styles) == 0 {
 		return html.EscapeString(text)
@@ -393,4 +481,92 @@ func sanitizeTableContent(table *TableContent, policy *bluemonday.Policy) {
 
 type ChartRenderer interface {
 	RenderChart(workspaceID uuid.UUID, chartID string) (string, error)
+	// RenderMetric formats the data of a chart as text for inline metrics
+	RenderMetric(workspaceID uuid.UUID, chartReference string, value InlineMetricValue) (string, error)
+}
+
+// ResolvedMetrics is the text of the inline metrics of an update at the time
+// it was sent. It is keyed by the chart reference and the metric value
+type ResolvedMetrics map[string]string
+
+func resolvedMetricKey(chartReference string, value InlineMetricValue) string {
+	return chartReference + ":" + value.String()
+}
+
+// ResolveMetrics renders the inline metrics of the blocks with the current
+// data of their charts. Metrics that cannot be rendered are left out
+func (bc BlockContents) ResolveMetrics(workspaceID uuid.UUID, renderer ChartRenderer) ResolvedMetrics {
+	metrics := make(ResolvedMetrics)
+
+	if renderer == nil {
+		return metrics
+	}
+
+	var walk func(content interface{})
+	walk = func(content interface{}) {
+		switch v := content.(type) {
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case []map[string]interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]interface{}:
+			if t, _ := v["type"].(string); t == "metric" {
+				chartReference, value := inlineMetricProps(v)
+				key := resolvedMetricKey(chartReference, value)
+
+				if _, ok := metrics[key]; ok || chartReference == "" {
+					return
+				}
+
+				text, err := renderer.RenderMetric(workspaceID, chartReference, value)
+				if err == nil && text != "" {
+					metrics[key] = text
+				}
+
+				return
+			}
+
+			for _, key := range []string{"content", "rows", "cells"} {
+				walk(v[key])
+			}
+		}
+	}
+
+	var walkBlocks func(blocks []Block)
+	walkBlocks = func(blocks []Block) {
+		for _, block := range blocks {
+			walk(block.Content)
+			walkBlocks(block.Children)
+		}
+	}
+
+	walkBlocks(bc)
+
+	return metrics
+}
+
+type frozenMetricsRenderer struct {
+	ChartRenderer
+	metrics ResolvedMetrics
+}
+
+func (f frozenMetricsRenderer) RenderMetric(_ uuid.UUID, chartReference string,
+	value InlineMetricValue) (string, error) {
+	return f.metrics[resolvedMetricKey(chartReference, value)], nil
+}
+
+// FrozenMetricsRenderer renders inline metrics from the given resolved
+// metrics instead of the current chart data. Charts are still rendered by
+// renderer. Metrics resolved before this was added are nil so renderer is
+// returned as is
+func FrozenMetricsRenderer(renderer ChartRenderer, metrics ResolvedMetrics) ChartRenderer {
+	if metrics == nil {
+		return renderer
+	}
+
+	return frozenMetricsRenderer{ChartRenderer: renderer, metrics: metrics}
 }
@@ -1,8 +1,12 @@
 package malak
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,11 +54,28 @@ func TestGetSimpleContent(t *testing.T) {
 			content:  "Plain Text",
 			expected: "Plain Text",
 		},
+		{
+			name: "Link",
+			content: []interface{}{
+				map[string]interface{}{
+					"type": "link",
+					"href": "https://malak.vc?a=b&c=d",
+					"content": []interface{}{
+						map[string]interface{}{
+							"type":   "text",
+							"text":   "Malak",
+							"styles": map[string]interface{}{},
+						},
+					},
+				},
+			},
+			expected: `<a href='https://malak.vc?a=b&amp;c=d' target="_blank" style='color: #556cd6;'>Malak</a>`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getSimpleContent(tt.content)
+			result := getSimpleContent(tt.content, uuid.Nil, nil, nil)
 			require.Equal(t, tt.expected, result)
 		})
 	}
@@ -138,3 +159,201 @@ func TestGetStyleString(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockContents_HTMLRewritesLinks(t *testing.T) {
+	newLink := func(href string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "link",
+			"href": href,
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": "link"},
+			},
+		}
+	}
+
+	blocks := BlockContents{
+		{
+			Type: "paragraph",
+			Content: []interface{}{
+				newLink("https://malak.vc"),
+				newLink("mailto:lanre@malak.vc"),
+			},
+		},
+	}
+
+	var rewritten []string
+
+	html := blocks.HTML(uuid.Nil, nil, func(href string) string {
+		rewritten = append(rewritten, href)
+		return "https://api.malak.vc/updates/click"
+	})
+
+	require.Equal(t, []string{"https://malak.vc"}, rewritten)
+	require.Contains(t, html, "href='https://api.malak.vc/updates/click'")
+	require.Contains(t, html, "href='mailto:lanre@malak.vc'")
+}
+
+type metricRenderer struct{}
+
+func (metricRenderer) RenderChart(uuid.UUID, string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (metricRenderer) RenderMetric(_ uuid.UUID, chartReference string,
+	value InlineMetricValue) (string, error) {
+	if chartReference != "chart_mrr" {
+		return "", errors.New("chart not found")
+	}
+
+	return chartReference + ":" + value.String(), nil
+}
+
+func TestBlockContents_HTMLResolvesMetrics(t *testing.T) {
+	newMetric := func(chartReference, value string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "metric",
+			"props": map[string]interface{}{
+				"chartReference": chartReference,
+				"value":          value,
+			},
+		}
+	}
+
+	blocks := BlockContents{
+		{
+			Type: "paragraph",
+			Content: []interface{}{
+				map[string]interface{}{"type": "text", "text": "MRR: "},
+				newMetric("chart_mrr", "latest"),
+				map[string]interface{}{"type": "text", "text": " change: "},
+				newMetric("chart_mrr", "mom_delta"),
+				newMetric("chart_mrr", "unknown"),
+				newMetric("chart_cash", "latest"),
+			},
+		},
+	}
+
+	html := blocks.HTML(uuid.Nil, metricRenderer{}, nil)
+
+	require.Contains(t, html, "MRR: <span class='metric' style='font-weight: bold;'>chart_mrr:latest</span>")
+	require.Contains(t, html, " change: <span class='metric' style='font-weight: bold;'>chart_mrr:mom_delta</span>")
+	require.Equal(t, 2, strings.Count(html, "chart_mrr:latest"))
+	require.Contains(t, html, `<span class="metric-error">N/A</span>`)
+}
+
+func TestBlockContents_ResolveMetrics(t *testing.T) {
+	blocks := BlockContents{
+		{
+			Type: "paragraph",
+			Content: []interface{}{
+				map[string]interface{}{
+					"type": "metric",
+					"props": map[string]interface{}{
+						"chartReference": "chart_mrr",
+						"value":          "latest",
+					},
+				},
+			},
+			Children: []Block{
+				{
+					Type: "paragraph",
+					Content: []interface{}{
+						map[string]interface{}{
+							"type": "metric",
+							"props": map[string]interface{}{
+								"chartReference": "chart_cash",
+								"value":          "latest",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	metrics := blocks.ResolveMetrics(uuid.Nil, metricRenderer{})
+	require.Equal(t, ResolvedMetrics{"chart_mrr:latest": "chart_mrr:latest"}, metrics)
+
+	// the chart data changed after the update was sent
+	metrics["chart_mrr:latest"] = "$1,000.00"
+
+	html := blocks.HTML(uuid.Nil, FrozenMetricsRenderer(metricRenderer{}, metrics), nil)
+	require.Contains(t, html, "$1,000.00")
+	require.NotContains(t, html, "chart_mrr:latest")
+	require.Contains(t, html, `<span class="metric-error">N/A</span>`)
+
+	require.Equal(t, metricRenderer{}, FrozenMetricsRenderer(metricRenderer{}, nil))
+}
+
+func TestIntegrationChart_FormatMetric(t *testing.T) {
+	now := time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC)
+
+	points := []IntegrationDataPoint{
+		{PointValue: 800000, CreatedAt: now.AddDate(0, -2, 0)},
+		{PointValue: 1000000, CreatedAt: now.AddDate(0, -1, -1)},
+		{PointValue: 1100000, CreatedAt: now.AddDate(0, 0, -10)},
+		{PointValue: 1250050, CreatedAt: now},
+	}
+
+	currency := IntegrationChart{DataPointType: IntegrationDataPointTypeCurrency}
+	others := IntegrationChart{DataPointType: IntegrationDataPointTypeOthers}
+
+	tests := []struct {
+		name     string
+		chart    IntegrationChart
+		points   []IntegrationDataPoint
+		value    InlineMetricValue
+		expected string
+	}{
+		{
+			name:     "no data points",
+			chart:    currency,
+			value:    InlineMetricValueLatest,
+			expected: "",
+		},
+		{
+			name:     "latest currency",
+			chart:    currency,
+			points:   points,
+			value:    InlineMetricValueLatest,
+			expected: "$12,500.50",
+		},
+		{
+			name:     "latest count",
+			chart:    others,
+			points:   points,
+			value:    InlineMetricValueLatest,
+			expected: "1,250,050",
+		},
+		{
+			name:     "month over month growth",
+			chart:    currency,
+			points:   points,
+			value:    InlineMetricValueMomDelta,
+			expected: "+$2,500.50 (+25.0%)",
+		},
+		{
+			name:  "month over month decline",
+			chart: others,
+			points: []IntegrationDataPoint{
+				{PointValue: 200, CreatedAt: now.AddDate(0, -1, 0)},
+				{PointValue: 150, CreatedAt: now},
+			},
+			value:    InlineMetricValueMomDelta,
+			expected: "-50 (-25.0%)",
+		},
+		{
+			name:     "no data a month before",
+			chart:    currency,
+			points:   points[2:],
+			value:    InlineMetricValueMomDelta,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.chart.FormatMetric(tt.points, tt.value))
+		})
+	}
+}
@@ -15,6 +15,8 @@ func addCronCommand(c *cobra.Command, cfg *config.Config) {
 	cmd.AddCommand(processDeckAnalytics(c, cfg))
 	cmd.AddCommand(syncDataPointForIntegration(c, cfg))
 	cmd.AddCommand(revokeAPIKeys(c, cfg))
+	cmd.AddCommand(deliverWebhooks(c, cfg))
+	cmd.AddCommand(sendRecurringUpdates(c, cfg))
 
 	c.AddCommand(cmd)
 }
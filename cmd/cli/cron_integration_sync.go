@@ -12,6 +12,7 @@ import (
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/datastore/postgres"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
 	"github.com/spf13/cobra"
 	"github.com/uptrace/bun"
 	"go.uber.org/zap"
@@ -39,6 +40,7 @@ func syncDataPointForIntegration(_ *cobra.Command, cfg *config.Config) *cobra.Co
 			logger.Debug("syncing datapoints")
 
 			integrationRepo := postgres.NewIntegrationRepo(db)
+			webhookRepo := postgres.NewWebhookRepository(db)
 
 			integrationManager, err := buildIntegrationManager(integrationRepo, hermes.DeRef(cfg), logger)
 			if err != nil {
@@ -67,7 +69,7 @@ func syncDataPointForIntegration(_ *cobra.Command, cfg *config.Config) *cobra.Co
 
 			for w := 1; w <= numWorkers; w++ {
 				wg.Add(1)
-				go worker(cmd.Context(), w, jobs, &wg, logger, integrationRepo, db)
+				go worker(cmd.Context(), w, jobs, &wg, logger, integrationRepo, webhookRepo, db)
 			}
 
 			for _, workspace := range workspaces {
@@ -202,6 +204,7 @@ func worker(
 	wg *sync.WaitGroup,
 	logger *zap.Logger,
 	integrationRepo malak.IntegrationRepository,
+	webhookRepo malak.WebhookRepository,
 	db *bun.DB,
 ) {
 	defer wg.Done()
@@ -258,6 +261,8 @@ func worker(
 			if updateErr != nil {
 				logger.Error("failed to update checkpoint", zap.Error(updateErr))
 			}
+
+			dispatchIntegrationSyncFailed(ctx, logger, webhookRepo, generator, job, err)
 			continue
 		}
 
@@ -287,6 +292,8 @@ func worker(
 			if updateErr != nil {
 				logger.Error("failed to update checkpoint", zap.Error(updateErr))
 			}
+
+			dispatchIntegrationSyncFailed(ctx, logger, webhookRepo, generator, job, err)
 			continue
 		}
 
@@ -304,3 +311,27 @@ func worker(
 		}
 	}
 }
+
+// dispatchIntegrationSyncFailed only stores the deliveries. They are due
+// immediately so the webhooks cron sends them on its next run
+func dispatchIntegrationSyncFailed(ctx context.Context,
+	logger *zap.Logger,
+	webhookRepo malak.WebhookRepository,
+	generator malak.ReferenceGeneratorOperation,
+	job syncJob,
+	syncErr error) {
+
+	_, err := webhook.Dispatch(ctx, webhookRepo, generator, job.workspace.ID,
+		malak.WebhookEventIntegrationSyncFailed,
+		malak.WebhookIntegrationSyncFailedData{
+			IntegrationReference: job.integration.Reference,
+			IntegrationName:      job.integration.Integration.IntegrationName,
+			Error:                syncErr.Error(),
+		})
+	if err != nil {
+		logger.Error("could not dispatch integration sync failed webhook",
+			zap.String("workspace_id", job.workspace.ID.String()),
+			zap.String("integration_id", job.integration.ID.String()),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/datastore/postgres"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type recurringUpdateProcessor struct {
+	cfg                *config.Config
+	logger             *zap.Logger
+	emailClient        email.Client
+	recurringRepo      malak.RecurringUpdateScheduleRepository
+	templateRepo       malak.TemplateRepository
+	updateRepo         malak.UpdateRepository
+	workspaceRepo      malak.WorkspaceRepository
+	userRepo           malak.UserRepository
+	integrationRepo    malak.IntegrationRepository
+	preferenceRepo     malak.PreferenceRepository
+	referenceGenerator malak.ReferenceGeneratorOperation
+}
+
+func sendRecurringUpdates(_ *cobra.Command, cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "recurring-updates",
+		Short: `Create drafts for upcoming recurring updates and schedule the ones that are due`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger, err := getLogger(hermes.DeRef(cfg))
+			if err != nil {
+				return err
+			}
+
+			logger = logger.With(zap.String("component", "recurring-updates"))
+
+			emailClient, err := getEmailProvider(hermes.DeRef(cfg))
+			if err != nil {
+				logger.Error("could not set up email client", zap.Error(err))
+				return err
+			}
+
+			defer emailClient.Close()
+
+			db, err := postgres.New(cfg, logger)
+			if err != nil {
+				logger.Error("could not connect to postgres database",
+					zap.Error(err))
+				return err
+			}
+
+			defer db.Close()
+
+			ctx := cmd.Context()
+
+			processor := &recurringUpdateProcessor{
+				cfg:                cfg,
+				logger:             logger,
+				emailClient:        emailClient,
+				recurringRepo:      postgres.NewRecurringUpdateScheduleRepository(db),
+				templateRepo:       postgres.NewTemplateRepository(db),
+				updateRepo:         postgres.NewUpdatesRepository(db),
+				workspaceRepo:      postgres.NewWorkspaceRepository(db),
+				userRepo:           postgres.NewUserRepository(db),
+				integrationRepo:    postgres.NewIntegrationRepo(db),
+				preferenceRepo:     postgres.NewPreferenceRepository(db),
+				referenceGenerator: malak.NewReferenceGenerator(),
+			}
+
+			now := time.Now()
+
+			schedules, err := processor.recurringRepo.Due(ctx, now)
+			if err != nil {
+				logger.Error("could not fetch due recurring schedules", zap.Error(err))
+				return err
+			}
+
+			logger.Info("processing recurring schedules",
+				zap.Int("schedules_count", len(schedules)))
+
+			for _, schedule := range schedules {
+				if err := processor.process(ctx, &schedule, now); err != nil {
+					logger.Error("could not process recurring schedule",
+						zap.String("schedule_id", schedule.ID.String()),
+						zap.Error(err))
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// process creates the draft of the upcoming occurrence if it does not exist
+// yet. Once the send time is reached, the draft is scheduled to the
+// recipients of the recurring schedule
+func (p *recurringUpdateProcessor) process(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule, now time.Time) error {
+
+	if schedule.DraftUpdateID == uuid.Nil {
+		return p.createDraft(ctx, schedule)
+	}
+
+	if now.Before(schedule.NextSendAt) {
+		// the owner still has time to review the draft
+		return nil
+	}
+
+	update, err := p.updateRepo.GetByID(ctx, schedule.DraftUpdateID)
+	if err != nil && !errors.Is(err, malak.ErrUpdateNotFound) {
+		return err
+	}
+
+	// the draft could have been deleted or sent manually
+	if err == nil && !update.IsSent() {
+		if err := p.scheduleDraft(ctx, schedule, update); err != nil {
+			return err
+		}
+	}
+
+	schedule.Advance(now)
+
+	return p.recurringRepo.Update(ctx, schedule)
+}
+
+func (p *recurringUpdateProcessor) createDraft(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule) error {
+
+	var title string
+	var content malak.BlockContents
+
+	if schedule.IsSystemTemplate {
+		tmpl, err := p.templateRepo.Get(ctx, schedule.TemplateReference)
+		if err != nil {
+			return fmt.Errorf("could not fetch template: %w", err)
+		}
+
+		title, content = tmpl.Title, tmpl.Content
+	} else {
+		tmpl, err := p.templateRepo.GetWorkspaceTemplate(ctx, malak.FetchWorkspaceTemplateOptions{
+			WorkspaceID: schedule.WorkspaceID,
+			Reference:   schedule.TemplateReference,
+		})
+		if err != nil {
+			return fmt.Errorf("could not fetch template: %w", err)
+		}
+
+		title, content = tmpl.Title, tmpl.Content
+	}
+
+	workspace, err := p.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: schedule.WorkspaceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics, err := malak.LoadTemplateMetrics(ctx, p.integrationRepo, schedule.WorkspaceID, content)
+	if err != nil {
+		return fmt.Errorf("could not load template metrics: %w", err)
+	}
+
+	update := &malak.Update{
+		WorkspaceID: schedule.WorkspaceID,
+		CreatedBy:   schedule.CreatedBy,
+		Content: content.ReplaceVariables(malak.TemplateVariables{
+			WorkspaceName: workspace.WorkspaceName,
+			Date:          schedule.NextSendAt,
+			Metrics:       metrics,
+		}),
+		Reference: p.referenceGenerator.Generate(malak.EntityTypeUpdate),
+		Status:    malak.UpdateStatusDraft,
+		Metadata:  malak.UpdateMetadata{},
+		Title:     title + " - " + schedule.NextSendAt.Format("January 2006"),
+	}
+
+	if err := p.updateRepo.Create(ctx, update, &malak.TemplateCreateUpdateOptions{
+		IsFromTemplate:   true,
+		IsSystemTemplate: schedule.IsSystemTemplate,
+		Reference:        schedule.TemplateReference,
+	}); err != nil {
+		return fmt.Errorf("could not create draft: %w", err)
+	}
+
+	schedule.DraftUpdateID = update.ID
+
+	if err := p.recurringRepo.Update(ctx, schedule); err != nil {
+		return err
+	}
+
+	// the draft exists already. A failed reminder should not create
+	// another draft on the next run
+	if err := p.sendReminder(ctx, schedule, update); err != nil {
+		p.logger.Error("could not send review reminder",
+			zap.String("schedule_id", schedule.ID.String()),
+			zap.Error(err))
+	}
+
+	return nil
+}
+
+func (p *recurringUpdateProcessor) scheduleDraft(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule, update *malak.Update) error {
+
+	workspace, err := p.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: schedule.WorkspaceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	preferences, err := p.preferenceRepo.Get(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	// the occurrence is skipped rather than retried so the next one
+	// still gets its own draft
+	if !preferences.Approval.CanSend(update) {
+		p.logger.Warn("skipping recurring update that was not approved",
+			zap.String("schedule_id", schedule.ID.String()),
+			zap.String("update_id", update.ID.String()))
+		return nil
+	}
+
+	updateSchedule := &malak.UpdateSchedule{
+		Reference:   p.referenceGenerator.Generate(malak.EntityTypeSchedule),
+		SendAt:      schedule.NextSendAt,
+		UpdateType:  malak.UpdateTypeLive,
+		ScheduledBy: schedule.CreatedBy,
+		Status:      malak.UpdateSendScheduleScheduled,
+		UpdateID:    update.ID,
+		CreatedAt:   time.Now(),
+	}
+
+	return p.updateRepo.SendUpdate(ctx, &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return p.referenceGenerator.Generate(et).String()
+		},
+		Emails:          schedule.Emails,
+		WorkspaceID:     schedule.WorkspaceID,
+		Schedule:        updateSchedule,
+		Generator:       p.referenceGenerator,
+		UserID:          schedule.CreatedBy,
+		UpdateReference: update.Reference,
+		Plan:            workspace.Plan,
+	})
+}
+
+func (p *recurringUpdateProcessor) sendReminder(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule, update *malak.Update) error {
+
+	user, err := p.userRepo.Get(ctx, &malak.FindUserOptions{
+		ID: schedule.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspace, err := p.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: schedule.WorkspaceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("template").Parse(email.UpdateReviewReminderTemplate)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"WorkspaceName": html.EscapeString(workspace.WorkspaceName),
+		"UpdateTitle":   html.EscapeString(update.Title),
+		"Recipients":    strconv.Itoa(len(schedule.Emails)),
+		"SendAt":        schedule.NextSendAt.Format("Monday, January 2 2006 at 15:04 MST"),
+		"Link":          strings.TrimSuffix(p.cfg.Frontend.AppURL, "/") + "/updates/" + update.Reference.String(),
+	}); err != nil {
+		return err
+	}
+
+	_, err = p.emailClient.Send(ctx, email.SendOptions{
+		HTML:      buf.String(),
+		Sender:    p.cfg.Email.Sender,
+		Recipient: user.Email,
+		Subject:   "Review your update before it is sent: " + update.Title,
+		DKIM: struct {
+			Sign       bool
+			PrivateKey []byte
+		}{
+			Sign:       false,
+			PrivateKey: []byte(""),
+		},
+	})
+	return err
+}
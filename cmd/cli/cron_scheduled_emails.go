@@ -7,8 +7,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 	"text/template"
 	"time"
@@ -24,6 +28,7 @@ import (
 	"github.com/ayinke-llc/malak/internal/datastore/postgres"
 	"github.com/ayinke-llc/malak/internal/pkg/chart"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/ayinke-llc/malak/internal/secret"
 	"github.com/ayinke-llc/malak/server"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -81,19 +86,36 @@ type EmailJob struct {
 	RetryCount  int
 	LastError   error
 	LastAttempt time.Time
+
+	// Links are the original urls of the tracked link placeholders in
+	// Content. The index of the link is used in the placeholder
+	Links []string
+
+	Sender updateSender
+}
+
+// updateSender is the address an update is sent from. The DKIM key is only
+// set when the workspace sends from its own verified domain
+type updateSender struct {
+	address malak.Email
+	dkimKey []byte
 }
 
 type EmailProcessor struct {
-	db            *bun.DB
-	emailClient   email.Client
-	logger        *zap.Logger
-	tracer        trace.Tracer
-	cfg           *config.Config
-	metrics       *ProcessMetrics
-	rateLimiter   *rate.Limiter
-	workspaceRepo malak.WorkspaceRepository
-	chartRenderer malak.ChartRenderer
-	storage       gulter.Storage
+	db             *bun.DB
+	emailClient    email.Client
+	logger         *zap.Logger
+	tracer         trace.Tracer
+	cfg            *config.Config
+	metrics        *ProcessMetrics
+	rateLimiter    *rate.Limiter
+	workspaceRepo  malak.WorkspaceRepository
+	preferenceRepo malak.PreferenceRepository
+	chartRenderer  malak.ChartRenderer
+	storage        gulter.Storage
+
+	sendingDomainRepo malak.SendingDomainRepository
+	secretsClient     secret.SecretClient
 }
 
 type ProcessorOptions struct {
@@ -122,30 +144,41 @@ type recipient struct {
 	bun.BaseModel `bun:"table:update_recipients"`
 }
 
-func NewEmailProcessor(db *bun.DB, emailClient email.Client, logger *zap.Logger, tracer trace.Tracer, cfg *config.Config, storage gulter.Storage, opts ProcessorOptions) *EmailProcessor {
+func NewEmailProcessor(db *bun.DB, emailClient email.Client, logger *zap.Logger, tracer trace.Tracer, cfg *config.Config, storage gulter.Storage, secretsClient secret.SecretClient, opts ProcessorOptions) *EmailProcessor {
 	return &EmailProcessor{
-		db:            db,
-		emailClient:   emailClient,
-		logger:        logger,
-		tracer:        tracer,
-		cfg:           cfg,
-		metrics:       &ProcessMetrics{StartTime: time.Now()},
-		rateLimiter:   rate.NewLimiter(rate.Limit(opts.RateLimit), opts.RateLimit),
-		workspaceRepo: postgres.NewWorkspaceRepository(db),
-		chartRenderer: chart.NewEChartsRenderer(storage, hermes.DeRef(cfg), db, postgres.NewIntegrationRepo(db)),
-		storage:       storage,
-	}
-}
-
-func (p *EmailProcessor) acquireProcessingLock(ctx context.Context, updateID uuid.UUID) (bool, error) {
-	var locked bool
-	err := p.db.NewSelect().
+		db:             db,
+		emailClient:    emailClient,
+		logger:         logger,
+		tracer:         tracer,
+		cfg:            cfg,
+		metrics:        &ProcessMetrics{StartTime: time.Now()},
+		rateLimiter:    rate.NewLimiter(rate.Limit(opts.RateLimit), opts.RateLimit),
+		workspaceRepo:  postgres.NewWorkspaceRepository(db),
+		preferenceRepo: postgres.NewPreferenceRepository(db),
+		chartRenderer:  chart.NewEChartsRenderer(storage, hermes.DeRef(cfg), db, postgres.NewIntegrationRepo(db)),
+		storage:        storage,
+
+		sendingDomainRepo: postgres.NewSendingDomainRepository(db),
+		secretsClient:     secretsClient,
+	}
+}
+
+// acquireProcessingLock claims the schedule by moving it to processing.
+// It only succeeds if the schedule is still scheduled, which is the same
+// condition used when a send is cancelled or rescheduled
+func (p *EmailProcessor) acquireProcessingLock(ctx context.Context, scheduleID uuid.UUID) (bool, error) {
+	res, err := p.db.NewUpdate().
 		TableExpr("update_schedules").
-		ColumnExpr("TRUE").
-		Where("id = ? AND status = ?", updateID, malak.UpdateSendScheduleScheduled).
-		For("UPDATE SKIP LOCKED").
-		Scan(ctx, &locked)
-	return locked, err
+		Set("status = ?", malak.UpdateSendScheduleProcessing).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND status = ?", scheduleID, malak.UpdateSendScheduleScheduled).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected == 1, err
 }
 
 func fetchPendingUpdates(ctx context.Context, db *bun.DB, lastProcessedID string, limit int) ([]*malak.UpdateSchedule, error) {
@@ -153,6 +186,7 @@ func fetchPendingUpdates(ctx context.Context, db *bun.DB, lastProcessedID string
 	query := db.NewSelect().
 		Model(&scheduledUpdates).
 		Where("status = ?", malak.UpdateSendScheduleScheduled).
+		Where("send_at <= ?", time.Now()).
 		Limit(limit)
 
 	if lastProcessedID != "" {
@@ -175,25 +209,51 @@ func (p *EmailProcessor) processUpdate(ctx context.Context, update *malak.Update
 		return fmt.Errorf("failed to acquire lock: %w", err)
 	}
 	if !locked {
-		return errors.New("update is being processed by another instance")
+		return errors.New("update is being processed by another instance or was cancelled")
 	}
 
-	if err := updateScheduleStatus(dbCtx, p.db, update, malak.UpdateSendScheduleProcessing); err != nil {
-		return fmt.Errorf("failed to update status: %w", err)
-	}
+	update.Status = malak.UpdateSendScheduleProcessing
 
 	updateDetails, err := fetchUpdateDetails(dbCtx, p.db, update.UpdateID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch update details: %w", err)
 	}
 
+	renderer := p.chartRenderer
+
+	if update.UpdateType == malak.UpdateTypeLive {
+		canSend, err := p.canSendUpdate(dbCtx, updateDetails)
+		if err != nil {
+			return fmt.Errorf("failed to check update approval: %w", err)
+		}
+
+		if !canSend {
+			if err := updateScheduleStatus(dbCtx, p.db, update, malak.UpdateSendScheduleFailed); err != nil {
+				return fmt.Errorf("failed to update final status: %w", err)
+			}
+			return malak.ErrUpdateNotApproved
+		}
+
+		// metrics are resolved once so every recipient and the public
+		// views of the update show the numbers at send time
+		metrics := updateDetails.Content.ResolveMetrics(updateDetails.WorkspaceID, p.chartRenderer)
+
+		if err := freezeSentRevision(dbCtx, p.db, update, updateDetails, metrics); err != nil {
+			return fmt.Errorf("failed to store sent revision: %w", err)
+		}
+
+		renderer = malak.FrozenMetricsRenderer(p.chartRenderer, metrics)
+	}
+
+	sender := p.updateSender(dbCtx, updateDetails)
+
 	emailCtx, emailCancel := context.WithTimeout(ctx, defaultProcessingTimeout)
 	defer emailCancel()
 
 	g, emailCtx := errgroup.WithContext(emailCtx)
 
 	for {
-		recipients, err := p.fetchNextBatch(dbCtx, update.UpdateID)
+		recipients, err := p.fetchNextBatch(dbCtx, updateDetails)
 		if err != nil {
 			return fmt.Errorf("failed to fetch recipients: %w", err)
 		}
@@ -201,7 +261,7 @@ func (p *EmailProcessor) processUpdate(ctx context.Context, update *malak.Update
 			break
 		}
 
-		jobs := p.createEmailJobs(recipients, updateDetails)
+		jobs := p.createEmailJobs(recipients, updateDetails, sender, renderer)
 		p.metrics.TotalEmails += int64(len(jobs))
 
 		results := make(chan error, len(jobs))
@@ -232,19 +292,125 @@ func (p *EmailProcessor) processUpdate(ctx context.Context, update *malak.Update
 	return updateScheduleStatus(dbCtx, p.db, update, malak.UpdateSendScheduleSent)
 }
 
-func (p *EmailProcessor) fetchNextBatch(ctx context.Context, updateID uuid.UUID) ([]recipient, error) {
+// canSendUpdate checks the approval policy of the workspace again at send
+// time. The update could have been scheduled before the policy was enabled
+func (p *EmailProcessor) canSendUpdate(ctx context.Context, update *malak.Update) (bool, error) {
+	preferences, err := p.preferenceRepo.Get(ctx, &malak.Workspace{ID: update.WorkspaceID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return preferences.Approval.CanSend(update), nil
+}
+
+// updateSender returns the address the update is sent from. Workspaces with a
+// verified sending domain send from it. Only the smtp client signs emails
+// with the DKIM key so other providers keep using the default sender.
+// Any failure falls back to the default sender rather than failing the send
+func (p *EmailProcessor) updateSender(ctx context.Context, update *malak.Update) updateSender {
+	sender := updateSender{address: p.cfg.Email.Sender}
+
+	if p.emailClient.Name() != malak.UpdateRecipientLogProviderSmtp || p.secretsClient == nil {
+		return sender
+	}
+
+	logger := p.logger.With(zap.String("workspace_id", update.WorkspaceID.String()))
+
+	workspace, err := p.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: update.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace", zap.Error(err))
+		return sender
+	}
+
+	if !workspace.Plan.Metadata.Updates.CustomDomain {
+		return sender
+	}
+
+	domain, err := p.sendingDomainRepo.Get(ctx, update.WorkspaceID)
+	if err != nil {
+		if !errors.Is(err, malak.ErrSendingDomainNotFound) {
+			logger.Error("could not fetch sending domain", zap.Error(err))
+		}
+
+		return sender
+	}
+
+	if !domain.IsVerified() {
+		return sender
+	}
+
+	key, err := p.secretsClient.Get(ctx, domain.PrivateKey)
+	if err != nil {
+		logger.Error("could not fetch dkim key of sending domain", zap.Error(err))
+		return sender
+	}
+
+	return updateSender{
+		address: domain.Sender,
+		dkimKey: []byte(key),
+	}
+}
+
+// skipSuppressedRecipients stops the update from going to contacts that
+// bounced, complained or unsubscribed. They are checked before every batch
+// so contacts suppressed while the update is being sent are skipped too
+func (p *EmailProcessor) skipSuppressedRecipients(ctx context.Context, update *malak.Update) (int64, error) {
+	res, err := p.db.NewUpdate().
+		Model(&malak.UpdateRecipient{}).
+		Set("status = ?", malak.RecipientStatusSkipped).
+		Set("updated_at = ?", time.Now()).
+		Where("update_id = ?", update.ID).
+		Where("status = ?", malak.RecipientStatusPending).
+		Where("contact_id IN (?)", suppressedContacts(p.db, update.WorkspaceID)).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func suppressedContacts(db *bun.DB, workspaceID uuid.UUID) *bun.SelectQuery {
+	return db.NewSelect().
+		Model((*malak.Suppression)(nil)).
+		Column("contact_id").
+		Where("workspace_id = ?", workspaceID)
+}
+
+func (p *EmailProcessor) fetchNextBatch(ctx context.Context, update *malak.Update) ([]recipient, error) {
+	skipped, err := p.skipSuppressedRecipients(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if skipped > 0 {
+		p.logger.Info("skipped suppressed recipients",
+			zap.String("update_id", update.ID.String()),
+			zap.Int64("skipped", skipped))
+	}
+
 	var recipients []recipient
-	err := p.db.NewSelect().
+	err = p.db.NewSelect().
 		Model(&recipients).
-		Where("update_id = ?", updateID).
+		Where("update_id = ?", update.ID).
 		Where("status = ?", malak.RecipientStatusPending).
+		Where("contact_id NOT IN (?)", suppressedContacts(p.db, update.WorkspaceID)).
 		Relation("Contact").
+		Relation("Contact.Lists").
+		Relation("Contact.Lists.List").
 		Limit(defaultBatchSize).
 		Scan(ctx)
 	return recipients, err
 }
 
-func (p *EmailProcessor) createEmailJobs(recipients []recipient, update *malak.Update) []*EmailJob {
+func (p *EmailProcessor) createEmailJobs(recipients []recipient, update *malak.Update,
+	sender updateSender, renderer malak.ChartRenderer) []*EmailJob {
 	workspace, err := p.workspaceRepo.Get(context.Background(), &malak.FindWorkspaceOptions{
 		ID: update.WorkspaceID,
 	})
@@ -253,24 +419,72 @@ func (p *EmailProcessor) createEmailJobs(recipients []recipient, update *malak.U
 		panic(err.Error())
 	}
 
-	content, err := prepareEmailTemplate(update, workspace.WorkspaceName, p.chartRenderer)
-	if err != nil {
-		// the template is supposed to be fine so this is okay to do
-		panic(err.Error())
+	// resend, sendgrid, ses and postmark all report opens through their
+	// webhooks. Adding our pixel would count every open twice
+	trackOpens := p.emailClient.Name() == malak.UpdateRecipientLogProviderSmtp
+
+	type rendered struct {
+		content string
+		links   []string
 	}
 
+	// recipients in the same lists see the same blocks so the update is
+	// only rendered once for each of these segments
+	conditionalLists := update.Content.ConditionalLists()
+	segments := make(map[string]rendered)
+
 	jobs := make([]*EmailJob, len(recipients))
 	for i, r := range recipients {
+		lists := recipientSegment(r, conditionalLists)
+		key := fmt.Sprint(lists)
+
+		segment, ok := segments[key]
+		if !ok {
+			segmentUpdate := *update
+			segmentUpdate.Content = update.Content.ForLists(lists)
+
+			content, links, err := prepareEmailTemplate(&segmentUpdate, workspace.WorkspaceName, renderer, trackOpens)
+			if err != nil {
+				// the template is supposed to be fine so this is okay to do
+				panic(err.Error())
+			}
+
+			segment = rendered{content: content, links: links}
+			segments[key] = segment
+		}
+
+		fields := malak.NewMergeFields(r.Contact)
+
 		jobs[i] = &EmailJob{
 			Recipient:  r,
-			Title:      update.Title,
-			Content:    content,
+			Title:      fields.Replace(update.Title),
+			Content:    fields.ReplaceHTML(segment.content),
+			Links:      segment.links,
+			Sender:     sender,
 			RetryCount: 0,
 		}
 	}
 	return jobs
 }
 
+// recipientSegment returns the conditional lists the recipient belongs to in
+// a stable order
+func recipientSegment(r recipient, conditionalLists []malak.Reference) []malak.Reference {
+	lists := make([]malak.Reference, 0)
+	if r.Contact == nil {
+		return lists
+	}
+
+	for _, ref := range r.Contact.ListReferences() {
+		if slices.Contains(conditionalLists, ref) && !slices.Contains(lists, ref) {
+			lists = append(lists, ref)
+		}
+	}
+
+	slices.Sort(lists)
+	return lists
+}
+
 func (p *EmailProcessor) processEmailBatch(ctx context.Context, jobs []*EmailJob, results chan<- error) error {
 	workerPool := make(chan struct{}, defaultWorkerCount)
 	var wg sync.WaitGroup
@@ -336,17 +550,23 @@ func (p *EmailProcessor) processEmailWithRetry(ctx context.Context, job *EmailJo
 }
 
 func (p *EmailProcessor) sendEmail(ctx context.Context, job *EmailJob) error {
+	unsubscribeLink := unsubscribeURL(p.cfg, job.Recipient)
+
 	opts := email.SendOptions{
-		HTML:      job.Content,
-		Sender:    p.cfg.Email.Sender,
+		HTML:      personalizeEmail(p.cfg, job, unsubscribeLink),
+		Sender:    job.Sender.address,
 		Recipient: job.Recipient.Contact.Email,
 		Subject:   job.Title,
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeLink + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
 		DKIM: struct {
 			Sign       bool
 			PrivateKey []byte
 		}{
-			Sign:       false,
-			PrivateKey: []byte(""),
+			Sign:       len(job.Sender.dkimKey) > 0,
+			PrivateKey: job.Sender.dkimKey,
 		},
 	}
 
@@ -430,8 +650,15 @@ func sendScheduledUpdates(c *cobra.Command, cfg *config.Config) *cobra.Command {
 					zap.Error(err))
 			}
 
+			// without a secrets provider, updates are sent from the
+			// default sender even if the workspace has its own domain
+			secretsProvider, err := buildSecretsProvider(cfg.Secrets.Provider, hermes.DeRef(cfg))
+			if err != nil {
+				logger.Error("could not build secrets provider", zap.Error(err))
+			}
+
 			opts := DefaultProcessorOptions()
-			processor := NewEmailProcessor(db, emailClient, logger, tracer, cfg, s3Store, opts)
+			processor := NewEmailProcessor(db, emailClient, logger, tracer, cfg, s3Store, secretsProvider, opts)
 
 			var lastProcessedID string
 			for {
@@ -499,6 +726,27 @@ func updateScheduleStatus(ctx context.Context, db *bun.DB, schedule *malak.Updat
 	return err
 }
 
+// freezeSentRevision stores the content the update is sent with. It is only
+// done once the schedule is sent so a cancelled schedule does not leave a
+// version investors never received
+func freezeSentRevision(ctx context.Context, db *bun.DB, schedule *malak.UpdateSchedule,
+	update *malak.Update, metrics malak.ResolvedMetrics) error {
+	_, err := db.NewInsert().
+		Model(&malak.UpdateRevision{
+			Reference:   malak.NewReferenceGenerator().Generate(malak.EntityTypeUpdateRevision),
+			UpdateID:    update.ID,
+			WorkspaceID: update.WorkspaceID,
+			CreatedBy:   schedule.ScheduledBy,
+			Title:       update.Title,
+			Content:     update.Content,
+			IsSent:      true,
+			ScheduleID:  schedule.ID,
+			Metrics:     metrics,
+		}).
+		Exec(ctx)
+	return err
+}
+
 func fetchUpdateDetails(ctx context.Context, db *bun.DB, updateID uuid.UUID) (*malak.Update, error) {
 	update := &malak.Update{}
 	err := db.NewSelect().
@@ -508,21 +756,103 @@ func fetchUpdateDetails(ctx context.Context, db *bun.DB, updateID uuid.UUID) (*m
 	return update, err
 }
 
-func prepareEmailTemplate(update *malak.Update, workspaceName string, renderer malak.ChartRenderer) (string, error) {
+// unsubscribeURLPlaceholder is left in the rendered template and swapped
+// for each recipient's signed link before sending
+const (
+	unsubscribeURLPlaceholder  = "%%unsubscribe_url%%"
+	openTrackingURLPlaceholder = "%%open_tracking_url%%"
+	viewURLPlaceholder         = "%%view_url%%"
+)
+
+func unsubscribeURL(cfg *config.Config, r recipient) string {
+	params := url.Values{}
+	params.Set("recipient", r.Reference.String())
+	params.Set("signature", malak.SignUnsubscribe(cfg.Auth.JWT.Key, r.Reference))
+
+	return strings.TrimSuffix(cfg.HTTP.PublicURL, "/") + "/updates/unsubscribe?" + params.Encode()
+}
+
+func openTrackingURL(cfg *config.Config, r recipient) string {
+	params := url.Values{}
+	params.Set("recipient", r.Reference.String())
+	params.Set("signature", malak.SignOpen(cfg.Auth.JWT.Key, r.Reference))
+
+	return strings.TrimSuffix(cfg.HTTP.PublicURL, "/") + "/updates/open?" + params.Encode()
+}
+
+// viewURL links to the public view of the update where the recipient can
+// react and comment
+func viewURL(cfg *config.Config, r recipient) string {
+	params := url.Values{}
+	params.Set("signature", malak.SignUpdateView(cfg.Auth.JWT.Key, r.Reference))
+
+	return strings.TrimSuffix(cfg.Frontend.AppURL, "/") + "/updates/view/" + r.Reference.String() + "?" + params.Encode()
+}
+
+func linkPlaceholder(index int) string {
+	return fmt.Sprintf("%%%%link_%d%%%%", index)
+}
+
+func clickURL(cfg *config.Config, r recipient, link string) string {
+	params := url.Values{}
+	params.Set("recipient", r.Reference.String())
+	params.Set("url", link)
+	params.Set("signature", malak.SignClick(cfg.Auth.JWT.Key, r.Reference, link))
+
+	return strings.TrimSuffix(cfg.HTTP.PublicURL, "/") + "/updates/click?" + params.Encode()
+}
+
+// personalizeEmail swaps the placeholders in the rendered update with the
+// links that belong to the recipient
+func personalizeEmail(cfg *config.Config, job *EmailJob, unsubscribeLink string) string {
+	replacements := []string{
+		unsubscribeURLPlaceholder, unsubscribeLink,
+		openTrackingURLPlaceholder, html.EscapeString(openTrackingURL(cfg, job.Recipient)),
+		viewURLPlaceholder, html.EscapeString(viewURL(cfg, job.Recipient)),
+	}
+
+	for i, link := range job.Links {
+		replacements = append(replacements,
+			linkPlaceholder(i), html.EscapeString(clickURL(cfg, job.Recipient, link)))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(job.Content)
+}
+
+// prepareEmailTemplate renders the update once for all recipients. Links are
+// replaced with placeholders that personalizeEmail fills in per recipient.
+// trackOpens embeds our own pixel for providers that do not report opens
+func prepareEmailTemplate(update *malak.Update, workspaceName string,
+	renderer malak.ChartRenderer, trackOpens bool) (string, []string, error) {
 	tmpl, err := template.New("template").Parse(email.UpdateHTMLEmailTemplate)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	var links []string
+
+	content := update.Content.HTML(update.WorkspaceID, renderer, func(href string) string {
+		links = append(links, href)
+		return linkPlaceholder(len(links) - 1)
+	})
+
+	data := map[string]string{
+		"Content":        content,
+		"Company":        workspaceName,
+		"UnsubscribeURL": unsubscribeURLPlaceholder,
+		"ViewURL":        viewURLPlaceholder,
+	}
+
+	if trackOpens {
+		data["OpenTrackingURL"] = openTrackingURLPlaceholder
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, map[string]string{
-		"Content": update.Content.HTML(update.WorkspaceID, renderer),
-		"Company": workspaceName,
-	}); err != nil {
-		return "", err
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", nil, err
 	}
 
-	return buf.String(), nil
+	return buf.String(), links, nil
 }
 
 func updateRecipientStatus(ctx context.Context, db *bun.DB, emailClient email.Client, r recipient, status malak.RecipientStatus, emailID string) error {
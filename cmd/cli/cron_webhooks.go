@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/datastore/postgres"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookBatchSize   = 100
+	defaultWebhookWorkerCount = 5
+)
+
+// deliverWebhooks retries failed deliveries and sends the ones that could
+// not be queued. The backoff is tracked with the next attempt of each
+// delivery since the queue has no way to delay a message
+func deliverWebhooks(_ *cobra.Command, cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "webhooks",
+		Short: `Send pending and failed webhook deliveries that are due`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger, err := getLogger(hermes.DeRef(cfg))
+			if err != nil {
+				return err
+			}
+
+			logger = logger.With(zap.String("component", "webhooks"))
+
+			db, err := postgres.New(cfg, logger)
+			if err != nil {
+				logger.Error("could not connect to postgres database",
+					zap.Error(err))
+				return err
+			}
+
+			defer db.Close()
+
+			ctx := cmd.Context()
+
+			secretsProvider, err := buildSecretsProvider(cfg.Secrets.Provider, hermes.DeRef(cfg))
+			if err != nil {
+				logger.Error("could not build secrets provider", zap.Error(err))
+				return err
+			}
+
+			webhookRepo := postgres.NewWebhookRepository(db)
+			webhookClient := webhook.New(secretsProvider)
+
+			deliveries, err := webhookRepo.ClaimDueDeliveries(ctx, time.Now(), defaultWebhookBatchSize)
+			if err != nil {
+				logger.Error("could not fetch due webhook deliveries", zap.Error(err))
+				return err
+			}
+
+			logger.Info("sending webhook deliveries",
+				zap.Int("deliveries_count", len(deliveries)))
+
+			jobs := make(chan malak.WebhookDelivery, len(deliveries))
+
+			var wg sync.WaitGroup
+
+			for i := 0; i < defaultWebhookWorkerCount; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					for delivery := range jobs {
+						logger := logger.With(
+							zap.String("delivery_id", delivery.ID.String()),
+							zap.String("webhook_id", delivery.WebhookID.String()))
+
+						hook, current, err := webhookRepo.GetDeliveryByID(ctx, delivery.ID)
+						if err != nil {
+							logger.Error("could not fetch webhook delivery", zap.Error(err))
+							continue
+						}
+
+						if current.Status != malak.WebhookDeliveryStatusPending {
+							continue
+						}
+
+						if !hook.IsActive {
+							current.Cancel("webhook is disabled")
+						} else if err := webhookClient.Deliver(ctx, hook, current); err != nil {
+							logger.Error("could not deliver webhook",
+								zap.Int("attempts", current.Attempts),
+								zap.Error(err))
+						}
+
+						if err := webhookRepo.UpdateDelivery(ctx, current); err != nil {
+							logger.Error("could not update webhook delivery", zap.Error(err))
+						}
+					}
+				}()
+			}
+
+			for _, delivery := range deliveries {
+				jobs <- delivery
+			}
+
+			close(jobs)
+			wg.Wait()
+
+			return nil
+		},
+	}
+}
@@ -40,14 +40,21 @@ import (
 	"github.com/ayinke-llc/malak/internal/integrations/mercury"
 	"github.com/ayinke-llc/malak/internal/pkg/billing/stripe"
 	"github.com/ayinke-llc/malak/internal/pkg/cache/rediscache"
+	"github.com/ayinke-llc/malak/internal/pkg/chart"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration/redispubsub"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/ayinke-llc/malak/internal/pkg/email/postmark"
 	"github.com/ayinke-llc/malak/internal/pkg/email/resend"
+	"github.com/ayinke-llc/malak/internal/pkg/email/sendgrid"
+	"github.com/ayinke-llc/malak/internal/pkg/email/ses"
 	"github.com/ayinke-llc/malak/internal/pkg/email/smtp"
 	"github.com/ayinke-llc/malak/internal/pkg/geolocation/maxmind"
 	"github.com/ayinke-llc/malak/internal/pkg/jwttoken"
 	watermillqueue "github.com/ayinke-llc/malak/internal/pkg/queue/watermill"
 	"github.com/ayinke-llc/malak/internal/pkg/socialauth"
 	"github.com/ayinke-llc/malak/internal/pkg/util"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
 	"github.com/ayinke-llc/malak/internal/secret"
 	"github.com/ayinke-llc/malak/internal/secret/aes"
 	"github.com/ayinke-llc/malak/internal/secret/infisical"
@@ -140,10 +147,18 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 			apiRepo := postgres.NewAPIKeyRepository(db)
 			fundingRepo := postgres.NewFundingRepo(db)
 			emailVerificationRepo := postgres.NewEmailVerificationRepository(db)
-
-			googleAuthProvider := socialauth.NewGoogle(*cfg)
-
-			tokenManager := jwttoken.New(*cfg)
+			teamRepo := postgres.NewTeamRepository(db)
+			passwordResetRepo := postgres.NewPasswordResetRepository(db)
+			twoFactorRepo := postgres.NewTwoFactorRepository(db)
+			auditLogRepo := postgres.NewAuditLogRepository(db)
+			webhookRepo := postgres.NewWebhookRepository(db)
+			suppressionRepo := postgres.NewSuppressionRepository(db)
+			commentRepo := postgres.NewUpdateCommentRepository(db)
+			linkRepo := postgres.NewUpdateLinkRepository(db)
+			recurringRepo := postgres.NewRecurringUpdateScheduleRepository(db)
+			sendingDomainRepo := postgres.NewSendingDomainRepository(db)
+
+			socialAuthManager := buildSocialAuthManager(*cfg)
 
 			opts, err := redis.ParseURL(cfg.Database.Redis.DSN)
 			if err != nil {
@@ -194,6 +209,33 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 						zap.Error(err))
 				}
 
+			case config.EmailProviderSendgrid:
+				var err error
+
+				emailClient, err = sendgrid.New(*cfg)
+				if err != nil {
+					logger.Fatal("could not set up sendgrid client",
+						zap.Error(err))
+				}
+
+			case config.EmailProviderSes:
+				var err error
+
+				emailClient, err = ses.New(*cfg)
+				if err != nil {
+					logger.Fatal("could not set up ses client",
+						zap.Error(err))
+				}
+
+			case config.EmailProviderPostmark:
+				var err error
+
+				emailClient, err = postmark.New(*cfg)
+				if err != nil {
+					logger.Fatal("could not set up postmark client",
+						zap.Error(err))
+				}
+
 			default:
 				logger.Fatal("unsupported email provider", zap.String("provider", cfg.Email.Provider.String()))
 			}
@@ -204,10 +246,16 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 					zap.Error(err))
 			}
 
+			secretsProvider, err := buildSecretsProvider(cfg.Secrets.Provider, hermes.DeRef(cfg))
+			if err != nil {
+				logger.Fatal("could not build secrets provider", zap.Error(err))
+			}
+
 			queueHandler, err := watermillqueue.New(
 				redisClient, hermes.DeRef(cfg),
 				logger, emailClient, userRepo, workspaceRepo,
-				updateRepo, contactRepo, billingClient)
+				updateRepo, contactRepo, billingClient,
+				webhookRepo, webhook.New(secretsProvider))
 			if err != nil {
 				logger.Fatal("could not set up watermill queue", zap.Error(err))
 			}
@@ -221,6 +269,8 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 				logger.Fatal("could not set up redis cache", zap.Error(err))
 			}
 
+			tokenManager := jwttoken.New(*cfg, redisCache)
+
 			rateLimiterStore, err := getRatelimiter(*cfg)
 			if err != nil {
 				logger.Fatal("could not create rate limiter",
@@ -350,21 +400,33 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 				logger.Fatal("could not build integration manager", zap.Error(err))
 			}
 
-			secretsProvider, err := buildSecretsProvider(cfg.Secrets.Provider, hermes.DeRef(cfg))
+			chartRenderer := chart.NewEChartsRenderer(s3Store, hermes.DeRef(cfg), db, integrationRepo)
+
+			collaborationPubSub, err := redispubsub.New(redisClient)
 			if err != nil {
-				logger.Fatal("could not build secrets provider", zap.Error(err))
+				logger.Fatal("could not set up collaboration pubsub", zap.Error(err))
 			}
 
+			collaborationHub := collaboration.New(logger, collaborationPubSub, updateRepo, collaboration.Options{})
+
+			collaborationCtx, cancelCollaboration := context.WithCancel(context.Background())
+			collaborationDone := make(chan struct{})
+
+			go func() {
+				defer close(collaborationDone)
+				collaborationHub.Run(collaborationCtx)
+			}()
+
 			srv, cleanupSrv := server.New(logger,
 				util.DeRef(cfg),
-				tokenManager, googleAuthProvider,
+				tokenManager, socialAuthManager,
 				dashRepo, userRepo, workspaceRepo, planRepo, contactRepo,
 				updateRepo, contactlistRepo, deckRepo, shareRepo,
 				preferenceRepo, integrationRepo,
 				templatesRepo, dashboardLinkRepo, apiRepo, emailVerificationRepo,
-				mid, queueHandler, redisCache, billingClient,
+				teamRepo, passwordResetRepo, twoFactorRepo, auditLogRepo, webhookRepo, suppressionRepo, commentRepo, linkRepo, recurringRepo, sendingDomainRepo, mid, queueHandler, redisCache, billingClient,
 				integrationManager, secretsProvider,
-				geoService, imageUploadGulterHandler, deckUploadGulterHandler,
+				geoService, chartRenderer, collaborationHub, imageUploadGulterHandler, deckUploadGulterHandler,
 				fundingRepo)
 
 			go func() {
@@ -378,6 +440,11 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 
 			cleanupSrv()
 
+			// pending collaborative edits are saved before the database
+			// is closed
+			cancelCollaboration()
+			<-collaborationDone
+
 			logger.Debug("shutting down Malak's server")
 			if err := db.Close(); err != nil {
 				logger.Error("could not close db",
@@ -395,6 +462,28 @@ func addHTTPCommand(c *cobra.Command, cfg *config.Config) {
 	c.AddCommand(cmd)
 }
 
+func buildSocialAuthManager(cfg config.Config) *socialauth.Manager {
+	manager := socialauth.NewManager()
+
+	if cfg.Auth.Google.IsEnabled {
+		manager.Add(socialauth.ProviderGoogle, socialauth.NewGoogle(cfg))
+	}
+
+	if cfg.Auth.GitHub.IsEnabled {
+		manager.Add(socialauth.ProviderGitHub, socialauth.NewGitHub(cfg))
+	}
+
+	if cfg.Auth.Microsoft.IsEnabled {
+		manager.Add(socialauth.ProviderMicrosoft, socialauth.NewMicrosoft(cfg))
+	}
+
+	if cfg.Auth.OIDC.IsEnabled {
+		manager.Add(cfg.Auth.OIDC.Name, socialauth.NewOIDC(cfg))
+	}
+
+	return manager
+}
+
 func buildIntegrationManager(integrationRepo malak.IntegrationRepository, cfg config.Config, logger *zap.Logger) (
 	*integrations.IntegrationsManager, error) {
 	i := integrations.NewManager()
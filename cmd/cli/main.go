@@ -11,7 +11,10 @@ import (
 
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/ayinke-llc/malak/internal/pkg/email/postmark"
 	"github.com/ayinke-llc/malak/internal/pkg/email/resend"
+	"github.com/ayinke-llc/malak/internal/pkg/email/sendgrid"
+	"github.com/ayinke-llc/malak/internal/pkg/email/ses"
 	"github.com/ayinke-llc/malak/internal/pkg/email/smtp"
 	"github.com/ayinke-llc/malak/internal/secret"
 	"github.com/google/uuid"
@@ -171,6 +174,7 @@ func setDefaults() {
 	viper.SetDefault("otel.endpoint", "localhost:9317")
 
 	viper.SetDefault("http.port", 5300)
+	viper.SetDefault("http.public_url", "http://localhost:5300")
 	viper.SetDefault("http.rate_limit.is_enabled", true)
 	viper.SetDefault("http.rate_limit.type", config.RateLimiterTypeMemory)
 	viper.SetDefault("http.rate_limit.requests_per_minute", 300)
@@ -178,11 +182,20 @@ func setDefaults() {
 
 	viper.SetDefault("api.provider", secret.SecretProviderAesGcm)
 
+	viper.SetDefault("email.sendgrid.endpoint", "https://api.sendgrid.com")
+	viper.SetDefault("email.postmark.endpoint", "https://api.postmarkapp.com")
+	viper.SetDefault("email.postmark.message_stream", "outbound")
+
 	viper.SetDefault("biling.is_enabled", false)
 	viper.SetDefault("billing.default_plan", uuid.Nil)
 	viper.SetDefault("billing.trial_days", 30)
 
 	viper.SetDefault("auth.google.scopes", []string{"profile", "email"})
+	viper.SetDefault("auth.github.scopes", []string{"read:user", "user:email"})
+	viper.SetDefault("auth.microsoft.scopes", []string{"openid", "profile", "email"})
+	viper.SetDefault("auth.microsoft.tenant", "common")
+	viper.SetDefault("auth.oidc.name", "oidc")
+	viper.SetDefault("auth.oidc.scopes", []string{"openid", "profile", "email"})
 
 	viper.SetDefault("secrets.infisical.site_url", "https://app.infisical.com")
 	viper.SetDefault("secrets.infisical.environment", "prod")
@@ -199,6 +212,15 @@ func getEmailProvider(cfg config.Config) (email.Client, error) {
 	case config.EmailProviderSmtp:
 		return smtp.New(cfg)
 
+	case config.EmailProviderSendgrid:
+		return sendgrid.New(cfg)
+
+	case config.EmailProviderSes:
+		return ses.New(cfg)
+
+	case config.EmailProviderPostmark:
+		return postmark.New(cfg)
+
 	default:
 		return nil, errors.New("unsupported email provider")
 	}
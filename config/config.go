@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"net/mail"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ayinke-llc/hermes"
@@ -24,11 +26,14 @@ type RateLimiterType string
 // ENUM(s3)
 type UploadDriver string
 
-// ENUM(smtp,resend,sendgrid)
+// ENUM(smtp,resend,sendgrid,ses,postmark)
 type EmailProvider string
 
 type HTTPConfig struct {
-	Port      int `yaml:"port" mapstructure:"port" json:"port"`
+	Port int `yaml:"port" mapstructure:"port" json:"port"`
+	// PublicURL is the address the API is reachable at from the internet.
+	// It is used to build links that are embedded in emails
+	PublicURL string `yaml:"public_url" mapstructure:"public_url" json:"public_url"`
 	RateLimit struct {
 		// If redis, you have to configure the redis struct in the database field
 		Type              RateLimiterType `yaml:"type" mapstructure:"type" json:"type"`
@@ -170,6 +175,35 @@ type Config struct {
 			APIKey        string `mapstructure:"api_key" yaml:"api_key" json:"api_key"`
 			WebhookSecret string `mapstructure:"webhook_secret" yaml:"webhook_secret" json:"webhook_secret"`
 		} `mapstructure:"resend" yaml:"resend" json:"resend"`
+		Sendgrid struct {
+			APIKey string `mapstructure:"api_key" yaml:"api_key" json:"api_key"`
+			// Base64 encoded ECDSA public key from the event webhook settings page
+			WebhookVerificationKey string `mapstructure:"webhook_verification_key" yaml:"webhook_verification_key" json:"webhook_verification_key"`
+			// Only needs to be changed when testing against a local
+			// stand-in of the SendGrid api
+			Endpoint string `mapstructure:"endpoint" yaml:"endpoint" json:"endpoint"`
+		} `mapstructure:"sendgrid" yaml:"sendgrid" json:"sendgrid"`
+		SES struct {
+			Region       string `mapstructure:"region" yaml:"region" json:"region"`
+			AccessKey    string `mapstructure:"access_key" yaml:"access_key" json:"access_key"`
+			AccessSecret string `mapstructure:"access_secret" yaml:"access_secret" json:"access_secret"`
+			// Bounce, complaint and open events are only published for
+			// emails sent with a configuration set
+			ConfigurationSet string `mapstructure:"configuration_set" yaml:"configuration_set" json:"configuration_set"`
+			Endpoint         string `mapstructure:"endpoint" yaml:"endpoint" json:"endpoint"`
+			// SNS topics allowed to post to the webhook. Messages and
+			// subscription confirmations from any other topic are rejected
+			TopicArns []string `mapstructure:"topic_arns" yaml:"topic_arns" json:"topic_arns"`
+		} `mapstructure:"ses" yaml:"ses" json:"ses"`
+		Postmark struct {
+			ServerToken   string `mapstructure:"server_token" yaml:"server_token" json:"server_token"`
+			MessageStream string `mapstructure:"message_stream" yaml:"message_stream" json:"message_stream"`
+			// Postmark does not sign webhooks so basic auth
+			// credentials have to be added to the webhook url
+			WebhookUsername string `mapstructure:"webhook_username" yaml:"webhook_username" json:"webhook_username"`
+			WebhookPassword string `mapstructure:"webhook_password" yaml:"webhook_password" json:"webhook_password"`
+			Endpoint        string `mapstructure:"endpoint" yaml:"endpoint" json:"endpoint"`
+		} `mapstructure:"postmark" yaml:"postmark" json:"postmark"`
 	} `mapstructure:"email" yaml:"email" json:"email"`
 
 	Auth struct {
@@ -181,6 +215,39 @@ type Config struct {
 			IsEnabled    bool     `yaml:"is_enabled" mapstructure:"is_enabled" json:"is_enabled"`
 		} `yaml:"google" mapstructure:"google" json:"google"`
 
+		GitHub struct {
+			ClientID     string   `yaml:"client_id" mapstructure:"client_id" json:"client_id"`
+			ClientSecret string   `yaml:"client_secret" mapstructure:"client_secret" json:"client_secret"`
+			RedirectURI  string   `yaml:"redirect_uri" mapstructure:"redirect_uri" json:"redirect_uri"`
+			Scopes       []string `yaml:"scopes" mapstructure:"scopes" json:"scopes"`
+			IsEnabled    bool     `yaml:"is_enabled" mapstructure:"is_enabled" json:"is_enabled"`
+		} `yaml:"github" mapstructure:"github" json:"github"`
+
+		Microsoft struct {
+			ClientID     string   `yaml:"client_id" mapstructure:"client_id" json:"client_id"`
+			ClientSecret string   `yaml:"client_secret" mapstructure:"client_secret" json:"client_secret"`
+			RedirectURI  string   `yaml:"redirect_uri" mapstructure:"redirect_uri" json:"redirect_uri"`
+			Scopes       []string `yaml:"scopes" mapstructure:"scopes" json:"scopes"`
+			// Tenant can be a tenant ID or one of common, organizations
+			// or consumers
+			Tenant    string `yaml:"tenant" mapstructure:"tenant" json:"tenant"`
+			IsEnabled bool   `yaml:"is_enabled" mapstructure:"is_enabled" json:"is_enabled"`
+		} `yaml:"microsoft" mapstructure:"microsoft" json:"microsoft"`
+
+		// OIDC allows signing in with any OpenID Connect compliant
+		// identity provider. Endpoints are discovered from the issuer
+		OIDC struct {
+			// Name is used to select the provider when signing in.
+			// e.g /auth/connect/{name}
+			Name         string   `yaml:"name" mapstructure:"name" json:"name"`
+			IssuerURL    string   `yaml:"issuer_url" mapstructure:"issuer_url" json:"issuer_url"`
+			ClientID     string   `yaml:"client_id" mapstructure:"client_id" json:"client_id"`
+			ClientSecret string   `yaml:"client_secret" mapstructure:"client_secret" json:"client_secret"`
+			RedirectURI  string   `yaml:"redirect_uri" mapstructure:"redirect_uri" json:"redirect_uri"`
+			Scopes       []string `yaml:"scopes" mapstructure:"scopes" json:"scopes"`
+			IsEnabled    bool     `yaml:"is_enabled" mapstructure:"is_enabled" json:"is_enabled"`
+		} `yaml:"oidc" mapstructure:"oidc" json:"oidc"`
+
 		JWT struct {
 			Key string `yaml:"key" mapstructure:"key" json:"key"`
 		} `yaml:"jwt" mapstructure:"jwt" json:"jwt"`
@@ -265,10 +332,6 @@ func (c *Config) Validate() error {
 		c.Uploader.S3.DeckBucket = "deck"
 	}
 
-	if !c.Auth.Google.IsEnabled {
-		return errors.New("at least one oauth authentication provider has to be turned on")
-	}
-
 	if c.Auth.Google.IsEnabled {
 
 		if hermes.IsStringEmpty(c.Auth.Google.ClientID) {
@@ -280,6 +343,58 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Auth.GitHub.IsEnabled {
+
+		if hermes.IsStringEmpty(c.Auth.GitHub.ClientID) {
+			return errors.New("please provide GitHub oauth key")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.GitHub.ClientSecret) {
+			return errors.New("please provide GitHub oauth secret")
+		}
+	}
+
+	if c.Auth.Microsoft.IsEnabled {
+
+		if hermes.IsStringEmpty(c.Auth.Microsoft.ClientID) {
+			return errors.New("please provide Microsoft oauth key")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.Microsoft.ClientSecret) {
+			return errors.New("please provide Microsoft oauth secret")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.Microsoft.Tenant) {
+			c.Auth.Microsoft.Tenant = "common"
+		}
+	}
+
+	if c.Auth.OIDC.IsEnabled {
+
+		if hermes.IsStringEmpty(c.Auth.OIDC.Name) {
+			return errors.New("please provide a name for your OIDC provider")
+		}
+
+		// providers are registered by name so a custom provider cannot
+		// share the name of a built in one
+		if slices.Contains([]string{"google", "github", "microsoft"},
+			strings.ToLower(strings.TrimSpace(c.Auth.OIDC.Name))) {
+			return errors.New("OIDC provider name is reserved for a built in provider")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.OIDC.IssuerURL) {
+			return errors.New("please provide your OIDC issuer url")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.OIDC.ClientID) {
+			return errors.New("please provide OIDC client id")
+		}
+
+		if hermes.IsStringEmpty(c.Auth.OIDC.ClientSecret) {
+			return errors.New("please provide OIDC client secret")
+		}
+	}
+
 	if hermes.IsStringEmpty(c.Auth.JWT.Key) {
 		return errors.New("please provide your JWT key")
 	}
@@ -288,6 +403,10 @@ func (c *Config) Validate() error {
 		return errors.New("email provider is not currently supported")
 	}
 
+	if c.Email.Provider == EmailProviderSes && len(c.Email.SES.TopicArns) == 0 {
+		return errors.New("please provide the sns topic arns ses events are published to")
+	}
+
 	// trial days is required. We do not want to
 	// somehow enforce users to provide a payment method/card
 	// before they can get into the app
@@ -49,6 +49,10 @@ const (
 	EmailProviderResend EmailProvider = "resend"
 	// EmailProviderSendgrid is a EmailProvider of type sendgrid.
 	EmailProviderSendgrid EmailProvider = "sendgrid"
+	// EmailProviderSes is a EmailProvider of type ses.
+	EmailProviderSes EmailProvider = "ses"
+	// EmailProviderPostmark is a EmailProvider of type postmark.
+	EmailProviderPostmark EmailProvider = "postmark"
 )
 
 var ErrInvalidEmailProvider = errors.New("not a valid EmailProvider")
@@ -69,6 +73,8 @@ var _EmailProviderValue = map[string]EmailProvider{
 	"smtp":     EmailProviderSmtp,
 	"resend":   EmailProviderResend,
 	"sendgrid": EmailProviderSendgrid,
+	"ses":      EmailProviderSes,
+	"postmark": EmailProviderPostmark,
 }
 
 // ParseEmailProvider attempts to convert a string to a EmailProvider.
@@ -13,6 +13,7 @@ package malak
 //go:generate mockgen -source=internal/pkg/jwttoken/jwt.go -destination=internal/pkg/jwttoken/mocks/token.go
 //go:generate mockgen -source=internal/pkg/queue/queue.go -destination=mocks/queue.go -package=malak_mocks
 //go:generate mockgen -source=internal/pkg/cache/cache.go -destination=mocks/cache.go -package=malak_mocks
+//go:generate mockgen -source=internal/pkg/collaboration/collaboration.go -destination=mocks/collaboration.go -package=malak_mocks
 //go:generate mockgen -source=user.go -destination=mocks/user.go -package=malak_mocks
 //go:generate mockgen -source=plan.go -destination=mocks/plan.go -package=malak_mocks
 //go:generate mockgen -source=workspace.go -destination=mocks/workspace.go -package=malak_mocks
@@ -35,3 +36,13 @@ package malak
 //go:generate mockgen -source=api_key.go -destination=mocks/api_key.go -package=malak_mocks
 //go:generate mockgen -source=fundraising.go -destination=mocks/fundraising.go -package=malak_mocks
 //go:generate mockgen -source=auth.go -destination=mocks/auth.go -package=malak_mocks
+//go:generate mockgen -source=team.go -destination=mocks/team.go -package=malak_mocks
+//go:generate mockgen -source=twofactor.go -destination=mocks/twofactor.go -package=malak_mocks
+//go:generate mockgen -source=audit_log.go -destination=mocks/audit_log.go -package=malak_mocks
+//go:generate mockgen -source=webhook.go -destination=mocks/webhook.go -package=malak_mocks
+//go:generate mockgen -source=internal/pkg/webhook/webhook.go -destination=internal/pkg/webhook/mocks/webhook.go -package=webhook_mocks
+//go:generate mockgen -source=suppression.go -destination=mocks/suppression.go -package=malak_mocks
+//go:generate mockgen -source=update_comment.go -destination=mocks/update_comment.go -package=malak_mocks
+//go:generate mockgen -source=update_link.go -destination=mocks/update_link.go -package=malak_mocks
+//go:generate mockgen -source=update_recurring.go -destination=mocks/update_recurring.go -package=malak_mocks
+//go:generate mockgen -source=sending_domain.go -destination=mocks/sending_domain.go -package=malak_mocks
@@ -2,7 +2,10 @@ package malak
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -123,6 +126,83 @@ type IntegrationChart struct {
 	bun.BaseModel `json:"-"`
 }
 
+// FormatValue formats a data point of the chart for display. Currency
+// values are stored in cents
+func (c IntegrationChart) FormatValue(v int64) string {
+	if c.DataPointType != IntegrationDataPointTypeCurrency {
+		return formatThousands(v)
+	}
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	return fmt.Sprintf("%s$%s.%02d", sign, formatThousands(v/100), v%100)
+}
+
+// FormatMetric formats the data points of the chart for an inline metric.
+// Points must be ordered from the oldest. The month over month delta
+// compares the latest point with the last one recorded a month before it.
+// An empty string is returned if there is not enough data
+func (c IntegrationChart) FormatMetric(points []IntegrationDataPoint, value InlineMetricValue) string {
+	if len(points) == 0 {
+		return ""
+	}
+
+	latest := points[len(points)-1]
+
+	if value != InlineMetricValueMomDelta {
+		return c.FormatValue(latest.PointValue)
+	}
+
+	monthAgo := latest.CreatedAt.AddDate(0, -1, 0)
+
+	var previous *IntegrationDataPoint
+	for i := len(points) - 2; i >= 0; i-- {
+		if !points[i].CreatedAt.After(monthAgo) {
+			previous = &points[i]
+			break
+		}
+	}
+
+	if previous == nil {
+		return ""
+	}
+
+	delta := latest.PointValue - previous.PointValue
+
+	formatted := c.FormatValue(delta)
+	if delta >= 0 {
+		formatted = "+" + formatted
+	}
+
+	if previous.PointValue == 0 {
+		return formatted
+	}
+
+	percent := float64(delta) / math.Abs(float64(previous.PointValue)) * 100
+
+	return fmt.Sprintf("%s (%+.1f%%)", formatted, percent)
+}
+
+func formatThousands(v int64) string {
+	s := strconv.FormatInt(v, 10)
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		s = s[1:]
+	}
+
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+
+	return sign + s
+}
+
 // keeping it simple here with a new struct
 type IntegrationDataValues struct {
 	// here so it is easy to find the chart this data point belongs to
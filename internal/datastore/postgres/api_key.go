@@ -64,6 +64,10 @@ func (r *apiKeyImpl) List(ctx context.Context, worskpaceID uuid.UUID) ([]malak.A
 }
 
 func (r *apiKeyImpl) Create(ctx context.Context, apiKey *malak.APIKey) error {
+	if apiKey.Scopes == nil {
+		apiKey.Scopes = []malak.Permission{}
+	}
+
 	return r.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
 
 		_, err := tx.NewInsert().Model(apiKey).Exec(ctx)
@@ -109,3 +113,12 @@ func (r *apiKeyImpl) Revoke(ctx context.Context, opts malak.RevokeAPIKeyOptions)
 	_, err := q.Exec(ctx)
 	return err
 }
+
+func (r *apiKeyImpl) UpdateLastUsed(ctx context.Context, apiKey *malak.APIKey) error {
+	_, err := r.inner.NewUpdate().
+		Model(apiKey).
+		Column("last_used_at", "last_used_ip").
+		Where("id = ?", apiKey.ID).
+		Exec(ctx)
+	return err
+}
@@ -285,3 +285,45 @@ func TestAPIKey_MaxLimit(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, keys, 15)
 }
+
+func TestAPIKey_ScopesAndLastUsed(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewAPIKeyRepository(client)
+
+	apiKey := &malak.APIKey{
+		ID:          uuid.New(),
+		WorkspaceID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+		CreatedBy:   uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6"),
+		Reference:   malak.NewReferenceGenerator().Generate(malak.EntityTypeApiKey),
+		Value:       "scoped_value",
+		KeyName:     "scoped_key",
+		Scopes: []malak.Permission{
+			malak.PermissionContactsRead,
+			malak.PermissionDecksRead,
+		},
+	}
+
+	require.NoError(t, repo.Create(t.Context(), apiKey))
+
+	fetched, err := repo.FetchByValue(t.Context(), apiKey.Value)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.Scopes, fetched.Scopes)
+	require.Nil(t, fetched.LastUsedAt)
+	require.Empty(t, fetched.LastUsedIP)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	fetched.LastUsedAt = &now
+	fetched.LastUsedIP = "10.0.0.1"
+
+	require.NoError(t, repo.UpdateLastUsed(t.Context(), fetched))
+
+	fetched, err = repo.FetchByValue(t.Context(), apiKey.Value)
+	require.NoError(t, err)
+	require.NotNil(t, fetched.LastUsedAt)
+	require.True(t, now.Equal(*fetched.LastUsedAt))
+	require.Equal(t, "10.0.0.1", fetched.LastUsedIP)
+	require.Equal(t, apiKey.Scopes, fetched.Scopes)
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type auditLogRepo struct {
+	inner *bun.DB
+}
+
+func NewAuditLogRepository(db *bun.DB) malak.AuditLogRepository {
+	return &auditLogRepo{
+		inner: db,
+	}
+}
+
+func (a *auditLogRepo) Create(ctx context.Context, log *malak.AuditLog) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	if log.Metadata == nil {
+		log.Metadata = malak.AuditLogMetadata{}
+	}
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	_, err := a.inner.NewInsert().Model(log).Exec(ctx)
+	return err
+}
+
+func (a *auditLogRepo) List(ctx context.Context,
+	opts malak.ListAuditLogOptions) ([]malak.AuditLog, int64, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	logs := make([]malak.AuditLog, 0, opts.Paginator.PerPage)
+
+	q := a.inner.NewSelect().
+		Order("created_at DESC").
+		Where("workspace_id = ?", opts.WorkspaceID)
+
+	if opts.ActorID != uuid.Nil {
+		q = q.Where("actor_id = ?", opts.ActorID)
+	}
+
+	if opts.ActorType.IsValid() {
+		q = q.Where("actor_type = ?", opts.ActorType)
+	}
+
+	if opts.EntityType.IsValid() {
+		q = q.Where("entity_type = ?", opts.EntityType)
+	}
+
+	if opts.From != nil {
+		q = q.Where("created_at >= ?", opts.From)
+	}
+
+	if opts.To != nil {
+		q = q.Where("created_at <= ?", opts.To)
+	}
+
+	total, err := q.
+		Model(&logs).
+		Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = q.Model(&logs).
+		Limit(int(opts.Paginator.PerPage)).
+		Offset(int(opts.Paginator.Offset())).
+		Scan(ctx)
+
+	return logs, int64(total), err
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ayinke-llc/malak"
+)
+
+func TestAuditLog(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewAuditLogRepository(client)
+
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	apiKeyID := uuid.New()
+
+	entries := []*malak.AuditLog{
+		{
+			WorkspaceID:     workspaceID,
+			ActorType:       malak.AuditLogActorTypeUser,
+			ActorID:         userID,
+			Action:          malak.AuditLogActionDeckDeleted,
+			EntityType:      malak.EntityTypeDeck,
+			EntityReference: "deck_oops",
+			IPAddress:       "127.0.0.1",
+			RequestID:       "request-1",
+		},
+		{
+			WorkspaceID:     workspaceID,
+			ActorType:       malak.AuditLogActorTypeUser,
+			ActorID:         userID,
+			Action:          malak.AuditLogActionApiKeyRevoked,
+			EntityType:      malak.EntityTypeApiKey,
+			EntityReference: "api_key_oops",
+			Metadata: malak.AuditLogMetadata{
+				"strategy": malak.RevocationTypeImmediate.String(),
+			},
+		},
+		{
+			WorkspaceID:     workspaceID,
+			ActorType:       malak.AuditLogActorTypeApiKey,
+			ActorID:         apiKeyID,
+			Action:          malak.AuditLogActionUpdateSent,
+			EntityType:      malak.EntityTypeUpdate,
+			EntityReference: "update_oops",
+		},
+	}
+
+	for _, entry := range entries {
+		require.NoError(t, repo.Create(t.Context(), entry))
+	}
+
+	paginator := malak.Paginator{
+		Page:    1,
+		PerPage: 10,
+	}
+
+	logs, total, err := repo.List(t.Context(), malak.ListAuditLogOptions{
+		Paginator:   paginator,
+		WorkspaceID: workspaceID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, logs, 3)
+
+	logs, total, err = repo.List(t.Context(), malak.ListAuditLogOptions{
+		Paginator:   paginator,
+		WorkspaceID: workspaceID,
+		ActorID:     userID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, logs, 2)
+
+	logs, total, err = repo.List(t.Context(), malak.ListAuditLogOptions{
+		Paginator:   paginator,
+		WorkspaceID: workspaceID,
+		ActorType:   malak.AuditLogActorTypeApiKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, apiKeyID, logs[0].ActorID)
+
+	logs, total, err = repo.List(t.Context(), malak.ListAuditLogOptions{
+		Paginator:   paginator,
+		WorkspaceID: workspaceID,
+		EntityType:  malak.EntityTypeApiKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "immediate", logs[0].Metadata["strategy"])
+
+	future := time.Now().Add(time.Hour)
+
+	_, total, err = repo.List(t.Context(), malak.ListAuditLogOptions{
+		Paginator:   paginator,
+		WorkspaceID: workspaceID,
+		From:        &future,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+
+	// audit logs cannot be changed once written
+	_, err = client.NewDelete().
+		Model(new(malak.AuditLog)).
+		Where("id = ?", entries[0].ID).
+		Exec(t.Context())
+	require.Error(t, err)
+
+	_, err = client.NewUpdate().
+		Model(new(malak.AuditLog)).
+		Set("action = ?", malak.AuditLogActionUpdateSent).
+		Where("id = ?", entries[0].ID).
+		Exec(t.Context())
+	require.Error(t, err)
+}
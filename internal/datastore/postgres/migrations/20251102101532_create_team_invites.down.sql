@@ -0,0 +1,2 @@
+DROP TABLE team_invites;
+DROP TYPE team_invite_status;
@@ -0,0 +1,22 @@
+CREATE TYPE team_invite_status AS ENUM ('pending', 'accepted', 'declined');
+
+CREATE TABLE team_invites (
+  id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  workspace_id uuid NOT NULL REFERENCES workspaces(id),
+  invited_by uuid NOT NULL REFERENCES users(id),
+  reference VARCHAR (220) UNIQUE NOT NULL,
+  email VARCHAR (220) NOT NULL,
+  role user_role NOT NULL DEFAULT 'member',
+  token VARCHAR(100) UNIQUE NOT NULL,
+  status team_invite_status NOT NULL DEFAULT 'pending',
+
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE team_invites ADD CONSTRAINT reference_check_key
+  CHECK (reference ~ 'invite_[a-zA-Z0-9._]+');
+
+CREATE INDEX IF NOT EXISTS idx_team_invites_workspace_id ON team_invites(workspace_id);
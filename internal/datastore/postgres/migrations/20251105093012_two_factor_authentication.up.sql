@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN two_factor_secret TEXT;
+ALTER TABLE users ADD COLUMN two_factor_enabled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE two_factor_recovery_codes(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL REFERENCES users(id),
+    code_hash VARCHAR(100) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_two_factor_recovery_codes_user_id_code_hash ON two_factor_recovery_codes(user_id, code_hash);
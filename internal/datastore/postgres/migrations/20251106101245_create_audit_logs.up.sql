@@ -0,0 +1,25 @@
+CREATE TABLE audit_logs(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id uuid NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_reference VARCHAR(220) NOT NULL DEFAULT '',
+    metadata jsonb NOT NULL DEFAULT '{}'::jsonb,
+    ip_address VARCHAR(100) NOT NULL DEFAULT '',
+    request_id VARCHAR(200) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_workspace_id_created_at ON audit_logs(workspace_id, created_at DESC);
+
+CREATE OR REPLACE FUNCTION prevent_audit_log_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit logs are append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_changes();
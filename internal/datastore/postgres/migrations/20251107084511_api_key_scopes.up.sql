@@ -0,0 +1,6 @@
+ALTER TABLE api_keys ADD COLUMN scopes JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE api_keys ADD COLUMN last_used_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE api_keys ADD COLUMN last_used_ip VARCHAR(100);
+
+-- keys could only be used to ingest data points before scopes existed
+UPDATE api_keys SET scopes = '["ingest:write"]'::jsonb;
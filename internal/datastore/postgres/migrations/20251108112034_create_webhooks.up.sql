@@ -0,0 +1,47 @@
+CREATE TABLE webhooks(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    created_by uuid NOT NULL REFERENCES users(id),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    url TEXT NOT NULL,
+    description VARCHAR(220) NOT NULL DEFAULT '',
+    events jsonb NOT NULL DEFAULT '[]'::jsonb,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    secret VARCHAR(220) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_reference_check_key
+  CHECK (reference ~ 'webhook_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_webhooks_workspace_id ON webhooks(workspace_id);
+
+CREATE TABLE webhook_deliveries(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id uuid NOT NULL REFERENCES webhooks(id),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    payload jsonb NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    response_status_code INTEGER NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_reference_check_key
+  CHECK (reference ~ 'webhook_delivery_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_next_attempt_at ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
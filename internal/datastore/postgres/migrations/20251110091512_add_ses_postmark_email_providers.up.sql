@@ -0,0 +1,2 @@
+ALTER TYPE update_recipient_email_provider ADD VALUE 'ses';
+ALTER TYPE update_recipient_email_provider ADD VALUE 'postmark';
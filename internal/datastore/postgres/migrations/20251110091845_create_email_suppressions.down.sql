@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_suppressions;
+ALTER TABLE update_recipient_stats DROP COLUMN IF EXISTS is_complained;
@@ -0,0 +1,18 @@
+ALTER TABLE update_recipient_stats ADD COLUMN is_complained BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE email_suppressions(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    contact_id uuid NOT NULL REFERENCES contacts(id),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    provider VARCHAR(50),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE email_suppressions ADD CONSTRAINT email_suppressions_reference_check_key
+  CHECK (reference ~ 'suppression_[a-zA-Z0-9._]+');
+
+CREATE UNIQUE INDEX idx_email_suppressions_workspace_id_contact_id ON email_suppressions(workspace_id, contact_id);
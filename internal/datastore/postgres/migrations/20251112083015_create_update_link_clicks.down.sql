@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS update_link_clicks;
+ALTER TABLE update_recipient_stats DROP COLUMN IF EXISTS total_clicks;
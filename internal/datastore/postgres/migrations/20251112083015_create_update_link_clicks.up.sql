@@ -0,0 +1,12 @@
+ALTER TABLE update_recipient_stats ADD COLUMN total_clicks INT NOT NULL DEFAULT 0;
+
+CREATE TABLE update_link_clicks(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    update_id uuid NOT NULL REFERENCES updates(id),
+    recipient_id uuid NOT NULL REFERENCES update_recipients(id),
+    url TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_update_link_clicks_update_id ON update_link_clicks(update_id);
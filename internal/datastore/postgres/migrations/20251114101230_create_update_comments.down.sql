@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS update_comments;
+ALTER TABLE update_recipient_stats DROP COLUMN IF EXISTS reaction;
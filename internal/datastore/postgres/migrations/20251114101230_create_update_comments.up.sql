@@ -0,0 +1,22 @@
+ALTER TABLE update_recipient_stats ADD COLUMN reaction VARCHAR(50);
+
+CREATE TABLE update_comments(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    update_id uuid NOT NULL REFERENCES updates(id),
+    recipient_id uuid NOT NULL REFERENCES update_recipients(id),
+    parent_id uuid REFERENCES update_comments(id),
+    author_type VARCHAR(50) NOT NULL,
+    author_name VARCHAR(220) NOT NULL DEFAULT '',
+    user_id uuid REFERENCES users(id),
+    content TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE update_comments ADD CONSTRAINT update_comments_reference_check_key
+  CHECK (reference ~ 'update_comment_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_update_comments_update_id ON update_comments(update_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS update_viewer_sessions;
+DROP INDEX IF EXISTS idx_update_links_update_id;
+ALTER TABLE update_links DROP COLUMN IF EXISTS password;
+ALTER TABLE update_links DROP COLUMN IF EXISTS is_password_protected;
+ALTER TABLE update_links DROP COLUMN IF EXISTS created_by;
+ALTER TABLE update_links DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE updates DROP COLUMN IF EXISTS is_public;
@@ -0,0 +1,39 @@
+ALTER TABLE updates ADD COLUMN is_public BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE update_links ADD COLUMN workspace_id uuid REFERENCES workspaces(id);
+ALTER TABLE update_links ADD COLUMN created_by uuid REFERENCES users(id);
+ALTER TABLE update_links ADD COLUMN is_password_protected BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE update_links ADD COLUMN password VARCHAR(220);
+
+UPDATE update_links SET workspace_id = updates.workspace_id, created_by = updates.created_by
+  FROM updates WHERE updates.id = update_links.update_id;
+
+ALTER TABLE update_links ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE update_links ALTER COLUMN created_by SET NOT NULL;
+
+CREATE INDEX idx_update_links_update_id ON update_links(update_id);
+
+CREATE TABLE update_viewer_sessions(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    update_id uuid NOT NULL REFERENCES updates(id),
+    link_id uuid REFERENCES update_links(id), -- NULL when viewed from the workspace page
+    source VARCHAR(50) NOT NULL,
+    session_id VARCHAR(200) UNIQUE NOT NULL,
+    device_info VARCHAR(255),
+    browser VARCHAR(100),
+    os VARCHAR(100),
+    ip_address VARCHAR(45),
+    country VARCHAR(100),
+    city VARCHAR(100),
+    time_spent_seconds INT NOT NULL DEFAULT 0,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE update_viewer_sessions ADD CONSTRAINT update_viewer_sessions_reference_check_key
+  CHECK (reference ~ 'update_viewer_session_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_update_viewer_sessions_update_id ON update_viewer_sessions(update_id);
@@ -0,0 +1,25 @@
+CREATE TABLE recurring_update_schedules(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    created_by uuid NOT NULL REFERENCES users(id),
+    template_reference VARCHAR(220) NOT NULL,
+    frequency VARCHAR(50) NOT NULL,
+    day_of_month SMALLINT NOT NULL CHECK (day_of_month BETWEEN 1 AND 28),
+    hour SMALLINT NOT NULL CHECK (hour BETWEEN 0 AND 23),
+    lead_days SMALLINT NOT NULL,
+    emails jsonb NOT NULL DEFAULT '[]'::jsonb,
+    next_send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    draft_update_id uuid REFERENCES updates(id), -- NULL until the draft for the next occurrence is created
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE recurring_update_schedules ADD CONSTRAINT recurring_update_schedules_reference_check_key
+  CHECK (reference ~ 'recurring_update_schedule_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_recurring_update_schedules_workspace_id ON recurring_update_schedules(workspace_id);
+CREATE INDEX idx_recurring_update_schedules_next_send_at ON recurring_update_schedules(next_send_at) WHERE is_active = TRUE;
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_update_schedules DROP COLUMN IF EXISTS is_system_template;
+DROP TABLE IF EXISTS workspace_templates;
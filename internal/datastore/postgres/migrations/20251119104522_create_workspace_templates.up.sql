@@ -0,0 +1,22 @@
+CREATE TABLE workspace_templates(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    created_by uuid NOT NULL REFERENCES users(id),
+    title VARCHAR(220) NOT NULL,
+    description TEXT,
+    content jsonb NOT NULL DEFAULT '[]'::jsonb,
+    number_of_uses INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE workspace_templates ADD CONSTRAINT workspace_templates_reference_check_key
+  CHECK (reference ~ 'workspace_template_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_workspace_templates_workspace_id ON workspace_templates(workspace_id);
+
+-- recurring schedules could only use system templates until now
+ALTER TABLE recurring_update_schedules ADD COLUMN is_system_template BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,19 @@
+CREATE TABLE update_revisions(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    update_id uuid NOT NULL REFERENCES updates(id),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    created_by uuid NOT NULL REFERENCES users(id),
+    title VARCHAR(210) NOT NULL,
+    content jsonb NOT NULL DEFAULT '[]'::jsonb,
+    is_sent BOOLEAN NOT NULL DEFAULT FALSE,
+    schedule_id uuid REFERENCES update_schedules(id), -- only set for the version that was sent
+    restored_from uuid REFERENCES update_revisions(id),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE update_revisions ADD CONSTRAINT update_revisions_reference_check_key
+  CHECK (reference ~ 'update_revision_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_update_revisions_update_id ON update_revisions(update_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS update_approvals;
+ALTER TABLE preferences DROP COLUMN IF EXISTS approval;
+ALTER TABLE updates DROP COLUMN IF EXISTS approval_status;
@@ -0,0 +1,19 @@
+ALTER TABLE updates ADD COLUMN approval_status VARCHAR(20) NOT NULL DEFAULT 'draft';
+ALTER TABLE preferences ADD COLUMN approval jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE TABLE update_approvals(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    update_id uuid NOT NULL REFERENCES updates(id),
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    approver_id uuid NOT NULL REFERENCES users(id),
+    decision VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE update_approvals ADD CONSTRAINT update_approvals_reference_check_key
+  CHECK (reference ~ 'update_approval_[a-zA-Z0-9._]+');
+
+CREATE INDEX idx_update_approvals_update_id ON update_approvals(update_id);
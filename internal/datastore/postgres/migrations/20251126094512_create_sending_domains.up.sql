@@ -0,0 +1,24 @@
+CREATE TABLE sending_domains(
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference VARCHAR(220) UNIQUE NOT NULL,
+    workspace_id uuid NOT NULL REFERENCES workspaces(id),
+    domain VARCHAR(253) NOT NULL,
+    sender VARCHAR(254) NOT NULL,
+    selector VARCHAR(63) NOT NULL,
+    public_key TEXT NOT NULL,
+    private_key TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    verified_at TIMESTAMP WITH TIME ZONE,
+    last_checked_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE sending_domains ADD CONSTRAINT sending_domains_reference_check_key
+  CHECK (reference ~ 'sending_domain_[a-zA-Z0-9._]+');
+
+-- a workspace can only have one sending domain at a time
+CREATE UNIQUE INDEX idx_sending_domains_workspace_id ON sending_domains(workspace_id)
+  WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- responses of failed deliveries are no longer kept. They could be the
+-- reply of an internal service the endpoint redirected to
+UPDATE webhook_deliveries SET response_body = '' WHERE status <> 'succeeded';
@@ -0,0 +1,2 @@
+ALTER TABLE password_resets RENAME COLUMN token_hash TO token;
+ALTER TABLE team_invites RENAME COLUMN token_hash TO token;
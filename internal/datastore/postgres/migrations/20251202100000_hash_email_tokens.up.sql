@@ -0,0 +1,5 @@
+ALTER TABLE password_resets RENAME COLUMN token TO token_hash;
+UPDATE password_resets SET token_hash = encode(sha256(token_hash::bytea), 'hex');
+
+ALTER TABLE team_invites RENAME COLUMN token TO token_hash;
+UPDATE team_invites SET token_hash = encode(sha256(token_hash::bytea), 'hex');
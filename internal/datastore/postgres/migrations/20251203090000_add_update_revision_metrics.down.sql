@@ -0,0 +1 @@
+ALTER TABLE update_revisions DROP COLUMN IF EXISTS metrics;
@@ -0,0 +1 @@
+ALTER TABLE update_revisions ADD COLUMN metrics jsonb;
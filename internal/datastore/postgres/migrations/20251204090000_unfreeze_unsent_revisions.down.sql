@@ -0,0 +1 @@
+-- no down on purpose
@@ -0,0 +1,6 @@
+-- sent revisions used to be frozen when the schedule was created. Schedules
+-- that were cancelled or have not gone out yet never sent that version
+UPDATE update_revisions SET is_sent = FALSE
+WHERE is_sent AND schedule_id IN (
+    SELECT id FROM update_schedules WHERE status IN ('scheduled', 'cancelled')
+);
@@ -0,0 +1 @@
+ALTER TYPE update_recipients_status ADD VALUE 'skipped';
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type passwordResetRepo struct {
+	inner *bun.DB
+}
+
+func NewPasswordResetRepository(db *bun.DB) malak.PasswordResetRepository {
+	return &passwordResetRepo{
+		inner: db,
+	}
+}
+
+func (p *passwordResetRepo) Create(ctx context.Context, reset *malak.PasswordReset) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return p.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			_, err := tx.NewDelete().
+				Model(&malak.PasswordReset{}).
+				Where("user_id = ?", reset.UserID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewInsert().Model(reset).Exec(ctx)
+			return err
+		})
+}
+
+func (p *passwordResetRepo) Get(ctx context.Context, token string) (*malak.PasswordReset, error) {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	reset := new(malak.PasswordReset)
+
+	err := p.inner.NewSelect().
+		Model(reset).
+		Where("token_hash = ?", malak.HashToken(token)).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrPasswordResetNotFound
+	}
+
+	return reset, err
+}
+
+func (p *passwordResetRepo) Reset(ctx context.Context, opts *malak.ResetPasswordOptions) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return p.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			_, err := tx.NewUpdate().
+				Model(new(malak.User)).
+				Set("password = ?", opts.Password).
+				Set("updated_at = ?", time.Now()).
+				Where("id = ?", opts.Reset.UserID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewDelete().
+				Model(&malak.PasswordReset{}).
+				Where("user_id = ?", opts.Reset.UserID).
+				Exec(ctx)
+			return err
+		})
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ayinke-llc/malak"
+)
+
+func TestPasswordReset(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewPasswordResetRepository(client)
+
+	userRepo := NewUserRepository(client)
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	_, err = repo.Get(t.Context(), "oops")
+	require.ErrorIs(t, err, malak.ErrPasswordResetNotFound)
+
+	first, err := malak.NewPasswordReset(user)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(t.Context(), first))
+
+	second, err := malak.NewPasswordReset(user)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(t.Context(), second))
+
+	// the token itself is never stored
+	var stored string
+	require.NoError(t, client.NewSelect().
+		Table("password_resets").
+		Column("token_hash").
+		Where("id = ?", second.ID).
+		Scan(t.Context(), &stored))
+	require.Equal(t, malak.HashToken(second.Token), stored)
+
+	// only the latest token is valid
+	_, err = repo.Get(t.Context(), first.Token)
+	require.ErrorIs(t, err, malak.ErrPasswordResetNotFound)
+
+	reset, err := repo.Get(t.Context(), second.Token)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, reset.ID)
+	require.False(t, reset.IsExpired())
+
+	hashed, err := malak.HashPassword("n3w-Passw0rd!!")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Reset(t.Context(), &malak.ResetPasswordOptions{
+		Reset:    reset,
+		Password: hashed,
+	}))
+
+	_, err = repo.Get(t.Context(), second.Token)
+	require.ErrorIs(t, err, malak.ErrPasswordResetNotFound)
+
+	user, err = userRepo.Get(t.Context(), &malak.FindUserOptions{
+		ID: user.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, user.HasPassword())
+	require.True(t, malak.VerifyPassword(*user.Password, "n3w-Passw0rd!!"))
+}
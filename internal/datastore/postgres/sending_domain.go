@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sendingDomainRepo struct {
+	inner *bun.DB
+}
+
+func NewSendingDomainRepository(db *bun.DB) malak.SendingDomainRepository {
+	return &sendingDomainRepo{
+		inner: db,
+	}
+}
+
+func (s *sendingDomainRepo) Create(ctx context.Context,
+	domain *malak.SendingDomain) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := s.inner.NewInsert().
+		Model(domain).
+		Exec(ctx)
+	if err != nil && strings.Contains(err.Error(), "duplicate key value violates") {
+		return malak.ErrSendingDomainExists
+	}
+
+	return err
+}
+
+func (s *sendingDomainRepo) Update(ctx context.Context,
+	domain *malak.SendingDomain) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	domain.UpdatedAt = time.Now()
+
+	_, err := s.inner.NewUpdate().
+		Model(domain).
+		Where("id = ?", domain.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *sendingDomainRepo) Delete(ctx context.Context,
+	domain *malak.SendingDomain) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := s.inner.NewDelete().
+		Model(domain).
+		Where("id = ?", domain.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *sendingDomainRepo) Get(ctx context.Context,
+	workspaceID uuid.UUID) (*malak.SendingDomain, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	domain := new(malak.SendingDomain)
+
+	err := s.inner.NewSelect().
+		Model(domain).
+		Where("workspace_id = ?", workspaceID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrSendingDomainNotFound
+	}
+
+	return domain, err
+}
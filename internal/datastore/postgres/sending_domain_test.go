@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendingDomain(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewSendingDomainRepository(client)
+
+	// from workspaces.yml migration
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	_, err := repo.Get(t.Context(), workspaceID)
+	require.ErrorIs(t, err, malak.ErrSendingDomainNotFound)
+
+	domain := &malak.SendingDomain{
+		Reference:   refGenerator.Generate(malak.EntityTypeSendingDomain),
+		WorkspaceID: workspaceID,
+		Domain:      "ayinke.ventures",
+		Sender:      malak.Email("updates@ayinke.ventures"),
+		Selector:    "malak",
+		PublicKey:   "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA",
+		PrivateKey:  "a4ae79a2-9b76-40d7-b5a1-661e60a02cb0/dkim",
+		Status:      malak.SendingDomainStatusPending,
+	}
+
+	require.NoError(t, repo.Create(t.Context(), domain))
+
+	// only one domain per workspace
+	require.ErrorIs(t, repo.Create(t.Context(), &malak.SendingDomain{
+		Reference:   refGenerator.Generate(malak.EntityTypeSendingDomain),
+		WorkspaceID: workspaceID,
+		Domain:      "malak.vc",
+		Sender:      malak.Email("updates@malak.vc"),
+		Selector:    "malak",
+		PublicKey:   "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA",
+		PrivateKey:  "a4ae79a2-9b76-40d7-b5a1-661e60a02cb0/dkim",
+		Status:      malak.SendingDomainStatusPending,
+	}), malak.ErrSendingDomainExists)
+
+	fetched, err := repo.Get(t.Context(), workspaceID)
+	require.NoError(t, err)
+	require.Equal(t, domain.Reference, fetched.Reference)
+	require.False(t, fetched.IsVerified())
+
+	now := time.Now()
+	fetched.Status = malak.SendingDomainStatusVerified
+	fetched.VerifiedAt = &now
+
+	require.NoError(t, repo.Update(t.Context(), fetched))
+
+	fetched, err = repo.Get(t.Context(), workspaceID)
+	require.NoError(t, err)
+	require.True(t, fetched.IsVerified())
+	require.NotNil(t, fetched.VerifiedAt)
+
+	require.NoError(t, repo.Delete(t.Context(), fetched))
+
+	_, err = repo.Get(t.Context(), workspaceID)
+	require.ErrorIs(t, err, malak.ErrSendingDomainNotFound)
+
+	// the domain can be registered again once deleted
+	domain.ID = uuid.Nil
+	domain.Reference = refGenerator.Generate(malak.EntityTypeSendingDomain)
+	require.NoError(t, repo.Create(t.Context(), domain))
+}
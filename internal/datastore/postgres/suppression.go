@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type suppressionRepo struct {
+	inner *bun.DB
+}
+
+func NewSuppressionRepository(db *bun.DB) malak.SuppressionRepository {
+	return &suppressionRepo{
+		inner: db,
+	}
+}
+
+func (s *suppressionRepo) Create(ctx context.Context,
+	suppression *malak.Suppression) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := s.inner.NewInsert().
+		Model(suppression).
+		On("CONFLICT (workspace_id,contact_id) DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+func (s *suppressionRepo) Get(ctx context.Context,
+	opts malak.FetchSuppressionOptions) (*malak.Suppression, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	suppression := new(malak.Suppression)
+
+	err := s.inner.NewSelect().
+		Model(suppression).
+		Relation("Contact").
+		Where("suppression.workspace_id = ?", opts.WorkspaceID).
+		Where("suppression.reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrSuppressionNotFound
+	}
+
+	return suppression, err
+}
+
+func (s *suppressionRepo) List(ctx context.Context,
+	opts malak.ListSuppressionOptions) ([]malak.Suppression, int64, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	suppressions := make([]malak.Suppression, 0, opts.Paginator.PerPage)
+
+	q := s.inner.NewSelect().
+		Model(&suppressions).
+		Relation("Contact").
+		Where("suppression.workspace_id = ?", opts.WorkspaceID)
+
+	total, err := q.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = q.Order("suppression.created_at DESC").
+		Limit(int(opts.Paginator.PerPage)).
+		Offset(int(opts.Paginator.Offset())).
+		Scan(ctx)
+
+	return suppressions, int64(total), err
+}
+
+func (s *suppressionRepo) Delete(ctx context.Context,
+	suppression *malak.Suppression) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := s.inner.NewDelete().
+		Model(suppression).
+		Where("id = ?", suppression.ID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppression_SendUpdateSkipsSuppressedContacts(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	contactRepo := NewContactRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+	suppressionRepo := NewSuppressionRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	contact := &malak.Contact{
+		Email:       malak.Email("bounced@example.com"),
+		WorkspaceID: workspace.ID,
+		CreatedBy:   userID,
+		OwnerID:     userID,
+		Reference:   refGenerator.Generate(malak.EntityTypeContact),
+	}
+
+	require.NoError(t, contactRepo.Create(t.Context(), contact))
+
+	suppression := &malak.Suppression{
+		WorkspaceID: workspace.ID,
+		ContactID:   contact.ID,
+		Reference:   refGenerator.Generate(malak.EntityTypeSuppression),
+		Reason:      malak.SuppressionReasonBounced,
+		Provider:    malak.UpdateRecipientLogProviderSes,
+	}
+
+	require.NoError(t, suppressionRepo.Create(t.Context(), suppression))
+
+	// suppressing twice is not an error
+	require.NoError(t, suppressionRepo.Create(t.Context(), &malak.Suppression{
+		WorkspaceID: workspace.ID,
+		ContactID:   contact.ID,
+		Reference:   refGenerator.Generate(malak.EntityTypeSuppression),
+		Reason:      malak.SuppressionReasonComplained,
+	}))
+
+	update := &malak.Update{
+		WorkspaceID: workspace.ID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   userID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	err = updatesRepo.SendUpdate(t.Context(), &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return string(refGenerator.Generate(et))
+		},
+		Generator:       refGenerator,
+		Emails:          []malak.Email{contact.Email, malak.Email("investor@example.com")},
+		UserID:          userID,
+		UpdateReference: update.Reference,
+		Schedule: &malak.UpdateSchedule{
+			Reference:   refGenerator.Generate(malak.EntityTypeSchedule),
+			SendAt:      time.Now(),
+			UpdateType:  malak.UpdateTypeLive,
+			ScheduledBy: userID,
+			Status:      malak.UpdateSendScheduleScheduled,
+			UpdateID:    update.ID,
+		},
+		WorkspaceID: workspace.ID,
+		Plan:        workspace.Plan,
+	})
+	require.NoError(t, err)
+
+	var recipients []malak.UpdateRecipient
+
+	err = client.NewSelect().
+		Model(&recipients).
+		Where("update_id = ?", update.ID).
+		Scan(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, recipients, 1)
+	require.NotEqual(t, contact.ID, recipients[0].ContactID)
+
+	recipient, err := updatesRepo.GetRecipient(t.Context(), recipients[0].Reference)
+	require.NoError(t, err)
+	require.Equal(t, recipients[0].ID, recipient.ID)
+
+	_, err = updatesRepo.GetRecipient(t.Context(), "recipient_oops")
+	require.ErrorIs(t, err, malak.ErrUpdateRecipientNotFound)
+}
+
+func TestSuppression_ListAndDelete(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	contactRepo := NewContactRepository(client)
+	suppressionRepo := NewSuppressionRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	contact := &malak.Contact{
+		Email:       malak.Email("unsubscribed@example.com"),
+		WorkspaceID: workspaceID,
+		CreatedBy:   userID,
+		OwnerID:     userID,
+		Reference:   refGenerator.Generate(malak.EntityTypeContact),
+	}
+
+	require.NoError(t, contactRepo.Create(t.Context(), contact))
+
+	suppression := &malak.Suppression{
+		WorkspaceID: workspaceID,
+		ContactID:   contact.ID,
+		Reference:   refGenerator.Generate(malak.EntityTypeSuppression),
+		Reason:      malak.SuppressionReasonUnsubscribed,
+	}
+
+	require.NoError(t, suppressionRepo.Create(t.Context(), suppression))
+
+	suppressions, total, err := suppressionRepo.List(t.Context(), malak.ListSuppressionOptions{
+		WorkspaceID: workspaceID,
+		Paginator: malak.Paginator{
+			PerPage: 10,
+			Page:    1,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, suppressions, 1)
+	require.Equal(t, contact.Email, suppressions[0].Contact.Email)
+
+	fetched, err := suppressionRepo.Get(t.Context(), malak.FetchSuppressionOptions{
+		WorkspaceID: workspaceID,
+		Reference:   suppression.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, malak.SuppressionReasonUnsubscribed, fetched.Reason)
+
+	_, err = suppressionRepo.Get(t.Context(), malak.FetchSuppressionOptions{
+		WorkspaceID: uuid.New(),
+		Reference:   suppression.Reference,
+	})
+	require.ErrorIs(t, err, malak.ErrSuppressionNotFound)
+
+	require.NoError(t, suppressionRepo.Delete(t.Context(), fetched))
+
+	_, total, err = suppressionRepo.List(t.Context(), malak.ListSuppressionOptions{
+		WorkspaceID: workspaceID,
+		Paginator: malak.Paginator{
+			PerPage: 10,
+			Page:    1,
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type teamRepo struct {
+	inner *bun.DB
+}
+
+func NewTeamRepository(db *bun.DB) malak.TeamRepository {
+	return &teamRepo{
+		inner: db,
+	}
+}
+
+func (t *teamRepo) CreateInvite(ctx context.Context,
+	opts *malak.CreateTeamInviteOptions) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			// lock the workspace so concurrent invites are counted one
+			// after the other and cannot both take the last seat
+			err := tx.NewSelect().
+				Model(new(malak.Workspace)).
+				Column("id").
+				Where("id = ?", opts.Invite.WorkspaceID).
+				For("UPDATE").
+				Scan(ctx)
+			if err != nil {
+				return err
+			}
+
+			// only keep the latest pending invite for an email
+			_, err = tx.NewDelete().
+				Model(new(malak.TeamInvite)).
+				Where("workspace_id = ?", opts.Invite.WorkspaceID).
+				Where("email = ?", opts.Invite.Email.String()).
+				Where("status = ?", malak.TeamInviteStatusPending).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewInsert().Model(opts.Invite).Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			members, err := tx.NewSelect().
+				Model(new(malak.UserRole)).
+				Where("workspace_id = ?", opts.Invite.WorkspaceID).
+				Count(ctx)
+			if err != nil {
+				return err
+			}
+
+			pendingInvites, err := tx.NewSelect().
+				Model(new(malak.TeamInvite)).
+				Where("workspace_id = ?", opts.Invite.WorkspaceID).
+				Where("status = ?", malak.TeamInviteStatusPending).
+				Where("expires_at > ?", time.Now()).
+				Count(ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := opts.Plan.Metadata.Team.Size.TakeN(int64(members + pendingInvites)); err != nil {
+				return malak.ErrTeamSeatsExhausted
+			}
+
+			return nil
+		})
+}
+
+func (t *teamRepo) GetInvite(ctx context.Context,
+	opts malak.FetchTeamInviteOptions) (*malak.TeamInvite, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	invite := new(malak.TeamInvite)
+
+	err := t.inner.NewSelect().
+		Model(invite).
+		Where("token_hash = ?", malak.HashToken(opts.Token)).
+		Where("status = ?", malak.TeamInviteStatusPending).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrTeamInviteNotFound
+	}
+
+	return invite, err
+}
+
+func (t *teamRepo) AcceptInvite(ctx context.Context,
+	opts *malak.AcceptTeamInviteOptions) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			exists, err := tx.NewSelect().
+				Model(new(malak.UserRole)).
+				Where("workspace_id = ?", opts.Invite.WorkspaceID).
+				Where("user_id = ?", opts.User.ID).
+				Exists(ctx)
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				return malak.ErrTeamMemberExists
+			}
+
+			opts.Invite.Status = malak.TeamInviteStatusAccepted
+			opts.Invite.UpdatedAt = time.Now()
+
+			_, err = tx.NewUpdate().
+				Model(opts.Invite).
+				Where("id = ?", opts.Invite.ID).
+				Column("status", "updated_at").
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			role := &malak.UserRole{
+				WorkspaceID: opts.Invite.WorkspaceID,
+				UserID:      opts.User.ID,
+				Role:        opts.Invite.Role,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			}
+
+			_, err = tx.NewInsert().Model(role).Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			// take the user straight into the workspace they just joined
+			opts.User.Metadata.CurrentWorkspace = opts.Invite.WorkspaceID
+			_, err = tx.NewUpdate().
+				Model(opts.User).
+				Where("id = ?", opts.User.ID).
+				Exec(ctx)
+			return err
+		})
+}
+
+func (t *teamRepo) DeclineInvite(ctx context.Context,
+	invite *malak.TeamInvite) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	invite.Status = malak.TeamInviteStatusDeclined
+	invite.UpdatedAt = time.Now()
+
+	_, err := t.inner.NewUpdate().
+		Model(invite).
+		Where("id = ?", invite.ID).
+		Column("status", "updated_at").
+		Exec(ctx)
+	return err
+}
+
+func (t *teamRepo) ListMembers(ctx context.Context,
+	workspaceID uuid.UUID) ([]malak.UserRole, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	members := make([]malak.UserRole, 0)
+
+	return members, t.inner.NewSelect().
+		Model(&members).
+		Relation("User").
+		Where("user_role.workspace_id = ?", workspaceID).
+		Order("user_role.created_at ASC").
+		Scan(ctx)
+}
+
+func (t *teamRepo) GetMember(ctx context.Context,
+	opts malak.FetchTeamMemberOptions) (*malak.UserRole, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	member := new(malak.UserRole)
+
+	err := t.inner.NewSelect().
+		Model(member).
+		Relation("User").
+		Where("user_role.workspace_id = ?", opts.WorkspaceID).
+		Where("user_role.user_id = ?", opts.UserID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrTeamMemberNotFound
+	}
+
+	return member, err
+}
+
+func (t *teamRepo) UpdateMemberRole(ctx context.Context,
+	member *malak.UserRole) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	member.UpdatedAt = time.Now()
+
+	_, err := t.inner.NewUpdate().
+		Model(member).
+		Where("id = ?", member.ID).
+		Column("role", "updated_at").
+		Exec(ctx)
+	return err
+}
+
+func (t *teamRepo) RemoveMember(ctx context.Context,
+	member *malak.UserRole) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			_, err := tx.NewDelete().
+				Model(new(malak.UserRole)).
+				Where("id = ?", member.ID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			// if they were currently in this workspace, reset it so they
+			// get asked to pick or create another one
+			_, err = tx.NewUpdate().
+				Model(new(malak.User)).
+				Set("metadata = jsonb_set(metadata, '{current_workspace}', to_jsonb(?::text))", uuid.Nil.String()).
+				Where("id = ?", member.UserID).
+				Where("metadata->>'current_workspace' = ?", member.WorkspaceID.String()).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			// they can no longer approve updates in the workspace
+			_, err = tx.NewUpdate().
+				Model(new(malak.Preference)).
+				Set(`approval = jsonb_set(approval, '{approvers}',
+					COALESCE((SELECT jsonb_agg(approver) FROM jsonb_array_elements(approval->'approvers') approver
+						WHERE approver <> to_jsonb(?::text)), '[]'::jsonb))`, member.UserID.String()).
+				Where("workspace_id = ?", member.WorkspaceID).
+				Where("approval->'approvers' IS NOT NULL").
+				Exec(ctx)
+			return err
+		})
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ayinke-llc/malak"
+)
+
+func TestTeam_Invite(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewTeamRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	inviter, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	gen := malak.NewReferenceGenerator()
+
+	t.Run("seats exhausted", func(t *testing.T) {
+		invite, err := malak.NewTeamInvite(workspace, inviter, "oops@test.com",
+			malak.RoleMember, gen.Generate(malak.EntityTypeInvite))
+		require.NoError(t, err)
+
+		plan := &malak.Plan{}
+		plan.Metadata.Team.Size = 1
+
+		err = repo.CreateInvite(t.Context(), &malak.CreateTeamInviteOptions{
+			Invite: invite,
+			Plan:   plan,
+		})
+		require.ErrorIs(t, err, malak.ErrTeamSeatsExhausted)
+
+		_, err = repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+			Token: invite.Token,
+		})
+		require.ErrorIs(t, err, malak.ErrTeamInviteNotFound)
+	})
+
+	t.Run("reinvite with another casing replaces the pending invite", func(t *testing.T) {
+		plan := &malak.Plan{}
+		plan.Metadata.Team.Size = 5
+
+		first, err := malak.NewTeamInvite(workspace, inviter, "again@test.com",
+			malak.RoleMember, gen.Generate(malak.EntityTypeInvite))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.CreateInvite(t.Context(), &malak.CreateTeamInviteOptions{
+			Invite: first,
+			Plan:   plan,
+		}))
+
+		second, err := malak.NewTeamInvite(workspace, inviter, malak.Email(" Again@Test.com"),
+			malak.RoleMember, gen.Generate(malak.EntityTypeInvite))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.CreateInvite(t.Context(), &malak.CreateTeamInviteOptions{
+			Invite: second,
+			Plan:   plan,
+		}))
+
+		_, err = repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+			Token: first.Token,
+		})
+		require.ErrorIs(t, err, malak.ErrTeamInviteNotFound)
+
+		fetched, err := repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+			Token: second.Token,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "again@test.com", string(fetched.Email))
+	})
+
+	plan := &malak.Plan{}
+	plan.Metadata.Team.Size = 5
+
+	invite, err := malak.NewTeamInvite(workspace, inviter, "teammate@test.com",
+		malak.RoleMember, gen.Generate(malak.EntityTypeInvite))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateInvite(t.Context(), &malak.CreateTeamInviteOptions{
+		Invite: invite,
+		Plan:   plan,
+	}))
+
+	fetchedInvite, err := repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+		Token: invite.Token,
+	})
+	require.NoError(t, err)
+	require.Equal(t, invite.ID, fetchedInvite.ID)
+	require.Equal(t, malak.TeamInviteStatusPending, fetchedInvite.Status)
+
+	user := &malak.User{
+		Email:    "teammate@test.com",
+		FullName: "Team mate",
+		Metadata: &malak.UserMetadata{},
+	}
+	require.NoError(t, userRepo.Create(t.Context(), user))
+
+	require.NoError(t, repo.AcceptInvite(t.Context(), &malak.AcceptTeamInviteOptions{
+		Invite: fetchedInvite,
+		User:   user,
+	}))
+
+	_, err = repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+		Token: invite.Token,
+	})
+	require.ErrorIs(t, err, malak.ErrTeamInviteNotFound)
+
+	members, err := repo.ListMembers(t.Context(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	member, err := repo.GetMember(t.Context(), malak.FetchTeamMemberOptions{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, malak.RoleMember, member.Role)
+	require.Equal(t, user.Email, member.User.Email)
+
+	member.Role = malak.RoleInvestor
+	require.NoError(t, repo.UpdateMemberRole(t.Context(), member))
+
+	member, err = repo.GetMember(t.Context(), malak.FetchTeamMemberOptions{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, malak.RoleInvestor, member.Role)
+
+	preferences := malak.NewPreference(workspace)
+	preferences.Approval = malak.ApprovalPreferences{
+		RequireApproval: true,
+		Approvers:       []uuid.UUID{inviter.ID, user.ID},
+	}
+
+	_, err = client.NewInsert().Model(preferences).Exec(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, repo.RemoveMember(t.Context(), member))
+
+	preferences, err = NewPreferenceRepository(client).Get(t.Context(), workspace)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{inviter.ID}, preferences.Approval.Approvers)
+
+	_, err = repo.GetMember(t.Context(), malak.FetchTeamMemberOptions{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+	})
+	require.ErrorIs(t, err, malak.ErrTeamMemberNotFound)
+
+	user, err = userRepo.Get(t.Context(), &malak.FindUserOptions{
+		ID: user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uuid.Nil, user.Metadata.CurrentWorkspace)
+}
+
+func TestTeam_DeclineInvite(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewTeamRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	inviter, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	invite, err := malak.NewTeamInvite(workspace, inviter, "teammate@test.com",
+		malak.RoleGuest, malak.NewReferenceGenerator().Generate(malak.EntityTypeInvite))
+	require.NoError(t, err)
+
+	plan := &malak.Plan{}
+	plan.Metadata.Team.Size = 5
+
+	require.NoError(t, repo.CreateInvite(t.Context(), &malak.CreateTeamInviteOptions{
+		Invite: invite,
+		Plan:   plan,
+	}))
+
+	require.NoError(t, repo.DeclineInvite(t.Context(), invite))
+
+	_, err = repo.GetInvite(t.Context(), malak.FetchTeamInviteOptions{
+		Token: invite.Token,
+	})
+	require.ErrorIs(t, err, malak.ErrTeamInviteNotFound)
+}
@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/ayinke-llc/malak"
 	"github.com/uptrace/bun"
@@ -39,3 +42,106 @@ func (t *templateRepo) System(ctx context.Context,
 
 	return templates, q.Scan(ctx)
 }
+
+func (t *templateRepo) Get(ctx context.Context,
+	reference malak.Reference) (*malak.SystemTemplate, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	template := new(malak.SystemTemplate)
+
+	err := t.inner.NewSelect().
+		Model(template).
+		Where("reference = ?", reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrTemplateNotFound
+	}
+
+	return template, err
+}
+
+func (t *templateRepo) Workspace(ctx context.Context,
+	opts malak.ListWorkspaceTemplateOptions) ([]malak.WorkspaceTemplate, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	templates := make([]malak.WorkspaceTemplate, 0)
+
+	q := t.inner.NewSelect().
+		Model(&templates).
+		Where("workspace_id = ?", opts.WorkspaceID)
+
+	switch opts.Filter {
+	case malak.SystemTemplateFilterMostUsed:
+		q = q.Order("number_of_uses DESC")
+	case malak.SystemTemplateFilterRecentlyCreated:
+		q = q.Order("created_at DESC")
+	default:
+		q = q.Order("updated_at DESC")
+	}
+
+	return templates, q.Scan(ctx)
+}
+
+func (t *templateRepo) GetWorkspaceTemplate(ctx context.Context,
+	opts malak.FetchWorkspaceTemplateOptions) (*malak.WorkspaceTemplate, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	template := new(malak.WorkspaceTemplate)
+
+	err := t.inner.NewSelect().
+		Model(template).
+		Where("workspace_id = ?", opts.WorkspaceID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrTemplateNotFound
+	}
+
+	return template, err
+}
+
+func (t *templateRepo) CreateWorkspaceTemplate(ctx context.Context,
+	template *malak.WorkspaceTemplate) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := t.inner.NewInsert().
+		Model(template).
+		Exec(ctx)
+	return err
+}
+
+func (t *templateRepo) UpdateWorkspaceTemplate(ctx context.Context,
+	template *malak.WorkspaceTemplate) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	template.UpdatedAt = time.Now()
+
+	_, err := t.inner.NewUpdate().
+		Model(template).
+		Where("id = ?", template.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *templateRepo) DeleteWorkspaceTemplate(ctx context.Context,
+	template *malak.WorkspaceTemplate) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := t.inner.NewDelete().
+		Model(template).
+		Where("id = ?", template.ID).
+		Exec(ctx)
+	return err
+}
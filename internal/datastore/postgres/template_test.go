@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,94 @@ func TestTemplateRepository_System(t *testing.T) {
 		require.Equal(t, "Oops template", result[1].Title)
 	})
 }
+
+func TestTemplateRepository_Get(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	templateRepo := NewTemplateRepository(client)
+
+	template, err := templateRepo.Get(t.Context(), malak.Reference("system_template_fQv90I.w_I"))
+	require.NoError(t, err)
+	require.Equal(t, malak.Reference("system_template_fQv90I.w_I"), template.Reference)
+
+	_, err = templateRepo.Get(t.Context(), malak.Reference("system_template_unknown"))
+	require.ErrorIs(t, err, malak.ErrTemplateNotFound)
+}
+
+func TestTemplateRepository_WorkspaceTemplates(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	templateRepo := NewTemplateRepository(client)
+	updatesRepo := NewUpdatesRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	// from workspaces.yml migration
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+	otherWorkspaceID := uuid.MustParse("c12da796-9362-4c70-b2cb-fc8a1eba2526")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	tmpl := &malak.WorkspaceTemplate{
+		WorkspaceID: workspaceID,
+		CreatedBy:   userID,
+		Reference:   refGenerator.Generate(malak.EntityTypeWorkspaceTemplate),
+		Title:       "Monthly investor update",
+		Content:     malak.BlockContents{},
+	}
+
+	require.NoError(t, templateRepo.CreateWorkspaceTemplate(t.Context(), tmpl))
+
+	templates, err := templateRepo.Workspace(t.Context(), malak.ListWorkspaceTemplateOptions{
+		WorkspaceID: workspaceID,
+		Filter:      malak.SystemTemplateFilterAll,
+	})
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	// templates are private to the workspace
+	templates, err = templateRepo.Workspace(t.Context(), malak.ListWorkspaceTemplateOptions{
+		WorkspaceID: otherWorkspaceID,
+		Filter:      malak.SystemTemplateFilterAll,
+	})
+	require.NoError(t, err)
+	require.Len(t, templates, 0)
+
+	_, err = templateRepo.GetWorkspaceTemplate(t.Context(), malak.FetchWorkspaceTemplateOptions{
+		WorkspaceID: otherWorkspaceID,
+		Reference:   tmpl.Reference,
+	})
+	require.ErrorIs(t, err, malak.ErrTemplateNotFound)
+
+	tmpl.Title = "Quarterly investor update"
+	require.NoError(t, templateRepo.UpdateWorkspaceTemplate(t.Context(), tmpl))
+
+	require.NoError(t, updatesRepo.Create(t.Context(), &malak.Update{
+		WorkspaceID: workspaceID,
+		Status:      malak.UpdateStatusDraft,
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+		CreatedBy:   userID,
+		Title:       "November update",
+		Content:     malak.BlockContents{},
+	}, &malak.TemplateCreateUpdateOptions{
+		IsFromTemplate: true,
+		Reference:      tmpl.Reference,
+	}))
+
+	fetched, err := templateRepo.GetWorkspaceTemplate(t.Context(), malak.FetchWorkspaceTemplateOptions{
+		WorkspaceID: workspaceID,
+		Reference:   tmpl.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Quarterly investor update", fetched.Title)
+	require.Equal(t, 1, fetched.NumberOfUses)
+
+	require.NoError(t, templateRepo.DeleteWorkspaceTemplate(t.Context(), fetched))
+
+	_, err = templateRepo.GetWorkspaceTemplate(t.Context(), malak.FetchWorkspaceTemplateOptions{
+		WorkspaceID: workspaceID,
+		Reference:   tmpl.Reference,
+	})
+	require.ErrorIs(t, err, malak.ErrTemplateNotFound)
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type twoFactorRepo struct {
+	inner *bun.DB
+}
+
+func NewTwoFactorRepository(db *bun.DB) malak.TwoFactorRepository {
+	return &twoFactorRepo{
+		inner: db,
+	}
+}
+
+func (t *twoFactorRepo) SaveSecret(ctx context.Context,
+	user *malak.User, secret string) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := t.inner.NewUpdate().
+		Model(user).
+		Set("two_factor_secret = ?", secret).
+		Set("two_factor_enabled_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", user.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *twoFactorRepo) Enable(ctx context.Context,
+	user *malak.User, codes []malak.TwoFactorRecoveryCode) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			_, err := tx.NewUpdate().
+				Model(user).
+				Set("two_factor_enabled_at = ?", time.Now()).
+				Set("updated_at = ?", time.Now()).
+				Where("id = ?", user.ID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			return replaceRecoveryCodes(ctx, tx, user, codes)
+		})
+}
+
+func (t *twoFactorRepo) Disable(ctx context.Context, user *malak.User) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			_, err := tx.NewUpdate().
+				Model(user).
+				Set("two_factor_secret = NULL").
+				Set("two_factor_enabled_at = NULL").
+				Set("updated_at = ?", time.Now()).
+				Where("id = ?", user.ID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewDelete().
+				Model(&malak.TwoFactorRecoveryCode{}).
+				Where("user_id = ?", user.ID).
+				Exec(ctx)
+			return err
+		})
+}
+
+func (t *twoFactorRepo) ReplaceRecoveryCodes(ctx context.Context,
+	user *malak.User, codes []malak.TwoFactorRecoveryCode) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return t.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+			return replaceRecoveryCodes(ctx, tx, user, codes)
+		})
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx bun.Tx,
+	user *malak.User, codes []malak.TwoFactorRecoveryCode) error {
+
+	_, err := tx.NewDelete().
+		Model(&malak.TwoFactorRecoveryCode{}).
+		Where("user_id = ?", user.ID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(codes) == 0 {
+		return nil
+	}
+
+	_, err = tx.NewInsert().Model(&codes).Exec(ctx)
+	return err
+}
+
+func (t *twoFactorRepo) UseRecoveryCode(ctx context.Context,
+	user *malak.User, code string) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	res, err := t.inner.NewUpdate().
+		Model(&malak.TwoFactorRecoveryCode{}).
+		Set("used_at = ?", time.Now()).
+		Where("user_id = ?", user.ID).
+		Where("code_hash = ?", malak.HashRecoveryCode(code)).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return malak.ErrTwoFactorRecoveryCodeNotFound
+	}
+
+	return nil
+}
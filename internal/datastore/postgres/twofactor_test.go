@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ayinke-llc/malak"
+)
+
+func TestTwoFactor(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewTwoFactorRepository(client)
+
+	userRepo := NewUserRepository(client)
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+	require.False(t, user.IsTwoFactorEnabled())
+
+	require.NoError(t, repo.SaveSecret(t.Context(), user, "JBSWY3DPEHPK3PXP"))
+
+	user, err = userRepo.Get(t.Context(), &malak.FindUserOptions{
+		ID: user.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, user.TwoFactorSecret)
+	// a pending secret does not turn on 2FA
+	require.False(t, user.IsTwoFactorEnabled())
+
+	codes, records, err := malak.NewRecoveryCodes(user)
+	require.NoError(t, err)
+	require.Len(t, codes, malak.NumberOfRecoveryCodes)
+
+	require.NoError(t, repo.Enable(t.Context(), user, records))
+
+	user, err = userRepo.Get(t.Context(), &malak.FindUserOptions{
+		ID: user.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, user.IsTwoFactorEnabled())
+
+	require.ErrorIs(t, repo.UseRecoveryCode(t.Context(), user, "oops"),
+		malak.ErrTwoFactorRecoveryCodeNotFound)
+
+	require.NoError(t, repo.UseRecoveryCode(t.Context(), user, codes[0]))
+
+	// codes can only be used once
+	require.ErrorIs(t, repo.UseRecoveryCode(t.Context(), user, codes[0]),
+		malak.ErrTwoFactorRecoveryCodeNotFound)
+
+	newCodes, newRecords, err := malak.NewRecoveryCodes(user)
+	require.NoError(t, err)
+	require.NoError(t, repo.ReplaceRecoveryCodes(t.Context(), user, newRecords))
+
+	// old codes are no longer valid once regenerated
+	require.ErrorIs(t, repo.UseRecoveryCode(t.Context(), user, codes[1]),
+		malak.ErrTwoFactorRecoveryCodeNotFound)
+
+	require.NoError(t, repo.UseRecoveryCode(t.Context(), user, newCodes[1]))
+
+	require.NoError(t, repo.Disable(t.Context(), user))
+
+	user, err = userRepo.Get(t.Context(), &malak.FindUserOptions{
+		ID: user.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, user.IsTwoFactorEnabled())
+	require.Nil(t, user.TwoFactorSecret)
+
+	require.ErrorIs(t, repo.UseRecoveryCode(t.Context(), user, newCodes[2]),
+		malak.ErrTwoFactorRecoveryCodeNotFound)
+}
@@ -36,8 +36,17 @@ func (u *updatesRepo) Create(ctx context.Context,
 			return err
 		}
 
-		if opts.IsSystemTemplate {
-			res, err := tx.NewUpdate().Model(new(malak.SystemTemplate)).
+		if opts.IsSystemTemplate || opts.IsFromTemplate {
+			var q *bun.UpdateQuery
+
+			if opts.IsSystemTemplate {
+				q = tx.NewUpdate().Model(new(malak.SystemTemplate))
+			} else {
+				q = tx.NewUpdate().Model(new(malak.WorkspaceTemplate)).
+					Where("workspace_id = ?", update.WorkspaceID)
+			}
+
+			res, err := q.
 				Where("reference = ?", opts.Reference).
 				Set("number_of_uses = number_of_uses + 1").
 				Exec(ctx)
@@ -117,6 +126,150 @@ func (u *updatesRepo) Update(ctx context.Context,
 	return err
 }
 
+func (u *updatesRepo) UpdateContent(ctx context.Context,
+	update *malak.Update, revision *malak.UpdateRevision) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	update.UpdatedAt = time.Now()
+
+	return u.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// only the edited columns are written so a concurrent send is
+		// never overwritten
+		res, err := tx.NewUpdate().
+			Model(update).
+			Column("title", "content", "approval_status", "updated_at").
+			Where("id = ?", update.ID).
+			Where("status <> ?", malak.UpdateStatusSent).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return malak.ErrUpdateSent
+		}
+
+		_, err = tx.NewInsert().
+			Model(revision).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (u *updatesRepo) ListRevisions(ctx context.Context,
+	updateID uuid.UUID) ([]malak.UpdateRevision, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	revisions := make([]malak.UpdateRevision, 0)
+
+	return revisions, u.inner.NewSelect().
+		Model(&revisions).
+		Where("update_id = ?", updateID).
+		Order("created_at DESC").
+		Scan(ctx)
+}
+
+func (u *updatesRepo) GetRevision(ctx context.Context,
+	opts malak.FetchUpdateRevisionOptions) (*malak.UpdateRevision, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	revision := &malak.UpdateRevision{}
+
+	err := u.inner.NewSelect().
+		Model(revision).
+		Where("update_id = ?", opts.UpdateID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateRevisionNotFound
+	}
+
+	return revision, err
+}
+
+func (u *updatesRepo) GetSentRevision(ctx context.Context,
+	updateID uuid.UUID) (*malak.UpdateRevision, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	revision := &malak.UpdateRevision{}
+
+	err := u.inner.NewSelect().
+		Model(revision).
+		Where("update_id = ?", updateID).
+		Where("is_sent = ?", true).
+		Order("created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateRevisionNotFound
+	}
+
+	return revision, err
+}
+
+func (u *updatesRepo) AddApproval(ctx context.Context,
+	update *malak.Update, approval *malak.UpdateApproval) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return u.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// another approver could have reviewed it already
+		res, err := tx.NewUpdate().
+			Model(update).
+			Set("approval_status = ?", update.ApprovalStatus).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", update.ID).
+			Where("approval_status = ?", malak.UpdateApprovalStatusInReview).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return malak.ErrUpdateNotInReview
+		}
+
+		_, err = tx.NewInsert().
+			Model(approval).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (u *updatesRepo) ListApprovals(ctx context.Context,
+	updateID uuid.UUID) ([]malak.UpdateApproval, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	approvals := make([]malak.UpdateApproval, 0)
+
+	return approvals, u.inner.NewSelect().
+		Model(&approvals).
+		Where("update_id = ?", updateID).
+		Order("created_at DESC").
+		Scan(ctx)
+}
+
 func (u *updatesRepo) UpdateStat(ctx context.Context,
 	stat *malak.UpdateStat,
 	recipientStat *malak.UpdateRecipientStat) error {
@@ -297,6 +450,147 @@ func (u *updatesRepo) GetSchedule(ctx context.Context, scheduleID uuid.UUID) (
 	return schedule, err
 }
 
+func (u *updatesRepo) FindSchedule(ctx context.Context,
+	opts malak.FetchUpdateScheduleOptions) (*malak.UpdateSchedule, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedule := &malak.UpdateSchedule{}
+
+	err := u.inner.NewSelect().
+		Model(schedule).
+		Where("update_id = ?", opts.UpdateID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateScheduleNotFound
+	}
+
+	return schedule, err
+}
+
+func (u *updatesRepo) ListSchedules(ctx context.Context,
+	updateID uuid.UUID) ([]malak.UpdateSchedule, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedules := make([]malak.UpdateSchedule, 0)
+
+	err := u.inner.NewSelect().
+		Model(&schedules).
+		Where("update_id = ?", updateID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return schedules, err
+}
+
+// The status condition is the same one the cron uses to claim a schedule
+// so only one of them can ever change a scheduled send
+func (u *updatesRepo) RescheduleSend(ctx context.Context,
+	schedule *malak.UpdateSchedule) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedule.UpdatedAt = time.Now()
+
+	res, err := u.inner.NewUpdate().
+		Model(schedule).
+		Column("send_at", "updated_at").
+		Where("id = ?", schedule.ID).
+		Where("status = ?", malak.UpdateSendScheduleScheduled).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return scheduleModified(res)
+}
+
+func (u *updatesRepo) CancelSchedule(ctx context.Context,
+	schedule *malak.UpdateSchedule) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return u.inner.RunInTx(ctx, &sql.TxOptions{},
+		func(ctx context.Context, tx bun.Tx) error {
+
+			schedule.Status = malak.UpdateSendScheduleCancelled
+			schedule.UpdatedAt = time.Now()
+
+			res, err := tx.NewUpdate().
+				Model(schedule).
+				Column("status", "updated_at").
+				Where("id = ?", schedule.ID).
+				Where("status = ?", malak.UpdateSendScheduleScheduled).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := scheduleModified(res); err != nil {
+				return err
+			}
+
+			var contactIDs []uuid.UUID
+
+			_, err = tx.NewDelete().
+				Model(new(malak.UpdateRecipient)).
+				Where("schedule_id = ?", schedule.ID).
+				Returning("contact_id").
+				Exec(ctx, &contactIDs)
+			if err != nil {
+				return err
+			}
+
+			if len(contactIDs) > 0 {
+				_, err = tx.NewDelete().
+					Model(new(malak.ContactShare)).
+					Where("item_id = ?", schedule.UpdateID).
+					Where("contact_id IN (?)", bun.In(contactIDs)).
+					Exec(ctx)
+				if err != nil {
+					return err
+				}
+			}
+
+			// the update can be edited again unless it has been sent or
+			// is going out to other recipients
+			_, err = tx.NewUpdate().
+				Model(new(malak.Update)).
+				Set("status = ?", malak.UpdateStatusDraft).
+				Where("id = ?", schedule.UpdateID).
+				Where("NOT EXISTS (?)", tx.NewSelect().
+					Model(new(malak.UpdateSchedule)).
+					ColumnExpr("1").
+					Where("update_id = ?", schedule.UpdateID).
+					Where("update_type = ?", malak.UpdateTypeLive).
+					Where("status IN (?)", bun.In([]malak.UpdateSendSchedule{
+						malak.UpdateSendScheduleScheduled,
+						malak.UpdateSendScheduleProcessing,
+						malak.UpdateSendScheduleSent,
+					}))).
+				Exec(ctx)
+			return err
+		})
+}
+
+func scheduleModified(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return malak.ErrUpdateScheduleNotModifiable
+	}
+
+	return nil
+}
+
 func (u *updatesRepo) SendUpdate(ctx context.Context,
 	opts *malak.CreateUpdateOptions) error {
 
@@ -346,6 +640,12 @@ func (u *updatesRepo) SendUpdate(ctx context.Context,
 				Column("id").
 				Where("email IN (?)", bun.In(opts.Emails)).
 				Where("workspace_id = ?", opts.WorkspaceID).
+				// contacts that hard bounced or complained should never
+				// be sent to again
+				Where("id NOT IN (?)", tx.NewSelect().
+					Model(new(malak.Suppression)).
+					Column("contact_id").
+					Where("workspace_id = ?", opts.WorkspaceID)).
 				Scan(ctx, &insertedContactIDs)
 			if err != nil {
 				return err
@@ -466,6 +766,77 @@ func (u *updatesRepo) RecipientStat(ctx context.Context,
 		Scan(ctx)
 }
 
+func (u *updatesRepo) GetRecipient(ctx context.Context,
+	reference malak.Reference) (*malak.UpdateRecipient, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	recipient := new(malak.UpdateRecipient)
+
+	err := u.inner.NewSelect().
+		Model(recipient).
+		Where("update_recipient.reference = ?", reference).
+		Relation("UpdateRecipientStat").
+		Relation("Contact").
+		Relation("Contact.Lists").
+		Relation("Contact.Lists.List").
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateRecipientNotFound
+	}
+
+	return recipient, err
+}
+
+func (u *updatesRepo) RecordClick(ctx context.Context,
+	click *malak.UpdateLinkClick) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return u.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(click).Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewUpdate().
+			Model((*malak.UpdateStat)(nil)).
+			Set("total_clicks = total_clicks + 1").
+			Where("update_id = ?", click.UpdateID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*malak.UpdateRecipientStat)(nil)).
+			Set("total_clicks = total_clicks + 1").
+			Where("recipient_id = ?", click.RecipientID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (u *updatesRepo) LinkStats(ctx context.Context,
+	update *malak.Update) ([]malak.UpdateLinkStat, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	stats := make([]malak.UpdateLinkStat, 0)
+
+	return stats, u.inner.NewSelect().
+		Model((*malak.UpdateLinkClick)(nil)).
+		Column("url").
+		ColumnExpr("COUNT(*) AS total_clicks").
+		ColumnExpr("COUNT(DISTINCT recipient_id) AS unique_clicks").
+		Where("update_id = ?", update.ID).
+		Group("url").
+		OrderExpr("total_clicks DESC").
+		Scan(ctx, &stats)
+}
+
 func (u *updatesRepo) Overview(ctx context.Context, workspaceID uuid.UUID) (*malak.UpdateOverview, error) {
 	ctx, cancelFn := withContext(ctx)
 	defer cancelFn()
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type updateCommentRepo struct {
+	inner *bun.DB
+}
+
+func NewUpdateCommentRepository(db *bun.DB) malak.UpdateCommentRepository {
+	return &updateCommentRepo{
+		inner: db,
+	}
+}
+
+func (u *updateCommentRepo) Create(ctx context.Context,
+	comment *malak.UpdateComment) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := u.inner.NewInsert().
+		Model(comment).
+		Exec(ctx)
+	return err
+}
+
+func (u *updateCommentRepo) Get(ctx context.Context,
+	opts malak.FetchUpdateCommentOptions) (*malak.UpdateComment, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	comment := new(malak.UpdateComment)
+
+	err := u.inner.NewSelect().
+		Model(comment).
+		Relation("Recipient").
+		Relation("Recipient.Contact").
+		Where("update_comment.update_id = ?", opts.UpdateID).
+		Where("update_comment.reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateCommentNotFound
+	}
+
+	return comment, err
+}
+
+func (u *updateCommentRepo) List(ctx context.Context,
+	opts malak.ListUpdateCommentOptions) ([]malak.UpdateComment, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	comments := make([]malak.UpdateComment, 0)
+
+	q := u.inner.NewSelect().
+		Model(&comments).
+		Relation("Recipient").
+		Relation("Recipient.Contact").
+		Relation("User").
+		Where("update_comment.update_id = ?", opts.UpdateID)
+
+	if opts.RecipientID != uuid.Nil {
+		q = q.Where("update_comment.recipient_id = ?", opts.RecipientID)
+	}
+
+	err := q.Order("update_comment.created_at ASC").
+		Scan(ctx)
+	return comments, err
+}
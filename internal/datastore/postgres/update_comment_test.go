@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateComment_Threads(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	commentRepo := NewUpdateCommentRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	workspaceID := workspace.ID
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspaceID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   userID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	err = updatesRepo.SendUpdate(t.Context(), &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return string(refGenerator.Generate(et))
+		},
+		Generator:       refGenerator,
+		Emails:          []malak.Email{malak.Email("first@example.com"), malak.Email("second@example.com")},
+		UserID:          userID,
+		UpdateReference: update.Reference,
+		Schedule: &malak.UpdateSchedule{
+			Reference:   refGenerator.Generate(malak.EntityTypeSchedule),
+			SendAt:      time.Now(),
+			UpdateType:  malak.UpdateTypeLive,
+			ScheduledBy: userID,
+			Status:      malak.UpdateSendScheduleScheduled,
+			UpdateID:    update.ID,
+		},
+		WorkspaceID: workspaceID,
+		Plan:        workspace.Plan,
+	})
+	require.NoError(t, err)
+
+	var recipients []malak.UpdateRecipient
+
+	require.NoError(t, client.NewSelect().
+		Model(&recipients).
+		Where("update_id = ?", update.ID).
+		Scan(t.Context()))
+	require.Len(t, recipients, 2)
+
+	newComment := func(recipient malak.UpdateRecipient,
+		parent *malak.UpdateComment, author malak.UpdateCommentAuthor) *malak.UpdateComment {
+
+		comment := &malak.UpdateComment{
+			Reference:   refGenerator.Generate(malak.EntityTypeUpdateComment),
+			WorkspaceID: workspaceID,
+			UpdateID:    update.ID,
+			RecipientID: recipient.ID,
+			AuthorType:  author,
+			Content:     "great progress",
+		}
+
+		if parent != nil {
+			comment.ParentID = parent.ID
+		}
+
+		if author == malak.UpdateCommentAuthorTeam {
+			comment.UserID = userID
+		}
+
+		require.NoError(t, commentRepo.Create(t.Context(), comment))
+		return comment
+	}
+
+	first := newComment(recipients[0], nil, malak.UpdateCommentAuthorInvestor)
+	newComment(recipients[0], first, malak.UpdateCommentAuthorTeam)
+	newComment(recipients[1], nil, malak.UpdateCommentAuthorInvestor)
+
+	comments, err := commentRepo.List(t.Context(), malak.ListUpdateCommentOptions{
+		UpdateID: update.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	require.Len(t, malak.GroupUpdateComments(comments), 2)
+
+	comments, err = commentRepo.List(t.Context(), malak.ListUpdateCommentOptions{
+		UpdateID:    update.ID,
+		RecipientID: recipients[0].ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+
+	threads := malak.GroupUpdateComments(comments)
+	require.Len(t, threads, 1)
+	require.Len(t, threads[0].Replies, 1)
+	require.NotNil(t, threads[0].Replies[0].User)
+
+	fetched, err := commentRepo.Get(t.Context(), malak.FetchUpdateCommentOptions{
+		UpdateID:  update.ID,
+		Reference: first.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.ID, fetched.ID)
+	require.NotNil(t, fetched.Recipient)
+
+	_, err = commentRepo.Get(t.Context(), malak.FetchUpdateCommentOptions{
+		UpdateID:  update.ID,
+		Reference: "update_comment_oops",
+	})
+	require.ErrorIs(t, err, malak.ErrUpdateCommentNotFound)
+}
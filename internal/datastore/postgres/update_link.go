@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type updateLinkRepo struct {
+	inner *bun.DB
+}
+
+func NewUpdateLinkRepository(db *bun.DB) malak.UpdateLinkRepository {
+	return &updateLinkRepo{
+		inner: db,
+	}
+}
+
+func (u *updateLinkRepo) Create(ctx context.Context,
+	link *malak.UpdateLink) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := u.inner.NewInsert().
+		Model(link).
+		Exec(ctx)
+	return err
+}
+
+func (u *updateLinkRepo) Get(ctx context.Context,
+	opts malak.FetchUpdateLinkOptions) (*malak.UpdateLink, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	link := new(malak.UpdateLink)
+
+	err := u.inner.NewSelect().
+		Model(link).
+		Where("update_id = ?", opts.UpdateID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateLinkNotFound
+	}
+
+	return link, err
+}
+
+func (u *updateLinkRepo) List(ctx context.Context,
+	updateID uuid.UUID) ([]malak.UpdateLink, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	links := make([]malak.UpdateLink, 0)
+
+	err := u.inner.NewSelect().
+		Model(&links).
+		Where("update_id = ?", updateID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return links, err
+}
+
+func (u *updateLinkRepo) Revoke(ctx context.Context,
+	link *malak.UpdateLink) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := u.inner.NewDelete().
+		Model(link).
+		Where("id = ?", link.ID).
+		Exec(ctx)
+	return err
+}
+
+func (u *updateLinkRepo) PublicDetails(ctx context.Context,
+	ref malak.Reference) (*malak.UpdateLink, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	link := new(malak.UpdateLink)
+
+	err := u.inner.NewSelect().
+		Model(link).
+		Relation("Update").
+		Where("update_link.reference = ?", ref).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateLinkNotFound
+	}
+
+	return link, err
+}
+
+func (u *updateLinkRepo) ListPublicUpdates(ctx context.Context,
+	opts malak.ListPublicUpdatesOptions) ([]malak.Update, int64, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	updates := make([]malak.Update, 0, opts.Paginator.PerPage)
+
+	q := u.inner.NewSelect().
+		Model(&updates).
+		Where("workspace_id = ?", opts.WorkspaceID).
+		Where("status = ?", malak.UpdateStatusSent).
+		Where("is_public = ?", true)
+
+	total, err := q.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = q.Order("sent_at DESC").
+		Limit(int(opts.Paginator.PerPage)).
+		Offset(int(opts.Paginator.Offset())).
+		Scan(ctx)
+
+	return updates, int64(total), err
+}
+
+func (u *updateLinkRepo) CreateSession(ctx context.Context,
+	session *malak.UpdateViewerSession) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := u.inner.NewInsert().
+		Model(session).
+		Exec(ctx)
+	return err
+}
+
+func (u *updateLinkRepo) UpdateSession(ctx context.Context,
+	session *malak.UpdateViewerSession) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	session.UpdatedAt = time.Now()
+
+	_, err := u.inner.NewUpdate().
+		Model(session).
+		Where("id = ?", session.ID).
+		Exec(ctx)
+	return err
+}
+
+func (u *updateLinkRepo) FindSession(ctx context.Context,
+	sessionID malak.Reference) (*malak.UpdateViewerSession, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	session := new(malak.UpdateViewerSession)
+
+	err := u.inner.NewSelect().
+		Model(session).
+		Where("session_id = ?", sessionID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrUpdateViewerSessionNotFound
+	}
+
+	return session, err
+}
+
+func (u *updateLinkRepo) ListSessions(ctx context.Context,
+	opts malak.ListUpdateViewerSessionOptions) ([]malak.UpdateViewerSession, int64, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	sessions := make([]malak.UpdateViewerSession, 0, opts.Paginator.PerPage)
+
+	q := u.inner.NewSelect().
+		Model(&sessions).
+		Where("update_id = ?", opts.UpdateID)
+
+	total, err := q.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = q.Order("created_at DESC").
+		Limit(int(opts.Paginator.PerPage)).
+		Offset(int(opts.Paginator.Offset())).
+		Scan(ctx)
+
+	return sessions, int64(total), err
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateLink(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	linkRepo := NewUpdateLinkRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	// from workspaces.yml migration
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspaceID,
+		Status:      malak.UpdateStatusSent,
+		CreatedBy:   userID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+		SentAt:      hermes.Ref(time.Now()),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	link := &malak.UpdateLink{
+		Reference:   refGenerator.Generate(malak.EntityTypeLink),
+		UpdateID:    update.ID,
+		WorkspaceID: workspaceID,
+		CreatedBy:   userID,
+	}
+
+	require.NoError(t, linkRepo.Create(t.Context(), link))
+
+	links, err := linkRepo.List(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+
+	details, err := linkRepo.PublicDetails(t.Context(), link.Reference)
+	require.NoError(t, err)
+	require.NotNil(t, details.Update)
+	require.Equal(t, update.Reference, details.Update.Reference)
+	require.False(t, details.IsExpired())
+
+	session := &malak.UpdateViewerSession{
+		Reference: refGenerator.Generate(malak.EntityTypeUpdateViewerSession),
+		SessionID: refGenerator.Generate(malak.EntityTypeSession),
+		UpdateID:  update.ID,
+		LinkID:    link.ID,
+		Source:    malak.UpdateViewSourceLink,
+	}
+
+	require.NoError(t, linkRepo.CreateSession(t.Context(), session))
+
+	session, err = linkRepo.FindSession(t.Context(), session.SessionID)
+	require.NoError(t, err)
+
+	session.TimeSpentSeconds = 30
+	require.NoError(t, linkRepo.UpdateSession(t.Context(), session))
+
+	sessions, total, err := linkRepo.ListSessions(t.Context(), malak.ListUpdateViewerSessionOptions{
+		UpdateID:  update.ID,
+		Paginator: malak.Paginator{PerPage: 10, Page: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, int64(30), sessions[0].TimeSpentSeconds)
+
+	_, err = linkRepo.FindSession(t.Context(), malak.Reference("session_unknown"))
+	require.ErrorIs(t, err, malak.ErrUpdateViewerSessionNotFound)
+
+	require.NoError(t, linkRepo.Revoke(t.Context(), link))
+
+	_, err = linkRepo.PublicDetails(t.Context(), link.Reference)
+	require.ErrorIs(t, err, malak.ErrUpdateLinkNotFound)
+
+	_, err = linkRepo.Get(t.Context(), malak.FetchUpdateLinkOptions{
+		UpdateID:  update.ID,
+		Reference: link.Reference,
+	})
+	require.ErrorIs(t, err, malak.ErrUpdateLinkNotFound)
+}
+
+func TestUpdateLink_ListPublicUpdates(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	linkRepo := NewUpdateLinkRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	for _, v := range []struct {
+		status   malak.UpdateStatus
+		isPublic bool
+	}{
+		{malak.UpdateStatusSent, true},
+		{malak.UpdateStatusSent, false},
+		// drafts are never listed even if marked public
+		{malak.UpdateStatusDraft, true},
+	} {
+		update := &malak.Update{
+			WorkspaceID: workspaceID,
+			Status:      v.status,
+			CreatedBy:   userID,
+			Content:     make([]malak.Block, 0),
+			Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+			IsPublic:    v.isPublic,
+		}
+
+		require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+	}
+
+	updates, total, err := linkRepo.ListPublicUpdates(t.Context(), malak.ListPublicUpdatesOptions{
+		WorkspaceID: workspaceID,
+		Paginator:   malak.Paginator{PerPage: 10, Page: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, updates, 1)
+	require.True(t, updates[0].IsPublic)
+}
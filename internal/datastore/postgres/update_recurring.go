@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+type recurringScheduleRepo struct {
+	inner *bun.DB
+}
+
+func NewRecurringUpdateScheduleRepository(db *bun.DB) malak.RecurringUpdateScheduleRepository {
+	return &recurringScheduleRepo{
+		inner: db,
+	}
+}
+
+func (r *recurringScheduleRepo) Create(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := r.inner.NewInsert().
+		Model(schedule).
+		Exec(ctx)
+	return err
+}
+
+func (r *recurringScheduleRepo) Get(ctx context.Context,
+	opts malak.FetchRecurringScheduleOptions) (*malak.RecurringUpdateSchedule, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedule := new(malak.RecurringUpdateSchedule)
+
+	err := r.inner.NewSelect().
+		Model(schedule).
+		Where("workspace_id = ?", opts.WorkspaceID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrRecurringScheduleNotFound
+	}
+
+	return schedule, err
+}
+
+func (r *recurringScheduleRepo) List(ctx context.Context,
+	workspaceID uuid.UUID) ([]malak.RecurringUpdateSchedule, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedules := make([]malak.RecurringUpdateSchedule, 0)
+
+	err := r.inner.NewSelect().
+		Model(&schedules).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return schedules, err
+}
+
+func (r *recurringScheduleRepo) Update(ctx context.Context,
+	schedule *malak.RecurringUpdateSchedule) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedule.UpdatedAt = time.Now()
+
+	_, err := r.inner.NewUpdate().
+		Model(schedule).
+		Where("id = ?", schedule.ID).
+		Exec(ctx)
+	return err
+}
+
+func (r *recurringScheduleRepo) Due(ctx context.Context,
+	now time.Time) ([]malak.RecurringUpdateSchedule, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	schedules := make([]malak.RecurringUpdateSchedule, 0)
+
+	err := r.inner.NewSelect().
+		Model(&schedules).
+		Where("is_active = ?", true).
+		Where("next_send_at - make_interval(days => lead_days) <= ?", now).
+		Order("next_send_at ASC").
+		Scan(ctx)
+	return schedules, err
+}
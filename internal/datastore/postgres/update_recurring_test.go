@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringUpdateSchedule(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewRecurringUpdateScheduleRepository(client)
+	updatesRepo := NewUpdatesRepository(client)
+
+	userID := uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6")
+	// from workspaces.yml migration
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	now := time.Now()
+
+	schedule := &malak.RecurringUpdateSchedule{
+		Reference:         refGenerator.Generate(malak.EntityTypeRecurringUpdateSchedule),
+		WorkspaceID:       workspaceID,
+		CreatedBy:         userID,
+		TemplateReference: malak.Reference("system_template_fQv90I.w_I"),
+		IsSystemTemplate:  true,
+		Frequency:         malak.RecurrenceFrequencyMonthly,
+		DayOfMonth:        1,
+		Hour:              9,
+		LeadDays:          3,
+		Emails:            []malak.Email{"investor@example.com"},
+		NextSendAt:        now.Add(time.Hour * 24 * 10),
+		IsActive:          true,
+	}
+
+	require.NoError(t, repo.Create(t.Context(), schedule))
+
+	schedules, err := repo.List(t.Context(), workspaceID)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, []malak.Email{"investor@example.com"}, schedules[0].Emails)
+
+	// draft is only due 3 days before sending
+	due, err := repo.Due(t.Context(), now)
+	require.NoError(t, err)
+	require.Len(t, due, 0)
+
+	due, err = repo.Due(t.Context(), now.Add(time.Hour*24*8))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	update := &malak.Update{
+		WorkspaceID: workspaceID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   userID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	schedule.DraftUpdateID = update.ID
+	schedule.IsActive = false
+	require.NoError(t, repo.Update(t.Context(), schedule))
+
+	schedule, err = repo.Get(t.Context(), malak.FetchRecurringScheduleOptions{
+		WorkspaceID: workspaceID,
+		Reference:   schedule.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, update.ID, schedule.DraftUpdateID)
+
+	// inactive schedules are never due
+	due, err = repo.Due(t.Context(), now.Add(time.Hour*24*8))
+	require.NoError(t, err)
+	require.Len(t, due, 0)
+
+	_, err = repo.Get(t.Context(), malak.FetchRecurringScheduleOptions{
+		WorkspaceID: workspaceID,
+		Reference:   malak.Reference("recurring_update_schedule_unknown"),
+	})
+	require.ErrorIs(t, err, malak.ErrRecurringScheduleNotFound)
+}
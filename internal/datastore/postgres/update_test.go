@@ -616,3 +616,421 @@ func TestUpdate_Overview(t *testing.T) {
 	require.Len(t, overview.LastUpdates, 1)
 	require.Equal(t, "Test Update", overview.LastUpdates[0].Title)
 }
+
+func TestUpdates_RecordClick(t *testing.T) {
+
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	// user from the fixtures
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspace.ID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   user.ID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	err = updatesRepo.SendUpdate(t.Context(), &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return string(refGenerator.Generate(et))
+		},
+		Generator:       refGenerator,
+		Emails:          []malak.Email{malak.Email("first@example.com"), malak.Email("second@example.com")},
+		UserID:          user.ID,
+		UpdateReference: update.Reference,
+		Schedule: &malak.UpdateSchedule{
+			Reference:   refGenerator.Generate(malak.EntityTypeSchedule),
+			SendAt:      time.Now(),
+			UpdateType:  malak.UpdateTypeLive,
+			ScheduledBy: user.ID,
+			Status:      malak.UpdateSendScheduleScheduled,
+			UpdateID:    update.ID,
+		},
+		WorkspaceID: workspace.ID,
+		Plan:        workspace.Plan,
+	})
+	require.NoError(t, err)
+
+	var recipients []malak.UpdateRecipient
+
+	require.NoError(t, client.NewSelect().
+		Model(&recipients).
+		Where("update_id = ?", update.ID).
+		Scan(t.Context()))
+	require.Len(t, recipients, 2)
+
+	for _, recipient := range recipients {
+		_, err := client.NewInsert().Model(&malak.UpdateRecipientStat{
+			Reference:   refGenerator.Generate(malak.EntityTypeRecipientStat),
+			RecipientID: recipient.ID,
+		}).Exec(t.Context())
+		require.NoError(t, err)
+	}
+
+	for _, click := range []struct {
+		recipient malak.UpdateRecipient
+		url       string
+	}{
+		{recipients[0], "https://malak.vc"},
+		{recipients[0], "https://malak.vc"},
+		{recipients[1], "https://malak.vc"},
+		{recipients[1], "https://malak.vc/pricing"},
+	} {
+		require.NoError(t, updatesRepo.RecordClick(t.Context(), &malak.UpdateLinkClick{
+			UpdateID:    update.ID,
+			RecipientID: click.recipient.ID,
+			URL:         click.url,
+		}))
+	}
+
+	stat, err := updatesRepo.Stat(t.Context(), update)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stat.TotalClicks)
+
+	links, err := updatesRepo.LinkStats(t.Context(), update)
+	require.NoError(t, err)
+	require.Equal(t, []malak.UpdateLinkStat{
+		{URL: "https://malak.vc", TotalClicks: 3, UniqueClicks: 2},
+		{URL: "https://malak.vc/pricing", TotalClicks: 1, UniqueClicks: 1},
+	}, links)
+
+	recipientStats, err := updatesRepo.RecipientStat(t.Context(), update)
+	require.NoError(t, err)
+
+	var totalClicks int64
+	for _, v := range recipientStats {
+		totalClicks += v.UpdateRecipientStat.TotalClicks
+	}
+
+	require.Equal(t, int64(4), totalClicks)
+}
+
+func TestUpdates_RescheduleAndCancel(t *testing.T) {
+
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	// user from the fixtures
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspace.ID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   user.ID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	schedule := &malak.UpdateSchedule{
+		Reference:   refGenerator.Generate(malak.EntityTypeSchedule),
+		SendAt:      time.Now().Add(time.Hour),
+		UpdateType:  malak.UpdateTypeLive,
+		ScheduledBy: user.ID,
+		Status:      malak.UpdateSendScheduleScheduled,
+		UpdateID:    update.ID,
+	}
+
+	err = updatesRepo.SendUpdate(t.Context(), &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return string(refGenerator.Generate(et))
+		},
+		Generator:       refGenerator,
+		Emails:          []malak.Email{malak.Email("oops@oops.com")},
+		UserID:          user.ID,
+		Schedule:        schedule,
+		WorkspaceID:     workspace.ID,
+		UpdateReference: update.Reference,
+		Plan:            workspace.Plan,
+	})
+	require.NoError(t, err)
+
+	schedules, err := updatesRepo.ListSchedules(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+
+	schedule, err = updatesRepo.FindSchedule(t.Context(), malak.FetchUpdateScheduleOptions{
+		UpdateID:  update.ID,
+		Reference: schedule.Reference,
+	})
+	require.NoError(t, err)
+
+	schedule.SendAt = time.Now().Add(time.Hour * 24)
+	require.NoError(t, updatesRepo.RescheduleSend(t.Context(), schedule))
+
+	require.NoError(t, updatesRepo.CancelSchedule(t.Context(), schedule))
+
+	update, err = updatesRepo.GetByID(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Equal(t, malak.UpdateStatusDraft, update.Status)
+
+	recipients, err := updatesRepo.RecipientStat(t.Context(), update)
+	require.NoError(t, err)
+	require.Len(t, recipients, 0)
+
+	// a cancelled schedule cannot be changed anymore
+	require.ErrorIs(t, updatesRepo.RescheduleSend(t.Context(), schedule),
+		malak.ErrUpdateScheduleNotModifiable)
+	require.ErrorIs(t, updatesRepo.CancelSchedule(t.Context(), schedule),
+		malak.ErrUpdateScheduleNotModifiable)
+
+	_, err = updatesRepo.FindSchedule(t.Context(), malak.FetchUpdateScheduleOptions{
+		UpdateID:  update.ID,
+		Reference: malak.Reference("schedule_unknown"),
+	})
+	require.ErrorIs(t, err, malak.ErrUpdateScheduleNotFound)
+}
+
+func TestUpdates_Revisions(t *testing.T) {
+
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	// user from the fixtures
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspace.ID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   user.ID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+		Title:       "November update",
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	update.Content = malak.BlockContents{
+		{ID: "first", Type: "paragraph"},
+	}
+
+	revision := &malak.UpdateRevision{
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:    update.ID,
+		WorkspaceID: workspace.ID,
+		CreatedBy:   user.ID,
+		Title:       update.Title,
+		Content:     update.Content,
+	}
+
+	require.NoError(t, updatesRepo.UpdateContent(t.Context(), update, revision))
+
+	_, err = updatesRepo.GetSentRevision(t.Context(), update.ID)
+	require.ErrorIs(t, err, malak.ErrUpdateRevisionNotFound)
+
+	fetchedRevision, err := updatesRepo.GetRevision(t.Context(), malak.FetchUpdateRevisionOptions{
+		UpdateID:  update.ID,
+		Reference: revision.Reference,
+	})
+	require.NoError(t, err)
+	require.Len(t, fetchedRevision.Content, 1)
+	require.False(t, fetchedRevision.IsSent)
+
+	_, err = updatesRepo.GetRevision(t.Context(), malak.FetchUpdateRevisionOptions{
+		UpdateID:  update.ID,
+		Reference: "update_revision_unknown",
+	})
+	require.ErrorIs(t, err, malak.ErrUpdateRevisionNotFound)
+
+	fetchedUpdate, err := updatesRepo.GetByID(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Len(t, fetchedUpdate.Content, 1)
+
+	err = updatesRepo.SendUpdate(t.Context(), &malak.CreateUpdateOptions{
+		Reference: func(et malak.EntityType) string {
+			return string(refGenerator.Generate(et))
+		},
+		Generator: refGenerator,
+		Emails:    []malak.Email{malak.Email("oops@oops.com")},
+		UserID:    user.ID,
+		Schedule: &malak.UpdateSchedule{
+			Reference:   refGenerator.Generate(malak.EntityTypeSchedule),
+			SendAt:      time.Now().Add(time.Hour),
+			UpdateType:  malak.UpdateTypeLive,
+			ScheduledBy: user.ID,
+			Status:      malak.UpdateSendScheduleScheduled,
+			UpdateID:    update.ID,
+		},
+		WorkspaceID:     workspace.ID,
+		UpdateReference: update.Reference,
+		Plan:            workspace.Plan,
+	})
+	require.NoError(t, err)
+
+	revisions, err := updatesRepo.ListRevisions(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+
+	// the version is only frozen once the schedule is actually sent
+	require.False(t, revisions[0].IsSent)
+
+	_, err = updatesRepo.GetSentRevision(t.Context(), update.ID)
+	require.ErrorIs(t, err, malak.ErrUpdateRevisionNotFound)
+
+	sent := &malak.UpdateRevision{
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:    update.ID,
+		WorkspaceID: workspace.ID,
+		CreatedBy:   user.ID,
+		Title:       update.Title,
+		Content:     update.Content,
+		IsSent:      true,
+		Metrics:     malak.ResolvedMetrics{"integration_chart_mrr:latest": "$12,000.00"},
+	}
+
+	_, err = client.NewInsert().Model(sent).Exec(t.Context())
+	require.NoError(t, err)
+
+	sentRevision, err := updatesRepo.GetSentRevision(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Equal(t, sent.Reference, sentRevision.Reference)
+	require.Equal(t, sent.Metrics, sentRevision.Metrics)
+
+	_, err = client.NewUpdate().
+		Model(new(malak.Update)).
+		Set("status = ?", malak.UpdateStatusSent).
+		Where("id = ?", update.ID).
+		Exec(t.Context())
+	require.NoError(t, err)
+
+	// the content of a sent update cannot be changed anymore
+	err = updatesRepo.UpdateContent(t.Context(), update, &malak.UpdateRevision{
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:    update.ID,
+		WorkspaceID: workspace.ID,
+		CreatedBy:   user.ID,
+		Title:       update.Title,
+		Content:     update.Content,
+	})
+	require.ErrorIs(t, err, malak.ErrUpdateSent)
+}
+
+func TestUpdates_Approvals(t *testing.T) {
+
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	updatesRepo := NewUpdatesRepository(client)
+	userRepo := NewUserRepository(client)
+	workspaceRepo := NewWorkspaceRepository(client)
+
+	// user from the fixtures
+	user, err := userRepo.Get(t.Context(), &malak.FindUserOptions{
+		Email: "lanre@test.com",
+	})
+	require.NoError(t, err)
+
+	// from workspaces.yml migration
+	workspace, err := workspaceRepo.Get(t.Context(), &malak.FindWorkspaceOptions{
+		ID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"),
+	})
+	require.NoError(t, err)
+
+	refGenerator := malak.NewReferenceGenerator()
+
+	update := &malak.Update{
+		WorkspaceID: workspace.ID,
+		Status:      malak.UpdateStatusDraft,
+		CreatedBy:   user.ID,
+		Content:     make([]malak.Block, 0),
+		Reference:   refGenerator.Generate(malak.EntityTypeUpdate),
+		Title:       "November update",
+	}
+
+	require.NoError(t, updatesRepo.Create(t.Context(), update, &malak.TemplateCreateUpdateOptions{}))
+
+	fetchedUpdate, err := updatesRepo.GetByID(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Equal(t, malak.UpdateApprovalStatusDraft, fetchedUpdate.ApprovalStatus)
+
+	newApproval := func(decision malak.UpdateApprovalDecision) *malak.UpdateApproval {
+		return &malak.UpdateApproval{
+			Reference:   refGenerator.Generate(malak.EntityTypeUpdateApproval),
+			UpdateID:    update.ID,
+			WorkspaceID: workspace.ID,
+			ApproverID:  user.ID,
+			Decision:    decision,
+			Comment:     "please add the revenue chart",
+		}
+	}
+
+	// only updates in review can be reviewed
+	fetchedUpdate.ApprovalStatus = malak.UpdateApprovalStatusApproved
+	require.ErrorIs(t, updatesRepo.AddApproval(t.Context(), fetchedUpdate,
+		newApproval(malak.UpdateApprovalDecisionApproved)), malak.ErrUpdateNotInReview)
+
+	fetchedUpdate.ApprovalStatus = malak.UpdateApprovalStatusInReview
+	require.NoError(t, updatesRepo.Update(t.Context(), fetchedUpdate))
+
+	fetchedUpdate.ApprovalStatus = malak.UpdateApprovalStatusDraft
+	require.NoError(t, updatesRepo.AddApproval(t.Context(), fetchedUpdate,
+		newApproval(malak.UpdateApprovalDecisionChangesRequested)))
+
+	fetchedUpdate.ApprovalStatus = malak.UpdateApprovalStatusInReview
+	require.NoError(t, updatesRepo.Update(t.Context(), fetchedUpdate))
+
+	fetchedUpdate.ApprovalStatus = malak.UpdateApprovalStatusApproved
+	require.NoError(t, updatesRepo.AddApproval(t.Context(), fetchedUpdate,
+		newApproval(malak.UpdateApprovalDecisionApproved)))
+
+	fetchedUpdate, err = updatesRepo.GetByID(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Equal(t, malak.UpdateApprovalStatusApproved, fetchedUpdate.ApprovalStatus)
+
+	approvals, err := updatesRepo.ListApprovals(t.Context(), update.ID)
+	require.NoError(t, err)
+	require.Len(t, approvals, 2)
+	require.Equal(t, malak.UpdateApprovalDecisionApproved, approvals[0].Decision)
+	require.Equal(t, malak.UpdateApprovalDecisionChangesRequested, approvals[1].Decision)
+}
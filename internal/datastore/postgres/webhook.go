@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/ayinke-llc/malak"
+)
+
+const maxWebhooksPerWorkspace = 10
+
+type webhookRepo struct {
+	inner *bun.DB
+}
+
+func NewWebhookRepository(db *bun.DB) malak.WebhookRepository {
+	return &webhookRepo{
+		inner: db,
+	}
+}
+
+func (w *webhookRepo) Create(ctx context.Context, webhook *malak.Webhook) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	if webhook.Events == nil {
+		webhook.Events = []malak.WebhookEvent{}
+	}
+
+	return w.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(webhook).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		count, err := tx.NewSelect().
+			Model(new(malak.Webhook)).
+			Where("workspace_id = ?", webhook.WorkspaceID).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		if count > maxWebhooksPerWorkspace {
+			return malak.ErrWebhookMaxLimit
+		}
+
+		return nil
+	})
+}
+
+func (w *webhookRepo) Update(ctx context.Context, webhook *malak.Webhook) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	webhook.UpdatedAt = time.Now()
+
+	_, err := w.inner.NewUpdate().
+		Model(webhook).
+		Where("id = ?", webhook.ID).
+		Exec(ctx)
+	return err
+}
+
+func (w *webhookRepo) Delete(ctx context.Context, webhook *malak.Webhook) error {
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	return w.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// pending deliveries should not go out once the endpoint is gone
+		_, err := tx.NewUpdate().
+			Model(new(malak.WebhookDelivery)).
+			Set("status = ?", malak.WebhookDeliveryStatusFailed).
+			Set("next_attempt_at = NULL").
+			Set("last_error = ?", "webhook was deleted").
+			Set("updated_at = NOW()").
+			Where("webhook_id = ?", webhook.ID).
+			Where("status = ?", malak.WebhookDeliveryStatusPending).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model(webhook).
+			Where("id = ?", webhook.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webhookRepo) Get(ctx context.Context,
+	opts malak.FetchWebhookOptions) (*malak.Webhook, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	webhook := new(malak.Webhook)
+
+	err := w.inner.NewSelect().
+		Model(webhook).
+		Where("workspace_id = ?", opts.WorkspaceID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+func (w *webhookRepo) List(ctx context.Context,
+	workspaceID uuid.UUID) ([]malak.Webhook, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	webhooks := make([]malak.Webhook, 0, maxWebhooksPerWorkspace)
+
+	return webhooks, w.inner.NewSelect().
+		Model(&webhooks).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC").
+		Scan(ctx)
+}
+
+func (w *webhookRepo) ListSubscribed(ctx context.Context,
+	workspaceID uuid.UUID, event malak.WebhookEvent) ([]malak.Webhook, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	webhooks := make([]malak.Webhook, 0)
+
+	return webhooks, w.inner.NewSelect().
+		Model(&webhooks).
+		Where("workspace_id = ?", workspaceID).
+		Where("is_active = ?", true).
+		Where("events @> ?::jsonb", `["`+event.String()+`"]`).
+		Scan(ctx)
+}
+
+func (w *webhookRepo) CreateDeliveries(ctx context.Context,
+	deliveries []*malak.WebhookDelivery) error {
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	_, err := w.inner.NewInsert().
+		Model(&deliveries).
+		Exec(ctx)
+	return err
+}
+
+func (w *webhookRepo) UpdateDelivery(ctx context.Context,
+	delivery *malak.WebhookDelivery) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	delivery.UpdatedAt = time.Now()
+
+	_, err := w.inner.NewUpdate().
+		Model(delivery).
+		Where("id = ?", delivery.ID).
+		Exec(ctx)
+	return err
+}
+
+func (w *webhookRepo) GetDelivery(ctx context.Context,
+	opts malak.FetchWebhookDeliveryOptions) (*malak.WebhookDelivery, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	delivery := new(malak.WebhookDelivery)
+
+	err := w.inner.NewSelect().
+		Model(delivery).
+		Where("webhook_id = ?", opts.WebhookID).
+		Where("reference = ?", opts.Reference).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, err
+}
+
+func (w *webhookRepo) GetDeliveryByID(ctx context.Context,
+	id uuid.UUID) (*malak.Webhook, *malak.WebhookDelivery, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	delivery := new(malak.WebhookDelivery)
+
+	err := w.inner.NewSelect().
+		Model(delivery).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrWebhookDeliveryNotFound
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook := new(malak.Webhook)
+
+	err = w.inner.NewSelect().
+		Model(webhook).
+		Where("id = ?", delivery.WebhookID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = malak.ErrWebhookNotFound
+	}
+
+	return webhook, delivery, err
+}
+
+func (w *webhookRepo) ListDeliveries(ctx context.Context,
+	opts malak.ListWebhookDeliveryOptions) ([]malak.WebhookDelivery, int64, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	deliveries := make([]malak.WebhookDelivery, 0, opts.Paginator.PerPage)
+
+	q := w.inner.NewSelect().
+		Model(&deliveries).
+		Where("webhook_id = ?", opts.WebhookID)
+
+	total, err := q.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = q.Order("created_at DESC").
+		Limit(int(opts.Paginator.PerPage)).
+		Offset(int(opts.Paginator.Offset())).
+		Scan(ctx)
+
+	return deliveries, int64(total), err
+}
+
+func (w *webhookRepo) ClaimDelivery(ctx context.Context,
+	id uuid.UUID, now time.Time) error {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	res, err := w.inner.NewUpdate().
+		Model(new(malak.WebhookDelivery)).
+		Set("next_attempt_at = ?", now.Add(malak.WebhookDeliveryClaimDuration)).
+		Set("updated_at = NOW()").
+		Where("id = ?", id).
+		Where("status = ?", malak.WebhookDeliveryStatusPending).
+		Where("next_attempt_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return malak.ErrWebhookDeliveryNotDue
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) ClaimDueDeliveries(ctx context.Context,
+	now time.Time, limit int) ([]malak.WebhookDelivery, error) {
+
+	ctx, cancelFn := withContext(ctx)
+	defer cancelFn()
+
+	deliveries := make([]malak.WebhookDelivery, 0, limit)
+
+	err := w.inner.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model(&deliveries).
+			Where("status = ?", malak.WebhookDeliveryStatusPending).
+			Where("next_attempt_at <= ?", now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+
+		_, err = tx.NewUpdate().
+			Model(new(malak.WebhookDelivery)).
+			Set("next_attempt_at = ?", now.Add(malak.WebhookDeliveryClaimDuration)).
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		return err
+	})
+
+	return deliveries, err
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhook(events ...malak.WebhookEvent) *malak.Webhook {
+	return &malak.Webhook{
+		WorkspaceID: uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0"), // First workspace
+		CreatedBy:   uuid.MustParse("1aa6b38e-33d3-499f-bc9d-3090738f29e6"), // lanre@test.com
+		Reference:   malak.NewReferenceGenerator().Generate(malak.EntityTypeWebhook),
+		URL:         "https://example.com/hooks",
+		Events:      events,
+		IsActive:    true,
+		Secret:      "whsec_secret",
+	}
+}
+
+func TestWebhook_Create(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewWebhookRepository(client)
+
+	webhook := newTestWebhook(malak.WebhookEventUpdateOpened)
+
+	require.NoError(t, repo.Create(t.Context(), webhook))
+
+	fetched, err := repo.Get(t.Context(), malak.FetchWebhookOptions{
+		WorkspaceID: webhook.WorkspaceID,
+		Reference:   webhook.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, webhook.URL, fetched.URL)
+	require.Equal(t, webhook.Secret, fetched.Secret)
+	require.Equal(t, []malak.WebhookEvent{malak.WebhookEventUpdateOpened}, fetched.Events)
+
+	_, err = repo.Get(t.Context(), malak.FetchWebhookOptions{
+		WorkspaceID: uuid.New(),
+		Reference:   webhook.Reference,
+	})
+	require.ErrorIs(t, err, malak.ErrWebhookNotFound)
+
+	for i := 1; i < maxWebhooksPerWorkspace; i++ {
+		require.NoError(t, repo.Create(t.Context(), newTestWebhook(malak.WebhookEventUpdateOpened)))
+	}
+
+	err = repo.Create(t.Context(), newTestWebhook(malak.WebhookEventUpdateOpened))
+	require.ErrorIs(t, err, malak.ErrWebhookMaxLimit)
+
+	webhooks, err := repo.List(t.Context(), webhook.WorkspaceID)
+	require.NoError(t, err)
+	require.Len(t, webhooks, maxWebhooksPerWorkspace)
+}
+
+func TestWebhook_ListSubscribed(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewWebhookRepository(client)
+
+	opened := newTestWebhook(malak.WebhookEventUpdateOpened, malak.WebhookEventFundraisingContactMoved)
+	require.NoError(t, repo.Create(t.Context(), opened))
+
+	require.NoError(t, repo.Create(t.Context(), newTestWebhook(malak.WebhookEventIntegrationSyncFailed)))
+
+	disabled := newTestWebhook(malak.WebhookEventUpdateOpened)
+	require.NoError(t, repo.Create(t.Context(), disabled))
+
+	disabled.IsActive = false
+	require.NoError(t, repo.Update(t.Context(), disabled))
+
+	webhooks, err := repo.ListSubscribed(t.Context(), opened.WorkspaceID, malak.WebhookEventUpdateOpened)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, opened.ID, webhooks[0].ID)
+}
+
+func TestWebhook_ClaimDelivery(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewWebhookRepository(client)
+
+	webhook := newTestWebhook(malak.WebhookEventUpdateOpened)
+	require.NoError(t, repo.Create(t.Context(), webhook))
+
+	deliveries, err := malak.NewWebhookDeliveries(malak.NewReferenceGenerator(),
+		[]malak.Webhook{*webhook}, malak.WebhookEventUpdateOpened,
+		map[string]string{"update_reference": "update_123"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateDeliveries(t.Context(), deliveries))
+
+	now := time.Now()
+
+	require.NoError(t, repo.ClaimDelivery(t.Context(), deliveries[0].ID, now))
+
+	// a second worker and the cron job cannot pick it up while claimed
+	require.ErrorIs(t, repo.ClaimDelivery(t.Context(), deliveries[0].ID, now),
+		malak.ErrWebhookDeliveryNotDue)
+
+	claimed, err := repo.ClaimDueDeliveries(t.Context(), now, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 0)
+
+	require.ErrorIs(t, repo.ClaimDelivery(t.Context(), uuid.New(), now),
+		malak.ErrWebhookDeliveryNotDue)
+}
+
+func TestWebhook_Deliveries(t *testing.T) {
+	client, teardownFunc := setupDatabase(t)
+	defer teardownFunc()
+
+	repo := NewWebhookRepository(client)
+
+	webhook := newTestWebhook(malak.WebhookEventUpdateOpened)
+	require.NoError(t, repo.Create(t.Context(), webhook))
+
+	deliveries, err := malak.NewWebhookDeliveries(malak.NewReferenceGenerator(),
+		[]malak.Webhook{*webhook, *webhook}, malak.WebhookEventUpdateOpened,
+		map[string]string{"update_reference": "update_123"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateDeliveries(t.Context(), deliveries))
+
+	now := time.Now()
+
+	claimed, err := repo.ClaimDueDeliveries(t.Context(), now, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	claimed, err = repo.ClaimDueDeliveries(t.Context(), now, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	// both have been claimed and are not due until the claim expires
+	claimed, err = repo.ClaimDueDeliveries(t.Context(), now, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 0)
+
+	fetchedWebhook, delivery, err := repo.GetDeliveryByID(t.Context(), deliveries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, webhook.ID, fetchedWebhook.ID)
+
+	delivery.RecordAttempt(http.StatusOK, "ok", nil, now)
+	require.NoError(t, repo.UpdateDelivery(t.Context(), delivery))
+
+	delivery, err = repo.GetDelivery(t.Context(), malak.FetchWebhookDeliveryOptions{
+		WebhookID: webhook.ID,
+		Reference: deliveries[0].Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, malak.WebhookDeliveryStatusSucceeded, delivery.Status)
+	require.Equal(t, 1, delivery.Attempts)
+
+	_, err = repo.GetDelivery(t.Context(), malak.FetchWebhookDeliveryOptions{
+		WebhookID: webhook.ID,
+		Reference: "webhook_delivery_oops",
+	})
+	require.ErrorIs(t, err, malak.ErrWebhookDeliveryNotFound)
+
+	list, total, err := repo.ListDeliveries(t.Context(), malak.ListWebhookDeliveryOptions{
+		WebhookID: webhook.ID,
+		Paginator: malak.Paginator{
+			PerPage: 10,
+			Page:    1,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, int64(2), total)
+
+	// deleting the webhook stops the pending delivery from going out
+	require.NoError(t, repo.Delete(t.Context(), webhook))
+
+	_, delivery, err = repo.GetDeliveryByID(t.Context(), deliveries[1].ID)
+	require.ErrorIs(t, err, malak.ErrWebhookNotFound)
+	require.Equal(t, malak.WebhookDeliveryStatusFailed, delivery.Status)
+}
@@ -13,6 +13,26 @@ const (
 
 type Cache interface {
 	Add(context.Context, string, []byte, time.Duration) error
+
+	// AddIfNotExists atomically sets the key only if it does not exist yet.
+	// It reports if the key was set
+	AddIfNotExists(ctx context.Context, key string, payload []byte, ttl time.Duration) (bool, error)
 	Exists(context.Context, string) (bool, error)
 	Get(context.Context, string) ([]byte, error)
+	Delete(context.Context, string) error
+
+	// CompareAndSwap atomically replaces the value of the key only if it
+	// still holds the old value. It reports if the value was replaced
+	CompareAndSwap(ctx context.Context, key string, old, new []byte, ttl time.Duration) (bool, error)
+
+	// Increment atomically adds one to the counter stored at the key and
+	// returns the new value. The ttl is only set when the counter is
+	// created so it does not slide with every increment
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// AddToSet atomically adds the member to the set and resets the ttl
+	// of the set
+	AddToSet(ctx context.Context, key, member string, ttl time.Duration) error
+	SetMembers(ctx context.Context, key string) ([]string, error)
+	RemoveFromSet(ctx context.Context, key string, members ...string) error
 }
@@ -104,6 +104,36 @@ func TestExists(t *testing.T) {
 	require.True(t, exists)
 }
 
+func TestGetAndDelete(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	c, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	key := "testKey"
+	payload := []byte("testPayload")
+
+	// Test when key doesn't exist
+	_, err = c.Get(ctx, key)
+	require.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	require.NoError(t, c.Add(ctx, key, payload, time.Hour))
+
+	val, err := c.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, payload, val)
+
+	require.NoError(t, c.Delete(ctx, key))
+
+	_, err = c.Get(ctx, key)
+	require.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	// deleting a missing key is not an error
+	require.NoError(t, c.Delete(ctx, key))
+}
+
 func TestExistsError(t *testing.T) {
 	redisClient, cleanup := setupRedis(t)
 	defer cleanup()
@@ -121,6 +151,124 @@ func TestExistsError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCompareAndSwap(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	c, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	key := "testKey"
+
+	// missing keys are never swapped
+	swapped, err := c.CompareAndSwap(ctx, key, []byte("old"), []byte("new"), time.Hour)
+	require.NoError(t, err)
+	require.False(t, swapped)
+
+	require.NoError(t, c.Add(ctx, key, []byte("old"), time.Hour))
+
+	swapped, err = c.CompareAndSwap(ctx, key, []byte("old"), []byte("new"), time.Hour)
+	require.NoError(t, err)
+	require.True(t, swapped)
+
+	// the value has changed so the same swap cannot happen twice
+	swapped, err = c.CompareAndSwap(ctx, key, []byte("old"), []byte("other"), time.Hour)
+	require.NoError(t, err)
+	require.False(t, swapped)
+
+	val, err := c.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), val)
+}
+
+func TestAddIfNotExists(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	c, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	key := "testKey"
+
+	added, err := c.AddIfNotExists(ctx, key, []byte("first"), time.Hour)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	added, err = c.AddIfNotExists(ctx, key, []byte("second"), time.Hour)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	val, err := c.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), val)
+}
+
+func TestIncrement(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	c, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	key := "testKey"
+
+	count, err := c.Increment(ctx, key, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, redisClient.Expire(ctx, makeKey(key), time.Minute).Err())
+
+	count, err = c.Increment(ctx, key, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// the ttl is only set when the counter is created
+	duration, err := redisClient.TTL(ctx, makeKey(key)).Result()
+	require.NoError(t, err)
+	require.True(t, duration > 0 && duration <= time.Minute)
+
+	val, err := c.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("2"), val)
+}
+
+func TestSet(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	c, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	key := "testKey"
+
+	members, err := c.SetMembers(ctx, key)
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	require.NoError(t, c.AddToSet(ctx, key, "first", time.Hour))
+	require.NoError(t, c.AddToSet(ctx, key, "second", time.Hour))
+	require.NoError(t, c.AddToSet(ctx, key, "second", time.Hour))
+
+	members, err = c.SetMembers(ctx, key)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second"}, members)
+
+	duration, err := redisClient.TTL(ctx, makeKey(key)).Result()
+	require.NoError(t, err)
+	require.True(t, duration > 0 && duration <= time.Hour)
+
+	require.NoError(t, c.RemoveFromSet(ctx, key, "first"))
+	require.NoError(t, c.RemoveFromSet(ctx, key))
+
+	members, err = c.SetMembers(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []string{"second"}, members)
+}
+
 func TestMakeKey(t *testing.T) {
 	tests := []struct {
 		input    string
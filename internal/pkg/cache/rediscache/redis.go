@@ -2,6 +2,7 @@ package rediscache
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ayinke-llc/malak/internal/pkg/cache"
@@ -26,7 +27,17 @@ func (r *redisCache) Get(ctx context.Context,
 	key string) ([]byte, error) {
 
 	cmd := r.inner.Get(ctx, makeKey(key))
-	return cmd.Bytes()
+	b, err := cmd.Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, cache.ErrCacheMiss
+	}
+
+	return b, err
+}
+
+func (r *redisCache) Delete(ctx context.Context,
+	key string) error {
+	return r.inner.Del(ctx, makeKey(key)).Err()
 }
 
 func (r *redisCache) Add(ctx context.Context,
@@ -36,6 +47,11 @@ func (r *redisCache) Add(ctx context.Context,
 		Err()
 }
 
+func (r *redisCache) AddIfNotExists(ctx context.Context,
+	key string, payload []byte, ttl time.Duration) (bool, error) {
+	return r.inner.SetNX(ctx, makeKey(key), payload, ttl).Result()
+}
+
 func (r *redisCache) Exists(ctx context.Context,
 	key string) (bool, error) {
 
@@ -57,3 +73,74 @@ func (r *redisCache) Exists(ctx context.Context,
 
 	return false, cache.ErrCacheMiss
 }
+
+// compareAndSwapScript sets the key only if it still holds the expected
+// value so concurrent writers cannot both succeed
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+func (r *redisCache) CompareAndSwap(ctx context.Context,
+	key string, old, new []byte, ttl time.Duration) (bool, error) {
+
+	swapped, err := compareAndSwapScript.Run(ctx, r.inner,
+		[]string{makeKey(key)}, old, new, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return swapped == 1, nil
+}
+
+// incrementScript only sets the expiry on the first increment so the
+// counter covers a fixed window
+var incrementScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+func (r *redisCache) Increment(ctx context.Context,
+	key string, ttl time.Duration) (int64, error) {
+	return incrementScript.Run(ctx, r.inner,
+		[]string{makeKey(key)}, ttl.Milliseconds()).Int64()
+}
+
+func (r *redisCache) AddToSet(ctx context.Context,
+	key, member string, ttl time.Duration) error {
+
+	key = makeKey(key)
+
+	_, err := r.inner.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, member)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (r *redisCache) SetMembers(ctx context.Context,
+	key string) ([]string, error) {
+	return r.inner.SMembers(ctx, makeKey(key)).Result()
+}
+
+func (r *redisCache) RemoveFromSet(ctx context.Context,
+	key string, members ...string) error {
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		values = append(values, member)
+	}
+
+	return r.inner.SRem(ctx, makeKey(key), values...).Err()
+}
@@ -42,6 +42,24 @@ func NewEChartsRenderer(storage gulter.Storage, cfg config.Config, db *bun.DB,
 	}
 }
 
+func (r *EChartsRenderer) RenderMetric(workspaceID uuid.UUID, chartReference string,
+	value malak.InlineMetricValue) (string, error) {
+	chart, err := r.integration.GetChart(context.Background(), malak.FetchChartOptions{
+		WorkspaceID: workspaceID,
+		Reference:   malak.Reference(chartReference),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch chart: %w", err)
+	}
+
+	dataPoints, err := r.integration.GetDataPoints(context.Background(), chart)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch chart data points: %w", err)
+	}
+
+	return chart.FormatMetric(dataPoints, value), nil
+}
+
 func (r *EChartsRenderer) RenderChart(workspaceID uuid.UUID, chartReference string) (string, error) {
 	chart, err := r.integration.GetChart(context.Background(), malak.FetchChartOptions{
 		WorkspaceID: workspaceID,
@@ -0,0 +1,70 @@
+package collaboration
+
+import (
+	"context"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+)
+
+// PubSub fans changes out to every instance of the API so editors connected
+// to different instances see the same document
+type PubSub interface {
+	Publish(context.Context, string, []byte) error
+	Subscribe(context.Context, string) (Subscription, error)
+}
+
+type Subscription interface {
+	Messages() <-chan []byte
+	Close() error
+}
+
+// ENUM(sync,operations,presence,error)
+type MessageType string
+
+// Editor is a single connection editing a draft. The same user can show up
+// more than once if they have the draft opened in multiple tabs
+type Editor struct {
+	SessionID string      `json:"session_id,omitempty"`
+	UserID    uuid.UUID   `json:"user_id,omitempty"`
+	FullName  string      `json:"full_name,omitempty"`
+	Email     malak.Email `json:"email,omitempty"`
+}
+
+// Message is what is exchanged with the editors over the websocket.
+// Editors only send operations, every other type is sent by the server.
+// A sync message is sent when joining and again whenever the draft is
+// saved outside the editor so editors must replace their copy with it
+type Message struct {
+	Type       MessageType `json:"type,omitempty"`
+	SessionID  string      `json:"session_id,omitempty"`
+	Operations []Operation `json:"operations,omitempty"`
+	Editors    []Editor    `json:"editors,omitempty"`
+	Message    string      `json:"message,omitempty"`
+}
+
+type envelopeType string
+
+const (
+	envelopeTypeOperations  envelopeType = "operations"
+	envelopeTypeSnapshot    envelopeType = "snapshot"
+	envelopeTypeSyncRequest envelopeType = "sync_request"
+	envelopeTypePresence    envelopeType = "presence"
+	envelopeTypeReload      envelopeType = "reload"
+)
+
+// envelope is what is exchanged between instances through the PubSub
+type envelope struct {
+	InstanceID string       `json:"instance_id"`
+	Type       envelopeType `json:"type"`
+	Operations []Operation  `json:"operations,omitempty"`
+	Editors    []Editor     `json:"editors,omitempty"`
+
+	// Content is the saved draft the replicas are replaced with on reload
+	Content  malak.BlockContents `json:"content,omitempty"`
+	EditedBy uuid.UUID           `json:"edited_by,omitempty"`
+}
+
+func channelName(updateID uuid.UUID) string {
+	return "malak-collaboration-" + updateID.String()
+}
@@ -0,0 +1,170 @@
+package collaboration
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/ayinke-llc/malak"
+)
+
+// Operation is the latest state of a top level block of the draft.
+//
+// Editors send the block with its position in the document. Positions are
+// fractional so a block can be moved or inserted between two others without
+// touching its neighbours. Deleted blocks are kept as tombstones so a late
+// edit cannot bring them back.
+//
+// Clock and SessionID are set by the server and order the operations. The
+// operation with the highest clock wins and the session breaks ties, which
+// makes merging independent of the order operations are received in
+type Operation struct {
+	BlockID   string       `json:"block_id,omitempty"`
+	Block     *malak.Block `json:"block,omitempty"`
+	Position  float64      `json:"position"`
+	Deleted   bool         `json:"deleted,omitempty"`
+	Clock     uint64       `json:"clock,omitempty"`
+	SessionID string       `json:"session_id,omitempty"`
+}
+
+func (o Operation) Validate() error {
+	if o.BlockID == "" {
+		return errors.New("please provide the block id")
+	}
+
+	if o.Deleted {
+		return nil
+	}
+
+	if o.Block == nil {
+		return errors.New("please provide the block")
+	}
+
+	if o.Block.ID != o.BlockID {
+		return errors.New("block id does not match the block")
+	}
+
+	return nil
+}
+
+// wins reports if o should replace the current state of the block
+func (o Operation) wins(current Operation) bool {
+	if o.Clock != current.Clock {
+		return o.Clock > current.Clock
+	}
+
+	return o.SessionID > current.SessionID
+}
+
+// Document is a replica of a draft being edited. It is a last writer wins
+// map of blocks so replicas that received the same operations end up with
+// the same content.
+//
+// It is not safe for concurrent use
+type Document struct {
+	blocks map[string]Operation
+	clock  uint64
+}
+
+// NewDocument creates a replica from the stored content of the draft. The
+// stored blocks lose to every edit made afterwards
+func NewDocument(content malak.BlockContents) *Document {
+	d := &Document{
+		blocks: make(map[string]Operation, len(content)),
+	}
+
+	for i, block := range content {
+		block := block
+
+		d.blocks[block.ID] = Operation{
+			BlockID:  block.ID,
+			Block:    &block,
+			Position: float64(i + 1),
+		}
+	}
+
+	return d
+}
+
+// Stamp orders an operation sent by an editor after everything the replica
+// has seen
+func (d *Document) Stamp(op Operation, sessionID string) Operation {
+	d.clock++
+
+	op.Clock = d.clock
+	op.SessionID = sessionID
+
+	if op.Deleted {
+		op.Block = nil
+	}
+
+	return op
+}
+
+// Apply merges the operations into the replica and returns the ones that
+// changed it. Applying the same operation again does nothing
+func (d *Document) Apply(ops ...Operation) []Operation {
+	applied := make([]Operation, 0, len(ops))
+
+	for _, op := range ops {
+		if op.Clock > d.clock {
+			d.clock = op.Clock
+		}
+
+		current, ok := d.blocks[op.BlockID]
+		if ok && !op.wins(current) {
+			continue
+		}
+
+		d.blocks[op.BlockID] = op
+		applied = append(applied, op)
+	}
+
+	return applied
+}
+
+// Snapshot lists every block of the replica including the deleted ones
+func (d *Document) Snapshot() []Operation {
+	ops := make([]Operation, 0, len(d.blocks))
+
+	for _, op := range d.blocks {
+		ops = append(ops, op)
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Position != ops[j].Position {
+			return ops[i].Position < ops[j].Position
+		}
+
+		return ops[i].BlockID < ops[j].BlockID
+	})
+
+	return ops
+}
+
+// Operations lists the blocks of the replica in order
+func (d *Document) Operations() []Operation {
+	ops := make([]Operation, 0, len(d.blocks))
+
+	for _, op := range d.Snapshot() {
+		if op.Deleted {
+			continue
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// Content builds the draft from the replica
+func (d *Document) Content() malak.BlockContents {
+	ops := d.Operations()
+
+	content := make(malak.BlockContents, 0, len(ops))
+
+	for _, op := range ops {
+		content = append(content, *op.Block)
+	}
+
+	return content
+}
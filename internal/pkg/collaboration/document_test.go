@@ -0,0 +1,168 @@
+package collaboration
+
+import (
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/stretchr/testify/require"
+)
+
+func block(id, text string) *malak.Block {
+	return &malak.Block{
+		ID:   id,
+		Type: "paragraph",
+		Content: []interface{}{
+			map[string]interface{}{"type": "text", "text": text},
+		},
+	}
+}
+
+func blockText(b malak.Block) string {
+	return b.Content.([]interface{})[0].(map[string]interface{})["text"].(string)
+}
+
+func TestNewDocument(t *testing.T) {
+	content := malak.BlockContents{*block("first", "hello"), *block("second", "world")}
+
+	doc := NewDocument(content)
+
+	require.Equal(t, content, doc.Content())
+
+	ops := doc.Operations()
+	require.Len(t, ops, 2)
+	require.Equal(t, float64(1), ops[0].Position)
+	require.Equal(t, float64(2), ops[1].Position)
+}
+
+func TestDocument_Apply(t *testing.T) {
+	content := malak.BlockContents{*block("first", "hello"), *block("second", "world")}
+
+	first := NewDocument(content)
+	second := NewDocument(content)
+
+	// both editors change the same block at the same time
+	fromFirst := first.Stamp(Operation{
+		BlockID:  "first",
+		Block:    block("first", "hello from a"),
+		Position: 1,
+	}, "session_a")
+
+	fromSecond := second.Stamp(Operation{
+		BlockID:  "first",
+		Block:    block("first", "hello from b"),
+		Position: 1,
+	}, "session_b")
+
+	inserted := second.Stamp(Operation{
+		BlockID:  "between",
+		Block:    block("between", "in between"),
+		Position: 1.5,
+	}, "session_b")
+
+	deleted := first.Stamp(Operation{
+		BlockID: "second",
+		Block:   block("second", "ignored"),
+		Deleted: true,
+	}, "session_a")
+
+	require.Nil(t, deleted.Block)
+
+	first.Apply(fromFirst, deleted)
+	first.Apply(fromSecond, inserted)
+
+	second.Apply(fromSecond, inserted)
+	second.Apply(deleted, fromFirst)
+
+	require.Equal(t, first.Content(), second.Content())
+
+	merged := first.Content()
+	require.Len(t, merged, 2)
+	require.Equal(t, "hello from b", blockText(merged[0]))
+	require.Equal(t, "in between", blockText(merged[1]))
+
+	// applying the same operations again does nothing
+	require.Empty(t, first.Apply(fromFirst, fromSecond, inserted, deleted))
+	require.Equal(t, merged, first.Content())
+
+	// a late edit cannot bring a deleted block back
+	require.Empty(t, first.Apply(Operation{
+		BlockID:   "second",
+		Block:     block("second", "too late"),
+		Position:  2,
+		Clock:     deleted.Clock,
+		SessionID: "session_0",
+	}))
+
+	// stamps come after everything the replica has seen
+	next := first.Stamp(Operation{
+		BlockID:  "first",
+		Block:    block("first", "newest"),
+		Position: 1,
+	}, "session_a")
+
+	require.Len(t, first.Apply(next), 1)
+	require.Equal(t, "newest", blockText(first.Content()[0]))
+}
+
+func TestDocument_Snapshot(t *testing.T) {
+	doc := NewDocument(malak.BlockContents{*block("first", "hello")})
+
+	doc.Apply(doc.Stamp(Operation{BlockID: "first", Deleted: true}, "session_a"))
+
+	require.Empty(t, doc.Operations())
+	require.Empty(t, doc.Content())
+
+	snapshot := doc.Snapshot()
+	require.Len(t, snapshot, 1)
+	require.True(t, snapshot[0].Deleted)
+
+	// a new replica catches up from the snapshot
+	replica := NewDocument(malak.BlockContents{*block("first", "hello")})
+	replica.Apply(snapshot...)
+
+	require.Empty(t, replica.Content())
+}
+
+func TestOperation_Validate(t *testing.T) {
+	tt := []struct {
+		name      string
+		operation Operation
+		hasError  bool
+	}{
+		{
+			name:      "no block id",
+			operation: Operation{Block: block("first", "hello")},
+			hasError:  true,
+		},
+		{
+			name:      "no block",
+			operation: Operation{BlockID: "first"},
+			hasError:  true,
+		},
+		{
+			name:      "block id does not match",
+			operation: Operation{BlockID: "first", Block: block("second", "hello")},
+			hasError:  true,
+		},
+		{
+			name:      "deleted block",
+			operation: Operation{BlockID: "first", Deleted: true},
+		},
+		{
+			name:      "valid block",
+			operation: Operation{BlockID: "first", Block: block("first", "hello")},
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			err := v.operation.Validate()
+			if v.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
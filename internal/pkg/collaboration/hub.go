@@ -0,0 +1,655 @@
+package collaboration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPresenceInterval = time.Second * 10
+	defaultPersistInterval  = time.Second * 3
+
+	// sessionBufferSize is how many messages an editor can lag behind
+	// before it gets disconnected
+	sessionBufferSize = 64
+)
+
+type Options struct {
+	// PresenceInterval is how often an instance announces the editors
+	// connected to it. Editors of an instance that has not announced them
+	// in three intervals are dropped
+	PresenceInterval time.Duration
+
+	// PersistInterval is how often edited drafts are saved
+	PersistInterval time.Duration
+
+	// ReferenceGenerator creates the references of the revisions stored
+	// when drafts are saved
+	ReferenceGenerator malak.ReferenceGeneratorOperation
+}
+
+// Hub keeps the drafts being edited through this instance in sync with the
+// editors connected to it and with the other instances
+type Hub struct {
+	instanceID string
+	logger     *zap.Logger
+	pubsub     PubSub
+	updateRepo malak.UpdateRepository
+	opts       Options
+
+	mu    sync.Mutex
+	rooms map[uuid.UUID]*room
+	// closing are the rooms whose last editor left and are still saving.
+	// Editors joining in the meantime start from their replica
+	closing map[uuid.UUID]*room
+}
+
+type remotePresence struct {
+	editors []Editor
+	seenAt  time.Time
+}
+
+// room is a draft with at least one editor connected to this instance
+type room struct {
+	updateID     uuid.UUID
+	subscription Subscription
+
+	mu       sync.Mutex
+	document *Document
+	sessions map[string]*Session
+	remote   map[string]remotePresence
+
+	// dirty is only set for edits made by editors connected to this
+	// instance. The instance an edit was made on saves it so a single
+	// revision is stored for it
+	dirty bool
+	// editedBy is the last editor connected to this instance that changed
+	// the draft. Revisions are attributed to them
+	editedBy uuid.UUID
+	// version changes every time the replica is reloaded so a save that
+	// was already in flight knows it wrote stale content
+	version uint64
+}
+
+func New(logger *zap.Logger, pubsub PubSub,
+	updateRepo malak.UpdateRepository, opts Options) *Hub {
+
+	if opts.PresenceInterval <= 0 {
+		opts.PresenceInterval = defaultPresenceInterval
+	}
+
+	if opts.PersistInterval <= 0 {
+		opts.PersistInterval = defaultPersistInterval
+	}
+
+	if opts.ReferenceGenerator == nil {
+		opts.ReferenceGenerator = malak.NewReferenceGenerator()
+	}
+
+	return &Hub{
+		instanceID: uuid.NewString(),
+		logger:     logger,
+		pubsub:     pubsub,
+		updateRepo: updateRepo,
+		opts:       opts,
+		rooms:      make(map[uuid.UUID]*room),
+		closing:    make(map[uuid.UUID]*room),
+	}
+}
+
+// Run announces presence and saves edited drafts until the context is
+// cancelled. Pending edits are saved before it returns
+func (h *Hub) Run(ctx context.Context) {
+	presenceTicker := time.NewTicker(h.opts.PresenceInterval)
+	defer presenceTicker.Stop()
+
+	persistTicker := time.NewTicker(h.opts.PersistInterval)
+	defer persistTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.persistAll(context.Background())
+			return
+
+		case <-presenceTicker.C:
+			h.heartbeat(ctx)
+
+		case <-persistTicker.C:
+			h.persistAll(ctx)
+		}
+	}
+}
+
+// Join connects an editor to a draft. The first message the session
+// receives is the current state of the draft
+func (h *Hub) Join(ctx context.Context, update *malak.Update, editor Editor) (*Session, error) {
+	editor.SessionID = uuid.NewString()
+
+	session := &Session{
+		ID:       editor.SessionID,
+		Editor:   editor,
+		hub:      h,
+		messages: make(chan Message, sessionBufferSize),
+	}
+
+	h.mu.Lock()
+
+	r, ok := h.rooms[update.ID]
+	if !ok {
+		subscription, err := h.pubsub.Subscribe(ctx, channelName(update.ID))
+		if err != nil {
+			h.mu.Unlock()
+			return nil, err
+		}
+
+		content := update.Content
+
+		// the stored draft does not have the edits of a room that is
+		// still saving yet
+		if closing, isClosing := h.closing[update.ID]; isClosing {
+			closing.mu.Lock()
+			content = closing.document.Content()
+			closing.mu.Unlock()
+		}
+
+		r = &room{
+			updateID:     update.ID,
+			subscription: subscription,
+			document:     NewDocument(content),
+			sessions:     make(map[string]*Session),
+			remote:       make(map[string]remotePresence),
+		}
+
+		h.rooms[update.ID] = r
+
+		go h.listen(r)
+	}
+
+	session.room = r
+
+	r.mu.Lock()
+	r.sessions[session.ID] = session
+	session.send(Message{
+		Type:       MessageTypeSync,
+		SessionID:  session.ID,
+		Operations: r.document.Operations(),
+		Editors:    r.editors(),
+	})
+	r.mu.Unlock()
+
+	h.mu.Unlock()
+
+	if !ok {
+		// other instances could have edits that are not saved yet
+		h.publish(ctx, r, envelope{Type: envelopeTypeSyncRequest})
+	}
+
+	h.presenceChanged(ctx, r)
+
+	return session, nil
+}
+
+func (h *Hub) leave(ctx context.Context, session *Session) {
+	r := session.room
+
+	h.mu.Lock()
+
+	r.mu.Lock()
+	delete(r.sessions, session.ID)
+	isEmpty := len(r.sessions) == 0
+	r.mu.Unlock()
+
+	if isEmpty {
+		if h.rooms[r.updateID] == r {
+			delete(h.rooms, r.updateID)
+		}
+
+		h.closing[r.updateID] = r
+	}
+
+	h.mu.Unlock()
+
+	if isEmpty {
+		if err := r.subscription.Close(); err != nil {
+			h.logger.Error("could not close collaboration subscription",
+				zap.String("update_id", r.updateID.String()),
+				zap.Error(err))
+		}
+
+		// saving is done without holding the hub so other drafts are not
+		// held back. Editors joining in the meantime start from this replica.
+		// It is saved a second time if it was reloaded during the first save
+		h.persist(ctx, r)
+		h.persist(ctx, r)
+
+		h.mu.Lock()
+		if h.closing[r.updateID] == r {
+			delete(h.closing, r.updateID)
+		}
+		h.mu.Unlock()
+	}
+
+	h.presenceChanged(ctx, r)
+}
+
+// Reload replaces the replicas of a draft with content that was saved
+// outside the editor like a save through the API or a restored revision.
+// Without it the editors would write their stale copy back over it
+func (h *Hub) Reload(ctx context.Context, update *malak.Update, editedBy uuid.UUID) {
+	rooms := make([]*room, 0, 2)
+
+	h.mu.Lock()
+	if r, ok := h.rooms[update.ID]; ok {
+		rooms = append(rooms, r)
+	}
+
+	if r, ok := h.closing[update.ID]; ok {
+		rooms = append(rooms, r)
+	}
+	h.mu.Unlock()
+
+	for _, r := range rooms {
+		h.reload(r, update.Content, editedBy)
+	}
+
+	h.publishTo(ctx, update.ID, envelope{
+		Type:     envelopeTypeReload,
+		Content:  update.Content,
+		EditedBy: editedBy,
+	})
+}
+
+func (h *Hub) reload(r *room, content malak.BlockContents, editedBy uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.document = NewDocument(content)
+	r.dirty = false
+	r.editedBy = editedBy
+	r.version++
+
+	r.broadcast(Message{
+		Type:       MessageTypeSync,
+		Operations: r.document.Operations(),
+		Editors:    r.editors(),
+	}, "")
+}
+
+func (h *Hub) apply(ctx context.Context, session *Session, ops []Operation) error {
+	r := session.room
+
+	for _, op := range ops {
+		if err := op.Validate(); err != nil {
+			r.mu.Lock()
+			session.send(Message{
+				Type:    MessageTypeError,
+				Message: err.Error(),
+			})
+			r.mu.Unlock()
+			return err
+		}
+	}
+
+	r.mu.Lock()
+
+	stamped := make([]Operation, 0, len(ops))
+	for _, op := range ops {
+		stamped = append(stamped, r.document.Stamp(op, session.ID))
+	}
+
+	applied := r.document.Apply(stamped...)
+	if len(applied) > 0 {
+		r.dirty = true
+		r.editedBy = session.Editor.UserID
+		r.broadcast(Message{
+			Type:       MessageTypeOperations,
+			Operations: applied,
+		}, session.ID)
+	}
+
+	r.mu.Unlock()
+
+	if len(applied) == 0 {
+		return nil
+	}
+
+	h.publish(ctx, r, envelope{
+		Type:       envelopeTypeOperations,
+		Operations: applied,
+	})
+
+	return nil
+}
+
+// listen applies what the other instances publish until the room is closed
+func (h *Hub) listen(r *room) {
+	for payload := range r.subscription.Messages() {
+		var msg envelope
+
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			h.logger.Error("could not decode collaboration message",
+				zap.String("update_id", r.updateID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		if msg.InstanceID == h.instanceID {
+			continue
+		}
+
+		switch msg.Type {
+		case envelopeTypeOperations, envelopeTypeSnapshot:
+			r.mu.Lock()
+			applied := r.document.Apply(msg.Operations...)
+			if len(applied) > 0 {
+				r.broadcast(Message{
+					Type:       MessageTypeOperations,
+					Operations: applied,
+				}, "")
+			}
+			r.mu.Unlock()
+
+		case envelopeTypeReload:
+			h.reload(r, msg.Content, msg.EditedBy)
+
+		case envelopeTypeSyncRequest:
+			r.mu.Lock()
+			snapshot := r.document.Snapshot()
+			r.mu.Unlock()
+
+			h.publish(context.Background(), r, envelope{
+				Type:       envelopeTypeSnapshot,
+				Operations: snapshot,
+			})
+
+		case envelopeTypePresence:
+			r.mu.Lock()
+			if len(msg.Editors) == 0 {
+				delete(r.remote, msg.InstanceID)
+			} else {
+				r.remote[msg.InstanceID] = remotePresence{
+					editors: msg.Editors,
+					seenAt:  time.Now(),
+				}
+			}
+
+			r.broadcast(Message{
+				Type:    MessageTypePresence,
+				Editors: r.editors(),
+			}, "")
+			r.mu.Unlock()
+		}
+	}
+}
+
+// presenceChanged tells the local editors and the other instances who is
+// editing through this instance
+func (h *Hub) presenceChanged(ctx context.Context, r *room) {
+	r.mu.Lock()
+	r.broadcast(Message{
+		Type:    MessageTypePresence,
+		Editors: r.editors(),
+	}, "")
+	local := r.localEditors()
+	r.mu.Unlock()
+
+	h.publish(ctx, r, envelope{
+		Type:    envelopeTypePresence,
+		Editors: local,
+	})
+}
+
+// heartbeat announces the local editors of every room and drops the editors
+// of instances that went away without saying so
+func (h *Hub) heartbeat(ctx context.Context) {
+	expiry := time.Now().Add(-3 * h.opts.PresenceInterval)
+
+	for _, r := range h.activeRooms() {
+		r.mu.Lock()
+
+		var expired bool
+		for instanceID, presence := range r.remote {
+			if presence.seenAt.Before(expiry) {
+				delete(r.remote, instanceID)
+				expired = true
+			}
+		}
+
+		if expired {
+			r.broadcast(Message{
+				Type:    MessageTypePresence,
+				Editors: r.editors(),
+			}, "")
+		}
+
+		local := r.localEditors()
+		r.mu.Unlock()
+
+		h.publish(ctx, r, envelope{
+			Type:    envelopeTypePresence,
+			Editors: local,
+		})
+	}
+}
+
+func (h *Hub) persistAll(ctx context.Context) {
+	for _, r := range h.activeRooms() {
+		h.persist(ctx, r)
+	}
+}
+
+// persist saves the draft if it was edited since it was last saved. Every
+// instance saves its own replica, they all converge to the same content
+func (h *Hub) persist(ctx context.Context, r *room) {
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return
+	}
+
+	content := r.document.Content()
+	editedBy := r.editedBy
+	version := r.version
+	r.dirty = false
+	r.mu.Unlock()
+
+	logger := h.logger.With(zap.String("update_id", r.updateID.String()))
+
+	markDirty := func() {
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+	}
+
+	rejectEdits := func() {
+		logger.Warn("dropping collaborative edits of a sent update")
+
+		r.mu.Lock()
+		r.broadcast(Message{
+			Type:    MessageTypeError,
+			Message: malak.ErrUpdateSent.Error(),
+		}, "")
+		r.mu.Unlock()
+	}
+
+	// the title could have changed while the draft was being edited so the
+	// revision is taken from the latest copy
+	update, err := h.updateRepo.GetByID(ctx, r.updateID)
+	if err != nil {
+		logger.Error("could not fetch update to save collaborative edits", zap.Error(err))
+		markDirty()
+		return
+	}
+
+	if update.IsSent() {
+		rejectEdits()
+		return
+	}
+
+	update.Content = content
+	update.ContentChanged()
+
+	revision := &malak.UpdateRevision{
+		Reference:   h.opts.ReferenceGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:    update.ID,
+		WorkspaceID: update.WorkspaceID,
+		CreatedBy:   editedBy,
+		Title:       update.Title,
+		Content:     content,
+	}
+
+	// the update can still be sent between the fetch and the save. The
+	// repository refuses to save the content of a sent update
+	err = h.updateRepo.UpdateContent(ctx, update, revision)
+	if errors.Is(err, malak.ErrUpdateSent) {
+		rejectEdits()
+		return
+	}
+
+	if err != nil {
+		logger.Error("could not save collaborative edits", zap.Error(err))
+		markDirty()
+		return
+	}
+
+	// the draft was saved outside the editor while this save was in flight
+	// so the reloaded replica has to be written over the stale content
+	r.mu.Lock()
+	if r.version != version {
+		r.dirty = true
+	}
+	r.mu.Unlock()
+}
+
+func (h *Hub) activeRooms() []*room {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rooms := make([]*room, 0, len(h.rooms))
+	for _, r := range h.rooms {
+		rooms = append(rooms, r)
+	}
+
+	return rooms
+}
+
+func (h *Hub) publish(ctx context.Context, r *room, msg envelope) {
+	h.publishTo(ctx, r.updateID, msg)
+}
+
+func (h *Hub) publishTo(ctx context.Context, updateID uuid.UUID, msg envelope) {
+	msg.InstanceID = h.instanceID
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("could not encode collaboration message", zap.Error(err))
+		return
+	}
+
+	if err := h.pubsub.Publish(ctx, channelName(updateID), b); err != nil {
+		h.logger.Error("could not publish collaboration message",
+			zap.String("update_id", updateID.String()),
+			zap.Error(err))
+	}
+}
+
+// broadcast sends a message to the local editors except the one it came
+// from. It must be called with the room locked
+func (r *room) broadcast(msg Message, except string) {
+	for id, session := range r.sessions {
+		if id == except {
+			continue
+		}
+
+		session.send(msg)
+	}
+}
+
+// editors lists everyone editing the draft. It must be called with the room
+// locked
+func (r *room) editors() []Editor {
+	editors := r.localEditors()
+
+	for _, presence := range r.remote {
+		editors = append(editors, presence.editors...)
+	}
+
+	return editors
+}
+
+func (r *room) localEditors() []Editor {
+	editors := make([]Editor, 0, len(r.sessions))
+
+	for _, session := range r.sessions {
+		editors = append(editors, session.Editor)
+	}
+
+	return editors
+}
+
+// Session is a single editor connected to a draft
+type Session struct {
+	ID     string
+	Editor Editor
+
+	hub      *Hub
+	room     *room
+	messages chan Message
+
+	leaveOnce sync.Once
+	closeOnce sync.Once
+	closed    bool
+}
+
+// Messages streams the changes made by the other editors. It is closed once
+// the session leaves or falls too far behind
+func (s *Session) Messages() <-chan Message { return s.messages }
+
+// Apply merges the operations of the editor into the draft and sends them
+// to every other editor. Invalid operations are rejected and the editor is
+// sent an error message
+func (s *Session) Apply(ctx context.Context, ops []Operation) error {
+	return s.hub.apply(ctx, s, ops)
+}
+
+// Leave disconnects the editor. The draft is saved once its last editor on
+// this instance leaves
+func (s *Session) Leave(ctx context.Context) {
+	s.leaveOnce.Do(func() {
+		s.hub.leave(ctx, s)
+
+		s.room.mu.Lock()
+		s.close()
+		s.room.mu.Unlock()
+	})
+}
+
+// send must be called with the room locked
+func (s *Session) send(msg Message) {
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.messages <- msg:
+	default:
+		// a slow editor should not hold back the others. The editor
+		// gets the whole draft again when it reconnects
+		s.close()
+	}
+}
+
+func (s *Session) close() {
+	s.closeOnce.Do(func() {
+		s.closed = true
+		close(s.messages)
+	})
+}
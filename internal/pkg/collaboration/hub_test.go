@@ -0,0 +1,360 @@
+package collaboration_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// memoryPubSub stands in for redis so several hubs can act as instances
+// of the API
+type memoryPubSub struct {
+	mu            sync.Mutex
+	subscriptions map[string][]*memorySubscription
+}
+
+func newMemoryPubSub() *memoryPubSub {
+	return &memoryPubSub{
+		subscriptions: make(map[string][]*memorySubscription),
+	}
+}
+
+func (m *memoryPubSub) Publish(_ context.Context, channel string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.subscriptions[channel] {
+		s.messages <- payload
+	}
+
+	return nil
+}
+
+func (m *memoryPubSub) Subscribe(_ context.Context, channel string) (collaboration.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &memorySubscription{
+		pubsub:   m,
+		channel:  channel,
+		messages: make(chan []byte, 100),
+	}
+
+	m.subscriptions[channel] = append(m.subscriptions[channel], s)
+
+	return s, nil
+}
+
+type memorySubscription struct {
+	pubsub   *memoryPubSub
+	channel  string
+	messages chan []byte
+}
+
+func (s *memorySubscription) Messages() <-chan []byte { return s.messages }
+
+func (s *memorySubscription) Close() error {
+	s.pubsub.mu.Lock()
+	defer s.pubsub.mu.Unlock()
+
+	subscriptions := s.pubsub.subscriptions[s.channel]
+	for i, v := range subscriptions {
+		if v == s {
+			s.pubsub.subscriptions[s.channel] = append(subscriptions[:i], subscriptions[i+1:]...)
+			break
+		}
+	}
+
+	close(s.messages)
+	return nil
+}
+
+func block(id, text string) *malak.Block {
+	return &malak.Block{
+		ID:   id,
+		Type: "paragraph",
+		Content: []interface{}{
+			map[string]interface{}{"type": "text", "text": text},
+		},
+	}
+}
+
+func blockText(b malak.Block) string {
+	return b.Content.([]interface{})[0].(map[string]interface{})["text"].(string)
+}
+
+// waitFor reads messages until one of the given type shows up
+func waitFor(t *testing.T, session *collaboration.Session, msgType collaboration.MessageType) collaboration.Message {
+	t.Helper()
+
+	for {
+		select {
+		case msg, ok := <-session.Messages():
+			require.True(t, ok, "session was closed")
+			if msg.Type == msgType {
+				return msg
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("did not receive %s message", msgType)
+		}
+	}
+}
+
+// waitForEditors reads presence messages until the expected number of
+// editors is listed
+func waitForEditors(t *testing.T, session *collaboration.Session, count int) collaboration.Message {
+	t.Helper()
+
+	for {
+		msg := waitFor(t, session, collaboration.MessageTypePresence)
+		if len(msg.Editors) == count {
+			return msg
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+	pubsub := newMemoryPubSub()
+
+	first := collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{})
+	second := collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{})
+
+	update := &malak.Update{
+		ID:      uuid.New(),
+		Title:   "Monthly update",
+		Status:  malak.UpdateStatusDraft,
+		Content: malak.BlockContents{*block("first", "hello")},
+	}
+
+	ctx := context.Background()
+
+	aliceID := uuid.New()
+
+	alice, err := first.Join(ctx, update, collaboration.Editor{UserID: aliceID, FullName: "Alice"})
+	require.NoError(t, err)
+
+	msg := waitFor(t, alice, collaboration.MessageTypeSync)
+	require.Equal(t, alice.ID, msg.SessionID)
+	require.Len(t, msg.Operations, 1)
+
+	bob, err := second.Join(ctx, update, collaboration.Editor{FullName: "Bob"})
+	require.NoError(t, err)
+
+	waitFor(t, bob, collaboration.MessageTypeSync)
+
+	// both instances know who is editing
+	waitForEditors(t, alice, 2)
+
+	require.Error(t, alice.Apply(ctx, []collaboration.Operation{{BlockID: "first"}}))
+
+	require.NoError(t, alice.Apply(ctx, []collaboration.Operation{
+		{
+			BlockID:  "second",
+			Block:    block("second", "from alice"),
+			Position: 2,
+		},
+	}))
+
+	msg = waitFor(t, bob, collaboration.MessageTypeOperations)
+	require.Len(t, msg.Operations, 1)
+	require.Equal(t, "second", msg.Operations[0].BlockID)
+	require.Equal(t, alice.ID, msg.Operations[0].SessionID)
+
+	stored := *update
+
+	// only the instance the edit was made on saves it
+	updateRepo.EXPECT().GetByID(gomock.Any(), update.ID).
+		Times(1).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID) (*malak.Update, error) {
+			u := stored
+			return &u, nil
+		})
+
+	var saved []malak.BlockContents
+	var mu sync.Mutex
+
+	updateRepo.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, u *malak.Update, revision *malak.UpdateRevision) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.Equal(t, "Monthly update", u.Title)
+			require.Equal(t, aliceID, revision.CreatedBy)
+			require.Equal(t, u.Content, revision.Content)
+			require.NotEmpty(t, revision.Reference)
+			saved = append(saved, u.Content)
+			return nil
+		})
+
+	bob.Leave(ctx)
+
+	msg = waitForEditors(t, alice, 1)
+	require.Equal(t, "Alice", msg.Editors[0].FullName)
+
+	alice.Leave(ctx)
+
+	_, ok := <-alice.Messages()
+	require.False(t, ok)
+
+	require.Len(t, saved, 1)
+	require.Len(t, saved[0], 2)
+	require.Equal(t, "from alice", blockText(saved[0][1]))
+}
+
+func TestHub_SentUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+	hub := collaboration.New(zap.NewNop(), newMemoryPubSub(), updateRepo, collaboration.Options{
+		PersistInterval: time.Millisecond * 10,
+	})
+
+	update := &malak.Update{
+		ID:      uuid.New(),
+		Status:  malak.UpdateStatusDraft,
+		Content: malak.BlockContents{*block("first", "hello")},
+	}
+
+	ctx := context.Background()
+
+	session, err := hub.Join(ctx, update, collaboration.Editor{FullName: "Alice"})
+	require.NoError(t, err)
+
+	require.NoError(t, session.Apply(ctx, []collaboration.Operation{
+		{BlockID: "first", Deleted: true},
+	}))
+
+	updateRepo.EXPECT().GetByID(gomock.Any(), update.ID).
+		Return(&malak.Update{
+			ID:     update.ID,
+			Status: malak.UpdateStatusSent,
+		}, nil)
+
+	runCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
+
+	// the edits must not overwrite what was sent to investors
+	go hub.Run(runCtx)
+
+	msg := waitFor(t, session, collaboration.MessageTypeError)
+	require.Equal(t, "update has been sent already. you cannot edit it", msg.Message)
+}
+
+func TestHub_SentWhileSaving(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+	hub := collaboration.New(zap.NewNop(), newMemoryPubSub(), updateRepo, collaboration.Options{
+		PersistInterval: time.Millisecond * 10,
+	})
+
+	update := &malak.Update{
+		ID:      uuid.New(),
+		Status:  malak.UpdateStatusDraft,
+		Content: malak.BlockContents{*block("first", "hello")},
+	}
+
+	ctx := context.Background()
+
+	session, err := hub.Join(ctx, update, collaboration.Editor{FullName: "Alice"})
+	require.NoError(t, err)
+
+	require.NoError(t, session.Apply(ctx, []collaboration.Operation{
+		{BlockID: "first", Deleted: true},
+	}))
+
+	updateRepo.EXPECT().GetByID(gomock.Any(), update.ID).
+		Return(&malak.Update{
+			ID:     update.ID,
+			Status: malak.UpdateStatusDraft,
+		}, nil)
+
+	// the update got sent after it was fetched
+	updateRepo.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(malak.ErrUpdateSent)
+
+	runCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
+
+	go hub.Run(runCtx)
+
+	msg := waitFor(t, session, collaboration.MessageTypeError)
+	require.Equal(t, malak.ErrUpdateSent.Error(), msg.Message)
+}
+
+func TestHub_Reload(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+	pubsub := newMemoryPubSub()
+
+	first := collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{})
+	second := collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{})
+
+	update := &malak.Update{
+		ID:      uuid.New(),
+		Status:  malak.UpdateStatusDraft,
+		Content: malak.BlockContents{*block("first", "hello")},
+	}
+
+	ctx := context.Background()
+
+	alice, err := first.Join(ctx, update, collaboration.Editor{FullName: "Alice"})
+	require.NoError(t, err)
+
+	waitFor(t, alice, collaboration.MessageTypeSync)
+
+	bob, err := second.Join(ctx, update, collaboration.Editor{FullName: "Bob"})
+	require.NoError(t, err)
+
+	waitFor(t, bob, collaboration.MessageTypeSync)
+
+	require.NoError(t, alice.Apply(ctx, []collaboration.Operation{
+		{
+			BlockID:  "first",
+			Block:    block("first", "from alice"),
+			Position: 1,
+		},
+	}))
+
+	waitFor(t, bob, collaboration.MessageTypeOperations)
+
+	// the draft was saved through the API while it was being edited
+	saved := *update
+	saved.Content = malak.BlockContents{*block("restored", "from the api")}
+
+	second.Reload(ctx, &saved, uuid.New())
+
+	for _, session := range []*collaboration.Session{alice, bob} {
+		msg := waitFor(t, session, collaboration.MessageTypeSync)
+		require.Len(t, msg.Operations, 1)
+		require.Equal(t, "restored", msg.Operations[0].BlockID)
+	}
+
+	// the stale edits of alice are not written back over the saved draft
+	updateRepo.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	bob.Leave(ctx)
+	alice.Leave(ctx)
+}
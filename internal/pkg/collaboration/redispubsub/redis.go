@@ -0,0 +1,63 @@
+package redispubsub
+
+import (
+	"context"
+
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisPubSub struct {
+	inner *redis.Client
+}
+
+func New(client *redis.Client) (collaboration.PubSub, error) {
+	return &redisPubSub{
+		inner: client,
+	}, client.Ping(context.Background()).Err()
+}
+
+func (r *redisPubSub) Publish(ctx context.Context,
+	channel string, payload []byte) error {
+	return r.inner.Publish(ctx, channel, payload).Err()
+}
+
+func (r *redisPubSub) Subscribe(ctx context.Context,
+	channel string) (collaboration.Subscription, error) {
+
+	pubsub := r.inner.Subscribe(ctx, channel)
+
+	// wait for the subscription to be confirmed so nothing published
+	// right after is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	s := &subscription{
+		inner:    pubsub,
+		messages: make(chan []byte),
+	}
+
+	go s.forward()
+
+	return s, nil
+}
+
+type subscription struct {
+	inner    *redis.PubSub
+	messages chan []byte
+}
+
+// forward stops once the subscription is closed
+func (s *subscription) forward() {
+	defer close(s.messages)
+
+	for msg := range s.inner.Channel() {
+		s.messages <- []byte(msg.Payload)
+	}
+}
+
+func (s *subscription) Messages() <-chan []byte { return s.messages }
+
+func (s *subscription) Close() error { return s.inner.Close() }
@@ -0,0 +1,73 @@
+package redispubsub
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupRedis(t *testing.T) (*redis.Client, func()) {
+	ctx := t.Context()
+
+	redisContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:latest",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	require.NoError(t, err)
+
+	host, err := redisContainer.Host(ctx)
+	require.NoError(t, err)
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("%s:%s", host, port.Port()),
+	})
+
+	return redisClient, func() {
+		redisClient.Close()
+		require.NoError(t, redisContainer.Terminate(ctx))
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	redisClient, cleanup := setupRedis(t)
+	defer cleanup()
+
+	p, err := New(redisClient)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	subscription, err := p.Subscribe(ctx, "collaboration")
+	require.NoError(t, err)
+
+	require.NoError(t, p.Publish(ctx, "collaboration", []byte("payload")))
+	require.NoError(t, p.Publish(ctx, "another-channel", []byte("ignored")))
+
+	select {
+	case msg := <-subscription.Messages():
+		require.Equal(t, []byte("payload"), msg)
+	case <-time.After(time.Second * 5):
+		t.Fatal("message was not received")
+	}
+
+	require.NoError(t, subscription.Close())
+
+	select {
+	case _, ok := <-subscription.Messages():
+		require.False(t, ok)
+	case <-time.After(time.Second * 5):
+		t.Fatal("messages were not closed")
+	}
+}
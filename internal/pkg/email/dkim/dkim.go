@@ -0,0 +1,240 @@
+// Package dkim signs outgoing emails for custom sending domains. Only
+// rsa-sha256 with relaxed/relaxed canonicalization is supported
+package dkim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Selector is the DKIM selector used for every sending domain. The
+	// public key is published at malak._domainkey.<domain>
+	Selector = "malak"
+
+	keySize = 2048
+)
+
+// signedHeaders are signed when they are present on the message
+var signedHeaders = []string{
+	"from", "to", "subject", "date", "message-id",
+	"mime-version", "content-type", "list-unsubscribe", "list-unsubscribe-post",
+}
+
+// GenerateKey creates a keypair. The private key is PEM encoded and the public
+// key is the base64 value that goes into the p= tag of the DNS record
+func GenerateKey() (privateKey []byte, publicKey string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	privateKey = pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	return privateKey, base64.StdEncoding.EncodeToString(public), nil
+}
+
+type SignOptions struct {
+	Domain     string
+	Selector   string
+	PrivateKey []byte
+}
+
+func (s SignOptions) Validate() error {
+	if strings.TrimSpace(s.Domain) == "" {
+		return errors.New("please provide the signing domain")
+	}
+
+	if strings.TrimSpace(s.Selector) == "" {
+		return errors.New("please provide the selector")
+	}
+
+	if len(s.PrivateKey) == 0 {
+		return errors.New("please provide the private key")
+	}
+
+	return nil
+}
+
+// Sign returns the message with a DKIM-Signature header prepended
+func Sign(message []byte, opts SignOptions) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(opts.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	message = normalizeLineEndings(message)
+
+	headers, body := splitMessage(message)
+
+	bodyHash := sha256.Sum256(canonicalizeBody(body))
+
+	toSign := make([]string, 0, len(signedHeaders))
+	var canonical bytes.Buffer
+
+	for _, name := range signedHeaders {
+		header, ok := findHeader(headers, name)
+		if !ok {
+			continue
+		}
+
+		toSign = append(toSign, name)
+		canonical.WriteString(canonicalizeHeader(header))
+		canonical.WriteString("\r\n")
+	}
+
+	if len(toSign) == 0 {
+		return nil, errors.New("message has no headers to sign")
+	}
+
+	signature := fmt.Sprintf("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s;\r\n"+
+		" t=%d; h=%s;\r\n bh=%s;\r\n b=",
+		opts.Domain, opts.Selector, time.Now().Unix(),
+		strings.Join(toSign, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]))
+
+	// the signature header is signed with an empty b= tag and without the
+	// trailing line break
+	canonical.WriteString(canonicalizeHeader(signature))
+
+	hash := sha256.Sum256(canonical.Bytes())
+
+	signed, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(signature)
+	buf.WriteString(base64.StdEncoding.EncodeToString(signed))
+	buf.WriteString("\r\n")
+	buf.Write(message)
+
+	return buf.Bytes(), nil
+}
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("private key is not pem encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an rsa key")
+	}
+
+	return rsaKey, nil
+}
+
+func normalizeLineEndings(b []byte) []byte {
+	b = bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(b, []byte("\n"), []byte("\r\n"))
+}
+
+// splitMessage returns the header fields, with their folded lines, and the
+// body
+func splitMessage(message []byte) ([]string, []byte) {
+	head, body, ok := bytes.Cut(message, []byte("\r\n\r\n"))
+	if !ok {
+		head, body = bytes.TrimSuffix(message, []byte("\r\n")), nil
+	}
+
+	headers := make([]string, 0)
+
+	for _, line := range strings.Split(string(head), "\r\n") {
+		if len(headers) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			headers[len(headers)-1] += "\r\n" + line
+			continue
+		}
+
+		headers = append(headers, line)
+	}
+
+	return headers, body
+}
+
+// findHeader returns the last occurrence of the header as it is the one
+// verifiers pick first
+func findHeader(headers []string, name string) (string, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		key, _, ok := strings.Cut(headers[i], ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return headers[i], true
+		}
+	}
+
+	return "", false
+}
+
+func canonicalizeHeader(header string) string {
+	key, value, _ := strings.Cut(header, ":")
+
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.Join(strings.FieldsFunc(value, isWSP), " ")
+
+	return strings.ToLower(strings.TrimSpace(key)) + ":" + value
+}
+
+func canonicalizeBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		lines[i] = strings.Join(splitKeepLeadingWSP(line), " ")
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// splitKeepLeadingWSP splits the line on whitespace runs. Leading whitespace
+// is reduced to a single space rather than removed
+func splitKeepLeadingWSP(line string) []string {
+	fields := strings.FieldsFunc(line, isWSP)
+	if len(line) > 0 && isWSP(rune(line[0])) {
+		fields = append([]string{""}, fields...)
+	}
+
+	return fields
+}
+
+func isWSP(r rune) bool { return r == ' ' || r == '\t' }
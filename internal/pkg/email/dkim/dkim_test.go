@@ -0,0 +1,145 @@
+package dkim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMessage = "From: Lanre <yo@ayinke.ventures>\r\n" +
+	"To: investor@example.com\r\n" +
+	"Subject: Our  update\r\n" +
+	"\tfor October\r\n" +
+	"Mime-Version: 1.0\r\n" +
+	"Content-Type: text/html; charset=UTF-8\r\n" +
+	"\r\n" +
+	"<p>Hello  investors</p> \r\n" +
+	"\r\n" +
+	"\r\n"
+
+func TestCanonicalize(t *testing.T) {
+	// examples from RFC 6376 section 3.4.5
+	require.Equal(t, "a:X", canonicalizeHeader("A: X"))
+	require.Equal(t, "b:Y Z", canonicalizeHeader("B : Y\t\r\n\tZ  "))
+
+	require.Equal(t, " C\r\nD E\r\n",
+		string(canonicalizeBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+
+	require.Empty(t, canonicalizeBody([]byte("\r\n\r\n")))
+}
+
+// verify checks the signature of a signed message the way a receiving
+// server would
+func verify(t *testing.T, signed []byte, publicKey string) error {
+	t.Helper()
+
+	headers, body := splitMessage(signed)
+
+	signature, ok := findHeader(headers, "dkim-signature")
+	require.True(t, ok)
+
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(canonicalizeHeader(signature), ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[strings.TrimPrefix(key, "dkim-signature:")] = strings.ReplaceAll(value, " ", "")
+	}
+
+	bodyHash := sha256.Sum256(canonicalizeBody(body))
+	if base64.StdEncoding.EncodeToString(bodyHash[:]) != tags["bh"] {
+		return rsa.ErrVerification
+	}
+
+	var canonical bytes.Buffer
+	for _, name := range strings.Split(tags["h"], ":") {
+		header, ok := findHeader(headers, name)
+		require.True(t, ok)
+
+		canonical.WriteString(canonicalizeHeader(header))
+		canonical.WriteString("\r\n")
+	}
+
+	emptied := regexp.MustCompile(`b=[^;]*$`).ReplaceAllString(signature, "b=")
+	canonical.WriteString(canonicalizeHeader(emptied))
+
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	require.NoError(t, err)
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	require.NoError(t, err)
+
+	sig, err := base64.StdEncoding.DecodeString(tags["b"])
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(canonical.Bytes())
+	return rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, hash[:], sig)
+}
+
+func TestSign(t *testing.T) {
+	privateKey, publicKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	opts := SignOptions{
+		Domain:     "ayinke.ventures",
+		Selector:   Selector,
+		PrivateKey: privateKey,
+	}
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := Sign([]byte(testMessage), SignOptions{Domain: "ayinke.ventures"})
+		require.Error(t, err)
+
+		_, err = Sign([]byte(testMessage), SignOptions{
+			Domain:     "ayinke.ventures",
+			Selector:   Selector,
+			PrivateKey: []byte("not a key"),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("signed message verifies", func(t *testing.T) {
+		signed, err := Sign([]byte(testMessage), opts)
+		require.NoError(t, err)
+
+		require.True(t, bytes.HasPrefix(signed, []byte("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=ayinke.ventures; s=malak;")))
+		require.Contains(t, string(signed), "h=from:to:subject:mime-version:content-type;")
+		require.True(t, bytes.HasSuffix(signed, []byte(testMessage)))
+
+		require.NoError(t, verify(t, signed, publicKey))
+	})
+
+	t.Run("tampered body fails verification", func(t *testing.T) {
+		signed, err := Sign([]byte(testMessage), opts)
+		require.NoError(t, err)
+
+		tampered := bytes.Replace(signed, []byte("investors"), []byte("everyone"), 1)
+
+		require.Error(t, verify(t, tampered, publicKey))
+	})
+
+	t.Run("tampered subject fails verification", func(t *testing.T) {
+		signed, err := Sign([]byte(testMessage), opts)
+		require.NoError(t, err)
+
+		tampered := bytes.Replace(signed, []byte("Our  update"), []byte("Our news"), 1)
+
+		require.Error(t, verify(t, tampered, publicKey))
+	})
+
+	t.Run("other key fails verification", func(t *testing.T) {
+		_, otherPublicKey, err := GenerateKey()
+		require.NoError(t, err)
+
+		signed, err := Sign([]byte(testMessage), opts)
+		require.NoError(t, err)
+
+		require.Error(t, verify(t, signed, otherPublicKey))
+	})
+}
@@ -26,6 +26,21 @@ var (
 
 	// go:embed templates/auth/email_verify.html
 	EmailVerificationTemplate string
+
+	//go:embed templates/auth/password_reset.html
+	PasswordResetTemplate string
+
+	//go:embed templates/team/invite.html
+	TeamInviteTemplate string
+
+	//go:embed templates/updates/comment.html
+	UpdateCommentTemplate string
+
+	//go:embed templates/updates/review_reminder.html
+	UpdateReviewReminderTemplate string
+
+	//go:embed templates/updates/approval_request.html
+	UpdateApprovalRequestTemplate string
 )
 
 type SendOptionsBatch []SendOptions
@@ -49,7 +64,10 @@ type SendOptions struct {
 	Sender    malak.Email
 	Recipient malak.Email
 	Subject   string
-	DKIM      struct {
+	// Headers are added to the email as is. Used for things like
+	// List-Unsubscribe
+	Headers map[string]string
+	DKIM    struct {
 		Sign       bool
 		PrivateKey []byte
 	}
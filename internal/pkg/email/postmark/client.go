@@ -0,0 +1,137 @@
+package postmark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+)
+
+const (
+	defaultEndpoint      = "https://api.postmarkapp.com"
+	defaultMessageStream = "outbound"
+)
+
+type client struct {
+	httpClient    *http.Client
+	endpoint      string
+	serverToken   string
+	messageStream string
+	senderName    string
+	senderEmail   string
+}
+
+func New(cfg config.Config) (email.Client, error) {
+	if hermes.IsStringEmpty(cfg.Email.Postmark.ServerToken) {
+		return nil, errors.New("please provide your postmark server token")
+	}
+
+	endpoint := cfg.Email.Postmark.Endpoint
+	if hermes.IsStringEmpty(endpoint) {
+		endpoint = defaultEndpoint
+	}
+
+	messageStream := cfg.Email.Postmark.MessageStream
+	if hermes.IsStringEmpty(messageStream) {
+		messageStream = defaultMessageStream
+	}
+
+	return &client{
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+		endpoint:      strings.TrimSuffix(endpoint, "/"),
+		serverToken:   cfg.Email.Postmark.ServerToken,
+		messageStream: messageStream,
+		senderName:    cfg.Email.SenderName,
+		senderEmail:   cfg.Email.Sender.String(),
+	}, nil
+}
+
+type header struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type sendRequest struct {
+	From          string   `json:"From"`
+	To            string   `json:"To"`
+	Subject       string   `json:"Subject"`
+	HTMLBody      string   `json:"HtmlBody"`
+	MessageStream string   `json:"MessageStream"`
+	TrackOpens    bool     `json:"TrackOpens"`
+	Headers       []header `json:"Headers,omitempty"`
+}
+
+type sendResponse struct {
+	MessageID string `json:"MessageID"`
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+func (s *client) Close() error { return nil }
+
+func (s *client) Send(ctx context.Context,
+	opts email.SendOptions) (string, error) {
+
+	headers := make([]header, 0, len(opts.Headers))
+	for k, v := range opts.Headers {
+		headers = append(headers, header{Name: k, Value: v})
+	}
+
+	b, err := json.Marshal(sendRequest{
+		From:          fmt.Sprintf("%s <%s>", s.senderName, s.senderEmail),
+		To:            opts.Recipient.String(),
+		Subject:       opts.Subject,
+		HTMLBody:      opts.HTML,
+		MessageStream: s.messageStream,
+		TrackOpens:    true,
+		Headers:       headers,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.endpoint+"/email", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Postmark-Server-Token", s.serverToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var res sendResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("postmark responded with status code %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK || res.ErrorCode != 0 {
+		return "", fmt.Errorf("postmark responded with error code %d: %s",
+			res.ErrorCode, res.Message)
+	}
+
+	return res.MessageID, nil
+}
+
+func (s *client) Name() malak.UpdateRecipientLogProvider {
+	return malak.UpdateRecipientLogProviderPostmark
+}
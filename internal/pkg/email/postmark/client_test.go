@@ -0,0 +1,86 @@
+package postmark
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/stretchr/testify/require"
+)
+
+func getConfig(endpoint string) config.Config {
+	var cfg config.Config
+
+	cfg.Email.Sender = "updates@malak.vc"
+	cfg.Email.SenderName = "Malak"
+	cfg.Email.Postmark.ServerToken = "server-token"
+	cfg.Email.Postmark.Endpoint = endpoint
+
+	return cfg
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(getConfig(""))
+	require.NoError(t, err)
+
+	cfg := getConfig("")
+	cfg.Email.Postmark.ServerToken = ""
+
+	_, err = New(cfg)
+	require.Error(t, err)
+}
+
+func TestClient_Send(t *testing.T) {
+	var received sendRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/email", r.URL.Path)
+		require.Equal(t, "server-token", r.Header.Get("X-Postmark-Server-Token"))
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte(`{"To":"lanre@ayinke.ventures","MessageID":"b7bc2f4a-e38e-4336-af7d-e6c392c2f817","ErrorCode":0,"Message":"OK"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(getConfig(server.URL))
+	require.NoError(t, err)
+
+	id, err := client.Send(t.Context(), email.SendOptions{
+		HTML:      "<p>Hello</p>",
+		Recipient: "lanre@ayinke.ventures",
+		Subject:   "Monthly update",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "b7bc2f4a-e38e-4336-af7d-e6c392c2f817", id)
+
+	require.Equal(t, "Malak <updates@malak.vc>", received.From)
+	require.Equal(t, "lanre@ayinke.ventures", received.To)
+	require.Equal(t, "outbound", received.MessageStream)
+	require.True(t, received.TrackOpens)
+
+	require.Equal(t, malak.UpdateRecipientLogProviderPostmark, client.Name())
+}
+
+func TestClient_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"ErrorCode":406,"Message":"You tried to send to a recipient that has been marked as inactive."}`))
+	}))
+	defer server.Close()
+
+	client, err := New(getConfig(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Send(t.Context(), email.SendOptions{
+		HTML:      "<p>Hello</p>",
+		Recipient: "lanre@ayinke.ventures",
+		Subject:   "Monthly update",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "marked as inactive")
+}
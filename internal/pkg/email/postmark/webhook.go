@@ -0,0 +1,91 @@
+package postmark
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+)
+
+// ENUM(Delivery,Bounce,SpamComplaint,Open)
+type RecordType string
+
+var ErrUnauthorized = errors.New("invalid postmark webhook credentials")
+
+// Event is the body of every webhook Postmark sends. Only the fields we
+// care about are decoded
+type Event struct {
+	RecordType RecordType `json:"RecordType"`
+	MessageID  string     `json:"MessageID"`
+	Recipient  string     `json:"Recipient"`
+	Email      string     `json:"Email"`
+
+	// Type is only set on bounces. e.g HardBounce, SoftBounce
+	Type string `json:"Type"`
+
+	DeliveredAt *time.Time `json:"DeliveredAt"`
+	BouncedAt   *time.Time `json:"BouncedAt"`
+	ReceivedAt  *time.Time `json:"ReceivedAt"`
+}
+
+// IsHardBounce reports if the address should never be sent to again.
+// Soft bounces and auto responders are retried by Postmark
+func (e Event) IsHardBounce() bool {
+	if e.RecordType != RecordTypeBounce {
+		return false
+	}
+
+	switch e.Type {
+	case "HardBounce", "BadEmailAddress", "ManuallyDeactivated", "Unsubscribe":
+		return true
+	default:
+		return false
+	}
+}
+
+// OccurredAt returns when Postmark recorded the event
+func (e Event) OccurredAt() time.Time {
+	for _, v := range []*time.Time{e.DeliveredAt, e.BouncedAt, e.ReceivedAt} {
+		if v != nil {
+			return *v
+		}
+	}
+
+	return time.Now()
+}
+
+// WebhookAuthenticator checks the basic auth credentials configured on the
+// webhook url. Postmark does not sign its webhooks
+type WebhookAuthenticator struct {
+	username string
+	password string
+}
+
+func NewWebhookAuthenticator(username, password string) (*WebhookAuthenticator, error) {
+	if hermes.IsStringEmpty(username) || hermes.IsStringEmpty(password) {
+		return nil, errors.New("please provide your postmark webhook username and password")
+	}
+
+	return &WebhookAuthenticator{
+		username: username,
+		password: password,
+	}, nil
+}
+
+func (a *WebhookAuthenticator) Verify(r *http.Request) error {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(a.username)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(a.password)) == 1
+
+	if !usernameMatch || !passwordMatch {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package postmark
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookAuthenticator_Verify(t *testing.T) {
+	_, err := NewWebhookAuthenticator("", "")
+	require.Error(t, err)
+
+	auth, err := NewWebhookAuthenticator("postmark", "secret")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/postmark", nil)
+	require.ErrorIs(t, auth.Verify(req), ErrUnauthorized)
+
+	req.SetBasicAuth("postmark", "oops")
+	require.ErrorIs(t, auth.Verify(req), ErrUnauthorized)
+
+	req.SetBasicAuth("postmark", "secret")
+	require.NoError(t, auth.Verify(req))
+}
+
+func TestEvent_IsHardBounce(t *testing.T) {
+	require.True(t, Event{RecordType: RecordTypeBounce, Type: "HardBounce"}.IsHardBounce())
+	require.False(t, Event{RecordType: RecordTypeBounce, Type: "SoftBounce"}.IsHardBounce())
+	require.False(t, Event{RecordType: RecordTypeDelivery, Type: "HardBounce"}.IsHardBounce())
+}
@@ -46,6 +46,7 @@ func (s *client) Send(ctx context.Context,
 		To:      []string{opts.Recipient.String()},
 		Subject: opts.Subject,
 		Html:    opts.HTML,
+		Headers: opts.Headers,
 	}
 
 	res, err := s.inner.Emails.Send(params)
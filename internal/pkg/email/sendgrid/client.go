@@ -0,0 +1,150 @@
+package sendgrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+)
+
+const defaultEndpoint = "https://api.sendgrid.com"
+
+type client struct {
+	httpClient  *http.Client
+	endpoint    string
+	apiKey      string
+	senderName  string
+	senderEmail string
+}
+
+func New(cfg config.Config) (email.Client, error) {
+	if hermes.IsStringEmpty(cfg.Email.Sendgrid.APIKey) {
+		return nil, errors.New("please provide your sendgrid api key")
+	}
+
+	endpoint := cfg.Email.Sendgrid.Endpoint
+	if hermes.IsStringEmpty(endpoint) {
+		endpoint = defaultEndpoint
+	}
+
+	return &client{
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		apiKey:      cfg.Email.Sendgrid.APIKey,
+		senderName:  cfg.Email.SenderName,
+		senderEmail: cfg.Email.Sender.String(),
+	}, nil
+}
+
+type address struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type content struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendRequest struct {
+	Personalizations []struct {
+		To []address `json:"to"`
+	} `json:"personalizations"`
+	From             address           `json:"from"`
+	Subject          string            `json:"subject"`
+	Content          []content         `json:"content"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	TrackingSettings struct {
+		OpenTracking struct {
+			Enable bool `json:"enable"`
+		} `json:"open_tracking"`
+	} `json:"tracking_settings"`
+}
+
+type errorResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+	} `json:"errors"`
+}
+
+func (s *client) Close() error { return nil }
+
+func (s *client) Send(ctx context.Context,
+	opts email.SendOptions) (string, error) {
+
+	req := sendRequest{
+		From: address{
+			Email: s.senderEmail,
+			Name:  s.senderName,
+		},
+		Subject: opts.Subject,
+		Content: []content{
+			{Type: "text/html", Value: opts.HTML},
+		},
+		Headers: opts.Headers,
+	}
+
+	req.Personalizations = append(req.Personalizations, struct {
+		To []address `json:"to"`
+	}{
+		To: []address{{Email: opts.Recipient.String()}},
+	})
+
+	// needed so the event webhook reports opens
+	req.TrackingSettings.OpenTracking.Enable = true
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.endpoint+"/v3/mail/send", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || len(errResp.Errors) == 0 {
+			return "", fmt.Errorf("sendgrid responded with status code %d", resp.StatusCode)
+		}
+
+		return "", fmt.Errorf("sendgrid responded with status code %d: %s",
+			resp.StatusCode, errResp.Errors[0].Message)
+	}
+
+	messageID := resp.Header.Get("X-Message-Id")
+	if hermes.IsStringEmpty(messageID) {
+		return "", errors.New("sendgrid did not return a message id")
+	}
+
+	return messageID, nil
+}
+
+func (s *client) Name() malak.UpdateRecipientLogProvider {
+	return malak.UpdateRecipientLogProviderSendgrid
+}
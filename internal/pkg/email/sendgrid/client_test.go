@@ -0,0 +1,89 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/stretchr/testify/require"
+)
+
+func getConfig(endpoint string) config.Config {
+	var cfg config.Config
+
+	cfg.Email.Sender = "updates@malak.vc"
+	cfg.Email.SenderName = "Malak"
+	cfg.Email.Sendgrid.APIKey = "SG.test"
+	cfg.Email.Sendgrid.Endpoint = endpoint
+
+	return cfg
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(getConfig(""))
+	require.NoError(t, err)
+
+	cfg := getConfig("")
+	cfg.Email.Sendgrid.APIKey = ""
+
+	_, err = New(cfg)
+	require.Error(t, err)
+}
+
+func TestClient_Send(t *testing.T) {
+	var received sendRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v3/mail/send", r.URL.Path)
+		require.Equal(t, "Bearer SG.test", r.Header.Get("Authorization"))
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.Header().Set("X-Message-Id", "message_123")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := New(getConfig(server.URL))
+	require.NoError(t, err)
+
+	id, err := client.Send(t.Context(), email.SendOptions{
+		HTML:      "<p>Hello</p>",
+		Recipient: "lanre@ayinke.ventures",
+		Subject:   "Monthly update",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "message_123", id)
+
+	require.Equal(t, "updates@malak.vc", received.From.Email)
+	require.Equal(t, "Monthly update", received.Subject)
+	require.Len(t, received.Personalizations, 1)
+	require.Equal(t, "lanre@ayinke.ventures", received.Personalizations[0].To[0].Email)
+	require.Equal(t, "<p>Hello</p>", received.Content[0].Value)
+	require.True(t, received.TrackingSettings.OpenTracking.Enable)
+
+	require.Equal(t, malak.UpdateRecipientLogProviderSendgrid, client.Name())
+}
+
+func TestClient_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"The provided authorization grant is invalid, expired, or revoked","field":null}]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(getConfig(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Send(t.Context(), email.SendOptions{
+		HTML:      "<p>Hello</p>",
+		Recipient: "lanre@ayinke.ventures",
+		Subject:   "Monthly update",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "authorization grant is invalid")
+}
@@ -0,0 +1,114 @@
+package sendgrid
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+)
+
+const (
+	SignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	TimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+
+	// same window svix allows for resend webhooks so a captured
+	// request cannot be replayed later on
+	timestampTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.New("missing sendgrid webhook signature")
+	ErrInvalidSignature = errors.New("invalid sendgrid webhook signature")
+	ErrInvalidTimestamp = errors.New("sendgrid webhook timestamp is too old or too new")
+)
+
+// Event is a single item in the batch of events SendGrid posts to the
+// event webhook
+type Event struct {
+	Email       string `json:"email"`
+	Timestamp   int64  `json:"timestamp"`
+	Event       string `json:"event"`
+	SGMessageID string `json:"sg_message_id"`
+	SGEventID   string `json:"sg_event_id"`
+	Reason      string `json:"reason"`
+
+	// Type is only set on bounces. It is either bounce or blocked
+	Type string `json:"type"`
+}
+
+// MessageID returns the id that was handed out when the email was sent.
+// SendGrid suffixes the X-Message-Id header value with routing data
+// in webhook events
+func (e Event) MessageID() string {
+	id, _, _ := strings.Cut(e.SGMessageID, ".")
+	return id
+}
+
+type WebhookVerifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+// NewWebhookVerifier takes the base64 encoded public key from the signed
+// event webhook settings page
+func NewWebhookVerifier(key string) (*WebhookVerifier, error) {
+	if hermes.IsStringEmpty(key) {
+		return nil, errors.New("please provide your sendgrid webhook verification key")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.New("sendgrid webhook verification key is not valid base64")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sendgrid webhook verification key must be an ECDSA public key")
+	}
+
+	return &WebhookVerifier{publicKey: publicKey}, nil
+}
+
+func (v *WebhookVerifier) Verify(payload []byte, headers http.Header) error {
+	signature := headers.Get(SignatureHeader)
+	timestamp := headers.Get(TimestampHeader)
+
+	if hermes.IsStringEmpty(signature) || hermes.IsStringEmpty(timestamp) {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if diff := time.Since(time.Unix(unix, 0)); diff > timestampTolerance || diff < -timestampTolerance {
+		return ErrInvalidTimestamp
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(timestamp))
+	hash.Write(payload)
+
+	if !ecdsa.VerifyASN1(v.publicKey, hash.Sum(nil), sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package sendgrid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return key, base64.StdEncoding.EncodeToString(der)
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, timestamp string, payload []byte) string {
+	t.Helper()
+
+	hash := sha256.Sum256(append([]byte(timestamp), payload...))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	key, publicKey := generateKey(t)
+
+	verifier, err := NewWebhookVerifier(publicKey)
+	require.NoError(t, err)
+
+	payload := []byte(`[{"event":"open","sg_message_id":"message_123.filter"}]`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	headers := http.Header{}
+	headers.Set(TimestampHeader, timestamp)
+	headers.Set(SignatureHeader, sign(t, key, timestamp, payload))
+
+	require.NoError(t, verifier.Verify(payload, headers))
+
+	require.ErrorIs(t, verifier.Verify([]byte(`[]`), headers), ErrInvalidSignature)
+
+	headers.Set(TimestampHeader, strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+	require.ErrorIs(t, verifier.Verify(payload, headers), ErrInvalidSignature)
+
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	headers.Set(TimestampHeader, stale)
+	headers.Set(SignatureHeader, sign(t, key, stale, payload))
+	require.ErrorIs(t, verifier.Verify(payload, headers), ErrInvalidTimestamp)
+
+	headers.Set(TimestampHeader, "oops")
+	require.ErrorIs(t, verifier.Verify(payload, headers), ErrInvalidTimestamp)
+
+	require.ErrorIs(t, verifier.Verify(payload, http.Header{}), ErrMissingSignature)
+
+	otherKey, _ := generateKey(t)
+	headers.Set(TimestampHeader, timestamp)
+	headers.Set(SignatureHeader, sign(t, otherKey, timestamp, payload))
+	require.ErrorIs(t, verifier.Verify(payload, headers), ErrInvalidSignature)
+}
+
+func TestNewWebhookVerifier_Errors(t *testing.T) {
+	_, err := NewWebhookVerifier("")
+	require.Error(t, err)
+
+	_, err = NewWebhookVerifier("not-base64!")
+	require.Error(t, err)
+
+	_, err = NewWebhookVerifier(base64.StdEncoding.EncodeToString([]byte("oops")))
+	require.Error(t, err)
+}
+
+func TestEvent_MessageID(t *testing.T) {
+	require.Equal(t, "message_123", Event{SGMessageID: "message_123.filterdrecv-p3mdw1-756b745b58-kmzbl-18-5F5FC76C-9.0"}.MessageID())
+	require.Equal(t, "message_123", Event{SGMessageID: "message_123"}.MessageID())
+}
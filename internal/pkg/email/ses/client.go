@@ -0,0 +1,182 @@
+package ses
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/email"
+)
+
+// signingName is the service name SES v2 requests are signed with
+const signingName = "ses"
+
+type client struct {
+	httpClient       *http.Client
+	signer           *v4.Signer
+	credentials      aws.CredentialsProvider
+	endpoint         string
+	region           string
+	configurationSet string
+	senderName       string
+	senderEmail      string
+}
+
+// New talks to the SES v2 api directly so we do not have to pull in the
+// whole service sdk just to send an email
+func New(cfg config.Config) (email.Client, error) {
+	if hermes.IsStringEmpty(cfg.Email.SES.Region) {
+		return nil, errors.New("please provide your ses region")
+	}
+
+	opts := []func(*awsConfig.LoadOptions) error{
+		awsConfig.WithRegion(cfg.Email.SES.Region),
+	}
+
+	// fallback to the default credential chain. e.g IAM roles
+	if !hermes.IsStringEmpty(cfg.Email.SES.AccessKey) {
+		opts = append(opts, awsConfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(
+				cfg.Email.SES.AccessKey,
+				cfg.Email.SES.AccessSecret,
+				"")))
+	}
+
+	conf, err := awsConfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := cfg.Email.SES.Endpoint
+	if hermes.IsStringEmpty(endpoint) {
+		endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", cfg.Email.SES.Region)
+	}
+
+	return &client{
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+		signer:           v4.NewSigner(),
+		credentials:      conf.Credentials,
+		endpoint:         strings.TrimSuffix(endpoint, "/"),
+		region:           cfg.Email.SES.Region,
+		configurationSet: cfg.Email.SES.ConfigurationSet,
+		senderName:       cfg.Email.SenderName,
+		senderEmail:      cfg.Email.Sender.String(),
+	}, nil
+}
+
+type content struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type header struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type sendRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject content `json:"Subject"`
+			Body    struct {
+				HTML content `json:"Html"`
+			} `json:"Body"`
+			Headers []header `json:"Headers,omitempty"`
+		} `json:"Simple"`
+	} `json:"Content"`
+	ConfigurationSetName string `json:"ConfigurationSetName,omitempty"`
+}
+
+type sendResponse struct {
+	MessageID string `json:"MessageId"`
+	Message   string `json:"message"`
+}
+
+func (s *client) Close() error { return nil }
+
+func (s *client) Send(ctx context.Context,
+	opts email.SendOptions) (string, error) {
+
+	var req sendRequest
+
+	req.FromEmailAddress = fmt.Sprintf("%s <%s>", s.senderName, s.senderEmail)
+	req.Destination.ToAddresses = []string{opts.Recipient.String()}
+	req.Content.Simple.Subject = content{Data: opts.Subject, Charset: "UTF-8"}
+	req.Content.Simple.Body.HTML = content{Data: opts.HTML, Charset: "UTF-8"}
+	for k, v := range opts.Headers {
+		req.Content.Simple.Headers = append(req.Content.Simple.Headers, header{Name: k, Value: v})
+	}
+
+	// bounce, complaint and open events are only published for
+	// emails sent through a configuration set
+	req.ConfigurationSetName = s.configurationSet
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.endpoint+"/v2/email/outbound-emails", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	creds, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(b)
+
+	if err := s.signer.SignHTTP(ctx, creds, httpReq, hex.EncodeToString(hash[:]),
+		signingName, s.region, time.Now()); err != nil {
+		return "", err
+	}
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var res sendResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("ses responded with status code %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ses responded with status code %d: %s",
+			resp.StatusCode, res.Message)
+	}
+
+	return res.MessageID, nil
+}
+
+func (s *client) Name() malak.UpdateRecipientLogProvider {
+	return malak.UpdateRecipientLogProviderSes
+}
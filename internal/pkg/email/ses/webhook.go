@@ -0,0 +1,309 @@
+package ses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidSignature       = errors.New("invalid sns message signature")
+	ErrInvalidCertificateURL  = errors.New("sns signing certificate must be hosted by amazon")
+	ErrUnsupportedMessageType = errors.New("unsupported sns message type")
+	ErrTopicNotAllowed        = errors.New("sns topic is not allowed")
+)
+
+// certificateHost matches the regional SNS endpoints, including the
+// china partition
+var certificateHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+const (
+	MessageTypeNotification             = "Notification"
+	MessageTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	MessageTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// Message is the envelope SNS posts to http subscriptions
+type Message struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// stringToSign builds the canonical string SNS signs. The keys must be in
+// this exact order
+func (m Message) stringToSign() (string, error) {
+	var keys []string
+
+	switch m.Type {
+	case MessageTypeNotification:
+		keys = []string{"Message", m.Message, "MessageId", m.MessageID}
+		if m.Subject != "" {
+			keys = append(keys, "Subject", m.Subject)
+		}
+
+		keys = append(keys, "Timestamp", m.Timestamp, "TopicArn", m.TopicArn, "Type", m.Type)
+
+	case MessageTypeSubscriptionConfirmation, MessageTypeUnsubscribeConfirmation:
+		keys = []string{
+			"Message", m.Message,
+			"MessageId", m.MessageID,
+			"SubscribeURL", m.SubscribeURL,
+			"Timestamp", m.Timestamp,
+			"Token", m.Token,
+			"TopicArn", m.TopicArn,
+			"Type", m.Type,
+		}
+
+	default:
+		return "", ErrUnsupportedMessageType
+	}
+
+	var sb strings.Builder
+
+	for _, v := range keys {
+		sb.WriteString(v)
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+// Event is the SES notification carried in the Message field. Event
+// publishing uses eventType while identity notifications use
+// notificationType
+type Event struct {
+	EventType        string `json:"eventType"`
+	NotificationType string `json:"notificationType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType string    `json:"bounceType"`
+		Timestamp  time.Time `json:"timestamp"`
+	} `json:"bounce"`
+	Complaint struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"complaint"`
+	Delivery struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"delivery"`
+	Open struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"open"`
+}
+
+// Type returns one of Bounce, Complaint, Delivery or Open
+func (e Event) Type() string {
+	if e.EventType != "" {
+		return e.EventType
+	}
+
+	return e.NotificationType
+}
+
+// IsHardBounce reports if the address should never be sent to again.
+// Transient bounces are retried by SES
+func (e Event) IsHardBounce() bool {
+	return e.Type() == "Bounce" && e.Bounce.BounceType == "Permanent"
+}
+
+// OccurredAt returns when SES recorded the event
+func (e Event) OccurredAt() time.Time {
+	for _, v := range []time.Time{
+		e.Bounce.Timestamp, e.Complaint.Timestamp,
+		e.Delivery.Timestamp, e.Open.Timestamp} {
+		if !v.IsZero() {
+			return v
+		}
+	}
+
+	return time.Now()
+}
+
+// CertificateFetcher retrieves the certificate used to sign SNS messages
+type CertificateFetcher func(ctx context.Context, certURL string) (*x509.Certificate, error)
+
+// DefaultCertificateFetcher only downloads certificates hosted by SNS
+func DefaultCertificateFetcher(httpClient *http.Client) CertificateFetcher {
+	return func(ctx context.Context, certURL string) (*x509.Certificate, error) {
+		u, err := url.Parse(certURL)
+		if err != nil {
+			return nil, ErrInvalidCertificateURL
+		}
+
+		if u.Scheme != "https" || !certificateHost.MatchString(u.Hostname()) {
+			return nil, ErrInvalidCertificateURL
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("could not fetch sns certificate. status code %d", resp.StatusCode)
+		}
+
+		b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		if err != nil {
+			return nil, err
+		}
+
+		block, _ := pem.Decode(b)
+		if block == nil {
+			return nil, errors.New("sns certificate is not pem encoded")
+		}
+
+		return x509.ParseCertificate(block.Bytes)
+	}
+}
+
+type WebhookVerifier struct {
+	httpClient *http.Client
+	fetcher    CertificateFetcher
+	topics     []string
+
+	mu    sync.RWMutex
+	certs map[string]*x509.Certificate
+}
+
+// NewWebhookVerifier only accepts messages published to one of the given
+// topic arns
+func NewWebhookVerifier(httpClient *http.Client,
+	fetcher CertificateFetcher, topics []string) *WebhookVerifier {
+
+	return &WebhookVerifier{
+		httpClient: httpClient,
+		fetcher:    fetcher,
+		topics:     topics,
+		certs:      make(map[string]*x509.Certificate),
+	}
+}
+
+func (v *WebhookVerifier) certificate(ctx context.Context,
+	certURL string) (*x509.Certificate, error) {
+
+	v.mu.RLock()
+	cert, ok := v.certs[certURL]
+	v.mu.RUnlock()
+
+	if ok {
+		return cert, nil
+	}
+
+	cert, err := v.fetcher(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+
+	return cert, nil
+}
+
+func (v *WebhookVerifier) Verify(ctx context.Context, msg *Message) error {
+	s, err := msg.stringToSign()
+	if err != nil {
+		return err
+	}
+
+	// checked before the signature so anyone's topic cannot make us fetch
+	// certificates or confirm a subscription
+	if !slices.Contains(v.topics, msg.TopicArn) {
+		return ErrTopicNotAllowed
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	cert, err := v.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	var hashed []byte
+	var hash crypto.Hash
+
+	switch msg.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(s))
+		hashed, hash = sum[:], crypto.SHA1
+	case "2":
+		sum := sha256.Sum256([]byte(s))
+		hashed, hash = sum[:], crypto.SHA256
+	default:
+		return ErrInvalidSignature
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, hashed, signature); err != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ConfirmSubscription visits the SubscribeURL of a verified confirmation
+// message so SNS starts delivering notifications
+func (v *WebhookVerifier) ConfirmSubscription(ctx context.Context, msg *Message) error {
+	if msg.Type != MessageTypeSubscriptionConfirmation {
+		return ErrUnsupportedMessageType
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msg.SubscribeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not confirm sns subscription. status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
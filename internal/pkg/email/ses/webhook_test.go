@@ -0,0 +1,154 @@
+package ses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return key, cert
+}
+
+func signMessage(t *testing.T, key *rsa.PrivateKey, msg *Message) {
+	t.Helper()
+
+	s, err := msg.stringToSign()
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte(s))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	msg.SignatureVersion = "2"
+	msg.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	key, cert := generateCertificate(t)
+
+	var fetched int
+
+	verifier := NewWebhookVerifier(http.DefaultClient,
+		func(_ context.Context, certURL string) (*x509.Certificate, error) {
+			fetched++
+			return cert, nil
+		}, []string{"arn:aws:sns:us-east-1:123456789012:ses-events"})
+
+	msg := &Message{
+		Type:           MessageTypeNotification,
+		MessageID:      "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:       "arn:aws:sns:us-east-1:123456789012:ses-events",
+		Message:        `{"eventType":"Bounce","mail":{"messageId":"0100018c-ses-message"},"bounce":{"bounceType":"Permanent"}}`,
+		Timestamp:      "2025-11-10T09:00:00.000Z",
+		SigningCertURL: "https://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem",
+	}
+
+	signMessage(t, key, msg)
+
+	require.NoError(t, verifier.Verify(t.Context(), msg))
+	require.NoError(t, verifier.Verify(t.Context(), msg))
+	require.Equal(t, 1, fetched)
+
+	other := *msg
+	other.TopicArn = "arn:aws:sns:us-east-1:999999999999:someone-elses-topic"
+	signMessage(t, key, &other)
+	require.ErrorIs(t, verifier.Verify(t.Context(), &other), ErrTopicNotAllowed)
+
+	msg.Message = `{"eventType":"Delivery"}`
+	require.ErrorIs(t, verifier.Verify(t.Context(), msg), ErrInvalidSignature)
+
+	msg.Type = "Oops"
+	require.ErrorIs(t, verifier.Verify(t.Context(), msg), ErrUnsupportedMessageType)
+}
+
+func TestWebhookVerifier_ConfirmSubscription(t *testing.T) {
+	var confirmed bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "ConfirmSubscription", r.URL.Query().Get("Action"))
+		confirmed = true
+	}))
+	defer server.Close()
+
+	verifier := NewWebhookVerifier(server.Client(), nil, nil)
+
+	err := verifier.ConfirmSubscription(t.Context(), &Message{
+		Type:         MessageTypeSubscriptionConfirmation,
+		SubscribeURL: server.URL + "/?Action=ConfirmSubscription&Token=token",
+	})
+	require.NoError(t, err)
+	require.True(t, confirmed)
+
+	err = verifier.ConfirmSubscription(t.Context(), &Message{
+		Type: MessageTypeNotification,
+	})
+	require.ErrorIs(t, err, ErrUnsupportedMessageType)
+}
+
+func TestDefaultCertificateFetcher(t *testing.T) {
+	fetcher := DefaultCertificateFetcher(http.DefaultClient)
+
+	for _, v := range []string{
+		"http://sns.us-east-1.amazonaws.com/cert.pem",
+		"https://evil.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com.evil.com/cert.pem",
+		"https://sns.attacker-bucket.s3.amazonaws.com/cert.pem",
+	} {
+		_, err := fetcher(t.Context(), v)
+		require.ErrorIs(t, err, ErrInvalidCertificateURL)
+	}
+
+	for _, v := range []string{
+		"sns.us-east-1.amazonaws.com",
+		"sns.cn-north-1.amazonaws.com.cn",
+	} {
+		require.True(t, certificateHost.MatchString(v))
+	}
+}
+
+func TestEvent(t *testing.T) {
+	var event Event
+
+	event.EventType = "Bounce"
+	event.Bounce.BounceType = "Permanent"
+
+	require.True(t, event.IsHardBounce())
+
+	event.Bounce.BounceType = "Transient"
+	require.False(t, event.IsHardBounce())
+
+	event = Event{NotificationType: "Complaint"}
+	require.Equal(t, "Complaint", event.Type())
+}
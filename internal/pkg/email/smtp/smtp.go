@@ -1,6 +1,7 @@
 package smtp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	pkgmail "net/mail"
@@ -8,6 +9,7 @@ import (
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/ayinke-llc/malak/internal/pkg/email/dkim"
 	"github.com/ayinke-llc/malak/internal/pkg/util"
 	"github.com/google/uuid"
 	"gopkg.in/gomail.v2"
@@ -60,9 +62,50 @@ func (s *smtpClient) Send(ctx context.Context,
 	msg.SetAddressHeader("From", opts.Sender.String(), opts.Sender.String())
 	msg.SetHeader("To", opts.Recipient.String())
 	msg.SetHeader("Subject", opts.Subject)
+	for k, v := range opts.Headers {
+		msg.SetHeader(k, v)
+	}
+
 	msg.AddAlternative("text/html", opts.HTML)
 
-	return uuid.NewString(), s.client.DialAndSend(msg)
+	if !opts.DKIM.Sign {
+		return uuid.NewString(), s.client.DialAndSend(msg)
+	}
+
+	signed, err := signMessage(msg, opts)
+	if err != nil {
+		return "", err
+	}
+
+	sender, err := s.client.Dial()
+	if err != nil {
+		return "", err
+	}
+
+	defer sender.Close()
+
+	return uuid.NewString(), sender.Send(opts.Sender.String(),
+		[]string{opts.Recipient.String()}, bytes.NewReader(signed))
+}
+
+// signMessage returns the raw message signed with the DKIM key of the
+// domain of the sender
+func signMessage(msg *gomail.Message, opts email.SendOptions) ([]byte, error) {
+	domain, err := malak.DomainFromEmail(opts.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := msg.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return dkim.Sign(buf.Bytes(), dkim.SignOptions{
+		Domain:     domain,
+		Selector:   dkim.Selector,
+		PrivateKey: opts.DKIM.PrivateKey,
+	})
 }
 
 func (s *smtpClient) Name() malak.UpdateRecipientLogProvider {
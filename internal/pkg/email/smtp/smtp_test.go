@@ -1,14 +1,17 @@
 package smtp
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
+	"github.com/ayinke-llc/malak/internal/pkg/email/dkim"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"gopkg.in/gomail.v2"
 )
 
 func TestSMTP_Send(t *testing.T) {
@@ -53,6 +56,44 @@ func TestSMTP_Send(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSignMessage(t *testing.T) {
+	privateKey, _, err := dkim.GenerateKey()
+	require.NoError(t, err)
+
+	opts := email.SendOptions{
+		HTML:      "This is my email in html format",
+		Sender:    "updates@ayinke.ventures",
+		Recipient: "lanre@example.com",
+		Subject:   "Our October update",
+	}
+
+	opts.DKIM.Sign = true
+	opts.DKIM.PrivateKey = privateKey
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", opts.Sender.String())
+	msg.SetHeader("To", opts.Recipient.String())
+	msg.SetHeader("Subject", opts.Subject)
+	msg.AddAlternative("text/html", opts.HTML)
+
+	t.Run("signed with the domain of the sender", func(t *testing.T) {
+		signed, err := signMessage(msg, opts)
+		require.NoError(t, err)
+
+		require.True(t, bytes.HasPrefix(signed, []byte("DKIM-Signature: ")))
+		require.Contains(t, string(signed), "d=ayinke.ventures; s=malak;")
+		require.Contains(t, string(signed), "Subject: Our October update")
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		invalid := opts
+		invalid.DKIM.PrivateKey = []byte("oops")
+
+		_, err := signMessage(msg, invalid)
+		require.Error(t, err)
+	})
+}
+
 func TestNew_Errors(t *testing.T) {
 
 	t.Run("smtp host is empty", func(t *testing.T) {
@@ -104,6 +145,26 @@ func getConfig(port int) config.Config {
 				APIKey        string "mapstructure:\"api_key\" yaml:\"api_key\" json:\"api_key\""
 				WebhookSecret string "mapstructure:\"webhook_secret\" yaml:\"webhook_secret\" json:\"webhook_secret\""
 			} "mapstructure:\"resend\" yaml:\"resend\" json:\"resend\""
+			Sendgrid struct {
+				APIKey                 string "mapstructure:\"api_key\" yaml:\"api_key\" json:\"api_key\""
+				WebhookVerificationKey string "mapstructure:\"webhook_verification_key\" yaml:\"webhook_verification_key\" json:\"webhook_verification_key\""
+				Endpoint               string "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+			} "mapstructure:\"sendgrid\" yaml:\"sendgrid\" json:\"sendgrid\""
+			SES struct {
+				Region           string   "mapstructure:\"region\" yaml:\"region\" json:\"region\""
+				AccessKey        string   "mapstructure:\"access_key\" yaml:\"access_key\" json:\"access_key\""
+				AccessSecret     string   "mapstructure:\"access_secret\" yaml:\"access_secret\" json:\"access_secret\""
+				ConfigurationSet string   "mapstructure:\"configuration_set\" yaml:\"configuration_set\" json:\"configuration_set\""
+				Endpoint         string   "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+				TopicArns        []string "mapstructure:\"topic_arns\" yaml:\"topic_arns\" json:\"topic_arns\""
+			} "mapstructure:\"ses\" yaml:\"ses\" json:\"ses\""
+			Postmark struct {
+				ServerToken     string "mapstructure:\"server_token\" yaml:\"server_token\" json:\"server_token\""
+				MessageStream   string "mapstructure:\"message_stream\" yaml:\"message_stream\" json:\"message_stream\""
+				WebhookUsername string "mapstructure:\"webhook_username\" yaml:\"webhook_username\" json:\"webhook_username\""
+				WebhookPassword string "mapstructure:\"webhook_password\" yaml:\"webhook_password\" json:\"webhook_password\""
+				Endpoint        string "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+			} "mapstructure:\"postmark\" yaml:\"postmark\" json:\"postmark\""
 		}{
 			Provider:   config.EmailProviderSmtp,
 			Sender:     malak.Email("yo@oops.com"),
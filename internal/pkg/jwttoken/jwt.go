@@ -1,15 +1,27 @@
 package jwttoken
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
 )
 
+const (
+	// AccessTokenTTL is kept short since access tokens are sent with
+	// every request. Clients use their refresh token to get a new one
+	AccessTokenTTL = time.Minute * 15
+
+	// RefreshTokenTTL is how long a session can stay idle before the user
+	// has to sign in again. Every refresh extends it
+	RefreshTokenTTL = time.Hour * 24 * 30
+)
+
 // ENUM(access,refresh)
 type Purpose uint8
 
@@ -17,29 +29,62 @@ type JWTokenData struct {
 	Token     string
 	Purpose   Purpose
 	UserID    uuid.UUID
+	SessionID uuid.UUID
+	// TokenID uniquely identifies a token. For refresh tokens, it is
+	// used to detect reuse of an already rotated token
+	TokenID   uuid.UUID
 	ExpiresAt time.Time
 }
 
 type jwtokenManager struct {
 	signingKey string
+	cache      cache.Cache
 }
 
 type JWTokenManager interface {
 	GenerateJWToken(JWTokenData) (JWTokenData, error)
 	ParseJWToken(string) (JWTokenData, error)
+
+	// CreateSession starts a new server side session for the user and
+	// returns an access and refresh token pair
+	CreateSession(context.Context, uuid.UUID) (Session, error)
+	// RefreshSession exchanges a parsed refresh token for a new token pair.
+	// The provided refresh token cannot be used again
+	RefreshSession(context.Context, JWTokenData) (Session, error)
+	// ValidateSession makes sure the session the token belongs to has
+	// not been revoked
+	ValidateSession(context.Context, JWTokenData) error
+	RevokeSession(context.Context, JWTokenData) error
+	RevokeAllSessions(context.Context, uuid.UUID) error
 }
 
-func New(cfg config.Config) JWTokenManager {
+func New(cfg config.Config, c cache.Cache) JWTokenManager {
 	return &jwtokenManager{
 		signingKey: cfg.Auth.JWT.Key,
+		cache:      c,
 	}
 }
 
 func (t *jwtokenManager) GenerateJWToken(data JWTokenData) (JWTokenData, error) {
+	ttl := AccessTokenTTL
+	if data.Purpose == PurposeRefresh {
+		ttl = RefreshTokenTTL
+	}
+
+	if data.TokenID == uuid.Nil {
+		data.TokenID = uuid.New()
+	}
+
+	data.ExpiresAt = time.Now().Add(ttl)
+
 	claims := jwt.MapClaims{
-		"signer": "malak",
-		"id":     data.UserID,
-		"exp":    time.Now().Add(time.Hour * 168), // 7 days
+		"signer":  "malak",
+		"id":      data.UserID,
+		"sid":     data.SessionID,
+		"jti":     data.TokenID,
+		"purpose": data.Purpose.String(),
+		"iat":     time.Now().Unix(),
+		"exp":     data.ExpiresAt.Unix(),
 	}
 	jwtoken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	token, err := jwtoken.SignedString([]byte(t.signingKey))
@@ -58,8 +103,7 @@ func (t *jwtokenManager) ParseJWToken(JWToken string) (JWTokenData, error) {
 		}
 		return []byte(t.signingKey), nil
 	})
-
-	if err != nil && parsedJWToken == nil {
+	if err != nil {
 		return JWTokenData{}, fmt.Errorf("ParseJWToken/Parse: parse JWToken failed: %w", err)
 	}
 
@@ -73,23 +117,47 @@ func (t *jwtokenManager) ParseJWToken(JWToken string) (JWTokenData, error) {
 		return JWTokenData{}, errors.New("user_id not exists")
 	}
 
-	expiresAt, ok := claims["exp"].(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return JWTokenData{}, err
+	}
+
+	// numeric dates are decoded as float64
+	expiresAt, ok := claims["exp"].(float64)
 	if !ok {
 		return JWTokenData{}, errors.New("ParseJWToken/parseJWTokenClaim/exp: expiration date not found")
 	}
 
-	expiresTime, err := time.Parse(time.RFC3339, expiresAt)
-	if err != nil {
-		return JWTokenData{}, err
+	data := JWTokenData{
+		Token:     JWToken,
+		UserID:    userID,
+		ExpiresAt: time.Unix(int64(expiresAt), 0),
 	}
 
-	userID, err := uuid.Parse(id)
+	purpose, ok := claims["purpose"].(string)
+	if !ok {
+		return JWTokenData{}, errors.New("ParseJWToken/parseJWTokenClaim/purpose: token purpose not found")
+	}
+
+	data.Purpose, err = ParsePurpose(purpose)
 	if err != nil {
 		return JWTokenData{}, err
 	}
 
-	return JWTokenData{
-		UserID:    userID,
-		ExpiresAt: expiresTime,
-	}, nil
+	for key, dst := range map[string]*uuid.UUID{
+		"sid": &data.SessionID,
+		"jti": &data.TokenID,
+	} {
+		v, ok := claims[key].(string)
+		if !ok {
+			return JWTokenData{}, fmt.Errorf("ParseJWToken/parseJWTokenClaim/%s: claim not found", key)
+		}
+
+		*dst, err = uuid.Parse(v)
+		if err != nil {
+			return JWTokenData{}, err
+		}
+	}
+
+	return data, nil
 }
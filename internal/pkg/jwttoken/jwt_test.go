@@ -1,9 +1,15 @@
 package jwttoken
 
 import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +24,30 @@ func getConfig() config.Config {
 				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
 				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
 			} "yaml:\"google\" mapstructure:\"google\" json:\"google\""
+			GitHub struct {
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"github\" mapstructure:\"github\" json:\"github\""
+			Microsoft struct {
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				Tenant       string   "yaml:\"tenant\" mapstructure:\"tenant\" json:\"tenant\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"microsoft\" mapstructure:\"microsoft\" json:\"microsoft\""
+			OIDC struct {
+				Name         string   "yaml:\"name\" mapstructure:\"name\" json:\"name\""
+				IssuerURL    string   "yaml:\"issuer_url\" mapstructure:\"issuer_url\" json:\"issuer_url\""
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"oidc\" mapstructure:\"oidc\" json:\"oidc\""
 			JWT struct {
 				Key string "yaml:\"key\" mapstructure:\"key\" json:\"key\""
 			} "yaml:\"jwt\" mapstructure:\"jwt\" json:\"jwt\""
@@ -33,7 +63,7 @@ func getConfig() config.Config {
 
 func TestJWT_Parse(t *testing.T) {
 
-	manager := New(getConfig())
+	manager := New(getConfig(), newMemoryCache())
 
 	userID := uuid.New()
 
@@ -48,11 +78,29 @@ func TestJWT_Parse(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, userID, parsedToken.UserID)
+	require.Equal(t, PurposeAccess, parsedToken.Purpose)
+	require.Equal(t, token.TokenID, parsedToken.TokenID)
+	require.WithinDuration(t, time.Now().Add(AccessTokenTTL), parsedToken.ExpiresAt, time.Minute)
+}
+
+func TestJWT_ParseInvalidSignature(t *testing.T) {
+
+	token, err := New(getConfig(), newMemoryCache()).GenerateJWToken(JWTokenData{
+		UserID:  uuid.New(),
+		Purpose: PurposeAccess,
+	})
+	require.NoError(t, err)
+
+	cfg := getConfig()
+	cfg.Auth.JWT.Key = "another-key"
+
+	_, err = New(cfg, newMemoryCache()).ParseJWToken(token.Token)
+	require.Error(t, err)
 }
 
 func TestJWT_Generate(t *testing.T) {
 
-	manager := New(getConfig())
+	manager := New(getConfig(), newMemoryCache())
 
 	token, err := manager.GenerateJWToken(JWTokenData{
 		UserID:  uuid.New(),
@@ -62,3 +110,241 @@ func TestJWT_Generate(t *testing.T) {
 
 	require.NotEmpty(t, token.Token)
 }
+
+// memoryCache is a minimal in-memory cache.Cache used to exercise
+// the session flows
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+	sets  map[string]map[string]struct{}
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		items: make(map[string][]byte),
+		sets:  make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *memoryCache) Add(_ context.Context, key string, b []byte, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[key] = b
+	return nil
+}
+
+func (m *memoryCache) Exists(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.items[key]; !ok {
+		return false, cache.ErrCacheMiss
+	}
+
+	return true, nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.items[key]
+	if !ok {
+		return nil, cache.ErrCacheMiss
+	}
+
+	return b, nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.items, key)
+	delete(m.sets, key)
+	return nil
+}
+
+func (m *memoryCache) CompareAndSwap(_ context.Context, key string, old, new []byte, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.items[key]
+	if !ok || !bytes.Equal(b, old) {
+		return false, nil
+	}
+
+	m.items[key] = new
+	return true, nil
+}
+
+func (m *memoryCache) AddIfNotExists(_ context.Context, key string, payload []byte, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.items[key]; ok {
+		return false, nil
+	}
+
+	m.items[key] = payload
+	return true, nil
+}
+
+func (m *memoryCache) Increment(_ context.Context, key string, _ time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count, _ := strconv.ParseInt(string(m.items[key]), 10, 64)
+	count++
+
+	m.items[key] = []byte(strconv.FormatInt(count, 10))
+	return count, nil
+}
+
+func (m *memoryCache) AddToSet(_ context.Context, key, member string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sets[key]; !ok {
+		m.sets[key] = make(map[string]struct{})
+	}
+
+	m.sets[key][member] = struct{}{}
+	return nil
+}
+
+func (m *memoryCache) SetMembers(_ context.Context, key string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members := make([]string, 0, len(m.sets[key]))
+	for member := range m.sets[key] {
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (m *memoryCache) RemoveFromSet(_ context.Context, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, member := range members {
+		delete(m.sets[key], member)
+	}
+
+	return nil
+}
+
+func TestSession_Refresh(t *testing.T) {
+
+	manager := New(getConfig(), newMemoryCache())
+
+	userID := uuid.New()
+
+	session, err := manager.CreateSession(t.Context(), userID)
+	require.NoError(t, err)
+
+	require.Equal(t, PurposeAccess, session.AccessToken.Purpose)
+	require.Equal(t, PurposeRefresh, session.RefreshToken.Purpose)
+	require.Equal(t, session.AccessToken.SessionID, session.RefreshToken.SessionID)
+
+	require.NoError(t, manager.ValidateSession(t.Context(), session.AccessToken))
+
+	// access tokens cannot be used to refresh
+	_, err = manager.RefreshSession(t.Context(), session.AccessToken)
+	require.ErrorIs(t, err, ErrInvalidTokenPurpose)
+
+	refreshToken, err := manager.ParseJWToken(session.RefreshToken.Token)
+	require.NoError(t, err)
+	require.Equal(t, session.RefreshToken.TokenID, refreshToken.TokenID)
+
+	refreshed, err := manager.RefreshSession(t.Context(), refreshToken)
+	require.NoError(t, err)
+	require.Equal(t, userID, refreshed.AccessToken.UserID)
+	require.Equal(t, session.AccessToken.SessionID, refreshed.AccessToken.SessionID)
+	require.NotEqual(t, session.RefreshToken.TokenID, refreshed.RefreshToken.TokenID)
+
+	// replaying the rotated token revokes the session
+	_, err = manager.RefreshSession(t.Context(), refreshToken)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	require.ErrorIs(t, manager.ValidateSession(t.Context(), refreshed.AccessToken), ErrSessionNotFound)
+
+	_, err = manager.RefreshSession(t.Context(), refreshed.RefreshToken)
+	require.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestSession_ConcurrentRefresh(t *testing.T) {
+
+	manager := New(getConfig(), newMemoryCache())
+
+	session, err := manager.CreateSession(t.Context(), uuid.New())
+	require.NoError(t, err)
+
+	refreshToken, err := manager.ParseJWToken(session.RefreshToken.Token)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = manager.RefreshSession(t.Context(), refreshToken)
+		}(i)
+	}
+
+	wg.Wait()
+
+	// only one of the requests can rotate the refresh token
+	var rotated int
+	for _, err := range errs {
+		if err == nil {
+			rotated++
+		}
+	}
+
+	require.LessOrEqual(t, rotated, 1)
+}
+
+func TestSession_Revoke(t *testing.T) {
+
+	manager := New(getConfig(), newMemoryCache())
+
+	userID := uuid.New()
+
+	first, err := manager.CreateSession(t.Context(), userID)
+	require.NoError(t, err)
+
+	second, err := manager.CreateSession(t.Context(), userID)
+	require.NoError(t, err)
+
+	third, err := manager.CreateSession(t.Context(), userID)
+	require.NoError(t, err)
+
+	other, err := manager.CreateSession(t.Context(), uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, manager.RevokeSession(t.Context(), first.AccessToken))
+
+	require.ErrorIs(t, manager.ValidateSession(t.Context(), first.AccessToken), ErrSessionNotFound)
+	require.NoError(t, manager.ValidateSession(t.Context(), second.AccessToken))
+
+	// refreshing a session keeps it revocable with the rest
+	refreshToken, err := manager.ParseJWToken(third.RefreshToken.Token)
+	require.NoError(t, err)
+
+	third, err = manager.RefreshSession(t.Context(), refreshToken)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.RevokeAllSessions(t.Context(), userID))
+
+	require.ErrorIs(t, manager.ValidateSession(t.Context(), second.AccessToken), ErrSessionNotFound)
+	require.ErrorIs(t, manager.ValidateSession(t.Context(), third.AccessToken), ErrSessionNotFound)
+
+	// other users are not affected
+	require.NoError(t, manager.ValidateSession(t.Context(), other.AccessToken))
+}
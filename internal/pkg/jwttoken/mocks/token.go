@@ -10,9 +10,11 @@
 package mock_jwttoken
 
 import (
+	context "context"
 	reflect "reflect"
 
 	jwttoken "github.com/ayinke-llc/malak/internal/pkg/jwttoken"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,21 @@ func (m *MockJWTokenManager) EXPECT() *MockJWTokenManagerMockRecorder {
 	return m.recorder
 }
 
+// CreateSession mocks base method.
+func (m *MockJWTokenManager) CreateSession(arg0 context.Context, arg1 uuid.UUID) (jwttoken.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(jwttoken.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockJWTokenManagerMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockJWTokenManager)(nil).CreateSession), arg0, arg1)
+}
+
 // GenerateJWToken mocks base method.
 func (m *MockJWTokenManager) GenerateJWToken(arg0 jwttoken.JWTokenData) (jwttoken.JWTokenData, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +86,60 @@ func (mr *MockJWTokenManagerMockRecorder) ParseJWToken(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseJWToken", reflect.TypeOf((*MockJWTokenManager)(nil).ParseJWToken), arg0)
 }
+
+// RefreshSession mocks base method.
+func (m *MockJWTokenManager) RefreshSession(arg0 context.Context, arg1 jwttoken.JWTokenData) (jwttoken.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", arg0, arg1)
+	ret0, _ := ret[0].(jwttoken.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockJWTokenManagerMockRecorder) RefreshSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockJWTokenManager)(nil).RefreshSession), arg0, arg1)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockJWTokenManager) RevokeAllSessions(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockJWTokenManagerMockRecorder) RevokeAllSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockJWTokenManager)(nil).RevokeAllSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockJWTokenManager) RevokeSession(arg0 context.Context, arg1 jwttoken.JWTokenData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockJWTokenManagerMockRecorder) RevokeSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockJWTokenManager)(nil).RevokeSession), arg0, arg1)
+}
+
+// ValidateSession mocks base method.
+func (m *MockJWTokenManager) ValidateSession(arg0 context.Context, arg1 jwttoken.JWTokenData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSession indicates an expected call of ValidateSession.
+func (mr *MockJWTokenManagerMockRecorder) ValidateSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSession", reflect.TypeOf((*MockJWTokenManager)(nil).ValidateSession), arg0, arg1)
+}
@@ -0,0 +1,190 @@
+package jwttoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session does not exist or has been revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+	ErrInvalidTokenPurpose = errors.New("token cannot be used for this operation")
+)
+
+// Session is an access and refresh token pair tied to a
+// server side session
+type Session struct {
+	AccessToken  JWTokenData
+	RefreshToken JWTokenData
+}
+
+// storedSession is what is kept in the cache for every active session
+type storedSession struct {
+	UserID         uuid.UUID `json:"user_id"`
+	RefreshTokenID uuid.UUID `json:"refresh_token_id"`
+}
+
+func sessionKey(id uuid.UUID) string { return "auth-session-" + id.String() }
+
+func userSessionsKey(id uuid.UUID) string { return "auth-user-sessions-" + id.String() }
+
+func (t *jwtokenManager) CreateSession(ctx context.Context, userID uuid.UUID) (Session, error) {
+	sessionID := uuid.New()
+
+	session, b, err := t.issueSession(userID, sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if err := t.cache.Add(ctx, sessionKey(sessionID), b, RefreshTokenTTL); err != nil {
+		return Session{}, fmt.Errorf("CreateSession: could not store session: %w", err)
+	}
+
+	if err := t.trackSession(ctx, userID, sessionID); err != nil {
+		return Session{}, fmt.Errorf("CreateSession: could not store user sessions: %w", err)
+	}
+
+	return session, nil
+}
+
+func (t *jwtokenManager) RefreshSession(ctx context.Context, data JWTokenData) (Session, error) {
+	if data.Purpose != PurposeRefresh {
+		return Session{}, ErrInvalidTokenPurpose
+	}
+
+	current, err := t.cache.Get(ctx, sessionKey(data.SessionID))
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return Session{}, ErrSessionNotFound
+	}
+
+	if err != nil {
+		return Session{}, err
+	}
+
+	var stored storedSession
+	if err := json.Unmarshal(current, &stored); err != nil {
+		return Session{}, err
+	}
+
+	if stored.RefreshTokenID != data.TokenID {
+		return Session{}, t.revokeReusedSession(ctx, data)
+	}
+
+	session, b, err := t.issueSession(stored.UserID, data.SessionID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	// the refresh token is only rotated if nobody else rotated it in the
+	// meantime. Losing the race means the same token was used twice
+	swapped, err := t.cache.CompareAndSwap(ctx, sessionKey(data.SessionID), current, b, RefreshTokenTTL)
+	if err != nil {
+		return Session{}, fmt.Errorf("RefreshSession: could not rotate session: %w", err)
+	}
+
+	if !swapped {
+		return Session{}, t.revokeReusedSession(ctx, data)
+	}
+
+	if err := t.trackSession(ctx, stored.UserID, data.SessionID); err != nil {
+		return Session{}, fmt.Errorf("RefreshSession: could not store user sessions: %w", err)
+	}
+
+	return session, nil
+}
+
+// revokeReusedSession kills the session an old refresh token is being
+// replayed for since we cannot tell which party is legitimate
+func (t *jwtokenManager) revokeReusedSession(ctx context.Context, data JWTokenData) error {
+	if err := t.RevokeSession(ctx, data); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+func (t *jwtokenManager) ValidateSession(ctx context.Context, data JWTokenData) error {
+	ok, err := t.cache.Exists(ctx, sessionKey(data.SessionID))
+	if errors.Is(err, cache.ErrCacheMiss) || (err == nil && !ok) {
+		return ErrSessionNotFound
+	}
+
+	return err
+}
+
+func (t *jwtokenManager) RevokeSession(ctx context.Context, data JWTokenData) error {
+	if err := t.cache.Delete(ctx, sessionKey(data.SessionID)); err != nil {
+		return err
+	}
+
+	return t.cache.RemoveFromSet(ctx, userSessionsKey(data.UserID), data.SessionID.String())
+}
+
+func (t *jwtokenManager) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	sessions, err := t.cache.SetMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range sessions {
+		sessionID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+
+		if err := t.cache.Delete(ctx, sessionKey(sessionID)); err != nil {
+			return fmt.Errorf("RevokeAllSessions: could not revoke session: %w", err)
+		}
+	}
+
+	// only the revoked sessions are removed so one created in the meantime
+	// can still be revoked later on
+	return t.cache.RemoveFromSet(ctx, userSessionsKey(userID), sessions...)
+}
+
+// trackSession adds the session to the user's sessions. The ttl is
+// extended every time so the list outlives the newest session
+func (t *jwtokenManager) trackSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return t.cache.AddToSet(ctx, userSessionsKey(userID), sessionID.String(), RefreshTokenTTL)
+}
+
+// issueSession generates a new token pair for the session and the value
+// to store for it
+func (t *jwtokenManager) issueSession(userID, sessionID uuid.UUID) (Session, []byte, error) {
+
+	access, err := t.GenerateJWToken(JWTokenData{
+		UserID:    userID,
+		SessionID: sessionID,
+		Purpose:   PurposeAccess,
+	})
+	if err != nil {
+		return Session{}, nil, err
+	}
+
+	refresh, err := t.GenerateJWToken(JWTokenData{
+		UserID:    userID,
+		SessionID: sessionID,
+		Purpose:   PurposeRefresh,
+	})
+	if err != nil {
+		return Session{}, nil, err
+	}
+
+	b, err := json.Marshal(storedSession{
+		UserID:         userID,
+		RefreshTokenID: refresh.TokenID,
+	})
+	if err != nil {
+		return Session{}, nil, err
+	}
+
+	return Session{
+		AccessToken:  access,
+		RefreshToken: refresh,
+	}, b, nil
+}
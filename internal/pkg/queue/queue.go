@@ -12,7 +12,9 @@ import (
 )
 
 // ENUM(billing_trial_ending,billing_create_customer,
-// invite_team_member, share_dashboard,subscription_expired, verify_email)
+// invite_team_member, share_dashboard,subscription_expired, verify_email,
+// reset_password, dispatch_webhook, deliver_webhook,
+// update_comment_notification, update_approval_request)
 type QueueTopic string
 
 type Message struct {
@@ -61,3 +63,52 @@ type EmailVerificationOptions struct {
 	UserID uuid.UUID
 	Token  string
 }
+
+type PasswordResetOptions struct {
+	UserID uuid.UUID
+	Token  string
+}
+
+type InviteTeamMemberOptions struct {
+	Workspace   *malak.Workspace
+	Token       string
+	Role        malak.Role
+	Recipient   malak.Email
+	InviterName string
+}
+
+// DispatchWebhookOptions creates a delivery of the event for every webhook
+// in the workspace that is subscribed to it
+type DispatchWebhookOptions struct {
+	WorkspaceID uuid.UUID
+	Event       malak.WebhookEvent
+	Data        any
+}
+
+type DeliverWebhookOptions struct {
+	DeliveryID uuid.UUID
+}
+
+// UpdateCommentNotificationOptions is queued for every new comment on an
+// update. Only one of UserID or Recipient is set. Comments from investors
+// go to the team member that created the update while replies from the
+// team go to the investor
+type UpdateCommentNotificationOptions struct {
+	WorkspaceID uuid.UUID
+	UserID      uuid.UUID
+	Recipient   malak.Email
+	UpdateTitle string
+	AuthorName  string
+	Content     string
+	Link        string
+}
+
+// UpdateApprovalRequestOptions is queued for every approver of the
+// workspace when an update is submitted for review
+type UpdateApprovalRequestOptions struct {
+	WorkspaceID   uuid.UUID
+	UserID        uuid.UUID
+	UpdateTitle   string
+	RequesterName string
+	Link          string
+}
@@ -23,6 +23,16 @@ const (
 	QueueTopicSubscriptionExpired QueueTopic = "subscription_expired"
 	// QueueTopicVerifyEmail is a QueueTopic of type verify_email.
 	QueueTopicVerifyEmail QueueTopic = "verify_email"
+	// QueueTopicResetPassword is a QueueTopic of type reset_password.
+	QueueTopicResetPassword QueueTopic = "reset_password"
+	// QueueTopicDispatchWebhook is a QueueTopic of type dispatch_webhook.
+	QueueTopicDispatchWebhook QueueTopic = "dispatch_webhook"
+	// QueueTopicDeliverWebhook is a QueueTopic of type deliver_webhook.
+	QueueTopicDeliverWebhook QueueTopic = "deliver_webhook"
+	// QueueTopicUpdateCommentNotification is a QueueTopic of type update_comment_notification.
+	QueueTopicUpdateCommentNotification QueueTopic = "update_comment_notification"
+	// QueueTopicUpdateApprovalRequest is a QueueTopic of type update_approval_request.
+	QueueTopicUpdateApprovalRequest QueueTopic = "update_approval_request"
 )
 
 var ErrInvalidQueueTopic = errors.New("not a valid QueueTopic")
@@ -40,12 +50,17 @@ func (x QueueTopic) IsValid() bool {
 }
 
 var _QueueTopicValue = map[string]QueueTopic{
-	"billing_trial_ending":    QueueTopicBillingTrialEnding,
-	"billing_create_customer": QueueTopicBillingCreateCustomer,
-	"invite_team_member":      QueueTopicInviteTeamMember,
-	"share_dashboard":         QueueTopicShareDashboard,
-	"subscription_expired":    QueueTopicSubscriptionExpired,
-	"verify_email":            QueueTopicVerifyEmail,
+	"billing_trial_ending":        QueueTopicBillingTrialEnding,
+	"billing_create_customer":     QueueTopicBillingCreateCustomer,
+	"invite_team_member":          QueueTopicInviteTeamMember,
+	"share_dashboard":             QueueTopicShareDashboard,
+	"subscription_expired":        QueueTopicSubscriptionExpired,
+	"verify_email":                QueueTopicVerifyEmail,
+	"reset_password":              QueueTopicResetPassword,
+	"dispatch_webhook":            QueueTopicDispatchWebhook,
+	"deliver_webhook":             QueueTopicDeliverWebhook,
+	"update_comment_notification": QueueTopicUpdateCommentNotification,
+	"update_approval_request":     QueueTopicUpdateApprovalRequest,
 }
 
 // ParseQueueTopic attempts to convert a string to a QueueTopic.
@@ -25,6 +25,7 @@ import (
 	"github.com/ayinke-llc/malak/internal/pkg/billing"
 	"github.com/ayinke-llc/malak/internal/pkg/email"
 	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
 )
 
 var tracer = otel.Tracer("watermill")
@@ -42,6 +43,8 @@ type WatermillClient struct {
 	cfg           config.Config
 	emailClient   email.Client
 	billingClient billing.Client
+	webhookRepo   malak.WebhookRepository
+	webhookClient webhook.Client
 }
 
 func New(redisClient *redis.Client,
@@ -52,7 +55,9 @@ func New(redisClient *redis.Client,
 	workspaceRepo malak.WorkspaceRepository,
 	updateRepo malak.UpdateRepository,
 	contactRepo malak.ContactRepository,
-	billingClient billing.Client) (queue.QueueHandler, error) {
+	billingClient billing.Client,
+	webhookRepo malak.WebhookRepository,
+	webhookClient webhook.Client) (queue.QueueHandler, error) {
 
 	p, err := redisstream.NewPublisher(
 		redisstream.PublisherConfig{
@@ -119,6 +124,8 @@ func New(redisClient *redis.Client,
 		contactRepo:   contactRepo,
 		emailClient:   emailClient,
 		billingClient: billingClient,
+		webhookRepo:   webhookRepo,
+		webhookClient: webhookClient,
 	}
 
 	t.setUpRoutes(router, subscriber)
@@ -160,6 +167,48 @@ func (t *WatermillClient) setUpRoutes(router *message.Router,
 		subscriber,
 		t.sendEmailVerification,
 	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicInviteTeamMember.String(),
+		queue.QueueTopicInviteTeamMember.String(),
+		subscriber,
+		t.sendTeamInviteEmail,
+	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicResetPassword.String(),
+		queue.QueueTopicResetPassword.String(),
+		subscriber,
+		t.sendPasswordResetEmail,
+	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicDispatchWebhook.String(),
+		queue.QueueTopicDispatchWebhook.String(),
+		subscriber,
+		t.dispatchWebhook,
+	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicDeliverWebhook.String(),
+		queue.QueueTopicDeliverWebhook.String(),
+		subscriber,
+		t.deliverWebhook,
+	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicUpdateCommentNotification.String(),
+		queue.QueueTopicUpdateCommentNotification.String(),
+		subscriber,
+		t.sendUpdateCommentEmail,
+	)
+
+	router.AddNoPublisherHandler(
+		queue.QueueTopicUpdateApprovalRequest.String(),
+		queue.QueueTopicUpdateApprovalRequest.String(),
+		subscriber,
+		t.sendUpdateApprovalRequestEmail,
+	)
 }
 
 func (t *WatermillClient) Add(ctx context.Context,
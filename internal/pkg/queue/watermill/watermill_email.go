@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"html"
 	"text/template"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/ayinke-llc/malak"
@@ -257,3 +259,290 @@ func (t *WatermillClient) sendEmailVerification(msg *message.Message) error {
 
 	return nil
 }
+
+func (t *WatermillClient) sendTeamInviteEmail(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"sendTeamInviteEmail")
+
+	defer span.End()
+
+	var opts queue.InviteTeamMemberOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "sendTeamInviteEmail"),
+		zap.String("workspace_id", opts.Workspace.ID.String()))
+
+	logger.Debug("sending team invite email")
+
+	tmpl, err := template.New("template").Parse(email.TeamInviteTemplate)
+	if err != nil {
+		logger.Error("could not parse email template", zap.Error(err))
+		return err
+	}
+
+	var link = t.cfg.Frontend.AppURL + "/invites?token=" + opts.Token
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"WorkspaceName": opts.Workspace.WorkspaceName,
+		"InviterName":   opts.InviterName,
+		"Role":          opts.Role.String(),
+		"Link":          link,
+	}); err != nil {
+		logger.Error("could not embed content in template", zap.Error(err))
+		return err
+	}
+
+	emailOpts := email.SendOptions{
+		HTML:      buf.String(),
+		Sender:    t.cfg.Email.Sender,
+		Recipient: opts.Recipient,
+		Subject:   "You have been invited to join " + opts.Workspace.WorkspaceName + " on Malak",
+		DKIM: struct {
+			Sign       bool
+			PrivateKey []byte
+		}{
+			Sign:       false,
+			PrivateKey: []byte(""),
+		},
+	}
+
+	_, err = t.emailClient.Send(ctx, emailOpts)
+	if err != nil {
+		logger.Error("could not send email", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (t *WatermillClient) sendPasswordResetEmail(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"sendPasswordResetEmail")
+
+	defer span.End()
+
+	var opts queue.PasswordResetOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "sendPasswordResetEmail"),
+		zap.String("user_id", opts.UserID.String()))
+
+	logger.Debug("sending password reset email")
+
+	tmpl, err := template.New("template").Parse(email.PasswordResetTemplate)
+	if err != nil {
+		logger.Error("could not parse email template", zap.Error(err))
+		return err
+	}
+
+	user, err := t.userRepo.Get(ctx, &malak.FindUserOptions{
+		ID: opts.UserID,
+	})
+	if err != nil {
+		logger.Error("could not fetch user from database", zap.Error(err))
+		return err
+	}
+
+	var link = t.cfg.Frontend.AppURL + "/reset-password?token=" + opts.Token
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"FullName": user.FullName,
+		"Link":     link,
+	}); err != nil {
+		logger.Error("could not embed content in template", zap.Error(err))
+		return err
+	}
+
+	emailOpts := email.SendOptions{
+		HTML:      buf.String(),
+		Sender:    t.cfg.Email.Sender,
+		Recipient: user.Email,
+		Subject:   "Reset your Malak password",
+		DKIM: struct {
+			Sign       bool
+			PrivateKey []byte
+		}{
+			Sign:       false,
+			PrivateKey: []byte(""),
+		},
+	}
+
+	_, err = t.emailClient.Send(ctx, emailOpts)
+	if err != nil {
+		logger.Error("could not send email", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (t *WatermillClient) sendUpdateCommentEmail(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"sendUpdateCommentEmail")
+
+	defer span.End()
+
+	var opts queue.UpdateCommentNotificationOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "sendUpdateCommentEmail"),
+		zap.String("workspace_id", opts.WorkspaceID.String()))
+
+	logger.Debug("sending update comment email")
+
+	workspace, err := t.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: opts.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace", zap.Error(err))
+		return err
+	}
+
+	recipient := opts.Recipient
+
+	if opts.UserID != uuid.Nil {
+		user, err := t.userRepo.Get(ctx, &malak.FindUserOptions{
+			ID: opts.UserID,
+		})
+		if err != nil {
+			logger.Error("could not fetch user from database", zap.Error(err))
+			return err
+		}
+
+		recipient = user.Email
+	}
+
+	tmpl, err := template.New("template").Parse(email.UpdateCommentTemplate)
+	if err != nil {
+		logger.Error("could not parse email template", zap.Error(err))
+		return err
+	}
+
+	// comments come from investors and the update title is free text.
+	// text/template does not escape anything
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"WorkspaceName": html.EscapeString(workspace.WorkspaceName),
+		"AuthorName":    html.EscapeString(opts.AuthorName),
+		"UpdateTitle":   html.EscapeString(opts.UpdateTitle),
+		"Content":       html.EscapeString(opts.Content),
+		"Link":          opts.Link,
+	}); err != nil {
+		logger.Error("could not embed content in template", zap.Error(err))
+		return err
+	}
+
+	emailOpts := email.SendOptions{
+		HTML:      buf.String(),
+		Sender:    t.cfg.Email.Sender,
+		Recipient: recipient,
+		Subject:   opts.AuthorName + " commented on " + opts.UpdateTitle,
+		DKIM: struct {
+			Sign       bool
+			PrivateKey []byte
+		}{
+			Sign:       false,
+			PrivateKey: []byte(""),
+		},
+	}
+
+	_, err = t.emailClient.Send(ctx, emailOpts)
+	if err != nil {
+		logger.Error("could not send email", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (t *WatermillClient) sendUpdateApprovalRequestEmail(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"sendUpdateApprovalRequestEmail")
+
+	defer span.End()
+
+	var opts queue.UpdateApprovalRequestOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "sendUpdateApprovalRequestEmail"),
+		zap.String("workspace_id", opts.WorkspaceID.String()))
+
+	logger.Debug("sending update approval request email")
+
+	workspace, err := t.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: opts.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace", zap.Error(err))
+		return err
+	}
+
+	user, err := t.userRepo.Get(ctx, &malak.FindUserOptions{
+		ID: opts.UserID,
+	})
+	if err != nil {
+		logger.Error("could not fetch user from database", zap.Error(err))
+		return err
+	}
+
+	tmpl, err := template.New("template").Parse(email.UpdateApprovalRequestTemplate)
+	if err != nil {
+		logger.Error("could not parse email template", zap.Error(err))
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"WorkspaceName": html.EscapeString(workspace.WorkspaceName),
+		"RequesterName": html.EscapeString(opts.RequesterName),
+		"UpdateTitle":   html.EscapeString(opts.UpdateTitle),
+		"Link":          opts.Link,
+	}); err != nil {
+		logger.Error("could not embed content in template", zap.Error(err))
+		return err
+	}
+
+	emailOpts := email.SendOptions{
+		HTML:      buf.String(),
+		Sender:    t.cfg.Email.Sender,
+		Recipient: user.Email,
+		Subject:   opts.RequesterName + " requested your approval on " + opts.UpdateTitle,
+		DKIM: struct {
+			Sign       bool
+			PrivateKey []byte
+		}{
+			Sign:       false,
+			PrivateKey: []byte(""),
+		},
+	}
+
+	_, err = t.emailClient.Send(ctx, emailOpts)
+	if err != nil {
+		logger.Error("could not send email", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package watermillqueue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
+)
+
+func (t *WatermillClient) dispatchWebhook(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"dispatchWebhook")
+
+	defer span.End()
+
+	var opts queue.DispatchWebhookOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "dispatchWebhook"),
+		zap.String("workspace_id", opts.WorkspaceID.String()),
+		zap.String("event", opts.Event.String()))
+
+	logger.Debug("dispatching webhook event")
+
+	deliveries, err := webhook.Dispatch(ctx, t.webhookRepo,
+		malak.NewReferenceGenerator(), opts.WorkspaceID, opts.Event, opts.Data)
+	if err != nil {
+		logger.Error("could not create webhook deliveries", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "could not create webhook deliveries")
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		// the webhooks cron job sends deliveries that could not be queued
+		if err := t.Add(ctx, queue.QueueTopicDeliverWebhook, queue.DeliverWebhookOptions{
+			DeliveryID: delivery.ID,
+		}); err != nil {
+			logger.Error("could not queue webhook delivery",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// deliverWebhook makes a single delivery attempt. Failed attempts are not
+// retried here. Redis streams cannot hold a message back for the minutes
+// to hours of the backoff so the delivery is rescheduled in the database
+// and the webhooks cron job sends it once it is due
+func (t *WatermillClient) deliverWebhook(msg *message.Message) error {
+
+	ctx, span := tracer.Start(context.Background(),
+		"deliverWebhook")
+
+	defer span.End()
+
+	var opts queue.DeliverWebhookOptions
+
+	if err := json.NewDecoder(bytes.NewBuffer(msg.Payload)).
+		Decode(&opts); err != nil {
+		return err
+	}
+
+	logger := t.logger.With(zap.String("method", "deliverWebhook"),
+		zap.String("delivery_id", opts.DeliveryID.String()))
+
+	logger.Debug("delivering webhook")
+
+	webhook, delivery, err := t.webhookRepo.GetDeliveryByID(ctx, opts.DeliveryID)
+	if err != nil {
+		if errors.Is(err, malak.ErrWebhookDeliveryNotFound) ||
+			errors.Is(err, malak.ErrWebhookNotFound) {
+			logger.Error("webhook delivery no longer exists", zap.Error(err))
+			return nil
+		}
+
+		logger.Error("could not fetch webhook delivery", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "could not fetch webhook delivery")
+		return err
+	}
+
+	// the cron job could have picked up the delivery before this message
+	// was consumed
+	if delivery.Status != malak.WebhookDeliveryStatusPending {
+		logger.Debug("skipping delivery that is no longer pending",
+			zap.String("status", delivery.Status.String()))
+		return nil
+	}
+
+	// claimed before sending so the cron job does not send it at the
+	// same time
+	if err := t.webhookRepo.ClaimDelivery(ctx, delivery.ID, time.Now()); err != nil {
+		if errors.Is(err, malak.ErrWebhookDeliveryNotDue) {
+			logger.Debug("skipping delivery that is not due or claimed already")
+			return nil
+		}
+
+		logger.Error("could not claim webhook delivery", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "could not claim webhook delivery")
+		return err
+	}
+
+	if !webhook.IsActive {
+		delivery.Cancel("webhook is disabled")
+	} else if err := t.webhookClient.Deliver(ctx, webhook, delivery); err != nil {
+		logger.Error("could not deliver webhook",
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(err))
+	}
+
+	if err := t.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		logger.Error("could not update webhook delivery", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "could not update webhook delivery")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package socialauth
+
+import (
+	"errors"
+	"sort"
+)
+
+type Manager struct {
+	byName map[string]SocialAuthProvider
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		byName: make(map[string]SocialAuthProvider),
+	}
+}
+
+func (m *Manager) Add(name string, provider SocialAuthProvider) {
+	_, ok := m.byName[name]
+	if ok {
+		return
+	}
+
+	m.byName[name] = provider
+}
+
+func (m *Manager) Get(name string) (SocialAuthProvider, error) {
+	provider, ok := m.byName[name]
+	if ok {
+		return provider, nil
+	}
+
+	return nil, errors.New("provider does not exists")
+}
+
+// Providers returns the names of all registered providers
+func (m *Manager) Providers() []string {
+	names := make([]string, 0, len(m.byName))
+
+	for name := range m.byName {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
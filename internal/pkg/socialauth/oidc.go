@@ -0,0 +1,277 @@
+package socialauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/oauth2"
+)
+
+type oidcOptions struct {
+	name         string
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	redirectURI  string
+	scopes       []string
+
+	// endpoint and userInfoURL are used as is when provided. Else they
+	// are discovered from the issuer
+	endpoint    oauth2.Endpoint
+	userInfoURL string
+
+	// emailsURL is called when the user info endpoint does not return
+	// an email address. GitHub only returns public emails for example
+	emailsURL string
+
+	// verifiedEmail decides which email address can be trusted since
+	// providers report verification differently. It defaults to the
+	// standard email_verified claim
+	verifiedEmail emailVerifier
+}
+
+// emailVerifier returns the email address of the user only if the identity
+// provider vouches for it. We link accounts by email so an unverified
+// address could be used to take over an existing account
+type emailVerifier func(ctx context.Context, o *oidcAuthenticator,
+	token *oauth2.Token, claims userInfoClaims) (string, error)
+
+type userInfoClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     *bool  `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Login             string `json:"login"`
+}
+
+// emailVerifiedClaim is the rule from the OpenID Connect spec
+func emailVerifiedClaim(_ context.Context, _ *oidcAuthenticator,
+	_ *oauth2.Token, claims userInfoClaims) (string, error) {
+
+	if claims.EmailVerified == nil || !*claims.EmailVerified {
+		return "", errors.New("email address has not been verified with the identity provider")
+	}
+
+	return claims.Email, nil
+}
+
+// primaryEmail is used by providers that do not send the email_verified
+// claim but list the addresses of the user with their verification status
+func primaryEmail(ctx context.Context, o *oidcAuthenticator,
+	token *oauth2.Token, _ userInfoClaims) (string, error) {
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+
+	if err := o.get(ctx, o.opts.emailsURL, token, &emails); err != nil {
+		return "", err
+	}
+
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			return e.Email, nil
+		}
+	}
+
+	return "", errors.New("could not find a verified primary email address")
+}
+
+type oidcAuthenticator struct {
+	opts   oidcOptions
+	config config.Config
+	client *http.Client
+
+	mu          sync.Mutex
+	cfg         *oauth2.Config
+	userInfoURL string
+}
+
+// NewOIDC creates a provider for any OpenID Connect compliant identity
+// provider using the settings in cfg.Auth.OIDC
+func NewOIDC(cfg config.Config) SocialAuthProvider {
+	return newOIDCProvider(cfg, oidcOptions{
+		name:         cfg.Auth.OIDC.Name,
+		issuerURL:    cfg.Auth.OIDC.IssuerURL,
+		clientID:     cfg.Auth.OIDC.ClientID,
+		clientSecret: cfg.Auth.OIDC.ClientSecret,
+		redirectURI:  cfg.Auth.OIDC.RedirectURI,
+		scopes:       cfg.Auth.OIDC.Scopes,
+	}, &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	})
+}
+
+func newOIDCProvider(cfg config.Config, opts oidcOptions, client *http.Client) *oidcAuthenticator {
+	return &oidcAuthenticator{
+		opts:   opts,
+		config: cfg,
+		client: client,
+	}
+}
+
+type oidcDiscoveryDocument struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// oauthConfig lazily builds the oauth2 config so an unavailable identity
+// provider does not stop the server from starting up
+func (o *oidcAuthenticator) oauthConfig(ctx context.Context) (*oauth2.Config, string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cfg != nil {
+		return o.cfg, o.userInfoURL, nil
+	}
+
+	endpoint := o.opts.endpoint
+	userInfoURL := o.opts.userInfoURL
+
+	if hermes.IsStringEmpty(endpoint.TokenURL) || hermes.IsStringEmpty(userInfoURL) {
+		doc, err := o.discover(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		endpoint = oauth2.Endpoint{
+			AuthURL:  doc.AuthorizationEndpoint,
+			TokenURL: doc.TokenEndpoint,
+		}
+		userInfoURL = doc.UserInfoEndpoint
+	}
+
+	if hermes.IsStringEmpty(endpoint.TokenURL) || hermes.IsStringEmpty(userInfoURL) {
+		return nil, "", errors.New("identity provider does not support the token or userinfo endpoints")
+	}
+
+	o.cfg = &oauth2.Config{
+		ClientID:     o.opts.clientID,
+		ClientSecret: o.opts.clientSecret,
+		Endpoint:     endpoint,
+		RedirectURL:  o.opts.redirectURI,
+		Scopes:       o.opts.scopes,
+	}
+	o.userInfoURL = userInfoURL
+
+	return o.cfg, o.userInfoURL, nil
+}
+
+func (o *oidcAuthenticator) discover(ctx context.Context) (oidcDiscoveryDocument, error) {
+	var doc oidcDiscoveryDocument
+
+	discoveryURL := strings.TrimSuffix(o.opts.issuerURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return doc, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return doc, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return doc, fmt.Errorf("could not fetch openid configuration. status code %d", resp.StatusCode)
+	}
+
+	return doc, json.NewDecoder(resp.Body).Decode(&doc)
+}
+
+func (o *oidcAuthenticator) Validate(
+	ctx context.Context, opts ValidateOptions) (*oauth2.Token, error) {
+
+	ctx, span := getTracer(ctx, o.opts.name+".Validate", o.config.Otel.IsEnabled)
+	defer span.End()
+
+	cfg, _, err := o.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.Exchange(context.WithValue(ctx, oauth2.HTTPClient, o.client), opts.Code)
+}
+
+func (o *oidcAuthenticator) User(ctx context.Context, token *oauth2.Token) (User, error) {
+
+	ctx, span := getTracer(ctx, o.opts.name+".User", o.config.Otel.IsEnabled)
+	defer span.End()
+
+	_, userInfoURL, err := o.oauthConfig(ctx)
+	if err != nil {
+		return User{}, err
+	}
+
+	var claims userInfoClaims
+
+	if err := o.get(ctx, userInfoURL, token, &claims); err != nil {
+		return User{}, err
+	}
+
+	user := User{
+		Name: claims.Name,
+	}
+
+	if hermes.IsStringEmpty(user.Name) {
+		user.Name = claims.PreferredUsername
+	}
+
+	if hermes.IsStringEmpty(user.Name) {
+		user.Name = claims.Login
+	}
+
+	verifiedEmail := o.opts.verifiedEmail
+	if verifiedEmail == nil {
+		verifiedEmail = emailVerifiedClaim
+	}
+
+	user.Email, err = verifiedEmail(ctx, o, token, claims)
+	if err != nil {
+		return User{}, err
+	}
+
+	if hermes.IsStringEmpty(user.Email) {
+		return User{}, errors.New("identity provider did not return an email address")
+	}
+
+	return user, nil
+}
+
+func (o *oidcAuthenticator) get(ctx context.Context,
+	url string, token *oauth2.Token, dst interface{}) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	token.SetAuthHeader(req)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code from identity provider %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
@@ -0,0 +1,267 @@
+package socialauth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newIdentityProvider(t *testing.T, userInfo map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	var srv *httptest.Server
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"userinfo_endpoint":      srv.URL + "/userinfo",
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.Form.Get("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer"}`))
+	})
+
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(userInfo)
+	})
+
+	mux.HandleFunc("/emails", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"email": "secondary@example.com", "primary": false, "verified": true},
+			{"email": "primary@example.com", "primary": true, "verified": true},
+		})
+	})
+
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestOIDC_Discovery(t *testing.T) {
+	srv := newIdentityProvider(t, map[string]interface{}{
+		"email":          "lanre@example.com",
+		"email_verified": true,
+		"name":           "Lanre Adelowo",
+	})
+
+	provider := newOIDCProvider(config.Config{}, oidcOptions{
+		name:      "corporate",
+		issuerURL: srv.URL + "/",
+		clientID:  "client-id",
+	}, srv.Client())
+
+	_, err := provider.Validate(t.Context(), ValidateOptions{
+		Code: "invalid-code",
+	})
+	require.Error(t, err)
+
+	token, err := provider.Validate(t.Context(), ValidateOptions{
+		Code: "valid-code",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "access-token", token.AccessToken)
+
+	user, err := provider.User(t.Context(), token)
+	require.NoError(t, err)
+	require.Equal(t, User{
+		Email: "lanre@example.com",
+		Name:  "Lanre Adelowo",
+	}, user)
+}
+
+func TestOIDC_UnverifiedEmail(t *testing.T) {
+	srv := newIdentityProvider(t, map[string]interface{}{
+		"email":          "lanre@example.com",
+		"email_verified": false,
+		"name":           "Lanre Adelowo",
+	})
+
+	provider := newOIDCProvider(config.Config{}, oidcOptions{
+		name:      "corporate",
+		issuerURL: srv.URL,
+	}, srv.Client())
+
+	_, err := provider.User(t.Context(), &oauth2.Token{
+		AccessToken: "access-token",
+	})
+	require.Error(t, err)
+}
+
+func TestOIDC_MissingEmailVerifiedClaim(t *testing.T) {
+	srv := newIdentityProvider(t, map[string]interface{}{
+		"email": "lanre@example.com",
+		"name":  "Lanre Adelowo",
+	})
+
+	provider := newOIDCProvider(config.Config{}, oidcOptions{
+		name:      "corporate",
+		issuerURL: srv.URL,
+	}, srv.Client())
+
+	_, err := provider.User(t.Context(), &oauth2.Token{
+		AccessToken: "access-token",
+	})
+	require.Error(t, err)
+}
+
+func testIDToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	b, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(b) + ".signature"
+}
+
+func TestOIDC_Microsoft(t *testing.T) {
+	// Microsoft's userinfo endpoint never sends email_verified
+	srv := newIdentityProvider(t, map[string]interface{}{
+		"email": "lanre@example.com",
+		"name":  "Lanre Adelowo",
+	})
+
+	tt := []struct {
+		name     string
+		tenant   string
+		idToken  map[string]interface{}
+		email    string
+		hasError bool
+	}{
+		{
+			name:   "single tenant",
+			tenant: "9188040d-6c67-4c5b-b112-36a304b66dad",
+			email:  "lanre@example.com",
+		},
+		{
+			name:   "domain owner verified",
+			tenant: "common",
+			idToken: map[string]interface{}{
+				"email":    "lanre@example.com",
+				"xms_edov": true,
+			},
+			email: "lanre@example.com",
+		},
+		{
+			name:   "verified primary email",
+			tenant: "organizations",
+			idToken: map[string]interface{}{
+				"verified_primary_email": []string{"lanre@example.com"},
+			},
+			email: "lanre@example.com",
+		},
+		{
+			name:   "multi tenant without verification claims",
+			tenant: "common",
+			idToken: map[string]interface{}{
+				"email": "lanre@example.com",
+			},
+			hasError: true,
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			provider := newOIDCProvider(config.Config{}, oidcOptions{
+				name:          ProviderMicrosoft,
+				issuerURL:     srv.URL,
+				verifiedEmail: microsoftVerifiedEmail(v.tenant),
+			}, srv.Client())
+
+			token := &oauth2.Token{
+				AccessToken: "access-token",
+			}
+
+			if v.idToken != nil {
+				token = token.WithExtra(map[string]interface{}{
+					"id_token": testIDToken(t, v.idToken),
+				})
+			}
+
+			user, err := provider.User(t.Context(), token)
+			if v.hasError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, User{
+				Email: v.email,
+				Name:  "Lanre Adelowo",
+			}, user)
+		})
+	}
+}
+
+func TestOIDC_StaticEndpointsWithEmailFallback(t *testing.T) {
+	srv := newIdentityProvider(t, map[string]interface{}{
+		// public emails are not verified and must not be used
+		"email": "public@example.com",
+		"login": "adelowo",
+	})
+
+	provider := newOIDCProvider(config.Config{}, oidcOptions{
+		name: ProviderGitHub,
+		endpoint: oauth2.Endpoint{
+			AuthURL:  srv.URL + "/authorize",
+			TokenURL: srv.URL + "/token",
+		},
+		userInfoURL:   srv.URL + "/userinfo",
+		emailsURL:     srv.URL + "/emails",
+		verifiedEmail: primaryEmail,
+	}, srv.Client())
+
+	user, err := provider.User(t.Context(), &oauth2.Token{
+		AccessToken: "access-token",
+	})
+	require.NoError(t, err)
+	require.Equal(t, User{
+		Email: "primary@example.com",
+		Name:  "adelowo",
+	}, user)
+}
+
+func TestManager(t *testing.T) {
+	manager := NewManager()
+
+	_, err := manager.Get(ProviderGoogle)
+	require.Error(t, err)
+
+	google := NewGoogle(config.Config{})
+
+	manager.Add(ProviderGoogle, google)
+	manager.Add(ProviderGitHub, NewGitHub(config.Config{}))
+	// duplicates are ignored
+	manager.Add(ProviderGoogle, NewGitHub(config.Config{}))
+
+	provider, err := manager.Get(ProviderGoogle)
+	require.NoError(t, err)
+	require.Equal(t, google, provider)
+
+	require.Equal(t, []string{ProviderGitHub, ProviderGoogle}, manager.Providers())
+}
@@ -0,0 +1,130 @@
+package socialauth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ayinke-llc/malak/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// NewGitHub signs users in with GitHub. GitHub is plain oauth2 and not
+// OpenID Connect so the endpoints are provided upfront
+func NewGitHub(cfg config.Config) SocialAuthProvider {
+	return newOIDCProvider(cfg, oidcOptions{
+		name:         ProviderGitHub,
+		clientID:     cfg.Auth.GitHub.ClientID,
+		clientSecret: cfg.Auth.GitHub.ClientSecret,
+		redirectURI:  cfg.Auth.GitHub.RedirectURI,
+		scopes:       cfg.Auth.GitHub.Scopes,
+		endpoint:     github.Endpoint,
+		userInfoURL:  "https://api.github.com/user",
+		emailsURL:    "https://api.github.com/user/emails",
+		// GitHub never sends email_verified
+		verifiedEmail: primaryEmail,
+	}, &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	})
+}
+
+// NewMicrosoft signs users in with Microsoft Entra ID ( Azure AD )
+// and personal Microsoft accounts depending on the configured tenant
+func NewMicrosoft(cfg config.Config) SocialAuthProvider {
+	return newOIDCProvider(cfg, oidcOptions{
+		name:          ProviderMicrosoft,
+		issuerURL:     fmt.Sprintf("https://login.microsoftonline.com/%s/v2.0", cfg.Auth.Microsoft.Tenant),
+		clientID:      cfg.Auth.Microsoft.ClientID,
+		clientSecret:  cfg.Auth.Microsoft.ClientSecret,
+		redirectURI:   cfg.Auth.Microsoft.RedirectURI,
+		scopes:        cfg.Auth.Microsoft.Scopes,
+		verifiedEmail: microsoftVerifiedEmail(cfg.Auth.Microsoft.Tenant),
+	}, &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	})
+}
+
+// multiTenantMicrosoftTenants let anyone with a Microsoft account sign in
+// so the organisation behind the account is not known upfront
+var multiTenantMicrosoftTenants = []string{"common", "organizations", "consumers"}
+
+// microsoftBool is needed as optional claims can come as a boolean or as
+// a string depending on how they were configured on the app
+type microsoftBool bool
+
+func (m *microsoftBool) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	*m = microsoftBool(s == "true" || s == "1")
+	return nil
+}
+
+type microsoftIDTokenClaims struct {
+	Email string `json:"email"`
+	// xms_edov is set when the domain of the email is owned by the tenant
+	EmailDomainOwnerVerified microsoftBool `json:"xms_edov"`
+	VerifiedPrimaryEmail     []string      `json:"verified_primary_email"`
+}
+
+// microsoftVerifiedEmail is needed since Microsoft never sends the
+// email_verified claim. The verification claims are read from the id token
+// instead. Accounts of a single tenant are trusted as the organisation
+// that owns the tenant issues their addresses
+func microsoftVerifiedEmail(tenant string) emailVerifier {
+	return func(_ context.Context, _ *oidcAuthenticator,
+		token *oauth2.Token, claims userInfoClaims) (string, error) {
+
+		idClaims, err := microsoftIDToken(token)
+		if err != nil {
+			return "", err
+		}
+
+		if len(idClaims.VerifiedPrimaryEmail) > 0 {
+			return idClaims.VerifiedPrimaryEmail[0], nil
+		}
+
+		if idClaims.EmailDomainOwnerVerified {
+			if idClaims.Email != "" {
+				return idClaims.Email, nil
+			}
+
+			return claims.Email, nil
+		}
+
+		if !slices.Contains(multiTenantMicrosoftTenants, strings.ToLower(tenant)) {
+			return claims.Email, nil
+		}
+
+		return "", errors.New("email address has not been verified with the identity provider")
+	}
+}
+
+// microsoftIDToken reads the claims of the id token without checking the
+// signature. It is fine as the token was received straight from the token
+// endpoint over TLS. See section 3.1.3.7 of OpenID Connect core
+func microsoftIDToken(token *oauth2.Token) (microsoftIDTokenClaims, error) {
+	var claims microsoftIDTokenClaims
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return claims, nil
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return claims, errors.New("malformed id token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, err
+	}
+
+	return claims, json.Unmarshal(payload, &claims)
+}
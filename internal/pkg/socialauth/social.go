@@ -6,6 +6,12 @@ import (
 	"golang.org/x/oauth2"
 )
 
+const (
+	ProviderGoogle    = "google"
+	ProviderGitHub    = "github"
+	ProviderMicrosoft = "microsoft"
+)
+
 type User struct {
 	Email string `json:"email,omitempty"`
 	Name  string `json:"name,omitempty"`
@@ -0,0 +1,71 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the shared secret used by the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).
+	EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+
+	// RFC 6238 Appendix B lists 8 digit codes. We only use the
+	// last 6 digits
+	tt := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, v := range tt {
+		code, err := GenerateCode(rfcSecret, time.Unix(v.unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, v.expected, code)
+	}
+
+	_, err := GenerateCode("not-base32!", time.Now())
+	require.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	require.True(t, Validate(secret, code, now))
+	require.True(t, Validate(secret, " "+code+" ", now))
+
+	// clock drift of one period either way is allowed
+	require.True(t, Validate(secret, code, now.Add(Period)))
+	require.True(t, Validate(secret, code, now.Add(-Period)))
+
+	require.False(t, Validate(secret, code, now.Add(Period*3)))
+	require.False(t, Validate(secret, "12345", now))
+	require.False(t, Validate("not-base32!", code, now))
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Malak", "lanre@example.com", "JBSWY3DPEHPK3PXP")
+
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Malak:lanre@example.com?"))
+	require.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	require.Contains(t, uri, "issuer=Malak")
+	require.Contains(t, uri, "digits=6")
+	require.Contains(t, uri, "period=30")
+}
@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/secret"
+)
+
+const (
+	defaultTimeout = time.Second * 10
+
+	// only the start of the response is kept in the delivery log
+	maxResponseBodySize = 1024
+)
+
+// Client sends signed payloads to webhook endpoints
+type Client interface {
+	// Deliver makes a single attempt and records the outcome on the
+	// delivery. An error is returned if the endpoint did not accept it
+	Deliver(context.Context, *malak.Webhook, *malak.WebhookDelivery) error
+}
+
+type httpClient struct {
+	client  *http.Client
+	secrets secret.SecretClient
+}
+
+var (
+	ErrBlockedAddress  = errors.New("webhook url resolves to an address that is not allowed")
+	ErrRedirectRefused = errors.New("webhook endpoints cannot redirect")
+	ErrSecretNotFound  = errors.New("could not fetch the signing secret of the webhook")
+)
+
+// reservedPrefixes are not covered by the netip helpers but should never be
+// reachable from webhooks
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// IsPublicAddress reports if webhooks can be delivered to the address.
+// Loopback, private, link local (which includes cloud metadata services)
+// and other reserved addresses are rejected
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dialControl runs after the host has been resolved so it also catches
+// public hostnames that point to internal addresses
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !IsPublicAddress(addr) {
+		return ErrBlockedAddress
+	}
+
+	return nil
+}
+
+func refuseRedirect(_ *http.Request, _ []*http.Request) error {
+	return ErrRedirectRefused
+}
+
+func New(secrets secret.SecretClient) Client {
+	dialer := &net.Dialer{
+		Timeout:   defaultTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+
+	transport := &http.Transport{
+		// proxies from the environment would bypass the address checks
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	return NewWithHTTPClient(&http.Client{
+		Timeout:       defaultTimeout,
+		Transport:     otelhttp.NewTransport(transport),
+		CheckRedirect: refuseRedirect,
+	}, secrets)
+}
+
+func NewWithHTTPClient(client *http.Client, secrets secret.SecretClient) Client {
+	return &httpClient{
+		client:  client,
+		secrets: secrets,
+	}
+}
+
+func (h *httpClient) Deliver(ctx context.Context,
+	webhook *malak.Webhook, delivery *malak.WebhookDelivery) error {
+
+	statusCode, body, err := h.send(ctx, webhook, delivery)
+
+	delivery.RecordAttempt(statusCode, body, err, time.Now())
+
+	if delivery.Status == malak.WebhookDeliveryStatusSucceeded {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return &StatusError{StatusCode: statusCode}
+}
+
+func (h *httpClient) send(ctx context.Context,
+	webhook *malak.Webhook, delivery *malak.WebhookDelivery) (int, string, error) {
+
+	// the error is recorded on the delivery so the one from the secrets
+	// provider is not exposed to the workspace
+	signingSecret, err := h.secrets.Get(ctx, webhook.Secret)
+	if err != nil {
+		return 0, "", ErrSecretNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Malak-Webhooks/1.0")
+	req.Header.Set(malak.WebhookEventHeader, delivery.Event.String())
+	req.Header.Set(malak.WebhookDeliveryHeader, delivery.Reference.String())
+	req.Header.Set(malak.WebhookSignatureHeader,
+		malak.SignWebhookPayload(signingSecret, time.Now(), delivery.Payload))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+
+	return resp.StatusCode, string(body), nil
+}
+
+// Dispatch stores a pending delivery of the event for every webhook in the
+// workspace that is subscribed to it
+func Dispatch(ctx context.Context,
+	repo malak.WebhookRepository,
+	generator malak.ReferenceGeneratorOperation,
+	workspaceID uuid.UUID,
+	event malak.WebhookEvent,
+	data any) ([]*malak.WebhookDelivery, error) {
+
+	webhooks, err := repo.ListSubscribed(ctx, workspaceID, event)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := malak.NewWebhookDeliveries(generator, webhooks, event, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, repo.CreateDeliveries(ctx, deliveries)
+}
+
+// StatusError is returned when the endpoint responds with a non 2xx status
+type StatusError struct {
+	StatusCode int
+}
+
+func (s *StatusError) Error() string {
+	return "webhook endpoint responded with status code " + strconv.Itoa(s.StatusCode)
+}
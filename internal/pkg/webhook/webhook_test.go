@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+)
+
+// newSecretClient returns the signing secret of every webhook in the tests
+func newSecretClient(t *testing.T) *malak_mocks.MockSecretClient {
+	controller := gomock.NewController(t)
+
+	secrets := malak_mocks.NewMockSecretClient(controller)
+
+	secrets.EXPECT().Get(gomock.Any(), "webhooks/webhook_123").
+		AnyTimes().
+		Return("whsec_secret", nil)
+
+	return secrets
+}
+
+func TestClient_Deliver(t *testing.T) {
+	var statusCode = http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, malak.WebhookEventUpdateOpened.String(), r.Header.Get(malak.WebhookEventHeader))
+		require.Equal(t, "webhook_delivery_123", r.Header.Get(malak.WebhookDeliveryHeader))
+
+		require.NoError(t, malak.VerifyWebhookSignature("whsec_secret",
+			r.Header.Get(malak.WebhookSignatureHeader), body, time.Minute, time.Now()))
+
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte("received"))
+	}))
+	defer srv.Close()
+
+	client := NewWithHTTPClient(srv.Client(), newSecretClient(t))
+
+	webhook := &malak.Webhook{
+		URL:    srv.URL,
+		Secret: "webhooks/webhook_123",
+	}
+
+	newDelivery := func() *malak.WebhookDelivery {
+		return &malak.WebhookDelivery{
+			Reference: "webhook_delivery_123",
+			Event:     malak.WebhookEventUpdateOpened,
+			Payload:   []byte(`{"event":"update_opened"}`),
+			Status:    malak.WebhookDeliveryStatusPending,
+		}
+	}
+
+	t.Run("endpoint accepts the payload", func(t *testing.T) {
+		delivery := newDelivery()
+
+		require.NoError(t, client.Deliver(t.Context(), webhook, delivery))
+		require.Equal(t, malak.WebhookDeliveryStatusSucceeded, delivery.Status)
+		require.Equal(t, 1, delivery.Attempts)
+		require.Equal(t, http.StatusOK, delivery.ResponseStatusCode)
+		require.Equal(t, "received", delivery.ResponseBody)
+		require.NotNil(t, delivery.DeliveredAt)
+		require.Nil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("endpoint rejects the payload", func(t *testing.T) {
+		statusCode = http.StatusInternalServerError
+
+		delivery := newDelivery()
+
+		err := client.Deliver(t.Context(), webhook, delivery)
+
+		var statusErr *StatusError
+		require.True(t, errors.As(err, &statusErr))
+		require.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+
+		require.Equal(t, malak.WebhookDeliveryStatusPending, delivery.Status)
+		require.Equal(t, 1, delivery.Attempts)
+		require.NotNil(t, delivery.NextAttemptAt)
+		require.NotEmpty(t, delivery.LastError)
+	})
+
+	t.Run("signing secret cannot be fetched", func(t *testing.T) {
+		controller := gomock.NewController(t)
+
+		secrets := malak_mocks.NewMockSecretClient(controller)
+
+		secrets.EXPECT().Get(gomock.Any(), "webhooks/webhook_123").
+			Times(1).
+			Return("", errors.New("vault is sealed"))
+
+		delivery := newDelivery()
+
+		err := NewWithHTTPClient(srv.Client(), secrets).Deliver(t.Context(), webhook, delivery)
+		require.ErrorIs(t, err, ErrSecretNotFound)
+
+		require.Equal(t, malak.WebhookDeliveryStatusPending, delivery.Status)
+		require.Equal(t, ErrSecretNotFound.Error(), delivery.LastError)
+	})
+
+	t.Run("endpoint cannot be reached", func(t *testing.T) {
+		delivery := newDelivery()
+
+		err := client.Deliver(t.Context(), &malak.Webhook{
+			URL:    "http://127.0.0.1:1",
+			Secret: "webhooks/webhook_123",
+		}, delivery)
+		require.Error(t, err)
+
+		require.Equal(t, malak.WebhookDeliveryStatusPending, delivery.Status)
+		require.Zero(t, delivery.ResponseStatusCode)
+		require.NotEmpty(t, delivery.LastError)
+	})
+}
+
+func TestClient_DeliverBlocksInternalAddresses(t *testing.T) {
+	var hits int
+
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer internal.Close()
+
+	newDelivery := func() *malak.WebhookDelivery {
+		return &malak.WebhookDelivery{
+			Reference: "webhook_delivery_123",
+			Event:     malak.WebhookEventUpdateOpened,
+			Payload:   []byte(`{"event":"update_opened"}`),
+			Status:    malak.WebhookDeliveryStatusPending,
+		}
+	}
+
+	t.Run("loopback address is refused", func(t *testing.T) {
+		delivery := newDelivery()
+
+		err := New(newSecretClient(t)).Deliver(t.Context(), &malak.Webhook{
+			URL:    internal.URL,
+			Secret: "webhooks/webhook_123",
+		}, delivery)
+		require.ErrorIs(t, err, ErrBlockedAddress)
+
+		require.Zero(t, hits)
+		require.Empty(t, delivery.ResponseBody)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, internal.URL, http.StatusFound)
+		}))
+		defer redirect.Close()
+
+		httpClient := redirect.Client()
+		httpClient.CheckRedirect = refuseRedirect
+
+		delivery := newDelivery()
+
+		err := NewWithHTTPClient(httpClient, newSecretClient(t)).Deliver(t.Context(), &malak.Webhook{
+			URL:    redirect.URL,
+			Secret: "webhooks/webhook_123",
+		}, delivery)
+		require.ErrorIs(t, err, ErrRedirectRefused)
+
+		require.Zero(t, hits)
+		require.Empty(t, delivery.ResponseBody)
+		require.Equal(t, malak.WebhookDeliveryStatusPending, delivery.Status)
+	})
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	for _, v := range []struct {
+		addr     string
+		expected bool
+	}{
+		{"127.0.0.1", false},
+		{"10.0.0.8", false},
+		{"172.16.4.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+	} {
+		t.Run(v.addr, func(t *testing.T) {
+			require.Equal(t, v.expected, IsPublicAddress(netip.MustParseAddr(v.addr)))
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	repo := malak_mocks.NewMockWebhookRepository(controller)
+
+	workspaceID := uuid.New()
+
+	repo.EXPECT().
+		ListSubscribed(gomock.Any(), workspaceID, malak.WebhookEventIntegrationSyncFailed).
+		Times(1).
+		Return([]malak.Webhook{
+			{
+				ID:          uuid.New(),
+				WorkspaceID: workspaceID,
+				IsActive:    true,
+				Events:      []malak.WebhookEvent{malak.WebhookEventIntegrationSyncFailed},
+			},
+		}, nil)
+
+	repo.EXPECT().
+		CreateDeliveries(gomock.Any(), gomock.Len(1)).
+		Times(1).
+		Return(nil)
+
+	deliveries, err := Dispatch(t.Context(), repo, malak.NewReferenceGenerator(),
+		workspaceID, malak.WebhookEventIntegrationSyncFailed, map[string]string{
+			"integration": "mercury",
+		})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, workspaceID, deliveries[0].WorkspaceID)
+	require.Equal(t, malak.WebhookDeliveryStatusPending, deliveries[0].Status)
+}
@@ -16,11 +16,25 @@ type CreateSecretOptions struct {
 	Value           string    `json:"value,omitempty"`
 	WorkspaceID     uuid.UUID `json:"workspace_id,omitempty"`
 	IntegrationName malak.IntegrationProvider
+
+	// Name is used in the key instead of the integration for secrets
+	// that do not belong to one. e.g dkim keys
+	Name string
 }
 
 func (s *CreateSecretOptions) Key() string {
-	return fmt.Sprintf("%s/%s",
-		s.WorkspaceID.String(), s.IntegrationName.String())
+	name := s.IntegrationName.String()
+	if s.Name != "" {
+		name = s.Name
+	}
+
+	// secrets that do not belong to a workspace like a user's totp
+	// secret are keyed by their name alone
+	if s.WorkspaceID == uuid.Nil {
+		return name
+	}
+
+	return fmt.Sprintf("%s/%s", s.WorkspaceID.String(), name)
 }
 
 type SecretClient interface {
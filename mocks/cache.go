@@ -55,6 +55,64 @@ func (mr *MockCacheMockRecorder) Add(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCache)(nil).Add), arg0, arg1, arg2, arg3)
 }
 
+// AddIfNotExists mocks base method.
+func (m *MockCache) AddIfNotExists(ctx context.Context, key string, payload []byte, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIfNotExists", ctx, key, payload, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIfNotExists indicates an expected call of AddIfNotExists.
+func (mr *MockCacheMockRecorder) AddIfNotExists(ctx, key, payload, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIfNotExists", reflect.TypeOf((*MockCache)(nil).AddIfNotExists), ctx, key, payload, ttl)
+}
+
+// AddToSet mocks base method.
+func (m *MockCache) AddToSet(ctx context.Context, key, member string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToSet", ctx, key, member, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToSet indicates an expected call of AddToSet.
+func (mr *MockCacheMockRecorder) AddToSet(ctx, key, member, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToSet", reflect.TypeOf((*MockCache)(nil).AddToSet), ctx, key, member, ttl)
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockCache) CompareAndSwap(ctx context.Context, key string, old, new []byte, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSwap", ctx, key, old, new, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockCacheMockRecorder) CompareAndSwap(ctx, key, old, new, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockCache)(nil).CompareAndSwap), ctx, key, old, new, ttl)
+}
+
+// Delete mocks base method.
+func (m *MockCache) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), arg0, arg1)
+}
+
 // Exists mocks base method.
 func (m *MockCache) Exists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +142,52 @@ func (mr *MockCacheMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), arg0, arg1)
 }
+
+// Increment mocks base method.
+func (m *MockCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCacheMockRecorder) Increment(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCache)(nil).Increment), ctx, key, ttl)
+}
+
+// RemoveFromSet mocks base method.
+func (m *MockCache) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range members {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveFromSet", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromSet indicates an expected call of RemoveFromSet.
+func (mr *MockCacheMockRecorder) RemoveFromSet(ctx, key any, members ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, members...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromSet", reflect.TypeOf((*MockCache)(nil).RemoveFromSet), varargs...)
+}
+
+// SetMembers mocks base method.
+func (m *MockCache) SetMembers(ctx context.Context, key string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMembers", ctx, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMembers indicates an expected call of SetMembers.
+func (mr *MockCacheMockRecorder) SetMembers(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMembers", reflect.TypeOf((*MockCache)(nil).SetMembers), ctx, key)
+}
@@ -41,6 +41,64 @@ func (m *MockTemplateRepository) EXPECT() *MockTemplateRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateWorkspaceTemplate mocks base method.
+func (m *MockTemplateRepository) CreateWorkspaceTemplate(arg0 context.Context, arg1 *malak.WorkspaceTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWorkspaceTemplate indicates an expected call of CreateWorkspaceTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) CreateWorkspaceTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).CreateWorkspaceTemplate), arg0, arg1)
+}
+
+// DeleteWorkspaceTemplate mocks base method.
+func (m *MockTemplateRepository) DeleteWorkspaceTemplate(arg0 context.Context, arg1 *malak.WorkspaceTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceTemplate indicates an expected call of DeleteWorkspaceTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) DeleteWorkspaceTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).DeleteWorkspaceTemplate), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockTemplateRepository) Get(arg0 context.Context, arg1 malak.Reference) (*malak.SystemTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*malak.SystemTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTemplateRepositoryMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTemplateRepository)(nil).Get), arg0, arg1)
+}
+
+// GetWorkspaceTemplate mocks base method.
+func (m *MockTemplateRepository) GetWorkspaceTemplate(arg0 context.Context, arg1 malak.FetchWorkspaceTemplateOptions) (*malak.WorkspaceTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*malak.WorkspaceTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceTemplate indicates an expected call of GetWorkspaceTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) GetWorkspaceTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).GetWorkspaceTemplate), arg0, arg1)
+}
+
 // System mocks base method.
 func (m *MockTemplateRepository) System(arg0 context.Context, arg1 malak.SystemTemplateFilter) ([]malak.SystemTemplate, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +113,32 @@ func (mr *MockTemplateRepositoryMockRecorder) System(arg0, arg1 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "System", reflect.TypeOf((*MockTemplateRepository)(nil).System), arg0, arg1)
 }
+
+// UpdateWorkspaceTemplate mocks base method.
+func (m *MockTemplateRepository) UpdateWorkspaceTemplate(arg0 context.Context, arg1 *malak.WorkspaceTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceTemplate indicates an expected call of UpdateWorkspaceTemplate.
+func (mr *MockTemplateRepositoryMockRecorder) UpdateWorkspaceTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceTemplate", reflect.TypeOf((*MockTemplateRepository)(nil).UpdateWorkspaceTemplate), arg0, arg1)
+}
+
+// Workspace mocks base method.
+func (m *MockTemplateRepository) Workspace(arg0 context.Context, arg1 malak.ListWorkspaceTemplateOptions) ([]malak.WorkspaceTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Workspace", arg0, arg1)
+	ret0, _ := ret[0].([]malak.WorkspaceTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Workspace indicates an expected call of Workspace.
+func (mr *MockTemplateRepositoryMockRecorder) Workspace(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Workspace", reflect.TypeOf((*MockTemplateRepository)(nil).Workspace), arg0, arg1)
+}
@@ -42,6 +42,34 @@ func (m *MockUpdateRepository) EXPECT() *MockUpdateRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddApproval mocks base method.
+func (m *MockUpdateRepository) AddApproval(arg0 context.Context, arg1 *malak.Update, arg2 *malak.UpdateApproval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApproval", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddApproval indicates an expected call of AddApproval.
+func (mr *MockUpdateRepositoryMockRecorder) AddApproval(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApproval", reflect.TypeOf((*MockUpdateRepository)(nil).AddApproval), arg0, arg1, arg2)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockUpdateRepository) CancelSchedule(arg0 context.Context, arg1 *malak.UpdateSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockUpdateRepositoryMockRecorder) CancelSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockUpdateRepository)(nil).CancelSchedule), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockUpdateRepository) Create(arg0 context.Context, arg1 *malak.Update, arg2 *malak.TemplateCreateUpdateOptions) error {
 	m.ctrl.T.Helper()
@@ -70,6 +98,21 @@ func (mr *MockUpdateRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUpdateRepository)(nil).Delete), arg0, arg1)
 }
 
+// FindSchedule mocks base method.
+func (m *MockUpdateRepository) FindSchedule(arg0 context.Context, arg1 malak.FetchUpdateScheduleOptions) (*malak.UpdateSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*malak.UpdateSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSchedule indicates an expected call of FindSchedule.
+func (mr *MockUpdateRepositoryMockRecorder) FindSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSchedule", reflect.TypeOf((*MockUpdateRepository)(nil).FindSchedule), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockUpdateRepository) Get(arg0 context.Context, arg1 malak.FetchUpdateOptions) (*malak.Update, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +143,36 @@ func (mr *MockUpdateRepositoryMockRecorder) GetByID(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUpdateRepository)(nil).GetByID), arg0, arg1)
 }
 
+// GetRecipient mocks base method.
+func (m *MockUpdateRepository) GetRecipient(arg0 context.Context, arg1 malak.Reference) (*malak.UpdateRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipient", arg0, arg1)
+	ret0, _ := ret[0].(*malak.UpdateRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipient indicates an expected call of GetRecipient.
+func (mr *MockUpdateRepositoryMockRecorder) GetRecipient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipient", reflect.TypeOf((*MockUpdateRepository)(nil).GetRecipient), arg0, arg1)
+}
+
+// GetRevision mocks base method.
+func (m *MockUpdateRepository) GetRevision(arg0 context.Context, arg1 malak.FetchUpdateRevisionOptions) (*malak.UpdateRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1)
+	ret0, _ := ret[0].(*malak.UpdateRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockUpdateRepositoryMockRecorder) GetRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockUpdateRepository)(nil).GetRevision), arg0, arg1)
+}
+
 // GetSchedule mocks base method.
 func (m *MockUpdateRepository) GetSchedule(arg0 context.Context, arg1 uuid.UUID) (*malak.UpdateSchedule, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +188,21 @@ func (mr *MockUpdateRepositoryMockRecorder) GetSchedule(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockUpdateRepository)(nil).GetSchedule), arg0, arg1)
 }
 
+// GetSentRevision mocks base method.
+func (m *MockUpdateRepository) GetSentRevision(arg0 context.Context, arg1 uuid.UUID) (*malak.UpdateRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentRevision", arg0, arg1)
+	ret0, _ := ret[0].(*malak.UpdateRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentRevision indicates an expected call of GetSentRevision.
+func (mr *MockUpdateRepositoryMockRecorder) GetSentRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentRevision", reflect.TypeOf((*MockUpdateRepository)(nil).GetSentRevision), arg0, arg1)
+}
+
 // GetStatByEmailID mocks base method.
 func (m *MockUpdateRepository) GetStatByEmailID(arg0 context.Context, arg1 string, arg2 malak.UpdateRecipientLogProvider) (*malak.UpdateRecipientLog, *malak.UpdateRecipientStat, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +219,21 @@ func (mr *MockUpdateRepositoryMockRecorder) GetStatByEmailID(arg0, arg1, arg2 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatByEmailID", reflect.TypeOf((*MockUpdateRepository)(nil).GetStatByEmailID), arg0, arg1, arg2)
 }
 
+// LinkStats mocks base method.
+func (m *MockUpdateRepository) LinkStats(arg0 context.Context, arg1 *malak.Update) ([]malak.UpdateLinkStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkStats", arg0, arg1)
+	ret0, _ := ret[0].([]malak.UpdateLinkStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkStats indicates an expected call of LinkStats.
+func (mr *MockUpdateRepositoryMockRecorder) LinkStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkStats", reflect.TypeOf((*MockUpdateRepository)(nil).LinkStats), arg0, arg1)
+}
+
 // List mocks base method.
 func (m *MockUpdateRepository) List(arg0 context.Context, arg1 malak.ListUpdateOptions) ([]malak.Update, int64, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +250,21 @@ func (mr *MockUpdateRepositoryMockRecorder) List(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUpdateRepository)(nil).List), arg0, arg1)
 }
 
+// ListApprovals mocks base method.
+func (m *MockUpdateRepository) ListApprovals(arg0 context.Context, arg1 uuid.UUID) ([]malak.UpdateApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovals", arg0, arg1)
+	ret0, _ := ret[0].([]malak.UpdateApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovals indicates an expected call of ListApprovals.
+func (mr *MockUpdateRepositoryMockRecorder) ListApprovals(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovals", reflect.TypeOf((*MockUpdateRepository)(nil).ListApprovals), arg0, arg1)
+}
+
 // ListPinned mocks base method.
 func (m *MockUpdateRepository) ListPinned(arg0 context.Context, arg1 uuid.UUID) ([]malak.Update, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +280,36 @@ func (mr *MockUpdateRepositoryMockRecorder) ListPinned(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPinned", reflect.TypeOf((*MockUpdateRepository)(nil).ListPinned), arg0, arg1)
 }
 
+// ListRevisions mocks base method.
+func (m *MockUpdateRepository) ListRevisions(arg0 context.Context, arg1 uuid.UUID) ([]malak.UpdateRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]malak.UpdateRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockUpdateRepositoryMockRecorder) ListRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockUpdateRepository)(nil).ListRevisions), arg0, arg1)
+}
+
+// ListSchedules mocks base method.
+func (m *MockUpdateRepository) ListSchedules(arg0 context.Context, arg1 uuid.UUID) ([]malak.UpdateSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", arg0, arg1)
+	ret0, _ := ret[0].([]malak.UpdateSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockUpdateRepositoryMockRecorder) ListSchedules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockUpdateRepository)(nil).ListSchedules), arg0, arg1)
+}
+
 // Overview mocks base method.
 func (m *MockUpdateRepository) Overview(arg0 context.Context, arg1 uuid.UUID) (*malak.UpdateOverview, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +340,34 @@ func (mr *MockUpdateRepositoryMockRecorder) RecipientStat(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecipientStat", reflect.TypeOf((*MockUpdateRepository)(nil).RecipientStat), arg0, arg1)
 }
 
+// RecordClick mocks base method.
+func (m *MockUpdateRepository) RecordClick(arg0 context.Context, arg1 *malak.UpdateLinkClick) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockUpdateRepositoryMockRecorder) RecordClick(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockUpdateRepository)(nil).RecordClick), arg0, arg1)
+}
+
+// RescheduleSend mocks base method.
+func (m *MockUpdateRepository) RescheduleSend(arg0 context.Context, arg1 *malak.UpdateSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleSend", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleSend indicates an expected call of RescheduleSend.
+func (mr *MockUpdateRepositoryMockRecorder) RescheduleSend(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleSend", reflect.TypeOf((*MockUpdateRepository)(nil).RescheduleSend), arg0, arg1)
+}
+
 // SendUpdate mocks base method.
 func (m *MockUpdateRepository) SendUpdate(arg0 context.Context, arg1 *malak.CreateUpdateOptions) error {
 	m.ctrl.T.Helper()
@@ -249,6 +425,20 @@ func (mr *MockUpdateRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUpdateRepository)(nil).Update), arg0, arg1)
 }
 
+// UpdateContent mocks base method.
+func (m *MockUpdateRepository) UpdateContent(arg0 context.Context, arg1 *malak.Update, arg2 *malak.UpdateRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContent indicates an expected call of UpdateContent.
+func (mr *MockUpdateRepositoryMockRecorder) UpdateContent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContent", reflect.TypeOf((*MockUpdateRepository)(nil).UpdateContent), arg0, arg1, arg2)
+}
+
 // UpdateStat mocks base method.
 func (m *MockUpdateRepository) UpdateStat(arg0 context.Context, arg1 *malak.UpdateStat, arg2 *malak.UpdateRecipientStat) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,133 @@
+package malak
+
+import "github.com/google/uuid"
+
+// Permission is a single action a workspace member can carry out.
+// Routes declare the permissions they need and a member's role decides
+// if they can go ahead
+type Permission string
+
+const (
+	PermissionWorkspaceRead   Permission = "workspace:read"
+	PermissionWorkspaceUpdate Permission = "workspace:update"
+
+	PermissionBillingManage Permission = "billing:manage"
+
+	PermissionPreferencesRead   Permission = "preferences:read"
+	PermissionPreferencesUpdate Permission = "preferences:update"
+
+	PermissionIntegrationsRead   Permission = "integrations:read"
+	PermissionIntegrationsManage Permission = "integrations:manage"
+
+	PermissionUpdatesRead  Permission = "updates:read"
+	PermissionUpdatesWrite Permission = "updates:write"
+	PermissionUpdatesSend  Permission = "updates:send"
+
+	PermissionContactsRead  Permission = "contacts:read"
+	PermissionContactsWrite Permission = "contacts:write"
+
+	PermissionDecksRead  Permission = "decks:read"
+	PermissionDecksWrite Permission = "decks:write"
+
+	PermissionDashboardsRead  Permission = "dashboards:read"
+	PermissionDashboardsWrite Permission = "dashboards:write"
+
+	PermissionPipelinesRead  Permission = "pipelines:read"
+	PermissionPipelinesWrite Permission = "pipelines:write"
+
+	PermissionAPIKeysManage  Permission = "api_keys:manage"
+	PermissionWebhooksManage Permission = "webhooks:manage"
+
+	PermissionTeamRead   Permission = "team:read"
+	PermissionTeamManage Permission = "team:manage"
+
+	PermissionAuditLogsRead Permission = "audit_logs:read"
+
+	// PermissionIngestWrite is only used by api keys pushing data points.
+	// The user that created the key must still be allowed to push them
+	PermissionIngestWrite Permission = "ingest:write"
+)
+
+func (p Permission) String() string { return string(p) }
+
+// rolePermissions is the permission matrix. Admins can do everything so
+// they are not listed here
+var rolePermissions = map[Role][]Permission{
+	RoleMember: {
+		PermissionWorkspaceRead,
+		PermissionPreferencesRead,
+		PermissionIntegrationsRead,
+		PermissionUpdatesRead,
+		PermissionUpdatesWrite,
+		PermissionUpdatesSend,
+		PermissionContactsRead,
+		PermissionContactsWrite,
+		PermissionDecksRead,
+		PermissionDecksWrite,
+		PermissionDashboardsRead,
+		PermissionDashboardsWrite,
+		PermissionIngestWrite,
+		PermissionPipelinesRead,
+		PermissionPipelinesWrite,
+		PermissionTeamRead,
+	},
+	RoleBilling: {
+		PermissionWorkspaceRead,
+		PermissionBillingManage,
+		PermissionPreferencesRead,
+	},
+	RoleInvestor: {
+		PermissionWorkspaceRead,
+		PermissionUpdatesRead,
+		PermissionDecksRead,
+		PermissionDashboardsRead,
+	},
+	RoleGuest: {
+		PermissionWorkspaceRead,
+		PermissionDecksRead,
+		PermissionDashboardsRead,
+	},
+}
+
+// Can checks the permission matrix for the role
+func (r Role) Can(permission Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RoleIn returns the role the user holds in the given workspace
+func (m UserRoles) RoleIn(workspaceID uuid.UUID) (Role, bool) {
+	for _, role := range m {
+		if role.WorkspaceID == workspaceID {
+			return role.Role, true
+		}
+	}
+
+	return "", false
+}
+
+// Can reports if the user's role in the workspace allows all of the
+// provided permissions
+func (u *User) Can(workspaceID uuid.UUID, permissions ...Permission) bool {
+	role, ok := u.Roles.RoleIn(workspaceID)
+	if !ok {
+		return false
+	}
+
+	for _, permission := range permissions {
+		if !role.Can(permission) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Can(t *testing.T) {
+
+	tt := []struct {
+		role       Role
+		permission Permission
+		allowed    bool
+	}{
+		{RoleAdmin, PermissionAPIKeysManage, true},
+		{RoleAdmin, PermissionBillingManage, true},
+		{RoleMember, PermissionUpdatesSend, true},
+		{RoleMember, PermissionBillingManage, false},
+		{RoleMember, PermissionAPIKeysManage, false},
+		{RoleMember, PermissionAuditLogsRead, false},
+		{RoleAdmin, PermissionAuditLogsRead, true},
+		{RoleAdmin, PermissionWebhooksManage, true},
+		{RoleMember, PermissionWebhooksManage, false},
+		{RoleBilling, PermissionBillingManage, true},
+		{RoleBilling, PermissionContactsRead, false},
+		{RoleInvestor, PermissionUpdatesRead, true},
+		{RoleInvestor, PermissionUpdatesWrite, false},
+		{RoleGuest, PermissionDecksRead, true},
+		{RoleGuest, PermissionUpdatesRead, false},
+	}
+
+	for _, v := range tt {
+		t.Run(v.role.String()+"_"+v.permission.String(), func(t *testing.T) {
+			require.Equal(t, v.allowed, v.role.Can(v.permission))
+		})
+	}
+}
+
+func TestUser_Can(t *testing.T) {
+
+	workspaceID := uuid.New()
+
+	user := &User{
+		Roles: UserRoles{
+			{
+				WorkspaceID: workspaceID,
+				Role:        RoleMember,
+			},
+			{
+				WorkspaceID: uuid.New(),
+				Role:        RoleAdmin,
+			},
+		},
+	}
+
+	require.True(t, user.Can(workspaceID, PermissionContactsRead, PermissionContactsWrite))
+	require.False(t, user.Can(workspaceID, PermissionContactsRead, PermissionAPIKeysManage))
+	require.False(t, user.Can(uuid.New(), PermissionContactsRead))
+}
@@ -22,6 +22,7 @@ type Preference struct {
 	WorkspaceID   uuid.UUID                `json:"workspace_id,omitempty"`
 	Communication CommunicationPreferences `json:"communication,omitempty"`
 	Billing       BillingPreferences       `json:"billing,omitempty"`
+	Approval      ApprovalPreferences      `json:"approval,omitempty"`
 
 	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty" bson:"created_at"`
 	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty" bson:"updated_at"`
@@ -37,7 +38,8 @@ func NewPreference(workspace *Workspace) *Preference {
 			EnableMarketing:      true,
 			EnableProductUpdates: true,
 		},
-		Billing: BillingPreferences{},
+		Billing:  BillingPreferences{},
+		Approval: ApprovalPreferences{},
 	}
 }
 
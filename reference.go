@@ -28,7 +28,16 @@ func GenerateReference(e EntityType) string {
 // deck_geographic_stat, session,dashboard_link,dashboard_link_access_log,api_key,
 // fundraising_pipeline,fundraising_pipeline_column,fundraising_pipeline_column_contact_document,
 // fundraising_pipeline_column_contact,fundraising_pipeline_column_contact_activity,
-// fundraising_pipeline_column_contact_deal, fundraising_pipeline_column_contact_position)
+// fundraising_pipeline_column_contact_deal, fundraising_pipeline_column_contact_position,
+// webhook,webhook_delivery,
+// suppression,
+// update_comment,
+// update_viewer_session,
+// recurring_update_schedule,
+// workspace_template,
+// update_revision,
+// update_approval,
+// sending_domain)
 type EntityType string
 
 type Reference string
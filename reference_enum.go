@@ -98,6 +98,26 @@ const (
 	EntityTypeFundraisingPipelineColumnContactDeal EntityType = "fundraising_pipeline_column_contact_deal"
 	// EntityTypeFundraisingPipelineColumnContactPosition is a EntityType of type fundraising_pipeline_column_contact_position.
 	EntityTypeFundraisingPipelineColumnContactPosition EntityType = "fundraising_pipeline_column_contact_position"
+	// EntityTypeWebhook is a EntityType of type webhook.
+	EntityTypeWebhook EntityType = "webhook"
+	// EntityTypeWebhookDelivery is a EntityType of type webhook_delivery.
+	EntityTypeWebhookDelivery EntityType = "webhook_delivery"
+	// EntityTypeSuppression is a EntityType of type suppression.
+	EntityTypeSuppression EntityType = "suppression"
+	// EntityTypeUpdateComment is a EntityType of type update_comment.
+	EntityTypeUpdateComment EntityType = "update_comment"
+	// EntityTypeUpdateViewerSession is a EntityType of type update_viewer_session.
+	EntityTypeUpdateViewerSession EntityType = "update_viewer_session"
+	// EntityTypeRecurringUpdateSchedule is a EntityType of type recurring_update_schedule.
+	EntityTypeRecurringUpdateSchedule EntityType = "recurring_update_schedule"
+	// EntityTypeWorkspaceTemplate is a EntityType of type workspace_template.
+	EntityTypeWorkspaceTemplate EntityType = "workspace_template"
+	// EntityTypeUpdateRevision is a EntityType of type update_revision.
+	EntityTypeUpdateRevision EntityType = "update_revision"
+	// EntityTypeUpdateApproval is a EntityType of type update_approval.
+	EntityTypeUpdateApproval EntityType = "update_approval"
+	// EntityTypeSendingDomain is a EntityType of type sending_domain.
+	EntityTypeSendingDomain EntityType = "sending_domain"
 )
 
 var ErrInvalidEntityType = errors.New("not a valid EntityType")
@@ -158,6 +178,16 @@ var _EntityTypeValue = map[string]EntityType{
 	"fundraising_pipeline_column_contact_activity": EntityTypeFundraisingPipelineColumnContactActivity,
 	"fundraising_pipeline_column_contact_deal":     EntityTypeFundraisingPipelineColumnContactDeal,
 	"fundraising_pipeline_column_contact_position": EntityTypeFundraisingPipelineColumnContactPosition,
+	"webhook":                   EntityTypeWebhook,
+	"webhook_delivery":          EntityTypeWebhookDelivery,
+	"suppression":               EntityTypeSuppression,
+	"update_comment":            EntityTypeUpdateComment,
+	"update_viewer_session":     EntityTypeUpdateViewerSession,
+	"recurring_update_schedule": EntityTypeRecurringUpdateSchedule,
+	"workspace_template":        EntityTypeWorkspaceTemplate,
+	"update_revision":           EntityTypeUpdateRevision,
+	"update_approval":           EntityTypeUpdateApproval,
+	"sending_domain":            EntityTypeSendingDomain,
 }
 
 // ParseEntityType attempts to convert a string to a EntityType.
@@ -0,0 +1,140 @@
+package malak
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrSendingDomainNotFound      = MalakError("sending domain not found")
+	ErrSendingDomainExists        = MalakError("workspace already has a sending domain")
+	ErrSendingDomainInvalid       = MalakError("please provide a valid domain")
+	ErrSendingDomainRecordMissing = MalakError("dkim record could not be found on the domain")
+	ErrSendingDomainRecordInvalid = MalakError("dkim record on the domain does not match the public key")
+)
+
+// ENUM(pending,verified,failed)
+type SendingDomainStatus string
+
+var domainRegexp = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// SendingDomain is the domain a workspace sends its updates from. Emails
+// are signed with the DKIM key once the public key has been published on
+// the domain
+type SendingDomain struct {
+	ID          uuid.UUID           `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference           `json:"reference,omitempty"`
+	WorkspaceID uuid.UUID           `json:"workspace_id,omitempty"`
+	Domain      string              `json:"domain,omitempty"`
+	Sender      Email               `json:"sender,omitempty"`
+	Selector    string              `json:"selector,omitempty"`
+	PublicKey   string              `json:"public_key,omitempty"`
+	Status      SendingDomainStatus `json:"status,omitempty"`
+
+	// PrivateKey is the key returned by the secret provider. The DKIM key
+	// itself is never stored in the database
+	PrivateKey string `json:"-"`
+
+	VerifiedAt    *time.Time `bun:",nullzero" json:"verified_at,omitempty"`
+	LastCheckedAt *time.Time `bun:",nullzero" json:"last_checked_at,omitempty"`
+
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+	DeletedAt *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
+
+	bun.BaseModel `bun:"table:sending_domains,alias:sending_domain" json:"-"`
+}
+
+// DNSRecord is a record the workspace has to add to its domain
+type DNSRecord struct {
+	Type  string `json:"type,omitempty"`
+	Host  string `json:"host,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// DomainFromEmail returns the domain of the address. It is lowercased and
+// validated
+func DomainFromEmail(email Email) (string, error) {
+	// lowercase here instead of relying on Email.String so callers that
+	// pass a raw Email never validate a mixed case domain
+	_, domain, ok := strings.Cut(string(email), "@")
+	if !ok {
+		return "", ErrSendingDomainInvalid
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+
+	if !domainRegexp.MatchString(domain) {
+		return "", ErrSendingDomainInvalid
+	}
+
+	return domain, nil
+}
+
+func (s *SendingDomain) IsVerified() bool {
+	return s.Status == SendingDomainStatusVerified
+}
+
+func (s *SendingDomain) DKIMHost() string {
+	return fmt.Sprintf("%s._domainkey.%s", s.Selector, s.Domain)
+}
+
+func (s *SendingDomain) DKIMValue() string {
+	return "v=DKIM1; k=rsa; p=" + s.PublicKey
+}
+
+// DNSRecords are the records to add to the domain before it can be
+// verified
+func (s *SendingDomain) DNSRecords() []DNSRecord {
+	return []DNSRecord{
+		{
+			Type:  "TXT",
+			Host:  s.DKIMHost(),
+			Value: s.DKIMValue(),
+		},
+	}
+}
+
+// TXTResolver looks up the TXT records of a host. *net.Resolver satisfies
+// it
+type TXTResolver interface {
+	LookupTXT(context.Context, string) ([]string, error)
+}
+
+// Verify checks the DKIM record has been published with the public key of
+// the domain. Providers sometimes add spaces when splitting long records so
+// they are ignored
+func (s *SendingDomain) Verify(ctx context.Context, resolver TXTResolver) error {
+	records, err := resolver.LookupTXT(ctx, s.DKIMHost())
+	if err != nil || len(records) == 0 {
+		return ErrSendingDomainRecordMissing
+	}
+
+	for _, record := range records {
+		for _, tag := range strings.Split(stripWhitespace(record), ";") {
+			if value, ok := strings.CutPrefix(tag, "p="); ok && value == s.PublicKey {
+				return nil
+			}
+		}
+	}
+
+	return ErrSendingDomainRecordInvalid
+}
+
+func stripWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+type SendingDomainRepository interface {
+	Create(context.Context, *SendingDomain) error
+	Update(context.Context, *SendingDomain) error
+	Delete(context.Context, *SendingDomain) error
+	// Get returns the sending domain of the workspace
+	Get(context.Context, uuid.UUID) (*SendingDomain, error)
+}
@@ -0,0 +1,83 @@
+package malak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubTXTResolver map[string][]string
+
+func (s stubTXTResolver) LookupTXT(_ context.Context, host string) ([]string, error) {
+	records, ok := s[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	return records, nil
+}
+
+func TestDomainFromEmail(t *testing.T) {
+	tt := []struct {
+		email    Email
+		domain   string
+		hasError bool
+	}{
+		{email: "updates@ayinke.ventures", domain: "ayinke.ventures"},
+		{email: "Updates@Mail.Ayinke.Ventures", domain: "mail.ayinke.ventures"},
+		{email: Email("updates@AYINKE.Ventures."), domain: "ayinke.ventures"},
+		{email: "updates@localhost", hasError: true},
+		{email: "updates", hasError: true},
+		{email: "updates@-ayinke.ventures", hasError: true},
+	}
+
+	for _, v := range tt {
+		t.Run(v.email.String(), func(t *testing.T) {
+			domain, err := DomainFromEmail(v.email)
+			if v.hasError {
+				require.ErrorIs(t, err, ErrSendingDomainInvalid)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, v.domain, domain)
+		})
+	}
+}
+
+func TestSendingDomain_Verify(t *testing.T) {
+	domain := &SendingDomain{
+		Domain:    "ayinke.ventures",
+		Selector:  "malak",
+		PublicKey: "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA",
+	}
+
+	records := domain.DNSRecords()
+	require.Len(t, records, 1)
+	require.Equal(t, "malak._domainkey.ayinke.ventures", records[0].Host)
+
+	t.Run("record not published", func(t *testing.T) {
+		require.ErrorIs(t, domain.Verify(t.Context(), stubTXTResolver{}),
+			ErrSendingDomainRecordMissing)
+	})
+
+	t.Run("record with another key", func(t *testing.T) {
+		require.ErrorIs(t, domain.Verify(t.Context(), stubTXTResolver{
+			records[0].Host: {"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEB"},
+		}), ErrSendingDomainRecordInvalid)
+	})
+
+	t.Run("record published", func(t *testing.T) {
+		require.NoError(t, domain.Verify(t.Context(), stubTXTResolver{
+			records[0].Host: {records[0].Value},
+		}))
+	})
+
+	t.Run("record split with spaces", func(t *testing.T) {
+		require.NoError(t, domain.Verify(t.Context(), stubTXTResolver{
+			records[0].Host: {"v=DKIM1;k=rsa;  p=MIIBIjANBgkqhkiG9w0B AQEFAAOCAQ8AMIIBCgKCAQEA"},
+		}))
+	})
+}
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/ayinke-llc/hermes"
 	"github.com/ayinke-llc/malak"
@@ -16,15 +18,20 @@ import (
 )
 
 type apiKeyHandler struct {
-	apiRepo   malak.APIKeyRepository
-	generator malak.ReferenceGeneratorOperation
-	cfg       config.Config
+	apiRepo      malak.APIKeyRepository
+	generator    malak.ReferenceGeneratorOperation
+	cfg          config.Config
+	auditLogRepo malak.AuditLogRepository
 }
 
 type createAPIKeyRequest struct {
 	GenericRequest
 
 	Title string `json:"title,omitempty" validate:"required"`
+
+	// Scopes default to ingest:write which is all keys could do before
+	// scopes were introduced
+	Scopes []malak.Permission `json:"scopes,omitempty"`
 }
 
 func (c *createAPIKeyRequest) Validate() error {
@@ -44,6 +51,28 @@ func (c *createAPIKeyRequest) Validate() error {
 
 	c.Title = p.Sanitize(c.Title)
 
+	if len(c.Scopes) == 0 {
+		c.Scopes = []malak.Permission{malak.PermissionIngestWrite}
+	}
+
+	seen := make(map[malak.Permission]bool, len(c.Scopes))
+	scopes := make([]malak.Permission, 0, len(c.Scopes))
+
+	for _, scope := range c.Scopes {
+		if !malak.IsValidAPIKeyScope(scope) {
+			return fmt.Errorf("%s is not a valid api key scope", scope)
+		}
+
+		if seen[scope] {
+			continue
+		}
+
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+
+	c.Scopes = scopes
+
 	return nil
 }
 
@@ -91,6 +120,7 @@ func (d *apiKeyHandler) create(
 		Reference:   d.generator.Generate(malak.EntityTypeApiKey),
 		Value:       encrypted,
 		KeyName:     req.Title,
+		Scopes:      req.Scopes,
 	}
 
 	if err := d.apiRepo.Create(ctx, key); err != nil {
@@ -108,6 +138,17 @@ func (d *apiKeyHandler) create(
 		return newAPIStatus(status, msg), StatusFailed
 	}
 
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionApiKeyCreated,
+		malak.EntityTypeApiKey, key.Reference.String())
+	entry.Metadata["scopes"] = strings.Join(scopes, ",")
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
 	return createdAPIKeyResponse{
 		APIStatus: newAPIStatus(http.StatusOK, "api key created"),
 		Value:     value,
@@ -246,5 +287,11 @@ func (d *apiKeyHandler) revoke(
 		return newAPIStatus(status, msg), StatusFailed
 	}
 
+	entry := newAuditLog(r, malak.AuditLogActionApiKeyRevoked,
+		malak.EntityTypeApiKey, key.Reference.String())
+	entry.Metadata["strategy"] = req.Strategy.String()
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
 	return newAPIStatus(http.StatusOK, "api key revoked"), StatusSuccess
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -55,6 +56,16 @@ func generateCreateAPIKeyTestTable() []struct {
 			},
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "invalid scope",
+			mockFn: func(apiRepo *malak_mocks.MockAPIKeyRepository, secretsClient *malak_mocks.MockSecretClient) {
+			},
+			req: createAPIKeyRequest{
+				Title:  "Valid Title",
+				Scopes: []malak.Permission{malak.PermissionAPIKeysManage},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "api key creation error",
 			mockFn: func(apiRepo *malak_mocks.MockAPIKeyRepository, secretsClient *malak_mocks.MockSecretClient) {
@@ -97,6 +108,35 @@ func generateCreateAPIKeyTestTable() []struct {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "successful creation with scopes",
+			mockFn: func(apiRepo *malak_mocks.MockAPIKeyRepository, secretsClient *malak_mocks.MockSecretClient) {
+
+				apiRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, key *malak.APIKey) error {
+						// duplicates are dropped
+						if !reflect.DeepEqual([]malak.Permission{
+							malak.PermissionContactsRead,
+							malak.PermissionUpdatesWrite,
+						}, key.Scopes) {
+							return errors.New("unexpected scopes")
+						}
+
+						return nil
+					})
+			},
+			req: createAPIKeyRequest{
+				Title: "Valid Title",
+				Scopes: []malak.Permission{
+					malak.PermissionContactsRead,
+					malak.PermissionUpdatesWrite,
+					malak.PermissionContactsRead,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 	}
 }
 
@@ -112,9 +152,10 @@ func TestAPIKeyHandler_Create(t *testing.T) {
 			v.mockFn(apiRepo, secretsClient)
 
 			h := &apiKeyHandler{
-				apiRepo:   apiRepo,
-				generator: &mockReferenceGenerator{},
-				cfg:       getConfig(),
+				auditLogRepo: newMockAuditLogRepository(controller),
+				apiRepo:      apiRepo,
+				generator:    &mockReferenceGenerator{},
+				cfg:          getConfig(),
 			}
 
 			var b = bytes.NewBuffer(nil)
@@ -329,9 +370,10 @@ func TestAPIKeyHandler_Revoke(t *testing.T) {
 			v.mockFn(apiRepo)
 
 			h := &apiKeyHandler{
-				apiRepo:   apiRepo,
-				generator: &mockReferenceGenerator{},
-				cfg:       getConfig(),
+				auditLogRepo: newMockAuditLogRepository(controller),
+				apiRepo:      apiRepo,
+				generator:    &mockReferenceGenerator{},
+				cfg:          getConfig(),
 			}
 
 			var b = bytes.NewBuffer(nil)
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+)
+
+type auditLogHandler struct {
+	cfg          config.Config
+	auditLogRepo malak.AuditLogRepository
+}
+
+// newAuditLog builds an audit log entry for the current request. The actor
+// is the api key if the request was authenticated with one, else the user
+func newAuditLog(r *http.Request, action malak.AuditLogAction,
+	entityType malak.EntityType, entityReference string) *malak.AuditLog {
+
+	ctx := r.Context()
+
+	entry := &malak.AuditLog{
+		Action:          action,
+		EntityType:      entityType,
+		EntityReference: entityReference,
+		Metadata:        malak.AuditLogMetadata{},
+		IPAddress:       getIP(r),
+		RequestID:       retrieveRequestID(r),
+		CreatedAt:       time.Now(),
+	}
+
+	if doesWorkspaceExistInContext(ctx) {
+		entry.WorkspaceID = getWorkspaceFromContext(ctx).ID
+	}
+
+	switch {
+	case doesAPIKeyExistInContext(ctx):
+		entry.ActorType = malak.AuditLogActorTypeApiKey
+		entry.ActorID = getAPIKeyFromContext(ctx).ID
+
+	case doesUserExistInContext(ctx):
+		entry.ActorType = malak.AuditLogActorTypeUser
+		entry.ActorID = getUserFromContext(ctx).ID
+	}
+
+	return entry
+}
+
+// recordAuditLog stores the entry. It never fails the request since the
+// action it describes has already taken place
+func recordAuditLog(ctx context.Context, logger *zap.Logger,
+	repo malak.AuditLogRepository, entry *malak.AuditLog) {
+
+	if err := repo.Create(ctx, entry); err != nil {
+		logger.Error("could not record audit log",
+			zap.String("action", entry.Action.String()),
+			zap.Error(err))
+	}
+}
+
+// parseAuditLogDate accepts either a full RFC3339 timestamp or just a date.
+// endOfDay moves date only values to the last second of that day so
+// filtering to a date includes everything that happened on it
+func parseAuditLogDate(s string, endOfDay bool) (*time.Time, error) {
+	if hermes.IsStringEmpty(s) {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if endOfDay {
+		t = t.Add(time.Hour*24 - time.Second)
+	}
+
+	return &t, nil
+}
+
+// @Description list the workspace audit logs
+// @Tags workspace
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Page to query data from. Defaults to 1"
+// @Param per_page query int false "Number to items to return. Defaults to 10 items"
+// @Param actor_id query string false "only show actions carried out by this user or api key"
+// @Param actor_type query string false "user or api_key"
+// @Param entity_type query string false "only show actions on this entity type. e.g deck"
+// @Param from query string false "start date. Either RFC3339 or YYYY-MM-DD"
+// @Param to query string false "end date. Either RFC3339 or YYYY-MM-DD"
+// @Success 200 {object} listAuditLogsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/audit-logs [get]
+func (a *auditLogHandler) list(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing audit logs")
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	opts, err := auditLogOptionsFromRequest(r)
+	if err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	opts.WorkspaceID = workspace.ID
+
+	span.SetAttributes(
+		append(opts.Paginator.OTELAttributes(),
+			attribute.String("entity_type", opts.EntityType.String()),
+			attribute.String("actor_type", opts.ActorType.String()))...)
+
+	logs, total, err := a.auditLogRepo.List(ctx, opts)
+	if err != nil {
+		logger.Error("could not list audit logs", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list audit logs"), StatusFailed
+	}
+
+	return listAuditLogsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "audit logs fetched"),
+		AuditLogs: logs,
+		Meta: meta{
+			Paging: pagingInfo{
+				PerPage: opts.Paginator.PerPage,
+				Page:    opts.Paginator.Page,
+				Total:   total,
+			},
+		},
+	}, StatusSuccess
+}
+
+func auditLogOptionsFromRequest(r *http.Request) (malak.ListAuditLogOptions, error) {
+	query := r.URL.Query()
+
+	opts := malak.ListAuditLogOptions{
+		Paginator: malak.PaginatorFromRequest(r),
+	}
+
+	if actorID := query.Get("actor_id"); !hermes.IsStringEmpty(actorID) {
+		id, err := uuid.Parse(actorID)
+		if err != nil {
+			return opts, errors.New("please provide a valid actor id")
+		}
+
+		opts.ActorID = id
+	}
+
+	if actorType := query.Get("actor_type"); !hermes.IsStringEmpty(actorType) {
+		t, err := malak.ParseAuditLogActorType(actorType)
+		if err != nil {
+			return opts, errors.New("please provide a valid actor type")
+		}
+
+		opts.ActorType = t
+	}
+
+	if entityType := query.Get("entity_type"); !hermes.IsStringEmpty(entityType) {
+		t, err := malak.ParseEntityType(entityType)
+		if err != nil {
+			return opts, errors.New("please provide a valid entity type")
+		}
+
+		opts.EntityType = t
+	}
+
+	var err error
+
+	opts.From, err = parseAuditLogDate(query.Get("from"), false)
+	if err != nil {
+		return opts, errors.New("please provide a valid from date")
+	}
+
+	opts.To, err = parseAuditLogDate(query.Get("to"), true)
+	if err != nil {
+		return opts, errors.New("please provide a valid to date")
+	}
+
+	if opts.From != nil && opts.To != nil && opts.From.After(*opts.To) {
+		return opts, errors.New("from date cannot be after the to date")
+	}
+
+	return opts, nil
+}
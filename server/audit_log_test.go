@@ -0,0 +1,169 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newMockAuditLogRepository(controller *gomock.Controller) *malak_mocks.MockAuditLogRepository {
+	repo := malak_mocks.NewMockAuditLogRepository(controller)
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(nil)
+
+	return repo
+}
+
+func generateListAuditLogsTestTable() []struct {
+	name               string
+	query              string
+	mockFn             func(auditLogRepo *malak_mocks.MockAuditLogRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		query              string
+		mockFn             func(auditLogRepo *malak_mocks.MockAuditLogRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid actor id",
+			query:              "?actor_id=oops",
+			mockFn:             func(auditLogRepo *malak_mocks.MockAuditLogRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid actor type",
+			query:              "?actor_type=robot",
+			mockFn:             func(auditLogRepo *malak_mocks.MockAuditLogRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid entity type",
+			query:              "?entity_type=spaceship",
+			mockFn:             func(auditLogRepo *malak_mocks.MockAuditLogRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid from date",
+			query:              "?from=yesterday",
+			mockFn:             func(auditLogRepo *malak_mocks.MockAuditLogRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "from date after to date",
+			query:              "?from=2025-02-01&to=2025-01-01",
+			mockFn:             func(auditLogRepo *malak_mocks.MockAuditLogRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "could not list audit logs",
+			query: "?actor_type=user",
+			mockFn: func(auditLogRepo *malak_mocks.MockAuditLogRepository) {
+				auditLogRepo.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, int64(0), errors.New("could not list audit logs"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "audit logs fetched",
+			query: "?entity_type=deck&from=2025-01-01&to=2025-01-31",
+			mockFn: func(auditLogRepo *malak_mocks.MockAuditLogRepository) {
+				auditLogRepo.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, opts malak.ListAuditLogOptions) ([]malak.AuditLog, int64, error) {
+						if opts.EntityType != malak.EntityTypeDeck {
+							return nil, 0, errors.New("entity type not passed through")
+						}
+
+						return []malak.AuditLog{
+							{
+								ActorType:       malak.AuditLogActorTypeUser,
+								Action:          malak.AuditLogActionDeckDeleted,
+								EntityType:      malak.EntityTypeDeck,
+								EntityReference: "deck_123",
+								Metadata:        malak.AuditLogMetadata{},
+								IPAddress:       "127.0.0.1",
+								RequestID:       "request-id",
+							},
+						}, 1, nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestAuditLogHandler_List(t *testing.T) {
+	for _, v := range generateListAuditLogsTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			auditLogRepo := malak_mocks.NewMockAuditLogRepository(controller)
+
+			v.mockFn(auditLogRepo)
+
+			h := &auditLogHandler{
+				cfg:          getConfig(),
+				auditLogRepo: auditLogRepo,
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/"+v.query, nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			WrapMalakHTTPHandler(getLogger(t), h.list, getConfig(), "workspaces.audit_logs.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestNewAuditLog(t *testing.T) {
+	userID := uuid.New()
+	workspaceID := uuid.New()
+	apiKeyID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{ID: userID}))
+	req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{ID: workspaceID}))
+
+	entry := newAuditLog(req, malak.AuditLogActionDeckDeleted, malak.EntityTypeDeck, "deck_123")
+
+	require.Equal(t, workspaceID, entry.WorkspaceID)
+	require.Equal(t, malak.AuditLogActorTypeUser, entry.ActorType)
+	require.Equal(t, userID, entry.ActorID)
+	require.Equal(t, "deck_123", entry.EntityReference)
+	require.Equal(t, "10.0.0.1", entry.IPAddress)
+	require.NotNil(t, entry.Metadata)
+	require.WithinDuration(t, time.Now(), entry.CreatedAt, time.Second)
+
+	// api keys take precedence over the user who owns them
+	req = req.WithContext(writeAPIKeyToCtx(req.Context(), &malak.APIKey{ID: apiKeyID}))
+
+	entry = newAuditLog(req, malak.AuditLogActionDeckDeleted, malak.EntityTypeDeck, "deck_123")
+
+	require.Equal(t, malak.AuditLogActorTypeApiKey, entry.ActorType)
+	require.Equal(t, apiKeyID, entry.ActorID)
+}
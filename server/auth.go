@@ -17,23 +17,30 @@ import (
 
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
 	"github.com/ayinke-llc/malak/internal/pkg/jwttoken"
 	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/ayinke-llc/malak/internal/pkg/socialauth"
 	"github.com/ayinke-llc/malak/internal/pkg/util"
+	"github.com/ayinke-llc/malak/internal/secret"
 )
 
 // ENUM(user)
 type CookieName string
 
 type authHandler struct {
-	googleCfg         socialauth.SocialAuthProvider
+	socialAuth        *socialauth.Manager
 	cfg               config.Config
 	userRepo          malak.UserRepository
 	workspaceRepo     malak.WorkspaceRepository
 	tokenManager      jwttoken.JWTokenManager
 	queue             queue.QueueHandler
 	emailVerification malak.EmailVerificationRepository
+	passwordReset     malak.PasswordResetRepository
+	twoFactor         malak.TwoFactorRepository
+	cache             cache.Cache
+	uuidGenerator     malak.UuidGenerator
+	secretsClient     secret.SecretClient
 }
 
 type signupRequest struct {
@@ -116,8 +123,9 @@ func (a *authHandler) Login(
 
 	logger.Debug("Authenticating user")
 
-	if provider != "google" {
-		return newAPIStatus(http.StatusBadRequest, "unspported provider"), StatusFailed
+	authProvider, err := a.socialAuth.Get(provider)
+	if err != nil {
+		return newAPIStatus(http.StatusBadRequest, "unsupported provider"), StatusFailed
 	}
 
 	req := new(authenticateUserRequest)
@@ -130,17 +138,17 @@ func (a *authHandler) Login(
 		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
 	}
 
-	token, err := a.googleCfg.Validate(ctx, socialauth.ValidateOptions{
+	token, err := authProvider.Validate(ctx, socialauth.ValidateOptions{
 		Code: req.Code,
 	})
 	if err != nil {
 		logger.Error("could not exchange token", zap.Error(err))
-		return newAPIStatus(http.StatusBadRequest, "could not verify your sign in with Google"), StatusFailed
+		return newAPIStatus(http.StatusBadRequest, "could not verify your sign in with the oauth2 provider"), StatusFailed
 	}
 
-	u, err := a.googleCfg.User(ctx, token)
+	u, err := authProvider.User(ctx, token)
 	if err != nil {
-		logger.Error("could not fetch user details from google", zap.Error(err))
+		logger.Error("could not fetch user details from oauth2 provider", zap.Error(err))
 		return newAPIStatus(http.StatusBadRequest, "could not fetch user details from oauth2 provider"), StatusFailed
 	}
 
@@ -164,7 +172,7 @@ func (a *authHandler) Login(
 			return newAPIStatus(http.StatusInternalServerError, "an error occurred while logging user into app"), StatusFailed
 		}
 
-		return a.generateUserToken(user, logger)
+		return a.generateUserToken(ctx, user, logger)
 	}
 
 	if err != nil {
@@ -172,7 +180,32 @@ func (a *authHandler) Login(
 		return newAPIStatus(http.StatusInternalServerError, "an error occurred while creating user"), StatusFailed
 	}
 
-	return a.generateUserToken(user, logger)
+	return a.generateUserToken(ctx, user, logger)
+}
+
+// @Description List the enabled social login providers
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} listSocialAuthProvidersResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/providers [get]
+func (a *authHandler) listProviders(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing social auth providers")
+
+	return listSocialAuthProvidersResponse{
+		Providers: a.socialAuth.Providers(),
+		APIStatus: newAPIStatus(http.StatusOK, "providers fetched"),
+	}, StatusSuccess
 }
 
 // @Description Fetch current user. This api should also double as a token validation api
@@ -267,7 +300,381 @@ func (a *authHandler) emailSignup(
 	// ignored on purpose
 	_ = a.sendVerificationEmail(user, logger)
 
-	return a.generateUserToken(user, logger)
+	return a.generateUserToken(ctx, user, logger)
+}
+
+type loginRequest struct {
+	GenericRequest
+
+	Email    malak.Email `json:"email" validate:"required"`
+	Password string      `json:"password" validate:"required"`
+}
+
+func (l *loginRequest) Validate() error {
+	if hermes.IsStringEmpty(l.Email.String()) {
+		return errors.New("please provide your email address")
+	}
+
+	if hermes.IsStringEmpty(l.Password) {
+		return errors.New("please provide your password")
+	}
+
+	return nil
+}
+
+// @Description Sign in with your email address and password
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body loginRequest true "login data"
+// @Success 200 {object} createdUserResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/login [post]
+func (a *authHandler) emailLogin(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("logging in user ( email + password )")
+
+	req := new(loginRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user, err := a.userRepo.Get(ctx, &malak.FindUserOptions{
+		Email: req.Email,
+	})
+	if errors.Is(err, malak.ErrUserNotFound) {
+		return newAPIStatus(http.StatusUnauthorized, "invalid email or password"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("an error occurred while fetching user", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "an error occurred while logging user into app"), StatusFailed
+	}
+
+	// accounts created via oauth do not have a password
+	if !user.HasPassword() || !malak.VerifyPassword(*user.Password, req.Password) {
+		return newAPIStatus(http.StatusUnauthorized, "invalid email or password"), StatusFailed
+	}
+
+	return a.generateUserToken(ctx, user, logger)
+}
+
+type forgotPasswordRequest struct {
+	GenericRequest
+
+	Email malak.Email `json:"email" validate:"required"`
+}
+
+func (f *forgotPasswordRequest) Validate() error {
+	if hermes.IsStringEmpty(f.Email.String()) {
+		return errors.New("please provide your email address")
+	}
+
+	_, err := mail.ParseAddress(f.Email.String())
+	if err != nil {
+		return errors.New("please provide a valid email address")
+	}
+
+	return nil
+}
+
+// @Description Request a password reset link
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body forgotPasswordRequest true "account email"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/password/forgot [post]
+func (a *authHandler) forgotPassword(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("requesting password reset")
+
+	req := new(forgotPasswordRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	// always respond the same way so this cannot be used to
+	// find out which emails have an account
+	resp := newAPIStatus(http.StatusOK,
+		"if an account exists with this email, a password reset link has been sent")
+
+	user, err := a.userRepo.Get(ctx, &malak.FindUserOptions{
+		Email: req.Email,
+	})
+	if errors.Is(err, malak.ErrUserNotFound) {
+		return resp, StatusSuccess
+	}
+
+	if err != nil {
+		logger.Error("an error occurred while fetching user", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not request a password reset"), StatusFailed
+	}
+
+	reset, err := malak.NewPasswordReset(user)
+	if err != nil {
+		logger.Error("could not generate password reset token", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not request a password reset"), StatusFailed
+	}
+
+	if err := a.passwordReset.Create(ctx, reset); err != nil {
+		logger.Error("could not store password reset token", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not request a password reset"), StatusFailed
+	}
+
+	if err := a.queue.Add(ctx, queue.QueueTopicResetPassword, queue.PasswordResetOptions{
+		UserID: user.ID,
+		Token:  reset.Token,
+	}); err != nil {
+		logger.Error("could not queue password reset email", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not send password reset email"), StatusFailed
+	}
+
+	return resp, StatusSuccess
+}
+
+type resetPasswordRequest struct {
+	GenericRequest
+
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (p *resetPasswordRequest) Validate() error {
+	if hermes.IsStringEmpty(p.Token) {
+		return errors.New("please provide your reset token")
+	}
+
+	if hermes.IsStringEmpty(p.Password) {
+		return errors.New("please provide your password")
+	}
+
+	if passwd.Strength(p.Password) < passwd.Moderate {
+		return errors.New("your password is too week")
+	}
+
+	var err error
+	p.Password, err = malak.HashPassword(p.Password)
+	return err
+}
+
+// @Description Set a new password using a password reset token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body resetPasswordRequest true "reset data"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/password/reset [post]
+func (a *authHandler) resetPassword(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("resetting password")
+
+	req := new(resetPasswordRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	reset, err := a.passwordReset.Get(ctx, req.Token)
+	if errors.Is(err, malak.ErrPasswordResetNotFound) {
+		return newAPIStatus(http.StatusNotFound, "password reset token does not exist"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch password reset token", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not reset your password"), StatusFailed
+	}
+
+	if reset.IsExpired() {
+		return newAPIStatus(http.StatusBadRequest, "password reset token has expired"), StatusFailed
+	}
+
+	if err := a.passwordReset.Reset(ctx, &malak.ResetPasswordOptions{
+		Reset:    reset,
+		Password: req.Password,
+	}); err != nil {
+		logger.Error("could not reset password", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not reset your password"), StatusFailed
+	}
+
+	// whoever knew the old password must not stay signed in
+	if err := a.tokenManager.RevokeAllSessions(ctx, reset.UserID); err != nil {
+		logger.Error("could not revoke all sessions", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"your password has been reset but we could not log you out of your other sessions"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "your password has been reset"), StatusSuccess
+}
+
+type refreshTokenRequest struct {
+	GenericRequest
+
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *refreshTokenRequest) Validate() error {
+	if hermes.IsStringEmpty(r.RefreshToken) {
+		return errors.New("please provide your refresh token")
+	}
+
+	return nil
+}
+
+// @Description Exchange a refresh token for a new access and refresh token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body refreshTokenRequest true "refresh token"
+// @Success 200 {object} refreshTokenResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/refresh [post]
+func (a *authHandler) refreshToken(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("refreshing session")
+
+	req := new(refreshTokenRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	data, err := a.tokenManager.ParseJWToken(req.RefreshToken)
+	if err != nil {
+		return newAPIStatus(http.StatusUnauthorized, "invalid refresh token"), StatusFailed
+	}
+
+	logger = logger.With(zap.String("user_id", data.UserID.String()))
+
+	session, err := a.tokenManager.RefreshSession(ctx, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, jwttoken.ErrInvalidTokenPurpose):
+			return newAPIStatus(http.StatusUnauthorized, "invalid refresh token"), StatusFailed
+
+		case errors.Is(err, jwttoken.ErrSessionNotFound),
+			errors.Is(err, jwttoken.ErrRefreshTokenReused):
+			return newAPIStatus(http.StatusUnauthorized, "session is expired. Please sign in again"), StatusFailed
+
+		default:
+			logger.Error("could not refresh session", zap.Error(err))
+			return newAPIStatus(http.StatusInternalServerError, "could not refresh your session"), StatusFailed
+		}
+	}
+
+	return refreshTokenResponse{
+		Token:        session.AccessToken.Token,
+		RefreshToken: session.RefreshToken.Token,
+		APIStatus:    newAPIStatus(http.StatusOK, "session refreshed"),
+	}, StatusSuccess
+}
+
+// @Description Sign out of the current session
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/logout [post]
+func (a *authHandler) logout(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("logging out of session")
+
+	if err := a.tokenManager.RevokeSession(ctx, getTokenFromContext(ctx)); err != nil {
+		logger.Error("could not revoke session", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not log you out"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "you have been logged out"), StatusSuccess
+}
+
+// @Description Sign out of every session across all devices
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/logout/all [post]
+func (a *authHandler) logoutAll(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("logging out of all sessions")
+
+	user := getUserFromContext(ctx)
+
+	if err := a.tokenManager.RevokeAllSessions(ctx, user.ID); err != nil {
+		logger.Error("could not revoke all sessions", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not log you out of all sessions"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "you have been logged out of all sessions"), StatusSuccess
 }
 
 func (a *authHandler) sendVerificationEmail(user *malak.User, logger *zap.Logger) error {
@@ -295,20 +702,33 @@ func (a *authHandler) sendVerificationEmail(user *malak.User, logger *zap.Logger
 	})
 }
 
-func (a *authHandler) generateUserToken(user *malak.User, logger *zap.Logger) (render.Renderer, Status) {
+// generateUserToken is called once the user has been authenticated. Users
+// with 2FA enabled get a challenge they have to complete before a session
+// is created
+func (a *authHandler) generateUserToken(ctx context.Context,
+	user *malak.User, logger *zap.Logger) (render.Renderer, Status) {
 
-	token, err := a.tokenManager.GenerateJWToken(jwttoken.JWTokenData{
-		UserID: user.ID,
-	})
+	if user.IsTwoFactorEnabled() {
+		return a.createTwoFactorChallenge(ctx, user, logger)
+	}
+
+	return a.createSession(ctx, user, logger)
+}
+
+func (a *authHandler) createSession(ctx context.Context,
+	user *malak.User, logger *zap.Logger) (render.Renderer, Status) {
+
+	session, err := a.tokenManager.CreateSession(ctx, user.ID)
 	if err != nil {
 		logger.Error("an error occurred while generating jwt token", zap.Error(err))
 		return newAPIStatus(http.StatusInternalServerError, "an error occurred while generating jwt token"), StatusFailed
 	}
 
 	resp := createdUserResponse{
-		User:      util.DeRef(user),
-		APIStatus: newAPIStatus(http.StatusOK, "Logged in Successfully"),
-		Token:     token.Token,
+		User:         util.DeRef(user),
+		APIStatus:    newAPIStatus(http.StatusOK, "Logged in Successfully"),
+		Token:        session.AccessToken.Token,
+		RefreshToken: session.RefreshToken.Token,
 	}
 	return resp, StatusSuccess
 }
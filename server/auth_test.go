@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ayinke-llc/hermes"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/sebdah/goldie/v2"
@@ -22,6 +23,7 @@ import (
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/jwttoken"
 	mock_jwttoken "github.com/ayinke-llc/malak/internal/pkg/jwttoken/mocks"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/ayinke-llc/malak/internal/pkg/socialauth"
 	socialauth_mocks "github.com/ayinke-llc/malak/internal/pkg/socialauth/mocks"
 	malak_mocks "github.com/ayinke-llc/malak/mocks"
@@ -124,6 +126,26 @@ func getConfig() config.Config {
 				APIKey        string "mapstructure:\"api_key\" yaml:\"api_key\" json:\"api_key\""
 				WebhookSecret string "mapstructure:\"webhook_secret\" yaml:\"webhook_secret\" json:\"webhook_secret\""
 			} "mapstructure:\"resend\" yaml:\"resend\" json:\"resend\""
+			Sendgrid struct {
+				APIKey                 string "mapstructure:\"api_key\" yaml:\"api_key\" json:\"api_key\""
+				WebhookVerificationKey string "mapstructure:\"webhook_verification_key\" yaml:\"webhook_verification_key\" json:\"webhook_verification_key\""
+				Endpoint               string "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+			} "mapstructure:\"sendgrid\" yaml:\"sendgrid\" json:\"sendgrid\""
+			SES struct {
+				Region           string   "mapstructure:\"region\" yaml:\"region\" json:\"region\""
+				AccessKey        string   "mapstructure:\"access_key\" yaml:\"access_key\" json:\"access_key\""
+				AccessSecret     string   "mapstructure:\"access_secret\" yaml:\"access_secret\" json:\"access_secret\""
+				ConfigurationSet string   "mapstructure:\"configuration_set\" yaml:\"configuration_set\" json:\"configuration_set\""
+				Endpoint         string   "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+				TopicArns        []string "mapstructure:\"topic_arns\" yaml:\"topic_arns\" json:\"topic_arns\""
+			} "mapstructure:\"ses\" yaml:\"ses\" json:\"ses\""
+			Postmark struct {
+				ServerToken     string "mapstructure:\"server_token\" yaml:\"server_token\" json:\"server_token\""
+				MessageStream   string "mapstructure:\"message_stream\" yaml:\"message_stream\" json:\"message_stream\""
+				WebhookUsername string "mapstructure:\"webhook_username\" yaml:\"webhook_username\" json:\"webhook_username\""
+				WebhookPassword string "mapstructure:\"webhook_password\" yaml:\"webhook_password\" json:\"webhook_password\""
+				Endpoint        string "mapstructure:\"endpoint\" yaml:\"endpoint\" json:\"endpoint\""
+			} "mapstructure:\"postmark\" yaml:\"postmark\" json:\"postmark\""
 		}{
 			Provider:   config.EmailProviderSmtp,
 			Sender:     malak.Email("test@example.com"),
@@ -173,6 +195,30 @@ func getConfig() config.Config {
 				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
 				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
 			} "yaml:\"google\" mapstructure:\"google\" json:\"google\""
+			GitHub struct {
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"github\" mapstructure:\"github\" json:\"github\""
+			Microsoft struct {
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				Tenant       string   "yaml:\"tenant\" mapstructure:\"tenant\" json:\"tenant\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"microsoft\" mapstructure:\"microsoft\" json:\"microsoft\""
+			OIDC struct {
+				Name         string   "yaml:\"name\" mapstructure:\"name\" json:\"name\""
+				IssuerURL    string   "yaml:\"issuer_url\" mapstructure:\"issuer_url\" json:\"issuer_url\""
+				ClientID     string   "yaml:\"client_id\" mapstructure:\"client_id\" json:\"client_id\""
+				ClientSecret string   "yaml:\"client_secret\" mapstructure:\"client_secret\" json:\"client_secret\""
+				RedirectURI  string   "yaml:\"redirect_uri\" mapstructure:\"redirect_uri\" json:\"redirect_uri\""
+				Scopes       []string "yaml:\"scopes\" mapstructure:\"scopes\" json:\"scopes\""
+				IsEnabled    bool     "yaml:\"is_enabled\" mapstructure:\"is_enabled\" json:\"is_enabled\""
+			} "yaml:\"oidc\" mapstructure:\"oidc\" json:\"oidc\""
 			JWT struct {
 				Key string "yaml:\"key\" mapstructure:\"key\" json:\"key\""
 			} "yaml:\"jwt\" mapstructure:\"jwt\" json:\"jwt\""
@@ -443,7 +489,7 @@ func generateEmailSignupTestTable() []struct {
 				userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				emailVerification.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				queueMock.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
-				tokenManager.EXPECT().GenerateJWToken(gomock.Any()).Times(1).Return(jwttoken.JWTokenData{}, errors.New("token error"))
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(jwttoken.Session{}, errors.New("token error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			req: signupRequest{
@@ -458,9 +504,15 @@ func generateEmailSignupTestTable() []struct {
 				userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				emailVerification.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				queueMock.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
-				tokenManager.EXPECT().GenerateJWToken(gomock.Any()).Times(1).Return(jwttoken.JWTokenData{
-					Token:  "test-token",
-					UserID: userID,
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(jwttoken.Session{
+					AccessToken: jwttoken.JWTokenData{
+						Token:  "test-token",
+						UserID: userID,
+					},
+					RefreshToken: jwttoken.JWTokenData{
+						Token:  "test-refresh-token",
+						UserID: userID,
+					},
 				}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
@@ -488,9 +540,12 @@ func TestAuthHandler_Login(t *testing.T) {
 
 			v.mockFn(googleCfg, userRepo)
 
+			socialAuth := socialauth.NewManager()
+			socialAuth.Add(socialauth.ProviderGoogle, googleCfg)
+
 			a := &authHandler{
 				cfg:          getConfig(),
-				googleCfg:    googleCfg,
+				socialAuth:   socialAuth,
 				userRepo:     userRepo,
 				tokenManager: jwtMock,
 			}
@@ -509,11 +564,17 @@ func TestAuthHandler_Login(t *testing.T) {
 
 			if v.expectedStatusCode == http.StatusOK {
 				jwtMock.EXPECT().
-					GenerateJWToken(gomock.Any()).
+					CreateSession(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(jwttoken.JWTokenData{
-						Token:  "b622268d-4512-4e3c-98da-88097753d4b9",
-						UserID: uuid.MustParse("7e6ad0c8-7a96-4add-a270-52615bd808e6"),
+					Return(jwttoken.Session{
+						AccessToken: jwttoken.JWTokenData{
+							Token:  "b622268d-4512-4e3c-98da-88097753d4b9",
+							UserID: uuid.MustParse("7e6ad0c8-7a96-4add-a270-52615bd808e6"),
+						},
+						RefreshToken: jwttoken.JWTokenData{
+							Token:  "1bd0a1c4-6b8a-4d8b-9a38-3f4f5e0b2d21",
+							UserID: uuid.MustParse("7e6ad0c8-7a96-4add-a270-52615bd808e6"),
+						},
 					}, nil)
 			}
 
@@ -543,6 +604,19 @@ func generateLoginTestTable() []struct {
 		req                authenticateUserRequest
 		provider           string
 	}{
+		{
+			name: "unsupported provider",
+			mockFn: func(googleMock *socialauth_mocks.MockSocialAuthProvider, userRepo *malak_mocks.MockUserRepository) {
+				googleMock.EXPECT().
+					Validate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			provider:           "github",
+			expectedStatusCode: http.StatusBadRequest,
+			req: authenticateUserRequest{
+				Code: "token",
+			},
+		},
 		{
 			name: "no code to exchange provided",
 			mockFn: func(googleMock *socialauth_mocks.MockSocialAuthProvider, userRepo *malak_mocks.MockUserRepository) {
@@ -749,3 +823,699 @@ func generateLoginTestTable() []struct {
 		},
 	}
 }
+
+func generateEmailLoginTestTable(t *testing.T) []struct {
+	name               string
+	mockFn             func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager)
+	expectedStatusCode int
+	req                loginRequest
+} {
+
+	hashed, err := malak.HashPassword("StrongPassword123!")
+	require.NoError(t, err)
+
+	return []struct {
+		name               string
+		mockFn             func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager)
+		expectedStatusCode int
+		req                loginRequest
+	}{
+		{
+			name: "empty email",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: loginRequest{
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "empty password",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: loginRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+		{
+			name: "user does not exist",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(nil, malak.ErrUserNotFound)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: loginRequest{
+				Email:    malak.Email("test@example.com"),
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "could not fetch user",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: loginRequest{
+				Email:    malak.Email("test@example.com"),
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "user has no password",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{
+					Email: malak.Email("test@example.com"),
+				}, nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: loginRequest{
+				Email:    malak.Email("test@example.com"),
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "wrong password",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{
+					Email:    malak.Email("test@example.com"),
+					Password: hermes.Ref(hashed),
+				}, nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: loginRequest{
+				Email:    malak.Email("test@example.com"),
+				Password: "WrongPassword123!",
+			},
+		},
+		{
+			name: "logged in successfully",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{
+					Email:    malak.Email("test@example.com"),
+					FullName: "Test User",
+					Metadata: &malak.UserMetadata{},
+					Roles:    malak.UserRoles{},
+					Password: hermes.Ref(hashed),
+				}, nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(jwttoken.Session{
+					AccessToken: jwttoken.JWTokenData{
+						Token: "test-token",
+					},
+					RefreshToken: jwttoken.JWTokenData{
+						Token: "test-refresh-token",
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: loginRequest{
+				Email:    malak.Email("test@example.com"),
+				Password: "StrongPassword123!",
+			},
+		},
+	}
+}
+
+func TestAuthHandler_EmailLogin(t *testing.T) {
+	for _, v := range generateEmailLoginTestTable(t) {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			userRepo := malak_mocks.NewMockUserRepository(controller)
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+
+			v.mockFn(userRepo, tokenManager)
+
+			a := &authHandler{
+				cfg:          getConfig(),
+				userRepo:     userRepo,
+				tokenManager: tokenManager,
+			}
+
+			var b = bytes.NewBuffer(nil)
+
+			require.NoError(t, json.NewEncoder(b).Encode(&v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			WrapMalakHTTPHandler(getLogger(t), a.emailLogin, getConfig(), "Auth.emailLogin").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateForgotPasswordTestTable() []struct {
+	name               string
+	mockFn             func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler)
+	expectedStatusCode int
+	req                forgotPasswordRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler)
+		expectedStatusCode int
+		req                forgotPasswordRequest
+	}{
+		{
+			name: "invalid email",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: forgotPasswordRequest{
+				Email: malak.Email("invalid-email"),
+			},
+		},
+		{
+			name: "user does not exist",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(nil, malak.ErrUserNotFound)
+				passwordReset.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+				queueMock.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: forgotPasswordRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+		{
+			name: "could not fetch user",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: forgotPasswordRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+		{
+			name: "could not store reset token",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{}, nil)
+				passwordReset.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: forgotPasswordRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+		{
+			name: "could not queue email",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{}, nil)
+				passwordReset.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				queueMock.EXPECT().Add(gomock.Any(), queue.QueueTopicResetPassword, gomock.Any()).Times(1).Return(errors.New("queue error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: forgotPasswordRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+		{
+			name: "reset email sent",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, passwordReset *malak_mocks.MockPasswordResetRepository, queueMock *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(&malak.User{}, nil)
+				passwordReset.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				queueMock.EXPECT().Add(gomock.Any(), queue.QueueTopicResetPassword, gomock.Any()).Times(1).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: forgotPasswordRequest{
+				Email: malak.Email("test@example.com"),
+			},
+		},
+	}
+}
+
+func TestAuthHandler_ForgotPassword(t *testing.T) {
+	for _, v := range generateForgotPasswordTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			userRepo := malak_mocks.NewMockUserRepository(controller)
+			passwordReset := malak_mocks.NewMockPasswordResetRepository(controller)
+			queueMock := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(userRepo, passwordReset, queueMock)
+
+			a := &authHandler{
+				cfg:           getConfig(),
+				userRepo:      userRepo,
+				passwordReset: passwordReset,
+				queue:         queueMock,
+			}
+
+			var b = bytes.NewBuffer(nil)
+
+			require.NoError(t, json.NewEncoder(b).Encode(&v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			WrapMalakHTTPHandler(getLogger(t), a.forgotPassword, getConfig(), "Auth.forgotPassword").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+var resetUserID = uuid.MustParse("8ce0f580-4d6e-4b35-9b3a-1d2a3f0e7c51")
+
+func generateResetPasswordTestTable() []struct {
+	name               string
+	mockFn             func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager)
+	expectedStatusCode int
+	req                resetPasswordRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager)
+		expectedStatusCode int
+		req                resetPasswordRequest
+	}{
+		{
+			name: "empty token",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: resetPasswordRequest{
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "weak password",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "weak",
+			},
+		},
+		{
+			name: "token does not exist",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(nil, malak.ErrPasswordResetNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "could not fetch token",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "token expired",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(&malak.PasswordReset{
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+				passwordReset.EXPECT().Reset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "could not reset password",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(&malak.PasswordReset{
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				passwordReset.EXPECT().Reset(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "could not revoke sessions",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(&malak.PasswordReset{
+					UserID:    resetUserID,
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				passwordReset.EXPECT().Reset(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				tokenManager.EXPECT().RevokeAllSessions(gomock.Any(), resetUserID).Times(1).Return(errors.New("cache error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+		{
+			name: "password reset",
+			mockFn: func(passwordReset *malak_mocks.MockPasswordResetRepository, tokenManager *mock_jwttoken.MockJWTokenManager) {
+				passwordReset.EXPECT().Get(gomock.Any(), "token").Times(1).Return(&malak.PasswordReset{
+					UserID:    resetUserID,
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				passwordReset.EXPECT().Reset(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				tokenManager.EXPECT().RevokeAllSessions(gomock.Any(), resetUserID).Times(1).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: resetPasswordRequest{
+				Token:    "token",
+				Password: "StrongPassword123!",
+			},
+		},
+	}
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	for _, v := range generateResetPasswordTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			passwordReset := malak_mocks.NewMockPasswordResetRepository(controller)
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+
+			v.mockFn(passwordReset, tokenManager)
+
+			a := &authHandler{
+				cfg:           getConfig(),
+				passwordReset: passwordReset,
+				tokenManager:  tokenManager,
+			}
+
+			var b = bytes.NewBuffer(nil)
+
+			require.NoError(t, json.NewEncoder(b).Encode(&v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			WrapMalakHTTPHandler(getLogger(t), a.resetPassword, getConfig(), "Auth.resetPassword").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateRefreshTokenTestTable() []struct {
+	name               string
+	mockFn             func(tokenManager *mock_jwttoken.MockJWTokenManager)
+	expectedStatusCode int
+	req                refreshTokenRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(tokenManager *mock_jwttoken.MockJWTokenManager)
+		expectedStatusCode int
+		req                refreshTokenRequest
+	}{
+		{
+			name: "empty refresh token",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken(gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid refresh token",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{}, errors.New("invalid token"))
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name: "access token used",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{}, nil)
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(jwttoken.Session{}, jwttoken.ErrInvalidTokenPurpose)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name: "session revoked",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{Purpose: jwttoken.PurposeRefresh}, nil)
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(jwttoken.Session{}, jwttoken.ErrSessionNotFound)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name: "refresh token reused",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{Purpose: jwttoken.PurposeRefresh}, nil)
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(jwttoken.Session{}, jwttoken.ErrRefreshTokenReused)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name: "could not refresh session",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{Purpose: jwttoken.PurposeRefresh}, nil)
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(jwttoken.Session{}, errors.New("cache error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+		{
+			name: "session refreshed",
+			mockFn: func(tokenManager *mock_jwttoken.MockJWTokenManager) {
+				tokenManager.EXPECT().ParseJWToken("refresh-token").Times(1).
+					Return(jwttoken.JWTokenData{Purpose: jwttoken.PurposeRefresh}, nil)
+				tokenManager.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(jwttoken.Session{
+						AccessToken: jwttoken.JWTokenData{
+							Token: "new-access-token",
+						},
+						RefreshToken: jwttoken.JWTokenData{
+							Token: "new-refresh-token",
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: refreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+		},
+	}
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	for _, v := range generateRefreshTokenTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+
+			v.mockFn(tokenManager)
+
+			a := &authHandler{
+				cfg:          getConfig(),
+				tokenManager: tokenManager,
+			}
+
+			var b = bytes.NewBuffer(nil)
+
+			require.NoError(t, json.NewEncoder(b).Encode(&v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			WrapMalakHTTPHandler(getLogger(t), a.refreshToken, getConfig(), "Auth.refreshToken").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			name:               "could not revoke session",
+			err:                errors.New("cache error"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:               "logged out",
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+
+			token := jwttoken.JWTokenData{
+				SessionID: uuid.MustParse("0c8a5e5d-4d4b-4a49-8f0b-5fd4c2a9b0a1"),
+			}
+
+			tokenManager.EXPECT().RevokeSession(gomock.Any(), token).
+				Times(1).
+				Return(v.err)
+
+			a := &authHandler{
+				cfg:          getConfig(),
+				tokenManager: tokenManager,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req = req.WithContext(writeTokenToCtx(writeUserToCtx(req.Context(), &malak.User{}), token))
+
+			WrapMalakHTTPHandler(getLogger(t), a.logout, getConfig(), "Auth.logout").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestAuthHandler_LogoutAll(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			name:               "could not revoke sessions",
+			err:                errors.New("cache error"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:               "logged out of all sessions",
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+
+			user := &malak.User{
+				ID: uuid.MustParse("7e6ad0c8-7a96-4add-a270-52615bd808e6"),
+			}
+
+			tokenManager.EXPECT().RevokeAllSessions(gomock.Any(), user.ID).
+				Times(1).
+				Return(v.err)
+
+			a := &authHandler{
+				cfg:          getConfig(),
+				tokenManager: tokenManager,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req = req.WithContext(writeUserToCtx(req.Context(), user))
+
+			WrapMalakHTTPHandler(getLogger(t), a.logoutAll, getConfig(), "Auth.logoutAll").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestAuthHandler_ListProviders(t *testing.T) {
+	socialAuth := socialauth.NewManager()
+	socialAuth.Add(socialauth.ProviderGoogle, socialauth.NewGoogle(getConfig()))
+	socialAuth.Add("corporate", socialauth.NewOIDC(getConfig()))
+
+	a := &authHandler{
+		cfg:        getConfig(),
+		socialAuth: socialAuth,
+	}
+
+	rr := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	WrapMalakHTTPHandler(getLogger(t), a.listProviders, getConfig(), "Auth.listProviders").
+		ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	verifyMatch(t, rr)
+}
@@ -24,6 +24,8 @@ type contactHandler struct {
 	contactListRepo    malak.ContactListRepository
 	contactShareRepo   malak.ContactShareRepository
 	referenceGenerator malak.ReferenceGeneratorOperation
+	suppressionRepo    malak.SuppressionRepository
+	auditLogRepo       malak.AuditLogRepository
 }
 
 type createContactRequest struct {
@@ -26,6 +26,7 @@ type dashboardHandler struct {
 	dashboardLinkRepo malak.DashboardLinkRepository
 	contactRepo       malak.ContactRepository
 	queue             queue.QueueHandler
+	auditLogRepo      malak.AuditLogRepository
 }
 
 type createDashboardRequest struct {
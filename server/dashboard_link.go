@@ -119,6 +119,17 @@ func (d *dashboardHandler) generateLink(
 			StatusFailed
 	}
 
+	entry := newAuditLog(r, malak.AuditLogActionDashboardLinkGenerated,
+		malak.EntityTypeDashboardLink, link.Reference.String())
+	entry.Metadata["dashboard"] = dashboard.Reference.String()
+	entry.Metadata["link_type"] = link.LinkType.String()
+
+	if !hermes.IsStringEmpty(req.Email.String()) {
+		entry.Metadata["email"] = req.Email.String()
+	}
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
 	if !hermes.IsStringEmpty(req.Email.String()) {
 		go func() {
 			err := d.queue.Add(context.Background(), queue.QueueTopicShareDashboard, queue.SendEmailOptions{
@@ -194,6 +205,12 @@ func (d *dashboardHandler) revokeAccessControl(
 			"could not revoke access"), StatusFailed
 	}
 
+	entry := newAuditLog(r, malak.AuditLogActionDashboardLinkRevoked,
+		malak.EntityTypeDashboardLink, chi.URLParam(r, "link_reference"))
+	entry.Metadata["dashboard"] = dashboard.Reference.String()
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
 	return newAPIStatus(http.StatusOK, "access revoked"), StatusSuccess
 }
 
@@ -150,6 +150,7 @@ func TestDashboardHandler_GenerateLink(t *testing.T) {
 			v.mockFn(dashboardRepo, dashboardLinkRepo, queueMock)
 
 			h := &dashboardHandler{
+				auditLogRepo:      newMockAuditLogRepository(controller),
 				dashboardRepo:     dashboardRepo,
 				dashboardLinkRepo: dashboardLinkRepo,
 				generator:         &mockReferenceGenerator{},
@@ -578,6 +579,7 @@ func TestDashboardHandler_RevokeAccessControl(t *testing.T) {
 			v.mockFn(dashboardRepo, dashboardLinkRepo)
 
 			h := &dashboardHandler{
+				auditLogRepo:      newMockAuditLogRepository(controller),
 				dashboardRepo:     dashboardRepo,
 				dashboardLinkRepo: dashboardLinkRepo,
 				generator:         &mockReferenceGenerator{},
@@ -18,6 +18,7 @@ import (
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/cache"
 	"github.com/ayinke-llc/malak/internal/pkg/geolocation"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/microcosm-cc/bluemonday"
@@ -33,6 +34,8 @@ type deckHandler struct {
 	gulterStore        gulter.Storage
 	geolocationService geolocation.GeolocationService
 	contactRepo        malak.ContactRepository
+	auditLogRepo       malak.AuditLogRepository
+	queue              queue.QueueHandler
 }
 
 func hashURL(rawURL string) (string, error) {
@@ -337,6 +340,10 @@ func (d *deckHandler) Delete(
 		return newAPIStatus(http.StatusInternalServerError, "error occurred while deleting deck"), StatusFailed
 	}
 
+	recordAuditLog(ctx, logger, d.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionDeckDeleted,
+			malak.EntityTypeDeck, deck.Reference.String()))
+
 	return newAPIStatus(http.StatusOK, "deleted your deck"), StatusSuccess
 }
 
@@ -480,6 +487,14 @@ func (d *deckHandler) updatePreferences(
 			StatusFailed
 	}
 
+	entry := newAuditLog(r, malak.AuditLogActionDeckPreferencesUpdated,
+		malak.EntityTypeDeck, deck.Reference.String())
+	entry.Metadata["password_protected"] = strconv.FormatBool(req.PasswordProtection.Enabled)
+	entry.Metadata["enable_downloading"] = strconv.FormatBool(req.EnableDownloading)
+	entry.Metadata["require_email"] = strconv.FormatBool(req.RequireEmail)
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
 	return fetchDeckResponse{
 		APIStatus: newAPIStatus(http.StatusOK, "Updated deck preferences"),
 		Deck:      hermes.DeRef(deck),
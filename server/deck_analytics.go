@@ -162,6 +162,14 @@ func (d *deckHandler) publicDeckDetails(
 			StatusFailed
 	}
 
+	dispatchWebhookEvent(ctx, logger, d.queue, deck.WorkspaceID,
+		malak.WebhookEventDeckViewerSessionCreated, malak.WebhookDeckViewerSessionCreatedData{
+			DeckReference:    malak.Reference(ref),
+			SessionReference: sessionReq.Reference,
+			Country:          country,
+			CreatedAt:        time.Now(),
+		})
+
 	return fetchPublicDeckResponse{
 		APIStatus: newAPIStatus(http.StatusOK, "fetched deck details"),
 		Deck: malak.PublicDeck{
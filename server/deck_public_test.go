@@ -221,6 +221,7 @@ func TestDeckHandler_PublicDeckDetails(t *testing.T) {
 				gulterStore:        gulterStore,
 				cfg:                getConfig(),
 				geolocationService: geoService,
+				queue:              newMockWebhookDispatchQueue(controller),
 			}
 
 			var b = bytes.NewBuffer(nil)
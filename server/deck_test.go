@@ -350,6 +350,7 @@ func TestDeckHandler_Delete(t *testing.T) {
 			v.mockFn(deckRepo)
 
 			u := &deckHandler{
+				auditLogRepo:       newMockAuditLogRepository(controller),
 				referenceGenerator: &mockReferenceGenerator{},
 				deckRepo:           deckRepo,
 			}
@@ -578,6 +579,7 @@ func TestDeckHandler_UpdatePreferences(t *testing.T) {
 			v.mockFn(deckRepo)
 
 			u := &deckHandler{
+				auditLogRepo:       newMockAuditLogRepository(controller),
 				referenceGenerator: &mockReferenceGenerator{},
 				deckRepo:           deckRepo,
 			}
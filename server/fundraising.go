@@ -10,6 +10,7 @@ import (
 	"github.com/ayinke-llc/hermes"
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
@@ -24,6 +25,7 @@ type fundraisingHandler struct {
 	fundingRepo        malak.FundraisingPipelineRepository
 	referenceGenerator malak.ReferenceGeneratorOperation
 	contactRepo        malak.ContactRepository
+	queue              queue.QueueHandler
 }
 
 type createNewPipelineRequest struct {
@@ -679,10 +681,21 @@ func (d *fundraisingHandler) moveContactAcrossBoard(
 		return newAPIStatus(http.StatusInternalServerError, "could not fetch your contact or column"), StatusFailed
 	}
 
+	fromColumnID := contact.FundraisingPipelineColumnID
+
 	if err = d.fundingRepo.MoveContactColumn(ctx, contact, column); err != nil {
 		logger.Error("could not move contact across column in board", zap.Error(err))
 		return newAPIStatus(http.StatusInternalServerError, "could not update contact column"), StatusFailed
 	}
 
+	dispatchWebhookEvent(ctx, logger, d.queue, workspace.ID,
+		malak.WebhookEventFundraisingContactMoved, malak.WebhookFundraisingContactMovedData{
+			PipelineReference: pipeline.Reference,
+			ContactReference:  contact.Reference,
+			FromColumnID:      fromColumnID,
+			ToColumnID:        column.ID,
+			ToColumnTitle:     column.Title,
+		})
+
 	return newAPIStatus(http.StatusOK, "column updated"), StatusSuccess
 }
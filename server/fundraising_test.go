@@ -1583,6 +1583,7 @@ func TestFundraisingHandler_MoveContactAcrossBoard(t *testing.T) {
 				cfg:                getConfig(),
 				fundingRepo:        fundingRepo,
 				referenceGenerator: &mockReferenceGenerator{},
+				queue:              newMockWebhookDispatchQueue(controller),
 			}
 
 			var b = bytes.NewBuffer(nil)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
 	"github.com/go-chi/render"
 	"go.opentelemetry.io/otel/attribute"
@@ -24,12 +25,15 @@ type MalakHTTPHandler func(
 	http.ResponseWriter,
 	*http.Request) (render.Renderer, Status)
 
-// WrapMalakHTTPHandler is a middleware that wraps our handlers and manages errors
+// WrapMalakHTTPHandler is a middleware that wraps our handlers and manages errors.
+// Permissions, if provided, are checked against the user's role in the
+// current workspace before the handler is called
 func WrapMalakHTTPHandler(
 	logger *zap.Logger,
 	handler MalakHTTPHandler,
 	cfg config.Config,
-	spanName string) http.HandlerFunc {
+	spanName string,
+	permissions ...malak.Permission) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -52,6 +56,20 @@ func WrapMalakHTTPHandler(
 				attribute.String("user_id", userID))
 		}
 
+		if doesAPIKeyExistInContext(r.Context()) {
+			reference := getAPIKeyFromContext(r.Context()).Reference.String()
+			logger = logger.With(zap.String("api_key", reference))
+			span.SetAttributes(
+				attribute.String("api_key", reference))
+		}
+
+		if !hasPermission(ctx, permissions...) {
+			span.SetStatus(codes.Error, "permission denied")
+			_ = render.Render(w, r, newAPIStatus(http.StatusForbidden,
+				"you do not have permission to perform this action"))
+			return
+		}
+
 		resp, status := handler(ctx, span, logger, w, r)
 		switch status {
 		case StatusFailed:
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/adelowo/gulter"
 	chi "github.com/go-chi/chi/v5"
@@ -21,6 +23,11 @@ import (
 	"github.com/ayinke-llc/malak/internal/integrations"
 	"github.com/ayinke-llc/malak/internal/pkg/billing"
 	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	"github.com/ayinke-llc/malak/internal/pkg/email/dkim"
+	"github.com/ayinke-llc/malak/internal/pkg/email/postmark"
+	"github.com/ayinke-llc/malak/internal/pkg/email/sendgrid"
+	"github.com/ayinke-llc/malak/internal/pkg/email/ses"
 	"github.com/ayinke-llc/malak/internal/pkg/geolocation"
 	"github.com/ayinke-llc/malak/internal/pkg/jwttoken"
 	"github.com/ayinke-llc/malak/internal/pkg/queue"
@@ -32,7 +39,7 @@ import (
 func New(logger *zap.Logger,
 	cfg config.Config,
 	jwtTokenManager jwttoken.JWTokenManager,
-	googleAuthProvider socialauth.SocialAuthProvider,
+	socialAuthManager *socialauth.Manager,
 	dashboardRepo malak.DashboardRepository,
 	userRepo malak.UserRepository,
 	workspaceRepo malak.WorkspaceRepository,
@@ -48,6 +55,16 @@ func New(logger *zap.Logger,
 	dashboardLinkRepo malak.DashboardLinkRepository,
 	apiRepo malak.APIKeyRepository,
 	emailVerificationRepo malak.EmailVerificationRepository,
+	teamRepo malak.TeamRepository,
+	passwordResetRepo malak.PasswordResetRepository,
+	twoFactorRepo malak.TwoFactorRepository,
+	auditLogRepo malak.AuditLogRepository,
+	webhookRepo malak.WebhookRepository,
+	suppressionRepo malak.SuppressionRepository,
+	commentRepo malak.UpdateCommentRepository,
+	linkRepo malak.UpdateLinkRepository,
+	recurringRepo malak.RecurringUpdateScheduleRepository,
+	sendingDomainRepo malak.SendingDomainRepository,
 	mid *httplimit.Middleware,
 	queueHandler queue.QueueHandler,
 	redisCache cache.Cache,
@@ -55,6 +72,8 @@ func New(logger *zap.Logger,
 	integrationManager *integrations.IntegrationsManager,
 	secretsClient secret.SecretClient,
 	geolocationService geolocation.GeolocationService,
+	chartRenderer malak.ChartRenderer,
+	collaborationHub *collaboration.Hub,
 	imageUploadGulterHandler *gulter.Gulter,
 	deckUploadGulterHandler *gulter.Gulter,
 	fundingRepo malak.FundraisingPipelineRepository) (*http.Server, func()) {
@@ -70,9 +89,9 @@ func New(logger *zap.Logger,
 			userRepo, workspaceRepo, planRepo,
 			contactRepo, updateRepo, contactListRepo,
 			deckRepo, shareRepo, preferenceRepo, integrationRepo, templatesRepo,
-			dashboardLinkRepo, apiRepo, emailVerificationRepo,
-			googleAuthProvider, mid, queueHandler, redisCache, billingClient,
-			integrationManager, secretsClient, geolocationService, imageUploadGulterHandler,
+			dashboardLinkRepo, apiRepo, emailVerificationRepo, teamRepo,
+			passwordResetRepo, twoFactorRepo, auditLogRepo, webhookRepo, suppressionRepo, commentRepo, linkRepo, recurringRepo, sendingDomainRepo, socialAuthManager, mid, queueHandler, redisCache, billingClient,
+			integrationManager, secretsClient, geolocationService, chartRenderer, collaborationHub, imageUploadGulterHandler,
 			deckUploadGulterHandler, fundingRepo),
 		Addr: fmt.Sprintf(":%d", cfg.HTTP.Port),
 	}
@@ -120,7 +139,17 @@ func buildRoutes(
 	dashboardLinkRepo malak.DashboardLinkRepository,
 	apiRepo malak.APIKeyRepository,
 	emailVerificationRepo malak.EmailVerificationRepository,
-	googleAuthProvider socialauth.SocialAuthProvider,
+	teamRepo malak.TeamRepository,
+	passwordResetRepo malak.PasswordResetRepository,
+	twoFactorRepo malak.TwoFactorRepository,
+	auditLogRepo malak.AuditLogRepository,
+	webhookRepo malak.WebhookRepository,
+	suppressionRepo malak.SuppressionRepository,
+	commentRepo malak.UpdateCommentRepository,
+	linkRepo malak.UpdateLinkRepository,
+	recurringRepo malak.RecurringUpdateScheduleRepository,
+	sendingDomainRepo malak.SendingDomainRepository,
+	socialAuthManager *socialauth.Manager,
 	ratelimiterMiddleware *httplimit.Middleware,
 	queueHandler queue.QueueHandler,
 	redisCache cache.Cache,
@@ -128,6 +157,8 @@ func buildRoutes(
 	integrationManager *integrations.IntegrationsManager,
 	secretsClient secret.SecretClient,
 	geolocationService geolocation.GeolocationService,
+	chartRenderer malak.ChartRenderer,
+	collaborationHub *collaboration.Hub,
 	imageUploadGulterHandler *gulter.Gulter,
 	deckUploadGulterHandler *gulter.Gulter,
 	fundingRepo malak.FundraisingPipelineRepository) http.Handler {
@@ -151,12 +182,18 @@ func buildRoutes(
 	referenceGenerator := malak.NewReferenceGenerator()
 
 	auth := &authHandler{
+		cfg:               cfg,
 		userRepo:          userRepo,
 		workspaceRepo:     workspaceRepo,
-		googleCfg:         googleAuthProvider,
+		socialAuth:        socialAuthManager,
 		tokenManager:      jwtTokenManager,
 		queue:             queueHandler,
 		emailVerification: emailVerificationRepo,
+		passwordReset:     passwordResetRepo,
+		twoFactor:         twoFactorRepo,
+		cache:             redisCache,
+		uuidGenerator:     malak.NewGoogleUUID(),
+		secretsClient:     secretsClient,
 	}
 
 	workspaceHandler := &workspaceHandler{
@@ -177,6 +214,10 @@ func buildRoutes(
 		updateRepo:              updateRepo,
 		contactRepo:             contactRepo,
 		fundingRepo:             fundingRepo,
+		sendingDomainRepo:       sendingDomainRepo,
+		auditLogRepo:            auditLogRepo,
+		dnsResolver:             net.DefaultResolver,
+		dkimKeyGenerator:        dkim.GenerateKey,
 	}
 
 	contactHandler := &contactHandler{
@@ -185,6 +226,8 @@ func buildRoutes(
 		referenceGenerator: referenceGenerator,
 		contactListRepo:    contactListRepo,
 		contactShareRepo:   shareRepo,
+		suppressionRepo:    suppressionRepo,
+		auditLogRepo:       auditLogRepo,
 	}
 
 	updateHandler := &updatesHandler{
@@ -196,6 +239,17 @@ func buildRoutes(
 		cache:              redisCache,
 		uuidGenerator:      malak.NewGoogleUUID(),
 		templateRepo:       templatesRepo,
+		auditLogRepo:       auditLogRepo,
+		suppressionRepo:    suppressionRepo,
+		commentRepo:        commentRepo,
+		linkRepo:           linkRepo,
+		workspaceRepo:      workspaceRepo,
+		geolocationService: geolocationService,
+		chartRenderer:      chartRenderer,
+		recurringRepo:      recurringRepo,
+		integrationRepo:    integrationRepo,
+		collaborationHub:   collaborationHub,
+		preferenceRepo:     preferenceRepo,
 	}
 
 	webhookHandler := &webhookHandler{
@@ -206,6 +260,9 @@ func buildRoutes(
 		referenceGenerator: referenceGenerator,
 		updateRepo:         updateRepo,
 		contactRepo:        contactRepo,
+		suppressionRepo:    suppressionRepo,
+		queue:              queueHandler,
+		cache:              redisCache,
 	}
 
 	if cfg.Email.Provider == config.EmailProviderResend {
@@ -217,6 +274,34 @@ func buildRoutes(
 		webhookHandler.svixClient = wh
 	}
 
+	if cfg.Email.Provider == config.EmailProviderSendgrid {
+		verifier, err := sendgrid.NewWebhookVerifier(cfg.Email.Sendgrid.WebhookVerificationKey)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		webhookHandler.sendgridVerifier = verifier
+	}
+
+	if cfg.Email.Provider == config.EmailProviderSes {
+		httpClient := &http.Client{
+			Timeout: time.Second * 10,
+		}
+
+		webhookHandler.sesVerifier = ses.NewWebhookVerifier(httpClient,
+			ses.DefaultCertificateFetcher(httpClient), cfg.Email.SES.TopicArns)
+	}
+
+	if cfg.Email.Provider == config.EmailProviderPostmark {
+		authenticator, err := postmark.NewWebhookAuthenticator(
+			cfg.Email.Postmark.WebhookUsername, cfg.Email.Postmark.WebhookPassword)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		webhookHandler.postmarkAuthenticator = authenticator
+	}
+
 	stripeHan := &stripeHandler{
 		user:            userRepo,
 		planRepo:        planRepo,
@@ -236,6 +321,8 @@ func buildRoutes(
 		cfg:                cfg,
 		geolocationService: geolocationService,
 		contactRepo:        contactRepo,
+		auditLogRepo:       auditLogRepo,
+		queue:              queueHandler,
 	}
 
 	dashHandler := &dashboardHandler{
@@ -246,12 +333,14 @@ func buildRoutes(
 		dashboardLinkRepo: dashboardLinkRepo,
 		contactRepo:       contactRepo,
 		queue:             queueHandler,
+		auditLogRepo:      auditLogRepo,
 	}
 
 	apiHandler := &apiKeyHandler{
-		generator: referenceGenerator,
-		apiRepo:   apiRepo,
-		cfg:       cfg,
+		generator:    referenceGenerator,
+		apiRepo:      apiRepo,
+		cfg:          cfg,
+		auditLogRepo: auditLogRepo,
 	}
 
 	pipelineHandler := &fundraisingHandler{
@@ -259,6 +348,30 @@ func buildRoutes(
 		cfg:                cfg,
 		fundingRepo:        fundingRepo,
 		contactRepo:        contactRepo,
+		queue:              queueHandler,
+	}
+
+	teamHandler := &teamHandler{
+		cfg:                cfg,
+		teamRepo:           teamRepo,
+		userRepo:           userRepo,
+		queue:              queueHandler,
+		referenceGenerator: referenceGenerator,
+		auditLogRepo:       auditLogRepo,
+	}
+
+	auditHandler := &auditLogHandler{
+		cfg:          cfg,
+		auditLogRepo: auditLogRepo,
+	}
+
+	webhookEndpointHandler := &webhookEndpointHandler{
+		cfg:           cfg,
+		webhookRepo:   webhookRepo,
+		generator:     referenceGenerator,
+		queue:         queueHandler,
+		auditLogRepo:  auditLogRepo,
+		secretsClient: secretsClient,
 	}
 
 	router.Use(middleware.RequestID)
@@ -282,29 +395,91 @@ func buildRoutes(
 
 	router.Route("/hooks", func(r chi.Router) {
 		r.Post("/resend", webhookHandler.handleResend(logger))
+		r.Post("/sendgrid", webhookHandler.handleSendgrid(logger))
+		r.Post("/ses", webhookHandler.handleSES(logger))
+		r.Post("/postmark", webhookHandler.handlePostmark(logger))
 		r.Post("/stripe", stripeHan.handleWebhook)
 	})
 
 	router.Route("/updates", func(r chi.Router) {
 		r.Post("/react", updateHandler.handleReaction(logger))
+		// GET only shows a confirmation for the link in the email footer so
+		// link scanners cannot unsubscribe anyone. POST is RFC 8058 one-click
+		r.Get("/unsubscribe", updateHandler.confirmUnsubscribe(logger))
+		r.Post("/unsubscribe", updateHandler.handleUnsubscribe(logger))
+		r.Get("/click", updateHandler.handleClick(logger))
+		r.Get("/open", webhookHandler.handleOpenPixel(logger))
 	})
 
 	router.Route("/v1", func(r chi.Router) {
 
 		r.Route("/ingest", func(r chi.Router) {
-			r.Use(requireAPIKeyOnly(logger, cfg, apiRepo, workspaceRepo))
+			r.Use(requireAPIKeyOnly(logger, cfg, apiRepo, workspaceRepo, userRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/{reference}/charts/{chart_reference}/points",
-				WrapMalakHTTPHandler(logger, workspaceHandler.addDataPoint, cfg, "api.charts.points"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.addDataPoint, cfg, "api.charts.points",
+					malak.PermissionIngestWrite))
 		})
 
 		r.Route("/auth", func(r chi.Router) {
+			r.Get("/providers",
+				WrapMalakHTTPHandler(logger, auth.listProviders, cfg, "Auth.providers"))
+
 			r.Post("/connect/{provider}",
 				WrapMalakHTTPHandler(logger, auth.Login, cfg, "Auth.Login"))
 
 			r.Post("/register",
 				WrapMalakHTTPHandler(logger, auth.emailSignup, cfg, "Auth.register"))
+
+			r.Post("/login",
+				WrapMalakHTTPHandler(logger, auth.emailLogin, cfg, "Auth.login"))
+
+			r.Post("/password/forgot",
+				WrapMalakHTTPHandler(logger, auth.forgotPassword, cfg, "Auth.password.forgot"))
+
+			r.Post("/password/reset",
+				WrapMalakHTTPHandler(logger, auth.resetPassword, cfg, "Auth.password.reset"))
+
+			r.Post("/refresh",
+				WrapMalakHTTPHandler(logger, auth.refreshToken, cfg, "Auth.refresh"))
+
+			r.Post("/2fa/verify",
+				WrapMalakHTTPHandler(logger, auth.verifyTwoFactor, cfg, "Auth.2fa.verify"))
+
+			r.Group(func(r chi.Router) {
+				r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+
+				r.Post("/logout",
+					WrapMalakHTTPHandler(logger, auth.logout, cfg, "Auth.logout"))
+
+				r.Post("/logout/all",
+					WrapMalakHTTPHandler(logger, auth.logoutAll, cfg, "Auth.logout.all"))
+
+				r.Route("/2fa", func(r chi.Router) {
+					r.Post("/setup",
+						WrapMalakHTTPHandler(logger, auth.setupTwoFactor, cfg, "Auth.2fa.setup"))
+
+					r.Post("/enable",
+						WrapMalakHTTPHandler(logger, auth.enableTwoFactor, cfg, "Auth.2fa.enable"))
+
+					r.Post("/disable",
+						WrapMalakHTTPHandler(logger, auth.disableTwoFactor, cfg, "Auth.2fa.disable"))
+
+					r.Post("/recovery-codes",
+						WrapMalakHTTPHandler(logger, auth.regenerateRecoveryCodes, cfg, "Auth.2fa.recovery_codes"))
+				})
+			})
+		})
+
+		r.Route("/invites", func(r chi.Router) {
+			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+
+			r.Post("/accept",
+				WrapMalakHTTPHandler(logger, teamHandler.acceptInvite, cfg, "invites.accept"))
+
+			r.Post("/decline",
+				WrapMalakHTTPHandler(logger, teamHandler.declineInvite, cfg, "invites.decline"))
 		})
 
 		r.Route("/user", func(r chi.Router) {
@@ -315,253 +490,474 @@ func buildRoutes(
 		})
 
 		r.Route("/workspaces", func(r chi.Router) {
-			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+			r.Use(requireUserOrAPIKey(logger, jwtTokenManager, cfg, userRepo, workspaceRepo, apiRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/",
 				WrapMalakHTTPHandler(logger, workspaceHandler.createWorkspace, cfg, "workspaces.new"))
 
 			r.Patch("/",
-				WrapMalakHTTPHandler(logger, workspaceHandler.updateWorkspace, cfg, "workspaces.update"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.updateWorkspace, cfg, "workspaces.update",
+					malak.PermissionWorkspaceUpdate))
 
 			r.Get("/overview",
-				WrapMalakHTTPHandler(logger, workspaceHandler.overview, cfg, "workspaces.overview"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.overview, cfg, "workspaces.overview",
+					malak.PermissionWorkspaceRead))
 
 			r.Get("/preferences",
-				WrapMalakHTTPHandler(logger, workspaceHandler.getPreferences, cfg, "workspaces.preferences.get"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.getPreferences, cfg, "workspaces.preferences.get",
+					malak.PermissionPreferencesRead))
 
 			r.Put("/preferences",
-				WrapMalakHTTPHandler(logger, workspaceHandler.updatePreferences, cfg, "workspaces.preferences.update"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.updatePreferences, cfg, "workspaces.preferences.update",
+					malak.PermissionPreferencesUpdate))
 
 			r.Post("/billing",
-				WrapMalakHTTPHandler(logger, workspaceHandler.getBillingPortal, cfg, "workspaces.billing.portal"))
+				WrapMalakHTTPHandler(logger, workspaceHandler.getBillingPortal, cfg, "workspaces.billing.portal",
+					malak.PermissionBillingManage))
+
+			r.Get("/audit-logs",
+				WrapMalakHTTPHandler(logger, auditHandler.list, cfg, "workspaces.audit_logs.list",
+					malak.PermissionAuditLogsRead))
+
+			r.Route("/domain", func(r chi.Router) {
+				r.Get("/",
+					WrapMalakHTTPHandler(logger, workspaceHandler.fetchSendingDomain, cfg, "workspaces.domain.fetch",
+						malak.PermissionWorkspaceRead))
+
+				r.Post("/",
+					WrapMalakHTTPHandler(logger, workspaceHandler.createSendingDomain, cfg, "workspaces.domain.create",
+						malak.PermissionWorkspaceUpdate))
+
+				r.Post("/verify",
+					WrapMalakHTTPHandler(logger, workspaceHandler.verifySendingDomain, cfg, "workspaces.domain.verify",
+						malak.PermissionWorkspaceUpdate))
+
+				r.Delete("/",
+					WrapMalakHTTPHandler(logger, workspaceHandler.deleteSendingDomain, cfg, "workspaces.domain.delete",
+						malak.PermissionWorkspaceUpdate))
+			})
 
 			r.Post("/switch/{reference}",
 				WrapMalakHTTPHandler(logger, workspaceHandler.switchCurrentWorkspaceForUser, cfg, "workspaces.switch"))
 
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/",
+					WrapMalakHTTPHandler(logger, teamHandler.list, cfg, "workspaces.members.list",
+						malak.PermissionTeamRead))
+
+				r.Post("/invite",
+					WrapMalakHTTPHandler(logger, teamHandler.invite, cfg, "workspaces.members.invite",
+						malak.PermissionTeamManage))
+
+				r.Put("/{user_id}",
+					WrapMalakHTTPHandler(logger, teamHandler.updateRole, cfg, "workspaces.members.update_role",
+						malak.PermissionTeamManage))
+
+				r.Delete("/{user_id}",
+					WrapMalakHTTPHandler(logger, teamHandler.remove, cfg, "workspaces.members.remove",
+						malak.PermissionTeamManage))
+			})
+
 			r.Route("/integrations", func(r chi.Router) {
 				r.Get("/",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.getIntegrations, cfg, "workspaces.integrations.list"))
+						workspaceHandler.getIntegrations, cfg, "workspaces.integrations.list",
+						malak.PermissionIntegrationsRead))
 
 				r.Post("/{reference}",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.enableIntegration, cfg, "workspaces.integrations.store"))
+						workspaceHandler.enableIntegration, cfg, "workspaces.integrations.store",
+						malak.PermissionIntegrationsManage))
 
 				r.Put("/{reference}",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.updateAPIKeyForIntegration, cfg, "workspaces.integrations.update_token"))
+						workspaceHandler.updateAPIKeyForIntegration, cfg, "workspaces.integrations.update_token",
+						malak.PermissionIntegrationsManage))
 
 				r.Delete("/{reference}",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.disableIntegration, cfg, "workspaces.integrations.disable"))
+						workspaceHandler.disableIntegration, cfg, "workspaces.integrations.disable",
+						malak.PermissionIntegrationsManage))
 
 				r.Post("/{reference}/ping",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.pingIntegration, cfg, "workspaces.integrations.ping"))
+						workspaceHandler.pingIntegration, cfg, "workspaces.integrations.ping",
+						malak.PermissionIntegrationsManage))
 
 				r.Post("/{reference}/charts",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.createChart, cfg, "workspaces.integrations.createChart"))
+						workspaceHandler.createChart, cfg, "workspaces.integrations.createChart",
+						malak.PermissionIntegrationsManage))
 
 				r.Post("/{reference}/charts/{chart_reference}/points",
 					WrapMalakHTTPHandler(logger,
-						workspaceHandler.addDataPoint, cfg, "workspaces.integrations.charts.addDataPoint"))
+						workspaceHandler.addDataPoint, cfg, "workspaces.integrations.charts.addDataPoint",
+						malak.PermissionIntegrationsManage))
 			})
 
 			r.Route("/updates", func(r chi.Router) {
 
 				r.Post("/",
-					WrapMalakHTTPHandler(logger, updateHandler.create, cfg, "updates.new"))
+					WrapMalakHTTPHandler(logger, updateHandler.create, cfg, "updates.new",
+						malak.PermissionUpdatesWrite))
 				r.Get("/",
-					WrapMalakHTTPHandler(logger, updateHandler.list, cfg, "updates.list"))
+					WrapMalakHTTPHandler(logger, updateHandler.list, cfg, "updates.list",
+						malak.PermissionUpdatesRead))
 
 				r.Get("/pins",
-					WrapMalakHTTPHandler(logger, updateHandler.listPinnedUpdates, cfg, "updates.list.pins"))
+					WrapMalakHTTPHandler(logger, updateHandler.listPinnedUpdates, cfg, "updates.list.pins",
+						malak.PermissionUpdatesRead))
 
 				r.Get("/templates",
-					WrapMalakHTTPHandler(logger, updateHandler.templates, cfg, "updates.list.templates"))
+					WrapMalakHTTPHandler(logger, updateHandler.templates, cfg, "updates.list.templates",
+						malak.PermissionUpdatesRead))
+
+				r.Get("/templates/{reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.fetchTemplate, cfg, "updates.templates.fetch",
+						malak.PermissionUpdatesRead))
+				r.Put("/templates/{reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.updateTemplate, cfg, "updates.templates.update",
+						malak.PermissionUpdatesWrite))
+				r.Delete("/templates/{reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.deleteTemplate, cfg, "updates.templates.delete",
+						malak.PermissionUpdatesWrite))
+
+				r.Post("/recurring",
+					WrapMalakHTTPHandler(logger, updateHandler.createRecurringSchedule, cfg, "updates.recurring.create",
+						malak.PermissionUpdatesWrite))
+				r.Get("/recurring",
+					WrapMalakHTTPHandler(logger, updateHandler.listRecurringSchedules, cfg, "updates.recurring.list",
+						malak.PermissionUpdatesRead))
 
 				r.Get("/{reference}",
-					WrapMalakHTTPHandler(logger, updateHandler.fetchUpdate, cfg, "updates.fetchUpdate"))
+					WrapMalakHTTPHandler(logger, updateHandler.fetchUpdate, cfg, "updates.fetchUpdate",
+						malak.PermissionUpdatesRead))
 				r.Delete("/{reference}",
-					WrapMalakHTTPHandler(logger, updateHandler.delete, cfg, "updates.delete"))
+					WrapMalakHTTPHandler(logger, updateHandler.delete, cfg, "updates.delete",
+						malak.PermissionUpdatesWrite))
 
 				r.Post("/{reference}",
-					WrapMalakHTTPHandler(logger, updateHandler.sendUpdate, cfg, "updates.send"))
+					WrapMalakHTTPHandler(logger, updateHandler.sendUpdate, cfg, "updates.send",
+						malak.PermissionUpdatesSend))
 
 				r.Put("/{reference}",
-					WrapMalakHTTPHandler(logger, updateHandler.update, cfg, "updates.content_update"))
+					WrapMalakHTTPHandler(logger, updateHandler.update, cfg, "updates.content_update",
+						malak.PermissionUpdatesWrite))
+
+				r.Get("/{reference}/collaborate", updateHandler.collaborate(logger))
 
 				r.Post("/{reference}/pin",
-					WrapMalakHTTPHandler(logger, updateHandler.togglePinned, cfg, "updates.togglePinned"))
+					WrapMalakHTTPHandler(logger, updateHandler.togglePinned, cfg, "updates.togglePinned",
+						malak.PermissionUpdatesWrite))
+
+				r.Get("/{reference}/revisions",
+					WrapMalakHTTPHandler(logger, updateHandler.listRevisions, cfg, "updates.revisions.list",
+						malak.PermissionUpdatesRead))
+				r.Get("/{reference}/revisions/diff",
+					WrapMalakHTTPHandler(logger, updateHandler.diffRevisions, cfg, "updates.revisions.diff",
+						malak.PermissionUpdatesRead))
+				r.Post("/{reference}/revisions/{revision_reference}/restore",
+					WrapMalakHTTPHandler(logger, updateHandler.restoreRevision, cfg, "updates.revisions.restore",
+						malak.PermissionUpdatesWrite))
+
+				r.Post("/{reference}/review",
+					WrapMalakHTTPHandler(logger, updateHandler.requestReview, cfg, "updates.review.request",
+						malak.PermissionUpdatesWrite))
+				r.Get("/{reference}/approvals",
+					WrapMalakHTTPHandler(logger, updateHandler.listApprovals, cfg, "updates.approvals.list",
+						malak.PermissionUpdatesRead))
+				r.Post("/{reference}/approvals",
+					WrapMalakHTTPHandler(logger, updateHandler.reviewUpdate, cfg, "updates.approvals.create",
+						malak.PermissionUpdatesRead))
+
+				r.Post("/{reference}/template",
+					WrapMalakHTTPHandler(logger, updateHandler.saveAsTemplate, cfg, "updates.templates.create",
+						malak.PermissionUpdatesWrite))
 
 				r.Post("/{reference}/duplicate",
-					WrapMalakHTTPHandler(logger, updateHandler.duplicate, cfg, "updates.duplicate"))
+					WrapMalakHTTPHandler(logger, updateHandler.duplicate, cfg, "updates.duplicate",
+						malak.PermissionUpdatesWrite))
 
 				r.Post("/{reference}/preview",
-					WrapMalakHTTPHandler(logger, updateHandler.previewUpdate, cfg, "updates.preview"))
+					WrapMalakHTTPHandler(logger, updateHandler.previewUpdate, cfg, "updates.preview",
+						malak.PermissionUpdatesSend))
 
 				r.Get("/{reference}/analytics",
-					WrapMalakHTTPHandler(logger, updateHandler.fetchUpdateAnalytics, cfg, "updates.analytics"))
+					WrapMalakHTTPHandler(logger, updateHandler.fetchUpdateAnalytics, cfg, "updates.analytics",
+						malak.PermissionUpdatesRead))
+
+				r.Get("/{reference}/comments",
+					WrapMalakHTTPHandler(logger, updateHandler.listComments, cfg, "updates.comments.list",
+						malak.PermissionUpdatesRead))
+
+				r.Post("/{reference}/comments/{comment_reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.replyComment, cfg, "updates.comments.reply",
+						malak.PermissionUpdatesWrite))
+
+				r.Post("/{reference}/links",
+					WrapMalakHTTPHandler(logger, updateHandler.createLink, cfg, "updates.links.create",
+						malak.PermissionUpdatesWrite))
+
+				r.Get("/{reference}/links",
+					WrapMalakHTTPHandler(logger, updateHandler.listLinks, cfg, "updates.links.list",
+						malak.PermissionUpdatesRead))
+
+				r.Delete("/{reference}/links/{link_reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.revokeLink, cfg, "updates.links.revoke",
+						malak.PermissionUpdatesWrite))
+
+				r.Post("/{reference}/visibility",
+					WrapMalakHTTPHandler(logger, updateHandler.updateVisibility, cfg, "updates.visibility",
+						malak.PermissionUpdatesWrite))
+
+				r.Get("/{reference}/views",
+					WrapMalakHTTPHandler(logger, updateHandler.listViewerSessions, cfg, "updates.views.list",
+						malak.PermissionUpdatesRead))
+
+				r.Get("/{reference}/schedules",
+					WrapMalakHTTPHandler(logger, updateHandler.listSchedules, cfg, "updates.schedules.list",
+						malak.PermissionUpdatesRead))
+
+				r.Patch("/{reference}/schedules/{schedule_reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.reschedule, cfg, "updates.schedules.update",
+						malak.PermissionUpdatesWrite))
+
+				r.Delete("/{reference}/schedules/{schedule_reference}",
+					WrapMalakHTTPHandler(logger, updateHandler.cancelSchedule, cfg, "updates.schedules.cancel",
+						malak.PermissionUpdatesWrite))
 			})
 		})
 
 		r.Route("/pipelines", func(r chi.Router) {
-			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+			r.Use(requireUserOrAPIKey(logger, jwtTokenManager, cfg, userRepo, workspaceRepo, apiRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/",
-				WrapMalakHTTPHandler(logger, pipelineHandler.newPipeline, cfg, "pipelines.create"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.newPipeline, cfg, "pipelines.create",
+					malak.PermissionPipelinesWrite))
 
 			r.Get("/",
-				WrapMalakHTTPHandler(logger, pipelineHandler.list, cfg, "pipelines.list"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.list, cfg, "pipelines.list",
+					malak.PermissionPipelinesRead))
 
 			r.Get("/{reference}",
-				WrapMalakHTTPHandler(logger, pipelineHandler.board, cfg, "pipelines.board"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.board, cfg, "pipelines.board",
+					malak.PermissionPipelinesRead))
 
 			r.Delete("/{reference}",
-				WrapMalakHTTPHandler(logger, pipelineHandler.closeBoard, cfg, "pipelines.board.close"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.closeBoard, cfg, "pipelines.board.close",
+					malak.PermissionPipelinesWrite))
 
 			r.Post("/{reference}/contacts",
-				WrapMalakHTTPHandler(logger, pipelineHandler.addContact, cfg, "pipelines.board.contacts.add"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.addContact, cfg, "pipelines.board.contacts.add",
+					malak.PermissionPipelinesWrite))
 
 			r.Post("/{reference}/contacts/board",
-				WrapMalakHTTPHandler(logger, pipelineHandler.moveContactAcrossBoard, cfg, "pipelines.board.contacts.move"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.moveContactAcrossBoard, cfg, "pipelines.board.contacts.move",
+					malak.PermissionPipelinesWrite))
 
 			r.Patch("/{reference}/contacts/{contact_id}",
-				WrapMalakHTTPHandler(logger, pipelineHandler.updateContactDeal, cfg, "pipelines.board.contacts.edit"))
+				WrapMalakHTTPHandler(logger, pipelineHandler.updateContactDeal, cfg, "pipelines.board.contacts.edit",
+					malak.PermissionPipelinesWrite))
 		})
 
 		r.Route("/contacts", func(r chi.Router) {
-			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+			r.Use(requireUserOrAPIKey(logger, jwtTokenManager, cfg, userRepo, workspaceRepo, apiRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/",
-				WrapMalakHTTPHandler(logger, contactHandler.Create, cfg, "contacts.create"))
+				WrapMalakHTTPHandler(logger, contactHandler.Create, cfg, "contacts.create",
+					malak.PermissionContactsWrite))
 
 			r.Get("/",
-				WrapMalakHTTPHandler(logger, contactHandler.list, cfg, "contacts.list"))
+				WrapMalakHTTPHandler(logger, contactHandler.list, cfg, "contacts.list",
+					malak.PermissionContactsRead))
 
 			r.Get("/all",
-				WrapMalakHTTPHandler(logger, contactHandler.listContacts, cfg, "contacts.all"))
+				WrapMalakHTTPHandler(logger, contactHandler.listContacts, cfg, "contacts.all",
+					malak.PermissionContactsRead))
 
 			r.Post("/batch",
-				WrapMalakHTTPHandler(logger, contactHandler.batchCreate, cfg, "contacts.batch"))
+				WrapMalakHTTPHandler(logger, contactHandler.batchCreate, cfg, "contacts.batch",
+					malak.PermissionContactsWrite))
 
 			r.Get("/search",
-				WrapMalakHTTPHandler(logger, contactHandler.search, cfg, "contacts.search"))
+				WrapMalakHTTPHandler(logger, contactHandler.search, cfg, "contacts.search",
+					malak.PermissionContactsRead))
+
+			r.Get("/suppressions",
+				WrapMalakHTTPHandler(logger, contactHandler.listSuppressions, cfg, "contacts.suppressions.list",
+					malak.PermissionContactsRead))
+
+			r.Delete("/suppressions/{reference}",
+				WrapMalakHTTPHandler(logger, contactHandler.deleteSuppression, cfg, "contacts.suppressions.delete",
+					malak.PermissionContactsWrite))
 
 			r.Get("/{reference}",
-				WrapMalakHTTPHandler(logger, contactHandler.fetchContact, cfg, "contacts.fetch"))
+				WrapMalakHTTPHandler(logger, contactHandler.fetchContact, cfg, "contacts.fetch",
+					malak.PermissionContactsRead))
 
 			r.Delete("/{reference}",
-				WrapMalakHTTPHandler(logger, contactHandler.deleteContact, cfg, "contacts.delete"))
+				WrapMalakHTTPHandler(logger, contactHandler.deleteContact, cfg, "contacts.delete",
+					malak.PermissionContactsWrite))
 
 			r.Put("/{reference}",
-				WrapMalakHTTPHandler(logger, contactHandler.editContact, cfg, "contacts.edit"))
+				WrapMalakHTTPHandler(logger, contactHandler.editContact, cfg, "contacts.edit",
+					malak.PermissionContactsWrite))
 
 			r.Route("/lists", func(r chi.Router) {
 				r.Post("/",
-					WrapMalakHTTPHandler(logger, contactHandler.createContactList, cfg, "contacts.lists.new"))
+					WrapMalakHTTPHandler(logger, contactHandler.createContactList, cfg, "contacts.lists.new",
+						malak.PermissionContactsWrite))
 
 				r.Get("/",
-					WrapMalakHTTPHandler(logger, contactHandler.fetchContactLists, cfg, "contacts.lists.fetch"))
+					WrapMalakHTTPHandler(logger, contactHandler.fetchContactLists, cfg, "contacts.lists.fetch",
+						malak.PermissionContactsRead))
 
 				r.Delete("/{reference}",
-					WrapMalakHTTPHandler(logger, contactHandler.deleteContactList, cfg, "contacts.lists.delete"))
+					WrapMalakHTTPHandler(logger, contactHandler.deleteContactList, cfg, "contacts.lists.delete",
+						malak.PermissionContactsWrite))
 
 				r.Put("/{reference}",
-					WrapMalakHTTPHandler(logger, contactHandler.editContactList, cfg, "contacts.lists.update"))
+					WrapMalakHTTPHandler(logger, contactHandler.editContactList, cfg, "contacts.lists.update",
+						malak.PermissionContactsWrite))
 
 				r.Post("/{reference}",
-					WrapMalakHTTPHandler(logger, contactHandler.addUserToContactList, cfg, "contacts.lists.add"))
+					WrapMalakHTTPHandler(logger, contactHandler.addUserToContactList, cfg, "contacts.lists.add",
+						malak.PermissionContactsWrite))
 			})
 		})
 
 		r.Route("/decks", func(r chi.Router) {
-			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+			r.Use(requireUserOrAPIKey(logger, jwtTokenManager, cfg, userRepo, workspaceRepo, apiRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/",
-				WrapMalakHTTPHandler(logger, deckHandler.Create, cfg, "decks.add"))
+				WrapMalakHTTPHandler(logger, deckHandler.Create, cfg, "decks.add",
+					malak.PermissionDecksWrite))
 
 			r.Get("/",
-				WrapMalakHTTPHandler(logger, deckHandler.List, cfg, "decks.list"))
+				WrapMalakHTTPHandler(logger, deckHandler.List, cfg, "decks.list",
+					malak.PermissionDecksRead))
 
 			r.Delete("/{reference}",
-				WrapMalakHTTPHandler(logger, deckHandler.Delete, cfg, "decks.delete"))
+				WrapMalakHTTPHandler(logger, deckHandler.Delete, cfg, "decks.delete",
+					malak.PermissionDecksWrite))
 
 			r.Get("/{reference}",
-				WrapMalakHTTPHandler(logger, deckHandler.fetch, cfg, "decks.retrieve"))
+				WrapMalakHTTPHandler(logger, deckHandler.fetch, cfg, "decks.retrieve",
+					malak.PermissionDecksRead))
 
 			r.Get("/{reference}/sessions",
-				WrapMalakHTTPHandler(logger, deckHandler.fetchDeckSessions, cfg, "decks.sessions"))
+				WrapMalakHTTPHandler(logger, deckHandler.fetchDeckSessions, cfg, "decks.sessions",
+					malak.PermissionDecksRead))
 
 			r.Get("/{reference}/analytics",
-				WrapMalakHTTPHandler(logger, deckHandler.fetchEngagements, cfg, "decks.engagements"))
+				WrapMalakHTTPHandler(logger, deckHandler.fetchEngagements, cfg, "decks.engagements",
+					malak.PermissionDecksRead))
 
 			r.Put("/{reference}/preferences",
-				WrapMalakHTTPHandler(logger, deckHandler.updatePreferences, cfg, "decks.preferences.update"))
+				WrapMalakHTTPHandler(logger, deckHandler.updatePreferences, cfg, "decks.preferences.update",
+					malak.PermissionDecksWrite))
 
 			r.Post("/{reference}/archive",
-				WrapMalakHTTPHandler(logger, deckHandler.toggleArchive, cfg, "decks.archive"))
+				WrapMalakHTTPHandler(logger, deckHandler.toggleArchive, cfg, "decks.archive",
+					malak.PermissionDecksWrite))
 
 			r.Post("/{reference}/pin",
-				WrapMalakHTTPHandler(logger, deckHandler.togglePinned, cfg, "decks.togglePinned"))
+				WrapMalakHTTPHandler(logger, deckHandler.togglePinned, cfg, "decks.togglePinned",
+					malak.PermissionDecksWrite))
 
 		})
 
 		r.Route("/dashboards", func(r chi.Router) {
-			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
+			r.Use(requireUserOrAPIKey(logger, jwtTokenManager, cfg, userRepo, workspaceRepo, apiRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
 			r.Post("/",
-				WrapMalakHTTPHandler(logger, dashHandler.create, cfg, "dashboards.create"))
+				WrapMalakHTTPHandler(logger, dashHandler.create, cfg, "dashboards.create",
+					malak.PermissionDashboardsWrite))
 
 			r.Get("/",
-				WrapMalakHTTPHandler(logger, dashHandler.list, cfg, "dashboards.list"))
+				WrapMalakHTTPHandler(logger, dashHandler.list, cfg, "dashboards.list",
+					malak.PermissionDashboardsRead))
 
 			r.Get("/charts",
-				WrapMalakHTTPHandler(logger, dashHandler.listAllCharts, cfg, "dashboards.list.charts"))
+				WrapMalakHTTPHandler(logger, dashHandler.listAllCharts, cfg, "dashboards.list.charts",
+					malak.PermissionDashboardsRead))
 
 			r.Get("/charts/{reference}",
-				WrapMalakHTTPHandler(logger, dashHandler.fetchChartingData, cfg, "dashboards.charts.datapoints"))
+				WrapMalakHTTPHandler(logger, dashHandler.fetchChartingData, cfg, "dashboards.charts.datapoints",
+					malak.PermissionDashboardsRead))
 
 			r.Get("/{reference}",
-				WrapMalakHTTPHandler(logger, dashHandler.fetchDashboard, cfg, "dashboards.fetch"))
+				WrapMalakHTTPHandler(logger, dashHandler.fetchDashboard, cfg, "dashboards.fetch",
+					malak.PermissionDashboardsRead))
 
 			r.Post("/{reference}/positions",
-				WrapMalakHTTPHandler(logger, dashHandler.updateDashboardPositions, cfg, "dashboards.positions.update"))
+				WrapMalakHTTPHandler(logger, dashHandler.updateDashboardPositions, cfg, "dashboards.positions.update",
+					malak.PermissionDashboardsWrite))
 
 			r.Put("/{reference}/charts",
-				WrapMalakHTTPHandler(logger, dashHandler.addChart, cfg, "dashboards.charts.add"))
+				WrapMalakHTTPHandler(logger, dashHandler.addChart, cfg, "dashboards.charts.add",
+					malak.PermissionDashboardsWrite))
 
 			r.Delete("/{reference}/charts",
-				WrapMalakHTTPHandler(logger, dashHandler.removeChart, cfg, "dashboards.charts.remove"))
+				WrapMalakHTTPHandler(logger, dashHandler.removeChart, cfg, "dashboards.charts.remove",
+					malak.PermissionDashboardsWrite))
 
 			r.Post("/{reference}/access-control/link",
-				WrapMalakHTTPHandler(logger, dashHandler.generateLink, cfg, "dashboards.access-control.link.generate"))
+				WrapMalakHTTPHandler(logger, dashHandler.generateLink, cfg, "dashboards.access-control.link.generate",
+					malak.PermissionDashboardsWrite))
 
 			r.Get("/{reference}/access-control",
-				WrapMalakHTTPHandler(logger, dashHandler.listAccessControls, cfg, "dashboards.access-control.list"))
+				WrapMalakHTTPHandler(logger, dashHandler.listAccessControls, cfg, "dashboards.access-control.list",
+					malak.PermissionDashboardsRead))
 
 			r.Delete("/{reference}/access-control/{link_reference}",
-				WrapMalakHTTPHandler(logger, dashHandler.revokeAccessControl, cfg, "dashboards.access-control.delete"))
+				WrapMalakHTTPHandler(logger, dashHandler.revokeAccessControl, cfg, "dashboards.access-control.delete",
+					malak.PermissionDashboardsWrite))
 		})
 
 		r.Route("/developers", func(r chi.Router) {
 			r.Use(requireAuthentication(logger, jwtTokenManager, cfg, userRepo, workspaceRepo))
 			r.Use(requireWorkspaceValidSubscription(cfg))
 
-			r.Post("/keys",
-				WrapMalakHTTPHandler(logger, apiHandler.create, cfg, "developers.keys.create"))
+			r.Group(func(r chi.Router) {
+				r.Use(requirePermission(cfg, malak.PermissionAPIKeysManage))
 
-			r.Get("/keys",
-				WrapMalakHTTPHandler(logger, apiHandler.list, cfg, "developers.keys.list"))
+				r.Post("/keys",
+					WrapMalakHTTPHandler(logger, apiHandler.create, cfg, "developers.keys.create"))
+
+				r.Get("/keys",
+					WrapMalakHTTPHandler(logger, apiHandler.list, cfg, "developers.keys.list"))
+
+				r.Delete("/keys/{reference}",
+					WrapMalakHTTPHandler(logger, apiHandler.revoke, cfg, "developers.keys.revoke"))
+			})
 
-			r.Delete("/keys/{reference}",
-				WrapMalakHTTPHandler(logger, apiHandler.revoke, cfg, "developers.keys.revoke"))
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Use(requirePermission(cfg, malak.PermissionWebhooksManage))
+
+				r.Post("/",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.create, cfg, "developers.webhooks.create"))
+
+				r.Get("/",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.list, cfg, "developers.webhooks.list"))
+
+				r.Patch("/{reference}",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.update, cfg, "developers.webhooks.update"))
+
+				r.Delete("/{reference}",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.delete, cfg, "developers.webhooks.delete"))
+
+				r.Get("/{reference}/deliveries",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.listDeliveries, cfg, "developers.webhooks.deliveries.list"))
+
+				r.Post("/{reference}/deliveries/{delivery_reference}/redeliver",
+					WrapMalakHTTPHandler(logger, webhookEndpointHandler.redeliver, cfg, "developers.webhooks.deliveries.redeliver"))
+			})
 		})
 
 		var images = []string{"image_body"}
@@ -574,14 +970,16 @@ func buildRoutes(
 				r.Use(deckUploadGulterHandler.Upload(images...))
 
 				r.Post("/",
-					WrapMalakHTTPHandler(logger, deckHandler.uploadImage, cfg, "decks.upload"))
+					WrapMalakHTTPHandler(logger, deckHandler.uploadImage, cfg, "decks.upload",
+						malak.PermissionDecksWrite))
 			})
 
 			r.Route("/images", func(r chi.Router) {
 				r.Use(imageUploadGulterHandler.Upload(images...))
 
 				r.Post("/",
-					WrapMalakHTTPHandler(logger, updateHandler.uploadImage, cfg, "updates.image_upload"))
+					WrapMalakHTTPHandler(logger, updateHandler.uploadImage, cfg, "updates.image_upload",
+						malak.PermissionUpdatesWrite))
 			})
 		})
 
@@ -596,6 +994,23 @@ func buildRoutes(
 
 			r.Get("/dashboards/{reference}/charts/{chart_reference}",
 				WrapMalakHTTPHandler(logger, dashHandler.publicChartingDataFetch, cfg, "public.charts.datapoints"))
+
+			r.Get("/updates/{reference}",
+				WrapMalakHTTPHandler(logger, updateHandler.publicUpdateDetails, cfg, "public.updates.fetch"))
+			r.Post("/updates/{reference}/comments",
+				WrapMalakHTTPHandler(logger, updateHandler.publicComment, cfg, "public.updates.comments.create"))
+			r.Post("/updates/{reference}/reactions",
+				WrapMalakHTTPHandler(logger, updateHandler.publicReaction, cfg, "public.updates.reactions.create"))
+
+			r.Post("/updates/links/{reference}",
+				WrapMalakHTTPHandler(logger, updateHandler.publicLinkDetails, cfg, "public.updates.links.fetch"))
+			r.Put("/updates/sessions",
+				WrapMalakHTTPHandler(logger, updateHandler.updateViewerSession, cfg, "public.updates.sessions.update"))
+
+			r.Get("/workspaces/{reference}/updates",
+				WrapMalakHTTPHandler(logger, updateHandler.listPublicUpdates, cfg, "public.workspaces.updates.list"))
+			r.Post("/workspaces/{reference}/updates/{update_reference}",
+				WrapMalakHTTPHandler(logger, updateHandler.publicWorkspaceUpdate, cfg, "public.workspaces.updates.fetch"))
 		})
 	})
 
@@ -28,7 +28,7 @@ func TestServer_New(t *testing.T) {
 		geoService := malak_mocks.NewMockGeolocationService(controller)
 
 		srv, closeFn := New(getLogger(t), cfg,
-			jwttoken.New(cfg), socialauth.NewGoogle(cfg),
+			jwttoken.New(cfg, malak_mocks.NewMockCache(controller)), socialauth.NewManager(),
 			malak_mocks.NewMockDashboardRepository(controller),
 			malak_mocks.NewMockUserRepository(controller),
 			malak_mocks.NewMockWorkspaceRepository(controller),
@@ -44,6 +44,16 @@ func TestServer_New(t *testing.T) {
 			malak_mocks.NewMockDashboardLinkRepository(controller),
 			malak_mocks.NewMockAPIKeyRepository(controller),
 			malak_mocks.NewMockEmailVerificationRepository(controller),
+			malak_mocks.NewMockTeamRepository(controller),
+			malak_mocks.NewMockPasswordResetRepository(controller),
+			malak_mocks.NewMockTwoFactorRepository(controller),
+			malak_mocks.NewMockAuditLogRepository(controller),
+			malak_mocks.NewMockWebhookRepository(controller),
+			malak_mocks.NewMockSuppressionRepository(controller),
+			malak_mocks.NewMockUpdateCommentRepository(controller),
+			malak_mocks.NewMockUpdateLinkRepository(controller),
+			malak_mocks.NewMockRecurringUpdateScheduleRepository(controller),
+			malak_mocks.NewMockSendingDomainRepository(controller),
 			&httplimit.Middleware{},
 			malak_mocks.NewMockQueueHandler(controller),
 			malak_mocks.NewMockCache(controller),
@@ -51,6 +61,8 @@ func TestServer_New(t *testing.T) {
 			integrations.NewManager(),
 			malak_mocks.NewMockSecretClient(controller),
 			geoService,
+			nil,
+			nil,
 			&gulter.Gulter{},
 			&gulter.Gulter{},
 			malak_mocks.NewMockFundraisingPipelineRepository(controller))
@@ -70,7 +82,7 @@ func TestServer_New(t *testing.T) {
 		geoService := malak_mocks.NewMockGeolocationService(controller)
 
 		srv, closeFn := New(getLogger(t), cfg,
-			jwttoken.New(cfg), socialauth.NewGoogle(cfg),
+			jwttoken.New(cfg, malak_mocks.NewMockCache(controller)), socialauth.NewManager(),
 			malak_mocks.NewMockDashboardRepository(controller),
 			malak_mocks.NewMockUserRepository(controller),
 			malak_mocks.NewMockWorkspaceRepository(controller),
@@ -86,6 +98,16 @@ func TestServer_New(t *testing.T) {
 			malak_mocks.NewMockDashboardLinkRepository(controller),
 			malak_mocks.NewMockAPIKeyRepository(controller),
 			malak_mocks.NewMockEmailVerificationRepository(controller),
+			malak_mocks.NewMockTeamRepository(controller),
+			malak_mocks.NewMockPasswordResetRepository(controller),
+			malak_mocks.NewMockTwoFactorRepository(controller),
+			malak_mocks.NewMockAuditLogRepository(controller),
+			malak_mocks.NewMockWebhookRepository(controller),
+			malak_mocks.NewMockSuppressionRepository(controller),
+			malak_mocks.NewMockUpdateCommentRepository(controller),
+			malak_mocks.NewMockUpdateLinkRepository(controller),
+			malak_mocks.NewMockRecurringUpdateScheduleRepository(controller),
+			malak_mocks.NewMockSendingDomainRepository(controller),
 			&httplimit.Middleware{},
 			malak_mocks.NewMockQueueHandler(controller),
 			malak_mocks.NewMockCache(controller),
@@ -93,6 +115,8 @@ func TestServer_New(t *testing.T) {
 			integrations.NewManager(),
 			malak_mocks.NewMockSecretClient(controller),
 			geoService,
+			nil,
+			nil,
 			&gulter.Gulter{},
 			&gulter.Gulter{},
 			malak_mocks.NewMockFundraisingPipelineRepository(controller))
@@ -127,7 +151,7 @@ func TestNew(t *testing.T) {
 
 	cfg := getConfig()
 
-	srv, closeFn := New(logger, cfg, jwttoken.New(cfg), socialauth.NewGoogle(cfg),
+	srv, closeFn := New(logger, cfg, jwttoken.New(cfg, malak_mocks.NewMockCache(controller)), socialauth.NewManager(),
 		malak_mocks.NewMockDashboardRepository(controller),
 		userRepo, workspaceRepo, planRepo, contactRepo, updateRepo,
 		contactListRepo, deckRepo, contactShareRepo, preferenceRepo,
@@ -136,9 +160,19 @@ func TestNew(t *testing.T) {
 		malak_mocks.NewMockDashboardLinkRepository(controller),
 		malak_mocks.NewMockAPIKeyRepository(controller),
 		malak_mocks.NewMockEmailVerificationRepository(controller),
+		malak_mocks.NewMockTeamRepository(controller),
+		malak_mocks.NewMockPasswordResetRepository(controller),
+		malak_mocks.NewMockTwoFactorRepository(controller),
+		malak_mocks.NewMockAuditLogRepository(controller),
+		malak_mocks.NewMockWebhookRepository(controller),
+		malak_mocks.NewMockSuppressionRepository(controller),
+		malak_mocks.NewMockUpdateCommentRepository(controller),
+		malak_mocks.NewMockUpdateLinkRepository(controller),
+		malak_mocks.NewMockRecurringUpdateScheduleRepository(controller),
+		malak_mocks.NewMockSendingDomainRepository(controller),
 		&httplimit.Middleware{},
 		queueRepo, cacheRepo, billingClient,
-		integrations.NewManager(), secretsClient, geoService,
+		integrations.NewManager(), secretsClient, geoService, nil, nil,
 		&gulter.Gulter{}, &gulter.Gulter{},
 		malak_mocks.NewMockFundraisingPipelineRepository(controller))
 
@@ -172,7 +206,7 @@ func TestNewWithInvalidConfig(t *testing.T) {
 	cfg := getConfig()
 	cfg.HTTP.Port = 0 // Invalid port
 
-	srv, closeFn := New(logger, cfg, jwttoken.New(cfg), socialauth.NewGoogle(cfg),
+	srv, closeFn := New(logger, cfg, jwttoken.New(cfg, malak_mocks.NewMockCache(controller)), socialauth.NewManager(),
 		malak_mocks.NewMockDashboardRepository(controller),
 		userRepo, workspaceRepo, planRepo, contactRepo, updateRepo,
 		contactListRepo, deckRepo, contactShareRepo, preferenceRepo,
@@ -181,9 +215,19 @@ func TestNewWithInvalidConfig(t *testing.T) {
 		malak_mocks.NewMockDashboardLinkRepository(controller),
 		malak_mocks.NewMockAPIKeyRepository(controller),
 		malak_mocks.NewMockEmailVerificationRepository(controller),
+		malak_mocks.NewMockTeamRepository(controller),
+		malak_mocks.NewMockPasswordResetRepository(controller),
+		malak_mocks.NewMockTwoFactorRepository(controller),
+		malak_mocks.NewMockAuditLogRepository(controller),
+		malak_mocks.NewMockWebhookRepository(controller),
+		malak_mocks.NewMockSuppressionRepository(controller),
+		malak_mocks.NewMockUpdateCommentRepository(controller),
+		malak_mocks.NewMockUpdateLinkRepository(controller),
+		malak_mocks.NewMockRecurringUpdateScheduleRepository(controller),
+		malak_mocks.NewMockSendingDomainRepository(controller),
 		&httplimit.Middleware{},
 		queueRepo, cacheRepo, billingClient,
-		integrations.NewManager(), secretsClient, geoService,
+		integrations.NewManager(), secretsClient, geoService, nil, nil,
 		&gulter.Gulter{}, &gulter.Gulter{},
 		malak_mocks.NewMockFundraisingPipelineRepository(controller))
 
@@ -18,8 +18,28 @@ import (
 	"github.com/ayinke-llc/malak/internal/pkg/util"
 )
 
+// collaborationPathSuffix is the path of the only websocket route. It is
+// the only route the token can be sent in the query for
+const collaborationPathSuffix = "/collaborate"
+
 func tokenFromRequest(r *http.Request) (string, error) {
 
+	// browsers cannot set headers when opening a websocket so the token
+	// is sent in the query instead. Tokens in urls end up in logs so this
+	// is limited to the collaboration route
+	if r.Header.Get("Authorization") == "" &&
+		r.Method == http.MethodGet &&
+		strings.HasSuffix(r.URL.Path, collaborationPathSuffix) &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			return "", errors.New("token not provided")
+		}
+
+		return token, nil
+	}
+
 	ss := strings.Split(r.Header.Get("Authorization"), " ")
 
 	if len(ss) != 2 {
@@ -34,6 +54,8 @@ type contextKey string
 const (
 	userCtx      contextKey = "user"
 	workspaceCtx contextKey = "workspace"
+	tokenCtx     contextKey = "token"
+	apiKeyCtx    contextKey = "api_key"
 )
 
 // HTTPThrottleKeyFunc throttles unauthenticated users by their IP.
@@ -115,6 +137,7 @@ func requireAPIKeyOnly(
 	cfg config.Config,
 	apiRepo malak.APIKeyRepository,
 	workspaceRepo malak.WorkspaceRepository,
+	userRepo malak.UserRepository,
 ) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -134,33 +157,127 @@ func requireAPIKeyOnly(
 				return
 			}
 
-			token = malak.HashKey(cfg.APIKey.HashSecret, token)
+			key, user, workspace, errResp := authenticateAPIKey(ctx, logger, cfg, apiRepo, workspaceRepo, userRepo, r, token)
+			if errResp != nil {
+				_ = render.Render(w, r, errResp)
+				return
+			}
 
-			// TODO: do we need to cache this instead of hitting the db?
-			// Maybe not, Maybe. but otel will tell us that
-			// for now, it is fine to leave as-is
-			key, err := apiRepo.FetchByValue(ctx, token)
-			if err != nil {
-				if errors.Is(err, malak.ErrAPIKeyNotFound) {
-					_ = render.Render(w, r, newAPIStatus(http.StatusUnauthorized, "token not found"))
-					return
-				}
+			r = r.WithContext(writeAPIKeyToCtx(writeWorkspaceToCtx(writeUserToCtx(r.Context(), user), workspace), key))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticateAPIKey loads the api key used to make the request, the user
+// that created it and the workspace it belongs to. A non nil renderer means
+// the request has to be rejected with it
+func authenticateAPIKey(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.Config,
+	apiRepo malak.APIKeyRepository,
+	workspaceRepo malak.WorkspaceRepository,
+	userRepo malak.UserRepository,
+	r *http.Request,
+	token string,
+) (*malak.APIKey, *malak.User, *malak.Workspace, render.Renderer) {
+
+	token = malak.HashKey(cfg.APIKey.HashSecret, token)
+
+	// TODO: do we need to cache this instead of hitting the db?
+	// Maybe not, Maybe. but otel will tell us that
+	// for now, it is fine to leave as-is
+	key, err := apiRepo.FetchByValue(ctx, token)
+	if err != nil {
+		if errors.Is(err, malak.ErrAPIKeyNotFound) {
+			return nil, nil, nil, newAPIStatus(http.StatusUnauthorized, "token not found")
+		}
+
+		logger.Error("error while fetching api key", zap.Error(err))
+		return nil, nil, nil, newAPIStatus(http.StatusInternalServerError, "error occurred while fetching api key")
+	}
+
+	if key.IsExpired() {
+		return nil, nil, nil, newAPIStatus(http.StatusUnauthorized, "api key has expired")
+	}
+
+	workspace, err := workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: key.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace from database", zap.Error(err))
+		return nil, nil, nil, newAPIStatus(http.StatusInternalServerError, "an error occurred while fetching workspace from database")
+	}
 
-				logger.Error("error while fetching api key", zap.Error(err))
-				_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "error occurred while fetching api key"))
+	ip := getIP(r)
+	now := time.Now()
+
+	if key.ShouldTrackUsage(ip, now) {
+		key.LastUsedAt = &now
+		key.LastUsedIP = ip
+
+		// not being able to track usage should not block the request
+		if err := apiRepo.UpdateLastUsed(ctx, key); err != nil {
+			logger.Error("could not update api key last used details", zap.Error(err))
+		}
+	}
+
+	user, err := userRepo.Get(ctx, &malak.FindUserOptions{
+		ID: key.CreatedBy,
+	})
+	if err != nil {
+		logger.Error("could not fetch api key owner from database", zap.Error(err))
+		return nil, nil, nil, newAPIStatus(http.StatusInternalServerError, "an error occurred while checking user")
+	}
+
+	return key, user, workspace, nil
+}
+
+// isJWT differentiates user sessions from api keys. API keys are opaque
+// random strings while JWTs always have three dot separated segments
+func isJWT(token string) bool { return strings.Count(token, ".") == 2 }
+
+// requireUserOrAPIKey authenticates the request with either a user session
+// or an api key. Requests made with an api key act on behalf of the user
+// that created it but are limited to the scopes granted to the key
+func requireUserOrAPIKey(
+	logger *zap.Logger,
+	jwtManager jwttoken.JWTokenManager,
+	cfg config.Config,
+	userRepo malak.UserRepository,
+	workspaceRepo malak.WorkspaceRepository,
+	apiRepo malak.APIKeyRepository,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+
+		userAuthentication := requireAuthentication(logger, jwtManager, cfg, userRepo, workspaceRepo)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			token, err := tokenFromRequest(r)
+			if err != nil || isJWT(token) {
+				userAuthentication.ServeHTTP(w, r)
 				return
 			}
 
-			workspace, err := workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
-				ID: key.WorkspaceID,
-			})
-			if err != nil {
-				logger.Error("could not fetch workspace from database", zap.Error(err))
-				_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "an error occurred while fetching workspace from database"))
+			ctx, span, rid := getTracer(r.Context(), r, "middleware.requireUserOrAPIKey", cfg.Otel.IsEnabled)
+			defer span.End()
+
+			logger := logger.With(
+				zap.String("request_id", rid),
+				zap.String("path", r.URL.Path),
+				zap.Bool("is_api", true),
+			)
+
+			key, user, workspace, errResp := authenticateAPIKey(ctx, logger, cfg, apiRepo, workspaceRepo, userRepo, r, token)
+			if errResp != nil {
+				_ = render.Render(w, r, errResp)
 				return
 			}
 
-			r = r.WithContext(writeWorkspaceToCtx(r.Context(), workspace))
+			r = r.WithContext(writeAPIKeyToCtx(writeWorkspaceToCtx(writeUserToCtx(r.Context(), user), workspace), key))
 
 			next.ServeHTTP(w, r)
 		})
@@ -203,6 +320,23 @@ func requireAuthentication(
 				return
 			}
 
+			// refresh tokens can only be exchanged for new tokens
+			if data.Purpose != jwttoken.PurposeAccess {
+				_ = render.Render(w, r, newAPIStatus(http.StatusUnauthorized, "could not validate JWT token"))
+				return
+			}
+
+			if err := jwtManager.ValidateSession(ctx, data); err != nil {
+				if errors.Is(err, jwttoken.ErrSessionNotFound) {
+					_ = render.Render(w, r, newAPIStatus(http.StatusUnauthorized, "session is expired"))
+					return
+				}
+
+				logger.Error("could not validate session", zap.Error(err))
+				_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "an error occurred while validating session"))
+				return
+			}
+
 			user, err := userRepo.Get(ctx, &malak.FindUserOptions{
 				ID: data.UserID,
 			})
@@ -212,10 +346,11 @@ func requireAuthentication(
 				return
 			}
 
-			r = r.WithContext(writeUserToCtx(ctx, user))
+			r = r.WithContext(writeTokenToCtx(writeUserToCtx(ctx, user), data))
 
-			// For auth/connect path, we don't need to check workspace
-			if strings.HasPrefix(r.URL.Path, "/v1/auth/connect") ||
+			// For auth paths, we don't need to check workspace
+			if strings.HasPrefix(r.URL.Path, "/v1/auth/") ||
+				strings.HasPrefix(r.URL.Path, "/v1/invites") ||
 				(r.URL.Path == "/v1/workspaces" && r.Method == http.MethodPost) {
 				// r.URL.Path == "/v1/user" {
 				next.ServeHTTP(w, r)
@@ -236,6 +371,16 @@ func requireAuthentication(
 				return
 			}
 
+			// members can still view their profile and switch to another
+			// workspace without 2FA
+			if workspace.Metadata.RequireTwoFactor && !user.IsTwoFactorEnabled() &&
+				!strings.HasPrefix(r.URL.Path, "/v1/user") &&
+				!strings.HasPrefix(r.URL.Path, "/v1/workspaces/switch/") {
+				_ = render.Render(w, r, newAPIStatus(http.StatusForbidden,
+					"this workspace requires two factor authentication. Please enable it on your account to continue"))
+				return
+			}
+
 			r = r.WithContext(writeWorkspaceToCtx(r.Context(), workspace))
 
 			next.ServeHTTP(w, r)
@@ -243,6 +388,57 @@ func requireAuthentication(
 	}
 }
 
+// requirePermission rejects requests from users whose role in the current
+// workspace does not allow every one of the provided permissions
+func requirePermission(
+	cfg config.Config,
+	permissions ...malak.Permission,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			ctx, span, _ := getTracer(r.Context(), r, "middleware.requirePermission", cfg.Otel.IsEnabled)
+			defer span.End()
+
+			if !hasPermission(ctx, permissions...) {
+				_ = render.Render(w, r, newAPIStatus(http.StatusForbidden,
+					"you do not have permission to perform this action"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasPermission(ctx context.Context, permissions ...malak.Permission) bool {
+	// api keys can only reach routes that declare the permissions they need
+	// and must have been granted every one of them. They act on behalf of
+	// the user that created them so they also cannot do more than the
+	// current role of that user allows
+	if doesAPIKeyExistInContext(ctx) {
+		if len(permissions) == 0 || !getAPIKeyFromContext(ctx).Can(permissions...) {
+			return false
+		}
+
+		if !doesUserExistInContext(ctx) || !doesWorkspaceExistInContext(ctx) {
+			return false
+		}
+
+		return getUserFromContext(ctx).Can(getWorkspaceFromContext(ctx).ID, permissions...)
+	}
+
+	if len(permissions) == 0 {
+		return true
+	}
+
+	if !doesUserExistInContext(ctx) || !doesWorkspaceExistInContext(ctx) {
+		return false
+	}
+
+	return getUserFromContext(ctx).Can(getWorkspaceFromContext(ctx).ID, permissions...)
+}
+
 func writeRequestIDHeader(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-Request-ID", retrieveRequestID(r))
@@ -256,6 +452,14 @@ func writeUserToCtx(ctx context.Context, user *malak.User) context.Context {
 	return context.WithValue(ctx, userCtx, user)
 }
 
+func writeTokenToCtx(ctx context.Context, data jwttoken.JWTokenData) context.Context {
+	return context.WithValue(ctx, tokenCtx, data)
+}
+
+func getTokenFromContext(ctx context.Context) jwttoken.JWTokenData {
+	return ctx.Value(tokenCtx).(jwttoken.JWTokenData)
+}
+
 func writeWorkspaceToCtx(ctx context.Context, workspace *malak.Workspace) context.Context {
 	return context.WithValue(ctx, workspaceCtx, workspace)
 }
@@ -278,6 +482,19 @@ func getUserFromContext(ctx context.Context) *malak.User {
 	return ctx.Value(userCtx).(*malak.User)
 }
 
+func writeAPIKeyToCtx(ctx context.Context, key *malak.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyCtx, key)
+}
+
+func doesAPIKeyExistInContext(ctx context.Context) bool {
+	_, ok := ctx.Value(apiKeyCtx).(*malak.APIKey)
+	return ok
+}
+
+func getAPIKeyFromContext(ctx context.Context) *malak.APIKey {
+	return ctx.Value(apiKeyCtx).(*malak.APIKey)
+}
+
 func jsonResponse(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
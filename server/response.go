@@ -47,6 +47,35 @@ type createdUserResponse struct {
 	Workspaces       []malak.Workspace `json:"workspaces,omitempty" validate:"required"`
 	CurrentWorkspace *malak.Workspace  `json:"current_workspace,omitempty" validate:"optional"`
 	Token            string            `json:"token,omitempty" validate:"required"`
+	RefreshToken     string            `json:"refresh_token,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listSocialAuthProvidersResponse struct {
+	Providers []string `json:"providers,omitempty" validate:"required"`
+	APIStatus
+}
+
+type refreshTokenResponse struct {
+	Token        string `json:"token,omitempty" validate:"required"`
+	RefreshToken string `json:"refresh_token,omitempty" validate:"required"`
+	APIStatus
+}
+
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty" validate:"required"`
+	ChallengeToken    string `json:"challenge_token,omitempty" validate:"required"`
+	APIStatus
+}
+
+type setupTwoFactorResponse struct {
+	Secret          string `json:"secret,omitempty" validate:"required"`
+	ProvisioningURI string `json:"provisioning_uri,omitempty" validate:"required"`
+	APIStatus
+}
+
+type twoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes,omitempty" validate:"required"`
 	APIStatus
 }
 
@@ -128,6 +157,7 @@ type fetchUpdateAnalyticsResponse struct {
 	APIStatus
 	Update     malak.UpdateStat        `json:"update,omitempty" validate:"required"`
 	Recipients []malak.UpdateRecipient `json:"recipients,omitempty" validate:"required"`
+	Links      []malak.UpdateLinkStat  `json:"links,omitempty" validate:"required"`
 }
 
 type fetchPublicDeckResponse struct {
@@ -173,8 +203,8 @@ type fetchDashboardResponse struct {
 
 type fetchTemplatesResponse struct {
 	Templates struct {
-		System    []malak.SystemTemplate `json:"system,omitempty" validate:"required"`
-		Workspace []malak.SystemTemplate `json:"workspace,omitempty" validate:"required"`
+		System    []malak.SystemTemplate    `json:"system,omitempty" validate:"required"`
+		Workspace []malak.WorkspaceTemplate `json:"workspace,omitempty" validate:"required"`
 	} `json:"templates,omitempty" validate:"required"`
 	APIStatus
 }
@@ -201,6 +231,40 @@ type listAPIKeysResponse struct {
 	APIStatus
 }
 
+type listAuditLogsResponse struct {
+	AuditLogs []malak.AuditLog `json:"audit_logs,omitempty" validate:"required"`
+	Meta      meta             `json:"meta,omitempty" validate:"required"`
+	APIStatus
+}
+
+type createdWebhookResponse struct {
+	Webhook malak.Webhook `json:"webhook,omitempty" validate:"required"`
+	// Secret is only returned when the webhook is created
+	Secret string `json:"secret,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchWebhookResponse struct {
+	Webhook malak.Webhook `json:"webhook,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listWebhooksResponse struct {
+	Webhooks []malak.Webhook `json:"webhooks,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listWebhookDeliveriesResponse struct {
+	Deliveries []malak.WebhookDelivery `json:"deliveries,omitempty" validate:"required"`
+	Meta       meta                    `json:"meta,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchWebhookDeliveryResponse struct {
+	Delivery malak.WebhookDelivery `json:"delivery,omitempty" validate:"required"`
+	APIStatus
+}
+
 type createdAPIKeyResponse struct {
 	APIStatus
 	Value string `json:"value,omitempty" validate:"required"`
@@ -228,3 +292,115 @@ type fetchBoardResponse struct {
 	Positions []malak.FundraiseContactPosition  `json:"positions,omitempty" validate:"required"`
 	APIStatus
 }
+
+type listTeamMembersResponse struct {
+	Members []malak.UserRole `json:"members,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchTeamMemberResponse struct {
+	Member malak.UserRole `json:"member,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listSuppressionsResponse struct {
+	Suppressions []malak.Suppression `json:"suppressions,omitempty" validate:"required"`
+	Meta         meta                `json:"meta,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchPublicUpdateResponse struct {
+	Update   malak.Update                `json:"update,omitempty" validate:"required"`
+	Reaction malak.ReactionStatus        `json:"reaction,omitempty" validate:"optional"`
+	Threads  []malak.UpdateCommentThread `json:"threads" validate:"required"`
+	APIStatus
+}
+
+type listUpdateCommentsResponse struct {
+	Threads []malak.UpdateCommentThread `json:"threads" validate:"required"`
+	APIStatus
+}
+
+type fetchUpdateCommentResponse struct {
+	Comment malak.UpdateComment `json:"comment,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchUpdateLinkResponse struct {
+	Link malak.UpdateLink `json:"link,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listUpdateLinksResponse struct {
+	Links []malak.UpdateLink `json:"links" validate:"required"`
+	APIStatus
+}
+
+type fetchUpdateScheduleResponse struct {
+	Schedule malak.UpdateSchedule `json:"schedule,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listUpdateSchedulesResponse struct {
+	Schedules []malak.UpdateSchedule `json:"schedules" validate:"required"`
+	APIStatus
+}
+
+type listUpdateRevisionsResponse struct {
+	Revisions []malak.UpdateRevision `json:"revisions,omitempty" validate:"required"`
+	APIStatus
+}
+
+type diffUpdateRevisionsResponse struct {
+	Diff []malak.BlockDiff `json:"diff,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchUpdateApprovalResponse struct {
+	Approval malak.UpdateApproval `json:"approval,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listUpdateApprovalsResponse struct {
+	Approvals []malak.UpdateApproval `json:"approvals" validate:"required"`
+	APIStatus
+}
+
+type fetchWorkspaceTemplateResponse struct {
+	Template malak.WorkspaceTemplate `json:"template,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchRecurringScheduleResponse struct {
+	Schedule malak.RecurringUpdateSchedule `json:"schedule,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listRecurringSchedulesResponse struct {
+	Schedules []malak.RecurringUpdateSchedule `json:"schedules" validate:"required"`
+	APIStatus
+}
+
+type listUpdateViewerSessionsResponse struct {
+	Sessions []malak.UpdateViewerSession `json:"sessions" validate:"required"`
+	Meta     meta                        `json:"meta,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchPublicUpdateLinkResponse struct {
+	Update    malak.PublicUpdate `json:"update,omitempty" validate:"required"`
+	SessionID malak.Reference    `json:"session_id,omitempty" validate:"required"`
+	APIStatus
+}
+
+type listPublicUpdatesResponse struct {
+	Updates []malak.PublicUpdate `json:"updates" validate:"required"`
+	Meta    meta                 `json:"meta,omitempty" validate:"required"`
+	APIStatus
+}
+
+type fetchSendingDomainResponse struct {
+	Domain  malak.SendingDomain `json:"domain,omitempty" validate:"required"`
+	Records []malak.DNSRecord   `json:"records" validate:"required"`
+	APIStatus
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// @Description list contacts that will not receive updates because they bounced, complained or unsubscribed
+// @Tags contacts
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Page to query data from. Defaults to 1"
+// @Param per_page query int false "Number to items to return. Defaults to 10 items"
+// @Success 200 {object} listSuppressionsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /contacts/suppressions [get]
+func (c *contactHandler) listSuppressions(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("Listing suppressed contacts")
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	opts := malak.ListSuppressionOptions{
+		Paginator:   malak.PaginatorFromRequest(r),
+		WorkspaceID: workspace.ID,
+	}
+
+	span.SetAttributes(opts.Paginator.OTELAttributes()...)
+
+	suppressions, totalCount, err := c.suppressionRepo.List(ctx, opts)
+	if err != nil {
+		logger.Error("could not list suppressed contacts",
+			zap.Error(err))
+
+		return newAPIStatus(
+			http.StatusInternalServerError,
+			"could not list suppressed contacts"), StatusFailed
+	}
+
+	return listSuppressionsResponse{
+		APIStatus:    newAPIStatus(http.StatusOK, "suppressed contacts listed successfully"),
+		Suppressions: suppressions,
+		Meta: meta{
+			Paging: pagingInfo{
+				PerPage: opts.Paginator.PerPage,
+				Page:    opts.Paginator.Page,
+				Total:   totalCount,
+			},
+		},
+	}, StatusSuccess
+}
+
+// @Description remove a contact from the suppression list so they can receive updates again
+// @Tags contacts
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "suppression unique reference.. e.g suppression_"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /contacts/suppressions/{reference} [delete]
+func (c *contactHandler) deleteSuppression(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+
+	span.SetAttributes(attribute.String("reference", ref))
+
+	logger = logger.With(zap.String("reference", ref))
+
+	logger.Debug("Removing contact from suppression list")
+
+	suppression, err := c.suppressionRepo.Get(ctx, malak.FetchSuppressionOptions{
+		WorkspaceID: getWorkspaceFromContext(r.Context()).ID,
+		Reference:   malak.Reference(ref),
+	})
+	if err != nil {
+		var msg = "could not fetch suppressed contact"
+		var status = http.StatusInternalServerError
+
+		if errors.Is(err, malak.ErrSuppressionNotFound) {
+			msg = err.Error()
+			status = http.StatusNotFound
+		} else {
+			logger.Error("could not fetch suppression", zap.Error(err))
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	if err := c.suppressionRepo.Delete(ctx, suppression); err != nil {
+		logger.Error("could not delete suppression", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not remove contact from suppression list"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionSuppressionDeleted,
+		malak.EntityTypeSuppression, suppression.Reference.String())
+	entry.Metadata["reason"] = suppression.Reason.String()
+	entry.Metadata["contact_id"] = suppression.ContactID.String()
+
+	recordAuditLog(ctx, logger, c.auditLogRepo, entry)
+
+	return newAPIStatus(http.StatusOK, "contact can now receive updates"), StatusSuccess
+}
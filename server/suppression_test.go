@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func generateListSuppressionsTestTable() []struct {
+	name               string
+	mockFn             func(suppressionRepo *malak_mocks.MockSuppressionRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(suppressionRepo *malak_mocks.MockSuppressionRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "could not list suppressions",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().List(gomock.Any(), gomock.Any()).
+					Return(nil, int64(0), errors.New("unknown error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed suppressions",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().List(gomock.Any(), gomock.Any()).
+					Return([]malak.Suppression{
+						{
+							ID:        uuid.MustParse("0b5d6a2c-8b38-4f6d-8a2b-2a9f57a1c4f1"),
+							ContactID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+							Reference: "suppression_test",
+							Reason:    malak.SuppressionReasonUnsubscribed,
+						},
+					}, int64(1), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestContactHandler_ListSuppressions(t *testing.T) {
+	for _, v := range generateListSuppressionsTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+			v.mockFn(suppressionRepo)
+
+			a := &contactHandler{
+				cfg:             getConfig(),
+				suppressionRepo: suppressionRepo,
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/contacts/suppressions", nil)
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			WrapMalakHTTPHandler(getLogger(t), a.listSuppressions, getConfig(), "contacts.suppressions.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateDeleteSuppressionTestTable() []struct {
+	name               string
+	mockFn             func(suppressionRepo *malak_mocks.MockSuppressionRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(suppressionRepo *malak_mocks.MockSuppressionRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "suppression not found",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrSuppressionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not fetch suppression",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("unknown error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "could not delete suppression",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.Suppression{Reference: "suppression_test"}, nil)
+
+				suppressionRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).
+					Return(errors.New("unknown error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "deleted suppression",
+			mockFn: func(suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				suppressionRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.Suppression{Reference: "suppression_test"}, nil)
+
+				suppressionRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestContactHandler_DeleteSuppression(t *testing.T) {
+	for _, v := range generateDeleteSuppressionTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+			v.mockFn(suppressionRepo)
+
+			a := &contactHandler{
+				cfg:             getConfig(),
+				suppressionRepo: suppressionRepo,
+				auditLogRepo:    newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/contacts/suppressions/suppression_test", nil)
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "suppression_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), a.deleteSuppression, getConfig(), "contacts.suppressions.delete").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -0,0 +1,504 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+)
+
+type teamHandler struct {
+	cfg                config.Config
+	teamRepo           malak.TeamRepository
+	userRepo           malak.UserRepository
+	queue              queue.QueueHandler
+	referenceGenerator malak.ReferenceGeneratorOperation
+	auditLogRepo       malak.AuditLogRepository
+}
+
+type inviteTeamMemberRequest struct {
+	GenericRequest
+
+	Email malak.Email `json:"email,omitempty" validate:"required"`
+	Role  malak.Role  `json:"role,omitempty" validate:"required"`
+}
+
+func (i *inviteTeamMemberRequest) Validate() error {
+	i.Email = malak.Email(strings.TrimSpace(i.Email.String()))
+
+	if hermes.IsStringEmpty(i.Email.String()) {
+		return errors.New("please provide the email of the team member")
+	}
+
+	_, err := mail.ParseAddress(i.Email.String())
+	if err != nil {
+		return errors.New("please provide a valid email address")
+	}
+
+	if !i.Role.IsValid() {
+		return errors.New("please provide a valid role")
+	}
+
+	return nil
+}
+
+// @Description invite a new team member to the workspace
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Param message body inviteTeamMemberRequest true "invite request body"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/members/invite [post]
+func (t *teamHandler) invite(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("inviting team member")
+
+	req := new(inviteTeamMemberRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user := getUserFromContext(r.Context())
+	workspace := getWorkspaceFromContext(r.Context())
+
+	existingUser, err := t.userRepo.Get(ctx, &malak.FindUserOptions{
+		Email: req.Email,
+	})
+	if err != nil && !errors.Is(err, malak.ErrUserNotFound) {
+		logger.Error("could not check if user exists", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while checking user"), StatusFailed
+	}
+
+	if err == nil && existingUser.CanAccessWorkspace(workspace.ID) {
+		return newAPIStatus(http.StatusBadRequest,
+			malak.ErrTeamMemberExists.Error()), StatusFailed
+	}
+
+	invite, err := malak.NewTeamInvite(workspace, user, req.Email, req.Role,
+		t.referenceGenerator.Generate(malak.EntityTypeInvite))
+	if err != nil {
+		logger.Error("could not generate invite", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not generate invite"), StatusFailed
+	}
+
+	if err := t.teamRepo.CreateInvite(ctx, &malak.CreateTeamInviteOptions{
+		Invite: invite,
+		Plan:   workspace.Plan,
+	}); err != nil {
+		logger.Error("could not create invite", zap.Error(err))
+
+		var status = http.StatusInternalServerError
+		var msg = "could not create invite"
+
+		if errors.Is(err, malak.ErrTeamSeatsExhausted) {
+			status = http.StatusPaymentRequired
+			msg = err.Error()
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	if err := t.queue.Add(ctx, queue.QueueTopicInviteTeamMember,
+		queue.InviteTeamMemberOptions{
+			Workspace:   workspace,
+			Token:       invite.Token,
+			Role:        invite.Role,
+			Recipient:   invite.Email,
+			InviterName: user.FullName,
+		}); err != nil {
+		logger.Error("could not queue invite email", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not send invite email"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionTeamMemberInvited,
+		malak.EntityTypeInvite, invite.Reference.String())
+	entry.Metadata["email"] = invite.Email.String()
+	entry.Metadata["role"] = invite.Role.String()
+
+	recordAuditLog(ctx, logger, t.auditLogRepo, entry)
+
+	return newAPIStatus(http.StatusOK, "team member invited"), StatusSuccess
+}
+
+// @Description list the members of the workspace
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} listTeamMembersResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/members [get]
+func (t *teamHandler) list(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing team members")
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	members, err := t.teamRepo.ListMembers(ctx, workspace.ID)
+	if err != nil {
+		logger.Error("could not list team members", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list team members"), StatusFailed
+	}
+
+	return listTeamMembersResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "team members fetched"),
+		Members:   members,
+	}, StatusSuccess
+}
+
+type updateTeamMemberRoleRequest struct {
+	GenericRequest
+
+	Role malak.Role `json:"role,omitempty" validate:"required"`
+}
+
+func (u *updateTeamMemberRoleRequest) Validate() error {
+	if !u.Role.IsValid() {
+		return errors.New("please provide a valid role")
+	}
+
+	return nil
+}
+
+// @Description change the role of a team member
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Param user_id path string required "user id of the team member"
+// @Param message body updateTeamMemberRoleRequest true "role request body"
+// @Success 200 {object} fetchTeamMemberResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/members/{user_id} [put]
+func (t *teamHandler) updateRole(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("updating team member role")
+
+	req := new(updateTeamMemberRoleRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	member, resp, status := t.fetchMember(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if member.Role == malak.RoleAdmin && req.Role != malak.RoleAdmin {
+		if resp, status := t.ensureAnotherAdmin(ctx, logger, member); status == StatusFailed {
+			return resp, status
+		}
+	}
+
+	previousRole := member.Role
+
+	member.Role = req.Role
+
+	if err := t.teamRepo.UpdateMemberRole(ctx, member); err != nil {
+		logger.Error("could not update team member role", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not update team member role"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionTeamMemberRoleUpdated,
+		malak.EntityTypeTeam, member.UserID.String())
+	entry.Metadata["previous_role"] = previousRole.String()
+	entry.Metadata["role"] = member.Role.String()
+
+	recordAuditLog(ctx, logger, t.auditLogRepo, entry)
+
+	return fetchTeamMemberResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "team member role updated"),
+		Member:    *member,
+	}, StatusSuccess
+}
+
+// @Description remove a team member from the workspace
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Param user_id path string required "user id of the team member"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/members/{user_id} [delete]
+func (t *teamHandler) remove(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("removing team member")
+
+	member, resp, status := t.fetchMember(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if member.Role == malak.RoleAdmin {
+		if resp, status := t.ensureAnotherAdmin(ctx, logger, member); status == StatusFailed {
+			return resp, status
+		}
+	}
+
+	if err := t.teamRepo.RemoveMember(ctx, member); err != nil {
+		logger.Error("could not remove team member", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not remove team member"), StatusFailed
+	}
+
+	recordAuditLog(ctx, logger, t.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionTeamMemberRemoved,
+			malak.EntityTypeTeam, member.UserID.String()))
+
+	return newAPIStatus(http.StatusOK, "team member removed"), StatusSuccess
+}
+
+func (t *teamHandler) fetchMember(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.UserRole, render.Renderer, Status) {
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, newAPIStatus(http.StatusBadRequest, "please provide a valid user id"), StatusFailed
+	}
+
+	member, err := t.teamRepo.GetMember(ctx, malak.FetchTeamMemberOptions{
+		WorkspaceID: workspace.ID,
+		UserID:      userID,
+	})
+	if err != nil {
+		var status = http.StatusInternalServerError
+		var msg = "could not fetch team member"
+
+		if errors.Is(err, malak.ErrTeamMemberNotFound) {
+			status = http.StatusNotFound
+			msg = err.Error()
+		} else {
+			logger.Error("could not fetch team member", zap.Error(err))
+		}
+
+		return nil, newAPIStatus(status, msg), StatusFailed
+	}
+
+	return member, nil, StatusSuccess
+}
+
+// ensureAnotherAdmin makes sure a workspace is never left without an admin
+func (t *teamHandler) ensureAnotherAdmin(ctx context.Context,
+	logger *zap.Logger, member *malak.UserRole) (render.Renderer, Status) {
+
+	members, err := t.teamRepo.ListMembers(ctx, member.WorkspaceID)
+	if err != nil {
+		logger.Error("could not list team members", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list team members"), StatusFailed
+	}
+
+	for _, v := range members {
+		if v.Role == malak.RoleAdmin && v.UserID != member.UserID {
+			return nil, StatusSuccess
+		}
+	}
+
+	return newAPIStatus(http.StatusBadRequest,
+		"workspace must have at least one admin"), StatusFailed
+}
+
+type teamInviteRequest struct {
+	GenericRequest
+
+	Token string `json:"token,omitempty" validate:"required"`
+}
+
+func (t *teamInviteRequest) Validate() error {
+	if hermes.IsStringEmpty(t.Token) {
+		return errors.New("please provide the invite token")
+	}
+
+	return nil
+}
+
+// @Description accept an invite to join a workspace
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Param message body teamInviteRequest true "invite token"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /invites/accept [post]
+func (t *teamHandler) acceptInvite(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("accepting team invite")
+
+	user := getUserFromContext(r.Context())
+
+	invite, resp, status := t.fetchInvite(ctx, logger, r, user)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if err := t.teamRepo.AcceptInvite(ctx, &malak.AcceptTeamInviteOptions{
+		Invite: invite,
+		User:   user,
+	}); err != nil {
+		var status = http.StatusInternalServerError
+		var msg = "could not accept invite"
+
+		if errors.Is(err, malak.ErrTeamMemberExists) {
+			status = http.StatusBadRequest
+			msg = err.Error()
+		} else {
+			logger.Error("could not accept invite", zap.Error(err))
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "invite accepted"), StatusSuccess
+}
+
+// @Description decline an invite to join a workspace
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Param message body teamInviteRequest true "invite token"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /invites/decline [post]
+func (t *teamHandler) declineInvite(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("declining team invite")
+
+	user := getUserFromContext(r.Context())
+
+	invite, resp, status := t.fetchInvite(ctx, logger, r, user)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if err := t.teamRepo.DeclineInvite(ctx, invite); err != nil {
+		logger.Error("could not decline invite", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not decline invite"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "invite declined"), StatusSuccess
+}
+
+func (t *teamHandler) fetchInvite(ctx context.Context,
+	logger *zap.Logger, r *http.Request, user *malak.User) (*malak.TeamInvite, render.Renderer, Status) {
+
+	req := new(teamInviteRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return nil, newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	invite, err := t.teamRepo.GetInvite(ctx, malak.FetchTeamInviteOptions{
+		Token: req.Token,
+	})
+	if err != nil {
+		var status = http.StatusInternalServerError
+		var msg = "could not fetch invite"
+
+		if errors.Is(err, malak.ErrTeamInviteNotFound) {
+			status = http.StatusNotFound
+			msg = err.Error()
+		} else {
+			logger.Error("could not fetch invite", zap.Error(err))
+		}
+
+		return nil, newAPIStatus(status, msg), StatusFailed
+	}
+
+	// invites can only be used by the account they were sent to
+	if invite.Email.String() != user.Email.String() {
+		return nil, newAPIStatus(http.StatusNotFound,
+			malak.ErrTeamInviteNotFound.Error()), StatusFailed
+	}
+
+	if invite.IsExpired() {
+		return nil, newAPIStatus(http.StatusBadRequest,
+			malak.ErrTeamInviteExpired.Error()), StatusFailed
+	}
+
+	return invite, nil, StatusSuccess
+}
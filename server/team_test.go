@@ -0,0 +1,670 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	teamTestWorkspaceID = uuid.MustParse("1b4e28ba-2fa1-11d2-883f-0016d3cca427")
+	teamTestUserID      = uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+)
+
+func generateInviteTeamMemberTestTable() []struct {
+	name               string
+	mockFn             func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler)
+	req                inviteTeamMemberRequest
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler)
+		req                inviteTeamMemberRequest
+		expectedStatusCode int
+	}{
+		{
+			name: "invalid email",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+			},
+			req: inviteTeamMemberRequest{
+				Email: "oops",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid role",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+			},
+			req: inviteTeamMemberRequest{
+				Email: "lanre@test.com",
+				Role:  "owner",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "user already a member",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.User{
+						Roles: malak.UserRoles{
+							{WorkspaceID: teamTestWorkspaceID},
+						},
+					}, nil)
+			},
+			req: inviteTeamMemberRequest{
+				Email: "lanre@test.com",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "seats exhausted",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrUserNotFound)
+
+				teamRepo.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(malak.ErrTeamSeatsExhausted)
+			},
+			req: inviteTeamMemberRequest{
+				Email: "lanre@test.com",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusPaymentRequired,
+		},
+		{
+			name: "could not queue email",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrUserNotFound)
+
+				teamRepo.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				queue.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not add to queue"))
+			},
+			req: inviteTeamMemberRequest{
+				Email: "lanre@test.com",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "invited successfully",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.User{}, nil)
+
+				teamRepo.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				queue.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			req: inviteTeamMemberRequest{
+				Email: "lanre@test.com",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invited with a mixed case email",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository, userRepo *malak_mocks.MockUserRepository, queue *malak_mocks.MockQueueHandler) {
+				userRepo.EXPECT().Get(gomock.Any(), &malak.FindUserOptions{
+					Email: "lanre@test.com",
+				}).
+					Times(1).
+					Return(nil, malak.ErrUserNotFound)
+
+				teamRepo.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, opts *malak.CreateTeamInviteOptions) error {
+						if string(opts.Invite.Email) != "lanre@test.com" {
+							return errors.New("invite email was not normalized")
+						}
+
+						return nil
+					})
+
+				queue.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			req: inviteTeamMemberRequest{
+				Email: " Lanre@Test.com ",
+				Role:  malak.RoleMember,
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestTeamHandler_Invite(t *testing.T) {
+	for _, v := range generateInviteTeamMemberTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			teamRepo := malak_mocks.NewMockTeamRepository(controller)
+			userRepo := malak_mocks.NewMockUserRepository(controller)
+			queue := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(teamRepo, userRepo, queue)
+
+			h := &teamHandler{
+				auditLogRepo:       newMockAuditLogRepository(controller),
+				cfg:                getConfig(),
+				teamRepo:           teamRepo,
+				userRepo:           userRepo,
+				queue:              queue,
+				referenceGenerator: &mockReferenceGenerator{},
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{ID: teamTestUserID}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{
+				ID:   teamTestWorkspaceID,
+				Plan: &malak.Plan{},
+			}))
+
+			WrapMalakHTTPHandler(getLogger(t), h.invite, getConfig(), "workspaces.members.invite").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateListTeamMembersTestTable() []struct {
+	name               string
+	mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "error listing members",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().ListMembers(gomock.Any(), teamTestWorkspaceID).
+					Times(1).
+					Return(nil, errors.New("could not list members"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed members",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().ListMembers(gomock.Any(), teamTestWorkspaceID).
+					Times(1).
+					Return([]malak.UserRole{
+						{
+							Role:        malak.RoleAdmin,
+							WorkspaceID: teamTestWorkspaceID,
+							UserID:      teamTestUserID,
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestTeamHandler_List(t *testing.T) {
+	for _, v := range generateListTeamMembersTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			teamRepo := malak_mocks.NewMockTeamRepository(controller)
+
+			v.mockFn(teamRepo)
+
+			h := &teamHandler{
+				cfg:      getConfig(),
+				teamRepo: teamRepo,
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{ID: teamTestUserID}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{ID: teamTestWorkspaceID}))
+
+			WrapMalakHTTPHandler(getLogger(t), h.list, getConfig(), "workspaces.members.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateTeamMemberRoleTestTable() []struct {
+	name               string
+	mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+	userID             string
+	req                updateTeamMemberRoleRequest
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+		userID             string
+		req                updateTeamMemberRoleRequest
+		expectedStatusCode int
+	}{
+		{
+			name: "invalid role",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+			},
+			userID:             teamTestUserID.String(),
+			req:                updateTeamMemberRoleRequest{Role: "owner"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid user id",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+			},
+			userID:             "oops",
+			req:                updateTeamMemberRoleRequest{Role: malak.RoleGuest},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "member not found",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrTeamMemberNotFound)
+			},
+			userID:             teamTestUserID.String(),
+			req:                updateTeamMemberRoleRequest{Role: malak.RoleGuest},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "cannot demote the only admin",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UserRole{
+						Role:        malak.RoleAdmin,
+						WorkspaceID: teamTestWorkspaceID,
+						UserID:      teamTestUserID,
+					}, nil)
+
+				teamRepo.EXPECT().ListMembers(gomock.Any(), teamTestWorkspaceID).
+					Times(1).
+					Return([]malak.UserRole{
+						{
+							Role:        malak.RoleAdmin,
+							WorkspaceID: teamTestWorkspaceID,
+							UserID:      teamTestUserID,
+						},
+						{
+							Role:        malak.RoleMember,
+							WorkspaceID: teamTestWorkspaceID,
+							UserID:      uuid.New(),
+						},
+					}, nil)
+			},
+			userID:             teamTestUserID.String(),
+			req:                updateTeamMemberRoleRequest{Role: malak.RoleMember},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "role updated",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UserRole{
+						Role:        malak.RoleMember,
+						WorkspaceID: teamTestWorkspaceID,
+						UserID:      teamTestUserID,
+					}, nil)
+
+				teamRepo.EXPECT().UpdateMemberRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			userID:             teamTestUserID.String(),
+			req:                updateTeamMemberRoleRequest{Role: malak.RoleInvestor},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestTeamHandler_UpdateRole(t *testing.T) {
+	for _, v := range generateUpdateTeamMemberRoleTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			teamRepo := malak_mocks.NewMockTeamRepository(controller)
+
+			v.mockFn(teamRepo)
+
+			h := &teamHandler{
+				auditLogRepo: newMockAuditLogRepository(controller),
+				cfg:          getConfig(),
+				teamRepo:     teamRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{ID: teamTestUserID}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{ID: teamTestWorkspaceID}))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("user_id", v.userID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			WrapMalakHTTPHandler(getLogger(t), h.updateRole, getConfig(), "workspaces.members.update_role").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateRemoveTeamMemberTestTable() []struct {
+	name               string
+	mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "member not found",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrTeamMemberNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not remove member",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UserRole{Role: malak.RoleMember}, nil)
+
+				teamRepo.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not remove"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "removed admin with another admin left",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UserRole{
+						Role:        malak.RoleAdmin,
+						WorkspaceID: teamTestWorkspaceID,
+						UserID:      teamTestUserID,
+					}, nil)
+
+				teamRepo.EXPECT().ListMembers(gomock.Any(), teamTestWorkspaceID).
+					Times(1).
+					Return([]malak.UserRole{
+						{Role: malak.RoleAdmin, UserID: teamTestUserID},
+						{Role: malak.RoleAdmin, UserID: uuid.New()},
+					}, nil)
+
+				teamRepo.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestTeamHandler_Remove(t *testing.T) {
+	for _, v := range generateRemoveTeamMemberTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			teamRepo := malak_mocks.NewMockTeamRepository(controller)
+
+			v.mockFn(teamRepo)
+
+			h := &teamHandler{
+				auditLogRepo: newMockAuditLogRepository(controller),
+				cfg:          getConfig(),
+				teamRepo:     teamRepo,
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{ID: teamTestUserID}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{ID: teamTestWorkspaceID}))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("user_id", teamTestUserID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			WrapMalakHTTPHandler(getLogger(t), h.remove, getConfig(), "workspaces.members.remove").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateAcceptTeamInviteTestTable() []struct {
+	name               string
+	mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+	req                teamInviteRequest
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(teamRepo *malak_mocks.MockTeamRepository)
+		req                teamInviteRequest
+		expectedStatusCode int
+	}{
+		{
+			name: "no token provided",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invite not found",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrTeamInviteNotFound)
+			},
+			req:                teamInviteRequest{Token: "token"},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "invite sent to another email",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.TeamInvite{
+						Email:     "someone@test.com",
+						ExpiresAt: time.Now().Add(time.Hour),
+					}, nil)
+			},
+			req:                teamInviteRequest{Token: "token"},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "invite expired",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.TeamInvite{
+						Email:     "lanre@test.com",
+						ExpiresAt: time.Now().Add(-time.Hour),
+					}, nil)
+			},
+			req:                teamInviteRequest{Token: "token"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "already a member",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.TeamInvite{
+						Email:     "lanre@test.com",
+						ExpiresAt: time.Now().Add(time.Hour),
+					}, nil)
+
+				teamRepo.EXPECT().AcceptInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(malak.ErrTeamMemberExists)
+			},
+			req:                teamInviteRequest{Token: "token"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invite accepted",
+			mockFn: func(teamRepo *malak_mocks.MockTeamRepository) {
+				teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.TeamInvite{
+						Email:     "lanre@test.com",
+						ExpiresAt: time.Now().Add(time.Hour),
+					}, nil)
+
+				teamRepo.EXPECT().AcceptInvite(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			req:                teamInviteRequest{Token: "token"},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestTeamHandler_AcceptInvite(t *testing.T) {
+	for _, v := range generateAcceptTeamInviteTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			teamRepo := malak_mocks.NewMockTeamRepository(controller)
+
+			v.mockFn(teamRepo)
+
+			h := &teamHandler{
+				cfg:      getConfig(),
+				teamRepo: teamRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{
+				ID:    teamTestUserID,
+				Email: "lanre@test.com",
+			}))
+
+			WrapMalakHTTPHandler(getLogger(t), h.acceptInvite, getConfig(), "invites.accept").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestTeamHandler_DeclineInvite(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	teamRepo := malak_mocks.NewMockTeamRepository(controller)
+
+	teamRepo.EXPECT().GetInvite(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&malak.TeamInvite{
+			Email:     "lanre@test.com",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+
+	teamRepo.EXPECT().DeclineInvite(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	h := &teamHandler{
+		cfg:      getConfig(),
+		teamRepo: teamRepo,
+	}
+
+	var b = bytes.NewBuffer(nil)
+	require.NoError(t, json.NewEncoder(b).Encode(teamInviteRequest{Token: "token"}))
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", b)
+	req.Header.Add("Content-Type", "application/json")
+
+	req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{
+		ID:    teamTestUserID,
+		Email: "lanre@test.com",
+	}))
+
+	WrapMalakHTTPHandler(getLogger(t), h.declineInvite, getConfig(), "invites.decline").
+		ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	verifyMatch(t, rr)
+}
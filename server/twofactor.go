@@ -0,0 +1,530 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/ayinke-llc/malak/internal/pkg/totp"
+	"github.com/ayinke-llc/malak/internal/secret"
+)
+
+const (
+	twoFactorIssuer = "Malak"
+
+	// how long a user has to provide their 2FA code after signing in
+	twoFactorChallengeTTL = time.Minute * 5
+
+	// the challenge is thrown away after this many codes and the
+	// user has to sign in again
+	maxTwoFactorAttempts = 5
+
+	// a user is locked out of two factor verification after this many
+	// codes across every challenge and session until the window runs out.
+	// This stops an attacker from starting new challenges to keep guessing.
+	// Attempts are counted before the code is checked so parallel requests
+	// cannot all get in before a failure is recorded
+	maxTwoFactorUserAttempts = 10
+	twoFactorLockoutDuration = time.Minute * 15
+)
+
+var errTwoFactorLockedOut = errors.New("too many invalid two factor codes. Please try again later")
+
+func twoFactorAttemptsKey(userID uuid.UUID) string {
+	return "auth-2fa-attempts-" + userID.String()
+}
+
+type twoFactorChallenge struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func twoFactorChallengeKey(token string) string {
+	return "auth-2fa-challenge-" + token
+}
+
+func twoFactorChallengeAttemptsKey(token string) string {
+	return "auth-2fa-challenge-attempts-" + token
+}
+
+func (a *authHandler) createTwoFactorChallenge(ctx context.Context,
+	user *malak.User, logger *zap.Logger) (render.Renderer, Status) {
+
+	token := a.uuidGenerator.Create().String()
+
+	if err := a.saveTwoFactorChallenge(ctx, token, twoFactorChallenge{
+		UserID: user.ID,
+	}); err != nil {
+		logger.Error("could not store two factor challenge", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "an error occurred while generating two factor challenge"), StatusFailed
+	}
+
+	return twoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		APIStatus:         newAPIStatus(http.StatusOK, "two factor authentication required"),
+	}, StatusSuccess
+}
+
+func (a *authHandler) saveTwoFactorChallenge(ctx context.Context,
+	token string, challenge twoFactorChallenge) error {
+
+	b, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	return a.cache.Add(ctx, twoFactorChallengeKey(token), b, twoFactorChallengeTTL)
+}
+
+// verifySecondFactor checks either a totp code or an unused recovery code.
+// totp codes cannot be replayed within the window they are valid for.
+// errTwoFactorLockedOut is returned once the user has tried too many times
+func (a *authHandler) verifySecondFactor(ctx context.Context,
+	user *malak.User, code, recoveryCode string) (bool, error) {
+
+	attempts, err := a.cache.Increment(ctx, twoFactorAttemptsKey(user.ID), twoFactorLockoutDuration)
+	if err != nil {
+		return false, err
+	}
+
+	if attempts > maxTwoFactorUserAttempts {
+		return false, errTwoFactorLockedOut
+	}
+
+	return a.checkSecondFactor(ctx, user, code, recoveryCode)
+}
+
+func (a *authHandler) checkSecondFactor(ctx context.Context,
+	user *malak.User, code, recoveryCode string) (bool, error) {
+
+	if !hermes.IsStringEmpty(recoveryCode) {
+		err := a.twoFactor.UseRecoveryCode(ctx, user, recoveryCode)
+		if errors.Is(err, malak.ErrTwoFactorRecoveryCodeNotFound) {
+			return false, nil
+		}
+
+		return err == nil, err
+	}
+
+	if user.TwoFactorSecret == nil {
+		return false, nil
+	}
+
+	totpSecret, err := a.secretsClient.Get(ctx, *user.TwoFactorSecret)
+	if err != nil {
+		return false, err
+	}
+
+	if !totp.Validate(totpSecret, code, time.Now()) {
+		return false, nil
+	}
+
+	// only the request that marks the code as used gets to use it
+	return a.cache.AddIfNotExists(ctx,
+		fmt.Sprintf("auth-2fa-used-%s-%s", user.ID, code),
+		[]byte("used"), totp.Period*(2*totp.Skew+1))
+}
+
+type verifyTwoFactorRequest struct {
+	GenericRequest
+
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code,omitempty"`
+	RecoveryCode   string `json:"recovery_code,omitempty"`
+}
+
+func (v *verifyTwoFactorRequest) Validate() error {
+	if hermes.IsStringEmpty(v.ChallengeToken) {
+		return errors.New("please provide your challenge token")
+	}
+
+	if hermes.IsStringEmpty(v.Code) && hermes.IsStringEmpty(v.RecoveryCode) {
+		return errors.New("please provide your two factor code or a recovery code")
+	}
+
+	return nil
+}
+
+// @Description Complete sign in by providing a two factor code
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body verifyTwoFactorRequest true "challenge data"
+// @Success 200 {object} createdUserResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/2fa/verify [post]
+func (a *authHandler) verifyTwoFactor(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("verifying two factor challenge")
+
+	req := new(verifyTwoFactorRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	key := twoFactorChallengeKey(req.ChallengeToken)
+
+	b, err := a.cache.Get(ctx, key)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return newAPIStatus(http.StatusUnauthorized, "two factor challenge has expired. Please sign in again"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch two factor challenge", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not verify two factor code"), StatusFailed
+	}
+
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal(b, &challenge); err != nil {
+		logger.Error("could not decode two factor challenge", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not verify two factor code"), StatusFailed
+	}
+
+	logger = logger.With(zap.String("user_id", challenge.UserID.String()))
+
+	attempts, err := a.cache.Increment(ctx,
+		twoFactorChallengeAttemptsKey(req.ChallengeToken), twoFactorChallengeTTL)
+	if err != nil {
+		logger.Error("could not count two factor challenge attempts", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not verify two factor code"), StatusFailed
+	}
+
+	if attempts > maxTwoFactorAttempts {
+		if err := a.cache.Delete(ctx, key); err != nil {
+			logger.Error("could not delete two factor challenge", zap.Error(err))
+		}
+
+		return newAPIStatus(http.StatusUnauthorized, "too many invalid attempts. Please sign in again"), StatusFailed
+	}
+
+	user, err := a.userRepo.Get(ctx, &malak.FindUserOptions{
+		ID: challenge.UserID,
+	})
+	if err != nil {
+		logger.Error("could not fetch user", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not verify two factor code"), StatusFailed
+	}
+
+	ok, err := a.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode)
+	if errors.Is(err, errTwoFactorLockedOut) {
+		return newAPIStatus(http.StatusTooManyRequests, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not verify two factor code", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not verify two factor code"), StatusFailed
+	}
+
+	if !ok {
+		return newAPIStatus(http.StatusUnauthorized, "invalid two factor code"), StatusFailed
+	}
+
+	if err := a.cache.Delete(ctx, key); err != nil {
+		logger.Error("could not delete two factor challenge", zap.Error(err))
+	}
+
+	return a.createSession(ctx, user, logger)
+}
+
+// @Description Start setting up two factor authentication. The provisioning uri should be displayed as a QR code
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} setupTwoFactorResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/2fa/setup [post]
+func (a *authHandler) setupTwoFactor(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("setting up two factor authentication")
+
+	user := getUserFromContext(ctx)
+
+	if user.IsTwoFactorEnabled() {
+		return newAPIStatus(http.StatusBadRequest, "two factor authentication is already enabled"), StatusFailed
+	}
+
+	totpSecret, err := totp.GenerateSecret()
+	if err != nil {
+		logger.Error("could not generate totp secret", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not set up two factor authentication"), StatusFailed
+	}
+
+	// every setup gets its own key as some providers refuse to overwrite
+	// an existing secret
+	key, err := a.secretsClient.Create(ctx, &secret.CreateSecretOptions{
+		Value: totpSecret,
+		Name:  fmt.Sprintf("users/%s/totp/%s", user.ID, a.uuidGenerator.Create()),
+	})
+	if err != nil {
+		logger.Error("could not store totp secret in secrets provider", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not set up two factor authentication"), StatusFailed
+	}
+
+	if err := a.twoFactor.SaveSecret(ctx, user, key); err != nil {
+		logger.Error("could not save totp secret", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not set up two factor authentication"), StatusFailed
+	}
+
+	return setupTwoFactorResponse{
+		Secret:          totpSecret,
+		ProvisioningURI: totp.ProvisioningURI(twoFactorIssuer, user.Email.String(), totpSecret),
+		APIStatus:       newAPIStatus(http.StatusOK, "scan the qr code with your authenticator app"),
+	}, StatusSuccess
+}
+
+type enableTwoFactorRequest struct {
+	GenericRequest
+
+	Code string `json:"code" validate:"required"`
+}
+
+func (e *enableTwoFactorRequest) Validate() error {
+	if hermes.IsStringEmpty(e.Code) {
+		return errors.New("please provide the code from your authenticator app")
+	}
+
+	return nil
+}
+
+// @Description Confirm the two factor setup with a code from the authenticator app. Recovery codes are only returned once
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body enableTwoFactorRequest true "authenticator code"
+// @Success 200 {object} twoFactorRecoveryCodesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/2fa/enable [post]
+func (a *authHandler) enableTwoFactor(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("enabling two factor authentication")
+
+	req := new(enableTwoFactorRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	if user.IsTwoFactorEnabled() {
+		return newAPIStatus(http.StatusBadRequest, "two factor authentication is already enabled"), StatusFailed
+	}
+
+	if user.TwoFactorSecret == nil {
+		return newAPIStatus(http.StatusBadRequest, "please set up two factor authentication first"), StatusFailed
+	}
+
+	ok, err := a.verifySecondFactor(ctx, user, req.Code, "")
+	if errors.Is(err, errTwoFactorLockedOut) {
+		return newAPIStatus(http.StatusTooManyRequests, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not verify two factor code", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not enable two factor authentication"), StatusFailed
+	}
+
+	if !ok {
+		return newAPIStatus(http.StatusBadRequest, "invalid two factor code"), StatusFailed
+	}
+
+	codes, records, err := malak.NewRecoveryCodes(user)
+	if err != nil {
+		logger.Error("could not generate recovery codes", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not enable two factor authentication"), StatusFailed
+	}
+
+	if err := a.twoFactor.Enable(ctx, user, records); err != nil {
+		logger.Error("could not enable two factor authentication", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not enable two factor authentication"), StatusFailed
+	}
+
+	return twoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+		APIStatus:     newAPIStatus(http.StatusOK, "two factor authentication has been enabled"),
+	}, StatusSuccess
+}
+
+type twoFactorCodeRequest struct {
+	GenericRequest
+
+	Code         string `json:"code,omitempty"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+func (t *twoFactorCodeRequest) Validate() error {
+	if hermes.IsStringEmpty(t.Code) && hermes.IsStringEmpty(t.RecoveryCode) {
+		return errors.New("please provide your two factor code or a recovery code")
+	}
+
+	return nil
+}
+
+// @Description Turn off two factor authentication
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body twoFactorCodeRequest true "two factor code"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/2fa/disable [post]
+func (a *authHandler) disableTwoFactor(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("disabling two factor authentication")
+
+	req := new(twoFactorCodeRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	if !user.IsTwoFactorEnabled() {
+		return newAPIStatus(http.StatusBadRequest, "two factor authentication is not enabled"), StatusFailed
+	}
+
+	ok, err := a.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode)
+	if errors.Is(err, errTwoFactorLockedOut) {
+		return newAPIStatus(http.StatusTooManyRequests, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not verify two factor code", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not disable two factor authentication"), StatusFailed
+	}
+
+	if !ok {
+		return newAPIStatus(http.StatusBadRequest, "invalid two factor code"), StatusFailed
+	}
+
+	if err := a.twoFactor.Disable(ctx, user); err != nil {
+		logger.Error("could not disable two factor authentication", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not disable two factor authentication"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "two factor authentication has been disabled"), StatusSuccess
+}
+
+// @Description Generate a new set of recovery codes. Previous codes stop working
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param message body twoFactorCodeRequest true "two factor code"
+// @Success 200 {object} twoFactorRecoveryCodesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /auth/2fa/recovery-codes [post]
+func (a *authHandler) regenerateRecoveryCodes(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("regenerating recovery codes")
+
+	req := new(twoFactorCodeRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	if !user.IsTwoFactorEnabled() {
+		return newAPIStatus(http.StatusBadRequest, "two factor authentication is not enabled"), StatusFailed
+	}
+
+	ok, err := a.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode)
+	if errors.Is(err, errTwoFactorLockedOut) {
+		return newAPIStatus(http.StatusTooManyRequests, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not verify two factor code", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not regenerate recovery codes"), StatusFailed
+	}
+
+	if !ok {
+		return newAPIStatus(http.StatusBadRequest, "invalid two factor code"), StatusFailed
+	}
+
+	codes, records, err := malak.NewRecoveryCodes(user)
+	if err != nil {
+		logger.Error("could not generate recovery codes", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not regenerate recovery codes"), StatusFailed
+	}
+
+	if err := a.twoFactor.ReplaceRecoveryCodes(ctx, user, records); err != nil {
+		logger.Error("could not store recovery codes", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not regenerate recovery codes"), StatusFailed
+	}
+
+	return twoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+		APIStatus:     newAPIStatus(http.StatusOK, "recovery codes have been regenerated"),
+	}, StatusSuccess
+}
@@ -0,0 +1,859 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/ayinke-llc/malak/internal/pkg/jwttoken"
+	mock_jwttoken "github.com/ayinke-llc/malak/internal/pkg/jwttoken/mocks"
+	"github.com/ayinke-llc/malak/internal/pkg/totp"
+	secretpkg "github.com/ayinke-llc/malak/internal/secret"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+)
+
+const (
+	testTwoFactorSecret = "JBSWY3DPEHPK3PXP"
+
+	// what is stored on the user. The raw secret only lives in the
+	// secrets provider
+	testTwoFactorSecretKey = "users/00000000-0000-0000-0000-000000000000/totp/123e4567-e89b-12d3-a456-426614174000"
+)
+
+func twoFactorUser() *malak.User {
+	return &malak.User{
+		Email:              malak.Email("test@example.com"),
+		FullName:           "Test User",
+		Metadata:           &malak.UserMetadata{},
+		Roles:              malak.UserRoles{},
+		TwoFactorSecret:    hermes.Ref(testTwoFactorSecretKey),
+		TwoFactorEnabledAt: hermes.Ref(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+func twoFactorChallengeBytes(t *testing.T) []byte {
+	b, err := json.Marshal(twoFactorChallenge{})
+	require.NoError(t, err)
+	return b
+}
+
+// expectTwoFactorSecret lets the handler read the totp secret of the
+// user from the secrets provider
+func expectTwoFactorSecret(secretsClient *malak_mocks.MockSecretClient) {
+	secretsClient.EXPECT().Get(gomock.Any(), testTwoFactorSecretKey).
+		AnyTimes().
+		Return(testTwoFactorSecret, nil)
+}
+
+// expectTwoFactorAttempts should be set up after the expectations of a
+// test case so cases can still return their own attempt count
+func expectTwoFactorAttempts(cacheMock *malak_mocks.MockCache, userID uuid.UUID) {
+	cacheMock.EXPECT().Increment(gomock.Any(), twoFactorAttemptsKey(userID), twoFactorLockoutDuration).
+		AnyTimes().
+		Return(int64(1), nil)
+
+	cacheMock.EXPECT().Increment(gomock.Any(), twoFactorChallengeAttemptsKey("challenge"), twoFactorChallengeTTL).
+		AnyTimes().
+		Return(int64(1), nil)
+}
+
+func testSession() jwttoken.Session {
+	return jwttoken.Session{
+		AccessToken: jwttoken.JWTokenData{
+			Token: "test-token",
+		},
+		RefreshToken: jwttoken.JWTokenData{
+			Token: "test-refresh-token",
+		},
+	}
+}
+
+func serveTwoFactorRequest(t *testing.T,
+	handler MalakHTTPHandler, user *malak.User, body interface{}) *httptest.ResponseRecorder {
+
+	var b = bytes.NewBuffer(nil)
+
+	require.NoError(t, json.NewEncoder(b).Encode(body))
+
+	rr := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodPost, "/", b)
+	req.Header.Add("Content-Type", "application/json")
+
+	if user != nil {
+		req = req.WithContext(writeUserToCtx(req.Context(), user))
+	}
+
+	WrapMalakHTTPHandler(getLogger(t), handler, getConfig(), "Auth.2fa").
+		ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestAuthHandler_EmailLogin_TwoFactorChallenge(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	hashed, err := malak.HashPassword("StrongPassword123!")
+	require.NoError(t, err)
+
+	user := twoFactorUser()
+	user.Password = hermes.Ref(hashed)
+
+	userRepo := malak_mocks.NewMockUserRepository(controller)
+	tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+	cacheMock := malak_mocks.NewMockCache(controller)
+
+	userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+
+	// no session until the challenge has been completed
+	tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+	cacheMock.EXPECT().
+		Add(gomock.Any(), twoFactorChallengeKey("123e4567-e89b-12d3-a456-426614174000"), gomock.Any(), twoFactorChallengeTTL).
+		Times(1).
+		Return(nil)
+
+	a := &authHandler{
+		cfg:           getConfig(),
+		userRepo:      userRepo,
+		tokenManager:  tokenManager,
+		cache:         cacheMock,
+		uuidGenerator: &mockUUIDGenerator{},
+	}
+
+	rr := serveTwoFactorRequest(t, a.emailLogin, nil, loginRequest{
+		Email:    malak.Email("test@example.com"),
+		Password: "StrongPassword123!",
+	})
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	verifyMatch(t, rr)
+}
+
+func generateVerifyTwoFactorTestTable(t *testing.T) []struct {
+	name               string
+	mockFn             func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache)
+	expectedStatusCode int
+	req                verifyTwoFactorRequest
+} {
+
+	code, err := totp.GenerateCode(testTwoFactorSecret, time.Now())
+	require.NoError(t, err)
+
+	return []struct {
+		name               string
+		mockFn             func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache)
+		expectedStatusCode int
+		req                verifyTwoFactorRequest
+	}{
+		{
+			name: "no challenge token",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: verifyTwoFactorRequest{
+				Code: code,
+			},
+		},
+		{
+			name: "no code provided",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+			},
+		},
+		{
+			name: "challenge expired",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), twoFactorChallengeKey("challenge")).
+					Times(1).
+					Return(nil, cache.ErrCacheMiss)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           code,
+			},
+		},
+		{
+			name: "could not fetch challenge",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("redis is down"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           code,
+			},
+		},
+		{
+			name: "invalid code",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           "abcdef",
+			},
+		},
+		{
+			name: "too many invalid attempts",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				// attempts are counted before the code is checked
+				cacheMock.EXPECT().Increment(gomock.Any(), twoFactorChallengeAttemptsKey("challenge"), twoFactorChallengeTTL).
+					Times(1).
+					Return(int64(maxTwoFactorAttempts+1), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+
+				cacheMock.EXPECT().Delete(gomock.Any(), twoFactorChallengeKey("challenge")).
+					Times(1).
+					Return(nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           "abcdef",
+			},
+		},
+		{
+			name: "code already used",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           code,
+			},
+		},
+		{
+			name: "invalid recovery code",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				twoFactorRepo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), "abcde-fghjk").
+					Times(1).
+					Return(malak.ErrTwoFactorRecoveryCodeNotFound)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				RecoveryCode:   "abcde-fghjk",
+			},
+		},
+		{
+			name: "locked out after too many failures",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), twoFactorChallengeKey("challenge")).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				// even a valid code is rejected until the lockout expires
+				cacheMock.EXPECT().Increment(gomock.Any(), twoFactorAttemptsKey(uuid.Nil), twoFactorLockoutDuration).
+					Times(1).
+					Return(int64(maxTwoFactorUserAttempts+1), nil)
+
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           code,
+			},
+		},
+		{
+			name: "verified with code",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+
+				cacheMock.EXPECT().Delete(gomock.Any(), twoFactorChallengeKey("challenge")).
+					Times(1).
+					Return(nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(testSession(), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				Code:           code,
+			},
+		},
+		{
+			name: "verified with recovery code",
+			mockFn: func(userRepo *malak_mocks.MockUserRepository, twoFactorRepo *malak_mocks.MockTwoFactorRepository, tokenManager *mock_jwttoken.MockJWTokenManager, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(twoFactorChallengeBytes(t), nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(twoFactorUser(), nil)
+
+				twoFactorRepo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), "abcde-fghjk").
+					Times(1).
+					Return(nil)
+
+				cacheMock.EXPECT().Delete(gomock.Any(), twoFactorChallengeKey("challenge")).
+					Times(1).
+					Return(nil)
+
+				tokenManager.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(testSession(), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: verifyTwoFactorRequest{
+				ChallengeToken: "challenge",
+				RecoveryCode:   "abcde-fghjk",
+			},
+		},
+	}
+}
+
+func TestAuthHandler_VerifyTwoFactor(t *testing.T) {
+	for _, v := range generateVerifyTwoFactorTestTable(t) {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			userRepo := malak_mocks.NewMockUserRepository(controller)
+			twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+			tokenManager := mock_jwttoken.NewMockJWTokenManager(controller)
+			cacheMock := malak_mocks.NewMockCache(controller)
+			secretsClient := malak_mocks.NewMockSecretClient(controller)
+
+			v.mockFn(userRepo, twoFactorRepo, tokenManager, cacheMock)
+
+			expectTwoFactorAttempts(cacheMock, uuid.Nil)
+			expectTwoFactorSecret(secretsClient)
+
+			a := &authHandler{
+				cfg:           getConfig(),
+				userRepo:      userRepo,
+				twoFactor:     twoFactorRepo,
+				tokenManager:  tokenManager,
+				cache:         cacheMock,
+				secretsClient: secretsClient,
+			}
+
+			rr := serveTwoFactorRequest(t, a.verifyTwoFactor, nil, v.req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestAuthHandler_SetupTwoFactor(t *testing.T) {
+
+	t.Run("already enabled", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		twoFactorRepo.EXPECT().SaveSecret(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		a := &authHandler{
+			cfg:       getConfig(),
+			twoFactor: twoFactorRepo,
+		}
+
+		rr := serveTwoFactorRequest(t, a.setupTwoFactor, twoFactorUser(), struct{}{})
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		verifyMatch(t, rr)
+	})
+
+	t.Run("could not store secret", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		secretsClient := malak_mocks.NewMockSecretClient(controller)
+		secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return("", errors.New("could not store secret"))
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		twoFactorRepo.EXPECT().SaveSecret(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		a := &authHandler{
+			cfg:           getConfig(),
+			twoFactor:     twoFactorRepo,
+			secretsClient: secretsClient,
+			uuidGenerator: &mockUUIDGenerator{},
+		}
+
+		rr := serveTwoFactorRequest(t, a.setupTwoFactor, &malak.User{}, struct{}{})
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		verifyMatch(t, rr)
+	})
+
+	t.Run("could not save secret", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		secretsClient := malak_mocks.NewMockSecretClient(controller)
+		secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(testTwoFactorSecretKey, nil)
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		twoFactorRepo.EXPECT().SaveSecret(gomock.Any(), gomock.Any(), testTwoFactorSecretKey).
+			Times(1).
+			Return(errors.New("could not save secret"))
+
+		a := &authHandler{
+			cfg:           getConfig(),
+			twoFactor:     twoFactorRepo,
+			secretsClient: secretsClient,
+			uuidGenerator: &mockUUIDGenerator{},
+		}
+
+		rr := serveTwoFactorRequest(t, a.setupTwoFactor, &malak.User{}, struct{}{})
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		verifyMatch(t, rr)
+	})
+
+	t.Run("secret generated", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		var secret string
+
+		secretsClient := malak_mocks.NewMockSecretClient(controller)
+		secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ interface{}, opts *secretpkg.CreateSecretOptions) (string, error) {
+				secret = opts.Value
+				require.Equal(t, testTwoFactorSecretKey, opts.Key())
+				return testTwoFactorSecretKey, nil
+			})
+
+		// only the reference to the secret is stored on the user
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		twoFactorRepo.EXPECT().SaveSecret(gomock.Any(), gomock.Any(), testTwoFactorSecretKey).
+			Times(1).
+			Return(nil)
+
+		a := &authHandler{
+			cfg:           getConfig(),
+			twoFactor:     twoFactorRepo,
+			secretsClient: secretsClient,
+			uuidGenerator: &mockUUIDGenerator{},
+		}
+
+		rr := serveTwoFactorRequest(t, a.setupTwoFactor, &malak.User{
+			Email: malak.Email("test@example.com"),
+		}, struct{}{})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp setupTwoFactorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+
+		// the secret is random so we cannot use a golden file
+		require.Equal(t, secret, resp.Secret)
+		require.Equal(t, totp.ProvisioningURI(twoFactorIssuer, "test@example.com", secret), resp.ProvisioningURI)
+	})
+}
+
+func generateEnableTwoFactorTestTable(t *testing.T) []struct {
+	name               string
+	mockFn             func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache)
+	user               *malak.User
+	expectedStatusCode int
+	req                enableTwoFactorRequest
+} {
+
+	code, err := totp.GenerateCode(testTwoFactorSecret, time.Now())
+	require.NoError(t, err)
+
+	pendingUser := &malak.User{
+		ID:              uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		TwoFactorSecret: hermes.Ref(testTwoFactorSecretKey),
+	}
+
+	return []struct {
+		name               string
+		mockFn             func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache)
+		user               *malak.User
+		expectedStatusCode int
+		req                enableTwoFactorRequest
+	}{
+		{
+			name: "no code provided",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               pendingUser,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "already enabled",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               twoFactorUser(),
+			expectedStatusCode: http.StatusBadRequest,
+			req: enableTwoFactorRequest{
+				Code: code,
+			},
+		},
+		{
+			name: "not set up",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               &malak.User{},
+			expectedStatusCode: http.StatusBadRequest,
+			req: enableTwoFactorRequest{
+				Code: code,
+			},
+		},
+		{
+			name: "invalid code",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+
+				twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               pendingUser,
+			expectedStatusCode: http.StatusBadRequest,
+			req: enableTwoFactorRequest{
+				Code: "abcdef",
+			},
+		},
+		{
+			name: "could not enable",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+
+				twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not enable"))
+			},
+			user:               pendingUser,
+			expectedStatusCode: http.StatusInternalServerError,
+			req: enableTwoFactorRequest{
+				Code: code,
+			},
+		},
+	}
+}
+
+func TestAuthHandler_EnableTwoFactor(t *testing.T) {
+	for _, v := range generateEnableTwoFactorTestTable(t) {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+			cacheMock := malak_mocks.NewMockCache(controller)
+			secretsClient := malak_mocks.NewMockSecretClient(controller)
+
+			v.mockFn(twoFactorRepo, cacheMock)
+
+			expectTwoFactorAttempts(cacheMock, v.user.ID)
+			expectTwoFactorSecret(secretsClient)
+
+			a := &authHandler{
+				cfg:           getConfig(),
+				twoFactor:     twoFactorRepo,
+				cache:         cacheMock,
+				secretsClient: secretsClient,
+			}
+
+			rr := serveTwoFactorRequest(t, a.enableTwoFactor, v.user, v.req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		code, err := totp.GenerateCode(testTwoFactorSecret, time.Now())
+		require.NoError(t, err)
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		cacheMock := malak_mocks.NewMockCache(controller)
+		secretsClient := malak_mocks.NewMockSecretClient(controller)
+
+		expectTwoFactorAttempts(cacheMock, uuid.Nil)
+		expectTwoFactorSecret(secretsClient)
+
+		cacheMock.EXPECT().AddIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+
+		var stored []malak.TwoFactorRecoveryCode
+
+		twoFactorRepo.EXPECT().Enable(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ interface{}, _ *malak.User, codes []malak.TwoFactorRecoveryCode) error {
+				stored = codes
+				return nil
+			})
+
+		a := &authHandler{
+			cfg:           getConfig(),
+			twoFactor:     twoFactorRepo,
+			cache:         cacheMock,
+			secretsClient: secretsClient,
+		}
+
+		rr := serveTwoFactorRequest(t, a.enableTwoFactor, &malak.User{
+			TwoFactorSecret: hermes.Ref(testTwoFactorSecretKey),
+		}, enableTwoFactorRequest{
+			Code: code,
+		})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp twoFactorRecoveryCodesResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+
+		// recovery codes are random so we cannot use a golden file
+		require.Len(t, resp.RecoveryCodes, malak.NumberOfRecoveryCodes)
+		require.Len(t, stored, malak.NumberOfRecoveryCodes)
+
+		for i, code := range resp.RecoveryCodes {
+			require.Equal(t, malak.HashRecoveryCode(code), stored[i].CodeHash)
+		}
+	})
+}
+
+func generateDisableTwoFactorTestTable() []struct {
+	name               string
+	mockFn             func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache)
+	user               *malak.User
+	expectedStatusCode int
+	req                twoFactorCodeRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache)
+		user               *malak.User
+		expectedStatusCode int
+		req                twoFactorCodeRequest
+	}{
+		{
+			name: "no code provided",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().Disable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               twoFactorUser(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "not enabled",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().Disable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               &malak.User{},
+			expectedStatusCode: http.StatusBadRequest,
+			req: twoFactorCodeRequest{
+				RecoveryCode: "abcde-fghjk",
+			},
+		},
+		{
+			name: "invalid code",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+
+				twoFactorRepo.EXPECT().Disable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			user:               twoFactorUser(),
+			expectedStatusCode: http.StatusBadRequest,
+			req: twoFactorCodeRequest{
+				Code: "abcdef",
+			},
+		},
+		{
+			name: "could not disable",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), "abcde-fghjk").
+					Times(1).
+					Return(nil)
+
+				twoFactorRepo.EXPECT().Disable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not disable"))
+			},
+			user:               twoFactorUser(),
+			expectedStatusCode: http.StatusInternalServerError,
+			req: twoFactorCodeRequest{
+				RecoveryCode: "abcde-fghjk",
+			},
+		},
+		{
+			name: "disabled",
+			mockFn: func(twoFactorRepo *malak_mocks.MockTwoFactorRepository, cacheMock *malak_mocks.MockCache) {
+				twoFactorRepo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), "abcde-fghjk").
+					Times(1).
+					Return(nil)
+
+				twoFactorRepo.EXPECT().Disable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			user:               twoFactorUser(),
+			expectedStatusCode: http.StatusOK,
+			req: twoFactorCodeRequest{
+				RecoveryCode: "abcde-fghjk",
+			},
+		},
+	}
+}
+
+func TestAuthHandler_DisableTwoFactor(t *testing.T) {
+	for _, v := range generateDisableTwoFactorTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+			cacheMock := malak_mocks.NewMockCache(controller)
+			secretsClient := malak_mocks.NewMockSecretClient(controller)
+
+			v.mockFn(twoFactorRepo, cacheMock)
+
+			expectTwoFactorAttempts(cacheMock, v.user.ID)
+			expectTwoFactorSecret(secretsClient)
+
+			a := &authHandler{
+				cfg:           getConfig(),
+				twoFactor:     twoFactorRepo,
+				cache:         cacheMock,
+				secretsClient: secretsClient,
+			}
+
+			rr := serveTwoFactorRequest(t, a.disableTwoFactor, v.user, v.req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestAuthHandler_RegenerateRecoveryCodes(t *testing.T) {
+
+	t.Run("not enabled", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		twoFactorRepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		a := &authHandler{
+			cfg:       getConfig(),
+			twoFactor: twoFactorRepo,
+		}
+
+		rr := serveTwoFactorRequest(t, a.regenerateRecoveryCodes, &malak.User{}, twoFactorCodeRequest{
+			RecoveryCode: "abcde-fghjk",
+		})
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		verifyMatch(t, rr)
+	})
+
+	t.Run("regenerated", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		defer controller.Finish()
+
+		twoFactorRepo := malak_mocks.NewMockTwoFactorRepository(controller)
+		cacheMock := malak_mocks.NewMockCache(controller)
+
+		expectTwoFactorAttempts(cacheMock, uuid.Nil)
+
+		twoFactorRepo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), "abcde-fghjk").
+			Times(1).
+			Return(nil)
+
+		twoFactorRepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil)
+
+		a := &authHandler{
+			cfg:       getConfig(),
+			twoFactor: twoFactorRepo,
+			cache:     cacheMock,
+		}
+
+		rr := serveTwoFactorRequest(t, a.regenerateRecoveryCodes, twoFactorUser(), twoFactorCodeRequest{
+			RecoveryCode: "abcde-fghjk",
+		})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp twoFactorRecoveryCodesResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.Len(t, resp.RecoveryCodes, malak.NumberOfRecoveryCodes)
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
 	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	"github.com/ayinke-llc/malak/internal/pkg/geolocation"
 	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/ayinke-llc/malak/internal/pkg/util"
 	"github.com/go-chi/chi/v5"
@@ -29,6 +31,17 @@ type updatesHandler struct {
 	uuidGenerator      malak.UuidGenerator
 	templateRepo       malak.TemplateRepository
 	gulter             gulter.Storage
+	auditLogRepo       malak.AuditLogRepository
+	suppressionRepo    malak.SuppressionRepository
+	commentRepo        malak.UpdateCommentRepository
+	linkRepo           malak.UpdateLinkRepository
+	workspaceRepo      malak.WorkspaceRepository
+	geolocationService geolocation.GeolocationService
+	chartRenderer      malak.ChartRenderer
+	recurringRepo      malak.RecurringUpdateScheduleRepository
+	integrationRepo    malak.IntegrationRepository
+	collaborationHub   *collaboration.Hub
+	preferenceRepo     malak.PreferenceRepository
 }
 
 // @Description list all templates. this will include both systems and your own created templates
@@ -66,12 +79,27 @@ func (u *updatesHandler) templates(
 			"could not list system templates"), StatusFailed
 	}
 
+	workspaceTemplates, err := u.templateRepo.Workspace(ctx, malak.ListWorkspaceTemplateOptions{
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+		Filter:      filter,
+	})
+	if err != nil {
+
+		logger.Error("could not list workspace templates",
+			zap.Error(err))
+
+		return newAPIStatus(
+			http.StatusInternalServerError,
+			"could not list workspace templates"), StatusFailed
+	}
+
 	return fetchTemplatesResponse{
 		Templates: struct {
-			System    []malak.SystemTemplate "json:\"system,omitempty\" validate:\"required\""
-			Workspace []malak.SystemTemplate "json:\"workspace,omitempty\" validate:\"required\""
+			System    []malak.SystemTemplate    "json:\"system,omitempty\" validate:\"required\""
+			Workspace []malak.WorkspaceTemplate "json:\"workspace,omitempty\" validate:\"required\""
 		}{
-			System: templates,
+			System:    templates,
+			Workspace: workspaceTemplates,
 		},
 		APIStatus: newAPIStatus(http.StatusCreated, "templates listed"),
 	}, StatusSuccess
@@ -177,10 +205,20 @@ func (u *updatesHandler) create(
 	}
 
 	opts := &malak.TemplateCreateUpdateOptions{
+		IsFromTemplate:   !util.IsStringEmpty(req.Template.Reference.String()),
 		IsSystemTemplate: req.Template.IsSystemTemplate,
 		Reference:        req.Template.Reference,
 	}
 
+	if opts.IsFromTemplate {
+		content, resp, status := u.templateContent(ctx, logger, workspace, opts)
+		if status == StatusFailed {
+			return resp, status
+		}
+
+		update.Content = content
+	}
+
 	if err := u.updateRepo.Create(ctx, update, opts); err != nil {
 
 		logger.Error("could not create update",
@@ -385,19 +423,51 @@ func (u *updatesHandler) update(
 			StatusFailed
 	}
 
+	if update.Title == req.Title && malak.IsSameContent(update.Content, req.Update) {
+		// nothing changed, no need for a new revision
+		return newAPIStatus(http.StatusOK,
+			"updates stored"), StatusSuccess
+	}
+
 	update.Content = req.Update
 	update.Title = req.Title
+	update.ContentChanged()
+
+	revision := &malak.UpdateRevision{
+		Reference:   u.referenceGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:    update.ID,
+		WorkspaceID: update.WorkspaceID,
+		CreatedBy:   getUserFromContext(ctx).ID,
+		Title:       update.Title,
+		Content:     update.Content,
+	}
+
+	if err := u.updateRepo.UpdateContent(ctx, update, revision); err != nil {
+		if errors.Is(err, malak.ErrUpdateSent) {
+			return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+		}
 
-	if err := u.updateRepo.Update(ctx, update); err != nil {
 		logger.Error("could not update content", zap.Error(err))
 		return newAPIStatus(http.StatusInternalServerError,
 			"could not update content"), StatusFailed
 	}
 
+	u.reloadCollaborators(ctx, update)
+
 	return newAPIStatus(http.StatusOK,
 		"updates stored"), StatusSuccess
 }
 
+// reloadCollaborators replaces the draft of anyone editing it with what was
+// just saved so their stale copy is not written back over it
+func (u *updatesHandler) reloadCollaborators(ctx context.Context, update *malak.Update) {
+	if u.collaborationHub == nil {
+		return
+	}
+
+	u.collaborationHub.Reload(ctx, update, getUserFromContext(ctx).ID)
+}
+
 // @Description List pinned updates
 // @Tags updates
 // @Accept  json
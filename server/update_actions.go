@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"errors"
+	"html/template"
 	"net/http"
 
 	"github.com/ayinke-llc/malak"
@@ -264,8 +265,9 @@ func (u *updatesHandler) fetchUpdate(
 // @Failure 500 {object} APIStatus
 // @Param provider query string true "provider type"
 // @Param email_id query string true "email id"
+// @Param reaction query string false "thumbs up or thumbs down"
 // @Router /updates/react [get]
-// ?provider=resend&email_id=xxxx
+// ?provider=resend&email_id=xxxx&reaction=thumbs%20up
 func (u *updatesHandler) handleReaction(
 	logger *zap.Logger,
 ) http.HandlerFunc {
@@ -281,6 +283,13 @@ func (u *updatesHandler) handleReaction(
 		provider := malak.UpdateRecipientLogProvider(r.URL.Query().Get("provider"))
 		emailID := r.URL.Query().Get("email_id")
 
+		// older emails do not send the reaction
+		reaction := malak.ReactionStatus(r.URL.Query().Get("reaction"))
+		if !util.IsStringEmpty(reaction.String()) && !reaction.IsValid() {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid reaction"))
+			return
+		}
+
 		span.SetAttributes(attribute.String("id", ref))
 
 		logger = logger.With(zap.String("reference", ref))
@@ -307,8 +316,7 @@ func (u *updatesHandler) handleReaction(
 			return
 		}
 
-		recipientStat.HasReaction = true
-		updateStat.TotalReactions += 1
+		react(updateStat, recipientStat, reaction)
 
 		if err := u.updateRepo.UpdateStat(ctx, updateStat, recipientStat); err != nil {
 			logger.Error("could not update stat", zap.Error(err),
@@ -320,3 +328,194 @@ func (u *updatesHandler) handleReaction(
 		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "added reaction"))
 	}
 }
+
+// unsubscribeConfirmationPage posts back to the same url, query string
+// included, with the RFC 8058 one-click body
+var unsubscribeConfirmationPage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<p>Click below to stop receiving updates from this workspace.</p>
+<form method="post">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>`))
+
+// @Tags updates
+// @Description Confirm an unsubscribe from the link in an update email. This does not unsubscribe the recipient
+// @Id confirmUnsubscribe
+// @Produce  html
+// @Success 200 {string} string
+// @Failure 400 {object} APIStatus
+// @Param recipient query string true "recipient reference"
+// @Param signature query string true "signature from the email"
+// @Router /updates/unsubscribe [get]
+func (u *updatesHandler) confirmUnsubscribe(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		_, span, rid := getTracer(r.Context(), r, "updates.unsubscribe.confirm.handler", u.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		ref := malak.Reference(r.URL.Query().Get("recipient"))
+		signature := r.URL.Query().Get("signature")
+
+		logger = logger.With(zap.String("request_id", rid),
+			zap.String("recipient_reference", ref.String()))
+
+		span.SetAttributes(attribute.String("recipient_reference", ref.String()))
+
+		if util.IsStringEmpty(ref.String()) || util.IsStringEmpty(signature) ||
+			!malak.VerifyUnsubscribe(u.cfg.Auth.JWT.Key, ref, signature) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid unsubscribe link"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		if err := unsubscribeConfirmationPage.Execute(w, nil); err != nil {
+			logger.Error("could not render unsubscribe confirmation", zap.Error(err))
+		}
+	}
+}
+
+// @Tags updates
+// @Description One-click unsubscribe from all updates sent by a workspace
+// @Id unsubscribe
+// @Accept  x-www-form-urlencoded
+// @Produce  json
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Param recipient query string true "recipient reference"
+// @Param signature query string true "signature from the email"
+// @Param List-Unsubscribe formData string true "must be One-Click"
+// @Router /updates/unsubscribe [post]
+func (u *updatesHandler) handleUnsubscribe(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "updates.unsubscribe.handler", u.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		ref := malak.Reference(r.URL.Query().Get("recipient"))
+		signature := r.URL.Query().Get("signature")
+
+		logger = logger.With(zap.String("request_id", rid),
+			zap.String("recipient_reference", ref.String()))
+
+		span.SetAttributes(attribute.String("recipient_reference", ref.String()))
+
+		if util.IsStringEmpty(ref.String()) || util.IsStringEmpty(signature) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid unsubscribe link"))
+			return
+		}
+
+		if !malak.VerifyUnsubscribe(u.cfg.Auth.JWT.Key, ref, signature) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid unsubscribe link"))
+			return
+		}
+
+		if r.PostFormValue("List-Unsubscribe") != "One-Click" {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid unsubscribe request"))
+			return
+		}
+
+		logger.Debug("unsubscribing recipient")
+
+		recipient, err := u.updateRepo.GetRecipient(ctx, ref)
+		if errors.Is(err, malak.ErrUpdateRecipientNotFound) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusNotFound, "recipient does not exist"))
+			return
+		}
+
+		if err != nil {
+			logger.Error("could not fetch recipient", zap.Error(err))
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not fetch recipient"))
+			return
+		}
+
+		update, err := u.updateRepo.GetByID(ctx, recipient.UpdateID)
+		if err != nil {
+			logger.Error("could not fetch update", zap.Error(err),
+				zap.String("update_id", recipient.UpdateID.String()))
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not fetch update"))
+			return
+		}
+
+		err = u.suppressionRepo.Create(ctx, &malak.Suppression{
+			WorkspaceID: update.WorkspaceID,
+			ContactID:   recipient.ContactID,
+			Reference:   u.referenceGenerator.Generate(malak.EntityTypeSuppression),
+			Reason:      malak.SuppressionReasonUnsubscribed,
+		})
+		if err != nil {
+			logger.Error("could not unsubscribe contact", zap.Error(err),
+				zap.String("contact_id", recipient.ContactID.String()))
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not unsubscribe"))
+			return
+		}
+
+		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "you have been unsubscribed"))
+	}
+}
+
+// @Tags updates
+// @Description Record a click on a link in an update and redirect to the link
+// @Id trackClick
+// @Produce  json
+// @Success 302
+// @Failure 400 {object} APIStatus
+// @Param recipient query string true "recipient reference"
+// @Param url query string true "link that was clicked"
+// @Param signature query string true "signature from the email"
+// @Router /updates/click [get]
+func (u *updatesHandler) handleClick(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "updates.click.handler", u.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		ref := malak.Reference(r.URL.Query().Get("recipient"))
+		link := r.URL.Query().Get("url")
+		signature := r.URL.Query().Get("signature")
+
+		logger = logger.With(zap.String("request_id", rid),
+			zap.String("recipient_reference", ref.String()))
+
+		span.SetAttributes(attribute.String("recipient_reference", ref.String()))
+
+		if !malak.VerifyClick(u.cfg.Auth.JWT.Key, ref, link, signature) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid link"))
+			return
+		}
+
+		// the link is already signed so a failure to record the click
+		// should not stop the investor from getting to the page
+		recipient, err := u.updateRepo.GetRecipient(ctx, ref)
+		if err != nil {
+			logger.Error("could not fetch recipient", zap.Error(err))
+			http.Redirect(w, r, link, http.StatusFound)
+			return
+		}
+
+		err = u.updateRepo.RecordClick(ctx, &malak.UpdateLinkClick{
+			UpdateID:    recipient.UpdateID,
+			RecipientID: recipient.ID,
+			URL:         link,
+		})
+		if err != nil {
+			logger.Error("could not record click", zap.Error(err))
+		}
+
+		http.Redirect(w, r, link, http.StatusFound)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/ayinke-llc/malak"
@@ -463,6 +465,7 @@ func TestUpdatesHandler_Pin(t *testing.T) {
 
 func generateUpdateReaction() []struct {
 	name               string
+	reaction           string
 	mockFn             func(update *malak_mocks.MockUpdateRepository)
 	expectedStatusCode int
 } {
@@ -471,9 +474,16 @@ func generateUpdateReaction() []struct {
 
 	return []struct {
 		name               string
+		reaction           string
 		mockFn             func(update *malak_mocks.MockUpdateRepository)
 		expectedStatusCode int
 	}{
+		{
+			name:               "invalid reaction",
+			reaction:           "heart",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "recipient stat does not exists",
 			mockFn: func(update *malak_mocks.MockUpdateRepository) {
@@ -550,6 +560,43 @@ func generateUpdateReaction() []struct {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:     "changing reaction is not counted again",
+			reaction: malak.ReactionStatusThumbsDown.String(),
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.
+					EXPECT().
+					GetStatByEmailID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, &malak.UpdateRecipientStat{
+						HasReaction: true,
+						Reaction:    malak.ReactionStatusThumbsUp,
+						Recipient: &malak.UpdateRecipient{
+							UpdateID: id,
+						},
+					}, nil)
+
+				update.EXPECT().
+					Stat(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateStat{TotalReactions: 1}, nil)
+
+				update.EXPECT().
+					UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, stat *malak.UpdateStat,
+						recipientStat *malak.UpdateRecipientStat) error {
+						if stat.TotalReactions != 1 {
+							return errors.New("reaction was counted twice")
+						}
+
+						if recipientStat.Reaction != malak.ReactionStatusThumbsDown {
+							return errors.New("reaction was not stored")
+						}
+
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 	}
 }
 
@@ -574,7 +621,7 @@ func TestUpdatesHandler_HandleReaction(t *testing.T) {
 
 			rr := httptest.NewRecorder()
 
-			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req := httptest.NewRequest(http.MethodPost, "/?reaction="+url.QueryEscape(v.reaction), b)
 			req.Header.Add("Content-Type", "application/json")
 
 			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
@@ -591,3 +638,282 @@ func TestUpdatesHandler_HandleReaction(t *testing.T) {
 		})
 	}
 }
+
+func generateUpdateUnsubscribe() []struct {
+	name               string
+	signature          string
+	body               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository)
+	expectedStatusCode int
+} {
+
+	validSignature := malak.SignUnsubscribe(getConfig().Auth.JWT.Key, "recipient_test")
+
+	return []struct {
+		name               string
+		signature          string
+		body               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid signature",
+			signature:          "oops",
+			body:               "List-Unsubscribe=One-Click",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "missing one click body",
+			signature:          validSignature,
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "recipient not found",
+			signature: validSignature,
+			body:      "List-Unsubscribe=One-Click",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), malak.Reference("recipient_test")).
+					Return(nil, malak.ErrUpdateRecipientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "could not fetch update",
+			signature: validSignature,
+			body:      "List-Unsubscribe=One-Click",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "could not suppress contact",
+			signature: validSignature,
+			body:      "List-Unsubscribe=One-Click",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(&malak.Update{}, nil)
+
+				suppression.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "unsubscribed",
+			signature: validSignature,
+			body:      "List-Unsubscribe=One-Click",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, suppression *malak_mocks.MockSuppressionRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(&malak.Update{}, nil)
+
+				suppression.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, s *malak.Suppression) error {
+						if s.Reason != malak.SuppressionReasonUnsubscribed {
+							return errors.New("unexpected reason")
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_HandleUnsubscribe(t *testing.T) {
+	for _, v := range generateUpdateUnsubscribe() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+
+			v.mockFn(updateRepo, suppressionRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				suppressionRepo:    suppressionRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost,
+				"/updates/unsubscribe?recipient=recipient_test&signature="+v.signature,
+				strings.NewReader(v.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			u.handleUnsubscribe(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestUpdatesHandler_ConfirmUnsubscribe(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		signature          string
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid signature",
+			signature:          "oops",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "shows confirmation",
+			signature:          malak.SignUnsubscribe(getConfig().Auth.JWT.Key, "recipient_test"),
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			// no expectations so any write to the repositories fails the test
+			u := &updatesHandler{
+				cfg:             getConfig(),
+				updateRepo:      malak_mocks.NewMockUpdateRepository(controller),
+				suppressionRepo: malak_mocks.NewMockSuppressionRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet,
+				"/updates/unsubscribe?recipient=recipient_test&signature="+v.signature, nil)
+
+			u.confirmUnsubscribe(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateClick() []struct {
+	name               string
+	signature          string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+
+	validSignature := malak.SignClick(getConfig().Auth.JWT.Key, "recipient_test", "https://malak.vc")
+
+	return []struct {
+		name               string
+		signature          string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid signature",
+			signature:          "oops",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "recipient not found still redirects",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), malak.Reference("recipient_test")).
+					Return(nil, malak.ErrUpdateRecipientNotFound)
+			},
+			expectedStatusCode: http.StatusFound,
+		},
+		{
+			name:      "could not record click still redirects",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+
+				update.EXPECT().
+					RecordClick(gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusFound,
+		},
+		{
+			name:      "click recorded",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+
+				update.EXPECT().
+					RecordClick(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusFound,
+		},
+	}
+}
+
+func TestUpdatesHandler_HandleClick(t *testing.T) {
+	for _, v := range generateUpdateClick() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet,
+				"/updates/click?recipient=recipient_test&url=https%3A%2F%2Fmalak.vc&signature="+v.signature, nil)
+
+			u.handleClick(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+
+			if v.expectedStatusCode == http.StatusFound {
+				require.Equal(t, "https://malak.vc", rr.Header().Get("Location"))
+				return
+			}
+
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -59,6 +59,7 @@ func (u *updatesHandler) fetchUpdateAnalytics(
 
 	var stat *malak.UpdateStat
 	var recipients []malak.UpdateRecipient
+	var links []malak.UpdateLinkStat
 
 	var g errgroup.Group
 
@@ -86,6 +87,18 @@ func (u *updatesHandler) fetchUpdateAnalytics(
 		return nil
 	})
 
+	g.Go(func() error {
+		var err error
+
+		links, err = u.updateRepo.LinkStats(ctx, update)
+		if err != nil {
+			logger.Error("could not fetch link stats", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		return newAPIStatus(http.StatusInternalServerError, "could not fetch analytics"),
 			StatusFailed
@@ -94,6 +107,7 @@ func (u *updatesHandler) fetchUpdateAnalytics(
 	return fetchUpdateAnalyticsResponse{
 		APIStatus:  newAPIStatus(http.StatusOK, "update fetched"),
 		Recipients: recipients,
+		Links:      links,
 		Update:     hermes.DeRef(stat),
 	}, StatusSuccess
 }
@@ -66,6 +66,11 @@ func generateFetchUpdateAnalaytics() []struct {
 					Stat(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, nil)
+
+				update.EXPECT().
+					LinkStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil)
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -87,6 +92,37 @@ func generateFetchUpdateAnalaytics() []struct {
 					Stat(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("could nto fetch update stats"))
+
+				update.EXPECT().
+					LinkStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "could not fetch link stats",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.
+					EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Update{}, nil)
+
+				update.EXPECT().
+					RecipientStat(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil)
+
+				update.EXPECT().
+					Stat(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UpdateStat{}, nil)
+
+				update.EXPECT().
+					LinkStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("could not fetch link stats"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -108,6 +144,13 @@ func generateFetchUpdateAnalaytics() []struct {
 					Stat(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&malak.UpdateStat{}, nil)
+
+				update.EXPECT().
+					LinkStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]malak.UpdateLinkStat{
+						{URL: "https://malak.vc", TotalClicks: 3, UniqueClicks: 2},
+					}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const maxApprovalCommentLength = 1000
+
+// @Description submit an update for review. The approvers of the workspace are notified
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} fetchUpdateReponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/review [post]
+func (u *updatesHandler) requestReview(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("requesting review of update")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if update.IsSent() {
+		return newAPIStatus(http.StatusBadRequest,
+			"update has been sent already"), StatusFailed
+	}
+
+	switch update.ApprovalStatus {
+	case malak.UpdateApprovalStatusInReview:
+		return newAPIStatus(http.StatusBadRequest,
+			"update is already in review"), StatusFailed
+	case malak.UpdateApprovalStatusApproved:
+		return newAPIStatus(http.StatusBadRequest,
+			"update has been approved already"), StatusFailed
+	}
+
+	preferences, err := u.preferenceRepo.Get(ctx, getWorkspaceFromContext(ctx))
+	if err != nil {
+		logger.Error("could not fetch preferences", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not fetch workspace preferences"), StatusFailed
+	}
+
+	if !preferences.Approval.RequireApproval {
+		return newAPIStatus(http.StatusBadRequest,
+			"updates do not require approval in this workspace"), StatusFailed
+	}
+
+	update.ApprovalStatus = malak.UpdateApprovalStatusInReview
+
+	if err := u.updateRepo.Update(ctx, update); err != nil {
+		logger.Error("could not submit update for review", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not submit update for review"), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	for _, approver := range preferences.Approval.Approvers {
+		if approver == user.ID {
+			continue
+		}
+
+		// the update is in review already so a failed notification
+		// should not fail the request
+		if err := u.queueHandler.Add(ctx, queue.QueueTopicUpdateApprovalRequest,
+			queue.UpdateApprovalRequestOptions{
+				WorkspaceID:   update.WorkspaceID,
+				UserID:        approver,
+				UpdateTitle:   update.Title,
+				RequesterName: user.FullName,
+				Link:          strings.TrimSuffix(u.cfg.Frontend.AppURL, "/") + "/updates/" + update.Reference.String(),
+			}); err != nil {
+			logger.Error("could not queue approval request",
+				zap.Error(err), zap.String("approver", approver.String()))
+		}
+	}
+
+	recordAuditLog(ctx, logger, u.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionUpdateReviewRequested,
+			malak.EntityTypeUpdate, update.Reference.String()))
+
+	return fetchUpdateReponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update submitted for review"),
+		Update:    hermes.DeRef(update),
+	}, StatusSuccess
+}
+
+type reviewUpdateRequest struct {
+	Decision malak.UpdateApprovalDecision `json:"decision,omitempty" validate:"required"`
+	Comment  string                       `json:"comment,omitempty" validate:"optional"`
+
+	GenericRequest
+}
+
+func (c *reviewUpdateRequest) Validate() error {
+	if !c.Decision.IsValid() {
+		return errors.New("please provide a valid decision")
+	}
+
+	c.Comment = strings.TrimSpace(c.Comment)
+
+	if c.Decision == malak.UpdateApprovalDecisionChangesRequested &&
+		hermes.IsStringEmpty(c.Comment) {
+		return errors.New("please describe the changes you are requesting")
+	}
+
+	if len(c.Comment) > maxApprovalCommentLength {
+		return errors.New("comment cannot be more than 1000 characters")
+	}
+
+	return nil
+}
+
+// @Description approve an update in review or request changes to it. Requesting changes moves the update back to a draft
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param message body reviewUpdateRequest true "request body to review an update"
+// @Success 200 {object} fetchUpdateApprovalResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/approvals [post]
+func (u *updatesHandler) reviewUpdate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("reviewing update")
+
+	// approvals are tied to the approver so they can only be made by a
+	// signed in user
+	if doesAPIKeyExistInContext(ctx) {
+		return newAPIStatus(http.StatusForbidden,
+			"updates cannot be reviewed with an api key"), StatusFailed
+	}
+
+	req := new(reviewUpdateRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if update.ApprovalStatus != malak.UpdateApprovalStatusInReview {
+		return newAPIStatus(http.StatusBadRequest,
+			malak.ErrUpdateNotInReview.Error()), StatusFailed
+	}
+
+	preferences, err := u.preferenceRepo.Get(ctx, getWorkspaceFromContext(ctx))
+	if err != nil {
+		logger.Error("could not fetch preferences", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not fetch workspace preferences"), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	if !preferences.Approval.IsApprover(user.ID) {
+		return newAPIStatus(http.StatusForbidden,
+			"you are not an approver of updates in this workspace"), StatusFailed
+	}
+
+	approval := &malak.UpdateApproval{
+		Reference:   u.referenceGenerator.Generate(malak.EntityTypeUpdateApproval),
+		UpdateID:    update.ID,
+		WorkspaceID: update.WorkspaceID,
+		ApproverID:  user.ID,
+		Decision:    req.Decision,
+		Comment:     req.Comment,
+	}
+
+	action := malak.AuditLogActionUpdateApproved
+	update.ApprovalStatus = malak.UpdateApprovalStatusApproved
+
+	if req.Decision == malak.UpdateApprovalDecisionChangesRequested {
+		action = malak.AuditLogActionUpdateChangesRequested
+		update.ApprovalStatus = malak.UpdateApprovalStatusDraft
+	}
+
+	span.SetAttributes(attribute.String("approval.decision", req.Decision.String()))
+
+	if err := u.updateRepo.AddApproval(ctx, update, approval); err != nil {
+		if errors.Is(err, malak.ErrUpdateNotInReview) {
+			return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+		}
+
+		logger.Error("could not review update", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not review update"), StatusFailed
+	}
+
+	entry := newAuditLog(r, action,
+		malak.EntityTypeUpdateApproval, approval.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchUpdateApprovalResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update reviewed"),
+		Approval:  hermes.DeRef(approval),
+	}, StatusSuccess
+}
+
+// @Description list the reviews of an update. The most recent comes first
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} listUpdateApprovalsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/approvals [get]
+func (u *updatesHandler) listApprovals(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing update approvals")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	approvals, err := u.updateRepo.ListApprovals(ctx, update.ID)
+	if err != nil {
+		logger.Error("could not list update approvals", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list update approvals"), StatusFailed
+	}
+
+	return listUpdateApprovalsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update approvals fetched"),
+		Approvals: approvals,
+	}, StatusSuccess
+}
@@ -0,0 +1,461 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testApproverID  = uuid.MustParse("9c2d4e6f-1a3b-4c5d-8e7f-0a1b2c3d4e5f")
+	testRequesterID = uuid.MustParse("1b3d5f7a-9c2e-4a6b-8d0f-2e4a6c8e0b1d")
+)
+
+func testUpdateInReview() *malak.Update {
+	update := testDraftUpdate()
+	update.ApprovalStatus = malak.UpdateApprovalStatusInReview
+	return update
+}
+
+func testApprovalPreferences() *malak.Preference {
+	return &malak.Preference{
+		WorkspaceID: testLinkWorkspaceID,
+		Approval: malak.ApprovalPreferences{
+			RequireApproval: true,
+			Approvers:       []uuid.UUID{testApproverID, testRequesterID},
+		},
+	}
+}
+
+func approvalRequest(r *http.Request, userID uuid.UUID) *http.Request {
+	r = r.WithContext(writeUserToCtx(r.Context(), &malak.User{
+		ID:       userID,
+		FullName: "Lanre Adelowo",
+	}))
+	r = r.WithContext(writeWorkspaceToCtx(r.Context(), &malak.Workspace{ID: testLinkWorkspaceID}))
+
+	ctx := chi.NewRouteContext()
+	ctx.URLParams.Add("reference", "update_test")
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func generateRequestReviewTestTable() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler)
+		expectedStatusCode int
+	}{
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "update already sent",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "update already in review",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "approval not required",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.Preference{}, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not submit update for review",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not update"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "submitted for review",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, u *malak.Update) error {
+						if u.ApprovalStatus != malak.UpdateApprovalStatusInReview {
+							return errors.New("update not in review")
+						}
+						return nil
+					})
+
+				// the requester is not notified of their own request
+				queueHandler.EXPECT().Add(gomock.Any(), queue.QueueTopicUpdateApprovalRequest,
+					gomock.Cond(func(x any) bool {
+						opts, ok := x.(queue.UpdateApprovalRequestOptions)
+						return ok && opts.UserID == testApproverID
+					})).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_RequestReview(t *testing.T) {
+	for _, v := range generateRequestReviewTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			preferenceRepo := malak_mocks.NewMockPreferenceRepository(controller)
+			queueHandler := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(updateRepo, preferenceRepo, queueHandler)
+
+			u := &updatesHandler{
+				cfg:            getConfig(),
+				updateRepo:     updateRepo,
+				preferenceRepo: preferenceRepo,
+				queueHandler:   queueHandler,
+				auditLogRepo:   newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := approvalRequest(httptest.NewRequest(http.MethodPost, "/", nil), testRequesterID)
+
+			WrapMalakHTTPHandler(getLogger(t), u.requestReview, getConfig(), "updates.review.request").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateReviewUpdateTestTable() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository)
+	req                reviewUpdateRequest
+	userID             uuid.UUID
+	apiKey             bool
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository)
+		req                reviewUpdateRequest
+		userID             uuid.UUID
+		apiKey             bool
+		expectedStatusCode int
+	}{
+		{
+			name:               "api key cannot review update",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {},
+			req:                reviewUpdateRequest{Decision: malak.UpdateApprovalDecisionApproved},
+			userID:             testApproverID,
+			apiKey:             true,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "invalid decision",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {},
+			req:                reviewUpdateRequest{Decision: "rejected"},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "changes requested without a comment",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {},
+			req: reviewUpdateRequest{
+				Decision: malak.UpdateApprovalDecisionChangesRequested,
+				Comment:  "   ",
+			},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "update not in review",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+			},
+			req:                reviewUpdateRequest{Decision: malak.UpdateApprovalDecisionApproved},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "user is not an approver",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+			},
+			req:                reviewUpdateRequest{Decision: malak.UpdateApprovalDecisionApproved},
+			userID:             uuid.MustParse("5e7f9a1b-3c5d-4e7f-9a1b-3c5d7e9f1a2b"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "update reviewed by another approver",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().AddApproval(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(malak.ErrUpdateNotInReview)
+			},
+			req:                reviewUpdateRequest{Decision: malak.UpdateApprovalDecisionApproved},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not review update",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().AddApproval(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("could not add approval"))
+			},
+			req:                reviewUpdateRequest{Decision: malak.UpdateApprovalDecisionApproved},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "update approved",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().AddApproval(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, u *malak.Update, _ *malak.UpdateApproval) error {
+						if u.ApprovalStatus != malak.UpdateApprovalStatusApproved {
+							return errors.New("update not approved")
+						}
+						return nil
+					})
+			},
+			req: reviewUpdateRequest{
+				Decision: malak.UpdateApprovalDecisionApproved,
+				Comment:  "looks good",
+			},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "changes requested",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testUpdateInReview(), nil)
+
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testApprovalPreferences(), nil)
+
+				update.EXPECT().AddApproval(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, u *malak.Update, _ *malak.UpdateApproval) error {
+						if u.ApprovalStatus != malak.UpdateApprovalStatusDraft {
+							return errors.New("update not moved back to draft")
+						}
+						return nil
+					})
+			},
+			req: reviewUpdateRequest{
+				Decision: malak.UpdateApprovalDecisionChangesRequested,
+				Comment:  "please add the revenue chart",
+			},
+			userID:             testApproverID,
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ReviewUpdate(t *testing.T) {
+	for _, v := range generateReviewUpdateTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			preferenceRepo := malak_mocks.NewMockPreferenceRepository(controller)
+
+			v.mockFn(updateRepo, preferenceRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				preferenceRepo:     preferenceRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				auditLogRepo:       newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = approvalRequest(req, v.userID)
+
+			var permissions []malak.Permission
+			if v.apiKey {
+				// the key and its creator hold the scope of the route so the
+				// request reaches the handler
+				permissions = append(permissions, malak.PermissionUpdatesRead)
+				req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{
+					ID: v.userID,
+					Roles: malak.UserRoles{
+						{WorkspaceID: testLinkWorkspaceID, Role: malak.RoleAdmin},
+					},
+				}))
+				req = req.WithContext(writeAPIKeyToCtx(req.Context(), &malak.APIKey{
+					ID:     uuid.New(),
+					Scopes: permissions,
+				}))
+			}
+
+			WrapMalakHTTPHandler(getLogger(t), u.reviewUpdate, getConfig(), "updates.approvals.create", permissions...).
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateListApprovalsTestTable() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not list approvals",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().ListApprovals(gomock.Any(), testLinkUpdateID).
+					Return(nil, errors.New("could not list approvals"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed approvals",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().ListApprovals(gomock.Any(), testLinkUpdateID).
+					Return([]malak.UpdateApproval{
+						{
+							Reference:   "update_approval_test",
+							UpdateID:    testLinkUpdateID,
+							WorkspaceID: testLinkWorkspaceID,
+							ApproverID:  testApproverID,
+							Decision:    malak.UpdateApprovalDecisionChangesRequested,
+							Comment:     "please add the revenue chart",
+							CreatedAt:   testLinkSentAt,
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ListApprovals(t *testing.T) {
+	for _, v := range generateListApprovalsTestTable() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:        getConfig(),
+				updateRepo: updateRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := approvalRequest(httptest.NewRequest(http.MethodGet, "/", nil), testApproverID)
+
+			WrapMalakHTTPHandler(getLogger(t), u.listApprovals, getConfig(), "updates.approvals.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
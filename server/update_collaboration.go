@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// maxCollaborationMessageSize caps a single message from an editor. A
+// message only carries the blocks that changed
+const maxCollaborationMessageSize = 1 << 20
+
+// @Tags updates
+// @Description Join the collaborative editing of a draft over a websocket. Editors send operations messages and receive sync, operations, presence and error messages
+// @Id collaborateOnUpdate
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param token query string false "access token. browsers cannot set the Authorization header on websockets"
+// @Success 101
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 403 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/collaborate [get]
+func (u *updatesHandler) collaborate(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "updates.collaborate", u.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		logger := logger.With(zap.String("request_id", rid))
+
+		if !hasPermission(ctx, malak.PermissionUpdatesWrite) {
+			_ = render.Render(w, r, newAPIStatus(http.StatusForbidden,
+				"you do not have permission to perform this action"))
+			return
+		}
+
+		update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+		if status == StatusFailed {
+			_ = render.Render(w, r, resp)
+			return
+		}
+
+		if update.IsSent() {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest,
+				"update has been sent already. you cannot edit it"))
+			return
+		}
+
+		span.SetAttributes(attribute.String("update.id", update.ID.String()))
+
+		user := getUserFromContext(ctx)
+
+		websocket.Server{
+			Handshake: u.checkCollaborationOrigin,
+			Handler: func(conn *websocket.Conn) {
+				conn.MaxPayloadBytes = maxCollaborationMessageSize
+
+				// the edits are saved once the editor leaves so this
+				// must outlive the connection
+				u.serveCollaboration(context.WithoutCancel(ctx), logger, conn, update,
+					collaboration.Editor{
+						UserID:   user.ID,
+						FullName: user.FullName,
+						Email:    user.Email,
+					})
+			},
+		}.ServeHTTP(w, r)
+	}
+}
+
+// checkCollaborationOrigin only accepts websockets opened from the
+// dashboard. The token can be sent in the query so any other site could
+// otherwise open one with a leaked link
+func (u *updatesHandler) checkCollaborationOrigin(cfg *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(cfg, r)
+	if err != nil {
+		return err
+	}
+
+	if origin == nil {
+		return errors.New("origin not provided")
+	}
+
+	allowed, err := url.Parse(u.cfg.Frontend.AppURL)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(origin.Scheme, allowed.Scheme) ||
+		!strings.EqualFold(origin.Host, allowed.Host) {
+		return errors.New("origin not allowed")
+	}
+
+	cfg.Origin = origin
+	return nil
+}
+
+func (u *updatesHandler) serveCollaboration(ctx context.Context,
+	logger *zap.Logger, conn *websocket.Conn,
+	update *malak.Update, editor collaboration.Editor) {
+
+	defer conn.Close()
+
+	session, err := u.collaborationHub.Join(ctx, update, editor)
+	if err != nil {
+		logger.Error("could not join collaborative editing", zap.Error(err))
+		_ = websocket.JSON.Send(conn, collaboration.Message{
+			Type:    collaboration.MessageTypeError,
+			Message: "could not join the draft",
+		})
+		return
+	}
+
+	defer session.Leave(ctx)
+
+	logger = logger.With(zap.String("session_id", session.ID))
+
+	go func() {
+		for msg := range session.Messages() {
+			if err := websocket.JSON.Send(conn, msg); err != nil {
+				logger.Debug("could not send collaboration message", zap.Error(err))
+				break
+			}
+		}
+
+		// the editor left or fell too far behind. Closing the
+		// connection stops the reads below
+		_ = conn.Close()
+	}()
+
+	for {
+		var msg collaboration.Message
+
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			if !errors.Is(err, io.EOF) {
+				logger.Debug("collaboration connection closed", zap.Error(err))
+			}
+			return
+		}
+
+		if msg.Type != collaboration.MessageTypeOperations {
+			continue
+		}
+
+		if err := session.Apply(ctx, msg.Operations); err != nil {
+			logger.Debug("invalid collaboration operations", zap.Error(err))
+		}
+	}
+}
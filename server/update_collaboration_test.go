@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+func collaborationRequest(r *http.Request, role malak.Role) *http.Request {
+	ctx := writeWorkspaceToCtx(r.Context(), &malak.Workspace{ID: testLinkWorkspaceID})
+	ctx = writeUserToCtx(ctx, &malak.User{
+		ID:       uuid.MustParse("4d7f8b55-2c5b-4bb4-9c1b-6a8f2e1b3c4d"),
+		FullName: "Lanre Adelowo",
+		Email:    "lanre@malak.vc",
+		Roles: malak.UserRoles{
+			{WorkspaceID: testLinkWorkspaceID, Role: role},
+		},
+	})
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("reference", "update_test")
+
+	return r.WithContext(context.WithValue(ctx, chi.RouteCtxKey, routeCtx))
+}
+
+func generateCollaborateRequest() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	role               malak.Role
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		role               malak.Role
+		expectedStatusCode int
+	}{
+		{
+			name:               "cannot edit updates",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			role:               malak.RoleInvestor,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			role:               malak.RoleMember,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "update already sent",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+			},
+			role:               malak.RoleMember,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_Collaborate(t *testing.T) {
+	for _, v := range generateCollaborateRequest() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:        getConfig(),
+				updateRepo: updateRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := collaborationRequest(httptest.NewRequest(http.MethodGet, "/", nil), v.role)
+
+			u.collaborate(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestUpdatesHandler_CollaborateWebsocket(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+	pubsub := malak_mocks.NewMockPubSub(controller)
+	subscription := malak_mocks.NewMockSubscription(controller)
+
+	messages := make(chan []byte)
+
+	pubsub.EXPECT().Subscribe(gomock.Any(), gomock.Any()).
+		Return(subscription, nil)
+
+	pubsub.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(nil)
+
+	subscription.EXPECT().Messages().
+		AnyTimes().
+		Return(messages)
+
+	subscription.EXPECT().Close().
+		DoAndReturn(func() error {
+			close(messages)
+			return nil
+		})
+
+	updateRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(testDraftUpdate(), nil)
+
+	updateRepo.EXPECT().GetByID(gomock.Any(), testLinkUpdateID).
+		Return(testDraftUpdate(), nil)
+
+	saved := make(chan *malak.Update, 1)
+
+	updateRepo.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, update *malak.Update, _ *malak.UpdateRevision) error {
+			saved <- update
+			return nil
+		})
+
+	u := &updatesHandler{
+		cfg:              getConfig(),
+		updateRepo:       updateRepo,
+		collaborationHub: collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{}),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.collaborate(getLogger(t)).ServeHTTP(w, collaborationRequest(r, malak.RoleMember))
+	}))
+	defer srv.Close()
+
+	u.cfg.Frontend.AppURL = srv.URL
+
+	conn, err := websocket.Dial(strings.Replace(srv.URL, "http", "ws", 1), "", srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetDeadline(time.Now().Add(time.Second*5)))
+
+	receive := func(msgType collaboration.MessageType) collaboration.Message {
+		for {
+			var msg collaboration.Message
+			require.NoError(t, websocket.JSON.Receive(conn, &msg))
+
+			if msg.Type == msgType {
+				return msg
+			}
+		}
+	}
+
+	msg := receive(collaboration.MessageTypeSync)
+	require.NotEmpty(t, msg.SessionID)
+	require.Len(t, msg.Operations, len(testDraftUpdate().Content))
+	require.Len(t, msg.Editors, 1)
+	require.Equal(t, "Lanre Adelowo", msg.Editors[0].FullName)
+
+	require.NoError(t, websocket.JSON.Send(conn, collaboration.Message{
+		Type:       collaboration.MessageTypeOperations,
+		Operations: []collaboration.Operation{{BlockID: "new-block"}},
+	}))
+
+	msg = receive(collaboration.MessageTypeError)
+	require.Equal(t, "please provide the block", msg.Message)
+
+	require.NoError(t, websocket.JSON.Send(conn, collaboration.Message{
+		Type: collaboration.MessageTypeOperations,
+		Operations: []collaboration.Operation{
+			{
+				BlockID:  "new-block",
+				Block:    &malak.Block{ID: "new-block", Type: "paragraph"},
+				Position: 100,
+			},
+		},
+	}))
+
+	require.NoError(t, conn.Close())
+
+	// the edits are saved once the last editor leaves
+	select {
+	case update := <-saved:
+		require.Len(t, update.Content, len(testDraftUpdate().Content)+1)
+		require.Equal(t, "new-block", update.Content[len(update.Content)-1].ID)
+	case <-time.After(time.Second * 5):
+		t.Fatal("edits were not saved")
+	}
+}
+
+func TestUpdatesHandler_CollaborateWebsocketOrigin(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+	updateRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(testDraftUpdate(), nil)
+
+	u := &updatesHandler{
+		cfg:        getConfig(),
+		updateRepo: updateRepo,
+	}
+
+	u.cfg.Frontend.AppURL = "https://app.malak.vc"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.collaborate(getLogger(t)).ServeHTTP(w, collaborationRequest(r, malak.RoleMember))
+	}))
+	defer srv.Close()
+
+	// other sites cannot open the websocket
+	_, err := websocket.Dial(strings.Replace(srv.URL, "http", "ws", 1), "", "https://evil.example")
+	require.Error(t, err)
+}
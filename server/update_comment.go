@@ -0,0 +1,508 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	"github.com/ayinke-llc/malak/internal/pkg/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const maxUpdateCommentLength = 5000
+
+// react records the reaction of a recipient. Changing a reaction does not
+// count as a new one
+func react(updateStat *malak.UpdateStat,
+	recipientStat *malak.UpdateRecipientStat, reaction malak.ReactionStatus) {
+
+	if !recipientStat.HasReaction {
+		updateStat.TotalReactions += 1
+	}
+
+	recipientStat.HasReaction = true
+
+	if !util.IsStringEmpty(reaction.String()) {
+		recipientStat.Reaction = reaction
+	}
+}
+
+// updateViewURL links to the public view of the update for a recipient
+func updateViewURL(cfg config.Config, recipient malak.Reference) string {
+	params := url.Values{}
+	params.Set("signature", malak.SignUpdateView(cfg.Auth.JWT.Key, recipient))
+
+	return strings.TrimSuffix(cfg.Frontend.AppURL, "/") + "/updates/view/" + recipient.String() + "?" + params.Encode()
+}
+
+type createUpdateCommentRequest struct {
+	Content string `json:"content,omitempty" validate:"required"`
+	// ParentReference is only used by investors. It is the thread the
+	// comment goes into. A new thread is started if empty
+	ParentReference malak.Reference `json:"parent_reference,omitempty" validate:"optional"`
+	GenericRequest
+}
+
+func (c *createUpdateCommentRequest) Validate() error {
+	c.Content = strings.TrimSpace(c.Content)
+
+	if util.IsStringEmpty(c.Content) {
+		return errors.New("please provide your comment")
+	}
+
+	if len(c.Content) > maxUpdateCommentLength {
+		return errors.New("comment cannot be more than 5000 characters")
+	}
+
+	return nil
+}
+
+type updateReactionRequest struct {
+	Reaction malak.ReactionStatus `json:"reaction,omitempty" validate:"required"`
+	GenericRequest
+}
+
+func (c *updateReactionRequest) Validate() error {
+	if !c.Reaction.IsValid() {
+		return errors.New("please provide a valid reaction")
+	}
+
+	return nil
+}
+
+// publicRecipient resolves the recipient and update from the signed link to
+// the public view of an update
+func (u *updatesHandler) publicRecipient(ctx context.Context,
+	logger *zap.Logger,
+	r *http.Request) (*malak.UpdateRecipient, *malak.Update, render.Renderer, Status) {
+
+	ref := malak.Reference(chi.URLParam(r, "reference"))
+	signature := r.URL.Query().Get("signature")
+
+	if util.IsStringEmpty(ref.String()) || util.IsStringEmpty(signature) {
+		return nil, nil, newAPIStatus(http.StatusBadRequest, "invalid update link"), StatusFailed
+	}
+
+	if !malak.VerifyUpdateView(u.cfg.Auth.JWT.Key, ref, signature) {
+		return nil, nil, newAPIStatus(http.StatusBadRequest, "invalid update link"), StatusFailed
+	}
+
+	recipient, err := u.updateRepo.GetRecipient(ctx, ref)
+	if errors.Is(err, malak.ErrUpdateRecipientNotFound) {
+		return nil, nil, newAPIStatus(http.StatusNotFound, "recipient does not exist"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch recipient", zap.Error(err))
+		return nil, nil, newAPIStatus(http.StatusInternalServerError, "could not fetch recipient"), StatusFailed
+	}
+
+	update, err := u.updateRepo.GetByID(ctx, recipient.UpdateID)
+	if err != nil {
+		logger.Error("could not fetch update", zap.Error(err),
+			zap.String("update_id", recipient.UpdateID.String()))
+		return nil, nil, newAPIStatus(http.StatusInternalServerError, "could not fetch update"), StatusFailed
+	}
+
+	return recipient, update, nil, StatusSuccess
+}
+
+// publicUpdateResponse renders the update with the threads of the
+// recipient. The details of the contact and team members are left out
+func (u *updatesHandler) publicUpdateResponse(ctx context.Context,
+	logger *zap.Logger, msg string,
+	update *malak.Update,
+	recipient *malak.UpdateRecipient) (render.Renderer, Status) {
+
+	comments, err := u.commentRepo.List(ctx, malak.ListUpdateCommentOptions{
+		UpdateID:    update.ID,
+		RecipientID: recipient.ID,
+	})
+	if err != nil {
+		logger.Error("could not list comments", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not list comments"), StatusFailed
+	}
+
+	for i := range comments {
+		comments[i].Recipient = nil
+		comments[i].User = nil
+	}
+
+	// the recipient sees the same blocks and merge fields as in the email
+	update.Content = update.Content.Personalize(recipient.Contact)
+	update.Title = malak.NewMergeFields(recipient.Contact).Replace(update.Title)
+
+	var reaction malak.ReactionStatus
+	if recipient.UpdateRecipientStat != nil {
+		reaction = recipient.UpdateRecipientStat.Reaction
+	}
+
+	return fetchPublicUpdateResponse{
+		APIStatus: newAPIStatus(http.StatusOK, msg),
+		Update:    util.DeRef(update),
+		Reaction:  reaction,
+		Threads:   malak.GroupUpdateComments(comments),
+	}, StatusSuccess
+}
+
+// @Description Fetch the public view of an update from the signed link in the email
+// @Tags updates
+// @Id fetchPublicUpdate
+// @Produce  json
+// @Param reference path string required "recipient unique reference.. e.g update_recipient_"
+// @Param signature query string true "signature from the email"
+// @Success 200 {object} fetchPublicUpdateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/updates/{reference} [get]
+func (u *updatesHandler) publicUpdateDetails(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("fetching public view of update")
+
+	recipient, update, resp, status := u.publicRecipient(ctx, logger, r)
+	if status != StatusSuccess {
+		return resp, status
+	}
+
+	span.SetAttributes(attribute.String("update_id", update.ID.String()))
+
+	return u.publicUpdateResponse(ctx, logger, "update fetched", update, recipient)
+}
+
+// @Description Comment on an update from the signed link in the email
+// @Tags updates
+// @Id createPublicUpdateComment
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "recipient unique reference.. e.g update_recipient_"
+// @Param signature query string true "signature from the email"
+// @Param message body createUpdateCommentRequest true "comment body"
+// @Success 200 {object} fetchPublicUpdateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/updates/{reference}/comments [post]
+func (u *updatesHandler) publicComment(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	req := new(createUpdateCommentRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	recipient, update, resp, status := u.publicRecipient(ctx, logger, r)
+	if status != StatusSuccess {
+		return resp, status
+	}
+
+	logger = logger.With(zap.String("update_id", update.ID.String()))
+
+	span.SetAttributes(attribute.String("update_id", update.ID.String()))
+
+	logger.Debug("adding investor comment to update")
+
+	comment := &malak.UpdateComment{
+		Reference:   u.referenceGenerator.Generate(malak.EntityTypeUpdateComment),
+		WorkspaceID: update.WorkspaceID,
+		UpdateID:    update.ID,
+		RecipientID: recipient.ID,
+		AuthorType:  malak.UpdateCommentAuthorInvestor,
+		Content:     req.Content,
+	}
+
+	if recipient.Contact != nil {
+		comment.AuthorName = malak.ContactDisplayName(recipient.Contact)
+	}
+
+	if !util.IsStringEmpty(req.ParentReference.String()) {
+		parent, err := u.commentRepo.Get(ctx, malak.FetchUpdateCommentOptions{
+			UpdateID:  update.ID,
+			Reference: req.ParentReference,
+		})
+		if err != nil && !errors.Is(err, malak.ErrUpdateCommentNotFound) {
+			logger.Error("could not fetch comment", zap.Error(err))
+			return newAPIStatus(http.StatusInternalServerError, "could not fetch comment"), StatusFailed
+		}
+
+		// threads of other investors are treated as missing
+		if err != nil || parent.RecipientID != recipient.ID || !parent.IsThread() {
+			return newAPIStatus(http.StatusNotFound, "comment does not exist"), StatusFailed
+		}
+
+		comment.ParentID = parent.ID
+	}
+
+	if err := u.commentRepo.Create(ctx, comment); err != nil {
+		logger.Error("could not create comment", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not add comment"), StatusFailed
+	}
+
+	// the comment is saved already so a failed notification is not
+	// surfaced to the investor
+	if err := u.queueHandler.Add(ctx, queue.QueueTopicUpdateCommentNotification,
+		queue.UpdateCommentNotificationOptions{
+			WorkspaceID: update.WorkspaceID,
+			UserID:      update.CreatedBy,
+			UpdateTitle: update.Title,
+			AuthorName:  comment.AuthorName,
+			Content:     comment.Content,
+			Link:        strings.TrimSuffix(u.cfg.Frontend.AppURL, "/") + "/updates/" + update.Reference.String(),
+		}); err != nil {
+		logger.Error("could not queue comment notification", zap.Error(err))
+	}
+
+	return u.publicUpdateResponse(ctx, logger, "comment added", update, recipient)
+}
+
+// @Description React to an update from the signed link in the email
+// @Tags updates
+// @Id createPublicUpdateReaction
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "recipient unique reference.. e.g update_recipient_"
+// @Param signature query string true "signature from the email"
+// @Param message body updateReactionRequest true "reaction body"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/updates/{reference}/reactions [post]
+func (u *updatesHandler) publicReaction(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	req := new(updateReactionRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	recipient, update, resp, status := u.publicRecipient(ctx, logger, r)
+	if status != StatusSuccess {
+		return resp, status
+	}
+
+	span.SetAttributes(attribute.String("update_id", update.ID.String()))
+
+	if recipient.UpdateRecipientStat == nil {
+		return newAPIStatus(http.StatusBadRequest, "update has not been sent to you yet"), StatusFailed
+	}
+
+	logger.Debug("reacting to update")
+
+	updateStat, err := u.updateRepo.Stat(ctx, update)
+	if err != nil {
+		logger.Error("could not fetch update stats", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not find update stat"), StatusFailed
+	}
+
+	react(updateStat, recipient.UpdateRecipientStat, req.Reaction)
+
+	if err := u.updateRepo.UpdateStat(ctx, updateStat, recipient.UpdateRecipientStat); err != nil {
+		logger.Error("could not update stat", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not update reaction"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "added reaction"), StatusSuccess
+}
+
+// @Description List the comment threads on an update
+// @Tags updates
+// @Id listUpdateComments
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} listUpdateCommentsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/comments [get]
+func (u *updatesHandler) listComments(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+
+	span.SetAttributes(attribute.String("reference", ref))
+
+	logger = logger.With(zap.String("reference", ref))
+
+	logger.Debug("listing update comments")
+
+	update, err := u.updateRepo.Get(ctx, malak.FetchUpdateOptions{
+		Reference:   malak.Reference(ref),
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+	})
+	if errors.Is(err, malak.ErrUpdateNotFound) {
+		return newAPIStatus(http.StatusNotFound,
+			"update does not exists"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch update", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update"), StatusFailed
+	}
+
+	comments, err := u.commentRepo.List(ctx, malak.ListUpdateCommentOptions{
+		UpdateID: update.ID,
+	})
+	if err != nil {
+		logger.Error("could not list comments", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list comments"), StatusFailed
+	}
+
+	return listUpdateCommentsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "comments fetched"),
+		Threads:   malak.GroupUpdateComments(comments),
+	}, StatusSuccess
+}
+
+// @Description Reply to a comment thread on an update. The investor is notified by email
+// @Tags updates
+// @Id replyUpdateComment
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param comment_reference path string required "comment unique reference.. e.g update_comment_"
+// @Param message body createUpdateCommentRequest true "reply body"
+// @Success 200 {object} fetchUpdateCommentResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/comments/{comment_reference} [post]
+func (u *updatesHandler) replyComment(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+	commentRef := chi.URLParam(r, "comment_reference")
+
+	span.SetAttributes(attribute.String("reference", ref),
+		attribute.String("comment_reference", commentRef))
+
+	logger = logger.With(zap.String("reference", ref),
+		zap.String("comment_reference", commentRef))
+
+	req := new(createUpdateCommentRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	user := getUserFromContext(ctx)
+
+	update, err := u.updateRepo.Get(ctx, malak.FetchUpdateOptions{
+		Reference:   malak.Reference(ref),
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+	})
+	if errors.Is(err, malak.ErrUpdateNotFound) {
+		return newAPIStatus(http.StatusNotFound,
+			"update does not exists"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch update", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update"), StatusFailed
+	}
+
+	parent, err := u.commentRepo.Get(ctx, malak.FetchUpdateCommentOptions{
+		UpdateID:  update.ID,
+		Reference: malak.Reference(commentRef),
+	})
+	if errors.Is(err, malak.ErrUpdateCommentNotFound) {
+		return newAPIStatus(http.StatusNotFound, "comment does not exist"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch comment", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not fetch comment"), StatusFailed
+	}
+
+	logger.Debug("replying to update comment")
+
+	comment := &malak.UpdateComment{
+		Reference:   u.referenceGenerator.Generate(malak.EntityTypeUpdateComment),
+		WorkspaceID: update.WorkspaceID,
+		UpdateID:    update.ID,
+		RecipientID: parent.RecipientID,
+		ParentID:    parent.ID,
+		AuthorType:  malak.UpdateCommentAuthorTeam,
+		AuthorName:  user.FullName,
+		UserID:      user.ID,
+		Content:     req.Content,
+	}
+
+	// replying to a reply keeps the conversation in the same thread
+	if !parent.IsThread() {
+		comment.ParentID = parent.ParentID
+	}
+
+	if err := u.commentRepo.Create(ctx, comment); err != nil {
+		logger.Error("could not create comment", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not add reply"), StatusFailed
+	}
+
+	if parent.Recipient != nil && parent.Recipient.Contact != nil {
+		if err := u.queueHandler.Add(ctx, queue.QueueTopicUpdateCommentNotification,
+			queue.UpdateCommentNotificationOptions{
+				WorkspaceID: update.WorkspaceID,
+				Recipient:   parent.Recipient.Contact.Email,
+				UpdateTitle: update.Title,
+				AuthorName:  comment.AuthorName,
+				Content:     comment.Content,
+				Link:        updateViewURL(u.cfg, parent.Recipient.Reference),
+			}); err != nil {
+			logger.Error("could not queue reply notification", zap.Error(err))
+		}
+	}
+
+	return fetchUpdateCommentResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "reply added"),
+		Comment:   util.DeRef(comment),
+	}, StatusSuccess
+}
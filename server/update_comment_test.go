@@ -0,0 +1,773 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testCommentUpdateID    = uuid.MustParse("ed3daf26-5839-4b91-bf19-64c8a6b3bb7a")
+	testCommentRecipientID = uuid.MustParse("0bd4e4a2-7a0c-4d4b-8d89-9c2f1a4e6b11")
+	testCommentThreadID    = uuid.MustParse("5c1f3b6e-2a1d-4d3e-9a5b-7e8f9a0b1c2d")
+)
+
+func testCommentThread() []malak.UpdateComment {
+	createdAt := time.Date(2025, time.November, 14, 10, 0, 0, 0, time.UTC)
+
+	return []malak.UpdateComment{
+		{
+			ID:          testCommentThreadID,
+			Reference:   "update_comment_thread",
+			UpdateID:    testCommentUpdateID,
+			RecipientID: testCommentRecipientID,
+			AuthorType:  malak.UpdateCommentAuthorInvestor,
+			AuthorName:  "Ada Investor",
+			Content:     "How is hiring going?",
+			Recipient:   &malak.UpdateRecipient{Reference: "update_recipient_test"},
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			ID:          uuid.MustParse("9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"),
+			Reference:   "update_comment_reply",
+			UpdateID:    testCommentUpdateID,
+			RecipientID: testCommentRecipientID,
+			ParentID:    testCommentThreadID,
+			AuthorType:  malak.UpdateCommentAuthorTeam,
+			AuthorName:  "Lanre",
+			Content:     "We closed two engineering roles",
+			User:        &malak.User{FullName: "Lanre"},
+			CreatedAt:   createdAt.Add(time.Hour),
+			UpdatedAt:   createdAt.Add(time.Hour),
+		},
+	}
+}
+
+func testPublicRecipient() *malak.UpdateRecipient {
+	return &malak.UpdateRecipient{
+		ID:        testCommentRecipientID,
+		Reference: "update_recipient_test",
+		UpdateID:  testCommentUpdateID,
+		Contact: &malak.Contact{
+			FirstName: "Ada",
+			LastName:  "Investor",
+			Email:     "ada@example.com",
+		},
+		UpdateRecipientStat: &malak.UpdateRecipientStat{
+			Reaction:    malak.ReactionStatusThumbsUp,
+			HasReaction: true,
+		},
+	}
+}
+
+func testCommentUpdate() *malak.Update {
+	return &malak.Update{
+		ID:        testCommentUpdateID,
+		Reference: "update_test",
+		Title:     "November update",
+		Status:    malak.UpdateStatusSent,
+	}
+}
+
+func generatePublicUpdateDetails() []struct {
+	name               string
+	signature          string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository)
+	expectedStatusCode int
+} {
+
+	validSignature := malak.SignUpdateView(getConfig().Auth.JWT.Key, "update_recipient_test")
+
+	return []struct {
+		name               string
+		signature          string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid signature",
+			signature:          malak.SignUnsubscribe(getConfig().Auth.JWT.Key, "update_recipient_test"),
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "recipient not found",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), malak.Reference("update_recipient_test")).
+					Return(nil, malak.ErrUpdateRecipientNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "could not fetch update",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), testCommentUpdateID).
+					Return(nil, errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "could not list comments",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "fetched update",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), malak.ListUpdateCommentOptions{
+						UpdateID:    testCommentUpdateID,
+						RecipientID: testCommentRecipientID,
+					}).
+					Return(testCommentThread(), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "fetched personalized update",
+			signature: validSignature,
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				recipient := testPublicRecipient()
+				recipient.Contact.Metadata = malak.CustomContactMetadata{"check_size": "$50k"}
+				recipient.Contact.Lists = []malak.ContactListMapping{
+					{List: &malak.ContactList{Reference: "list_lead_investors"}},
+				}
+
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(recipient, nil)
+
+				sent := testCommentUpdate()
+				sent.Content = malak.BlockContents{
+					{
+						ID:   "greeting",
+						Type: "paragraph",
+						Content: []interface{}{
+							map[string]interface{}{"type": "text", "text": "Hi {{first_name}}, thanks for the {{ metadata.check_size }}"},
+						},
+					},
+					{
+						ID:    "lead",
+						Type:  "paragraph",
+						Props: map[string]interface{}{malak.BlockPropVisibleToList: "list_lead_investors"},
+						Content: []interface{}{
+							map[string]interface{}{"type": "text", "text": "Board deck attached"},
+						},
+					},
+					{
+						ID:    "angels",
+						Type:  "paragraph",
+						Props: map[string]interface{}{malak.BlockPropVisibleToList: "list_angels"},
+						Content: []interface{}{
+							map[string]interface{}{"type": "text", "text": "Angel round details"},
+						},
+					},
+				}
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(sent, nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]malak.UpdateComment{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_PublicUpdateDetails(t *testing.T) {
+	for _, v := range generatePublicUpdateDetails() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			commentRepo := malak_mocks.NewMockUpdateCommentRepository(controller)
+
+			v.mockFn(updateRepo, commentRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				commentRepo:        commentRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/?signature="+v.signature, nil)
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_recipient_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.publicUpdateDetails, getConfig(), "public.updates.fetch").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generatePublicComment() []struct {
+	name               string
+	req                createUpdateCommentRequest
+	mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		req                createUpdateCommentRequest
+		mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler)
+		expectedStatusCode int
+	}{
+		{
+			name: "empty comment",
+			req: createUpdateCommentRequest{
+				Content: "   ",
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "parent comment belongs to another investor",
+			req: createUpdateCommentRequest{
+				Content:         "Thanks",
+				ParentReference: "update_comment_thread",
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateComment{
+						ID:          testCommentThreadID,
+						RecipientID: uuid.New(),
+					}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not create comment",
+			req: createUpdateCommentRequest{
+				Content: "How is hiring going?",
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "comment added and team notified",
+			req: createUpdateCommentRequest{
+				Content:         "How is hiring going?",
+				ParentReference: "update_comment_thread",
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, q *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&testCommentThread()[0], nil)
+
+				comment.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *malak.UpdateComment) error {
+						if c.ParentID != testCommentThreadID || c.AuthorName != "Ada Investor" {
+							return errors.New("comment was not added to the thread")
+						}
+						return nil
+					})
+
+				q.EXPECT().
+					Add(gomock.Any(), queue.QueueTopicUpdateCommentNotification, gomock.Any()).
+					Return(nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(testCommentThread(), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_PublicComment(t *testing.T) {
+	for _, v := range generatePublicComment() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			commentRepo := malak_mocks.NewMockUpdateCommentRepository(controller)
+			queueHandler := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(updateRepo, commentRepo, queueHandler)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				commentRepo:        commentRepo,
+				queueHandler:       queueHandler,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/?signature="+
+				malak.SignUpdateView(getConfig().Auth.JWT.Key, "update_recipient_test"), b)
+			req.Header.Add("Content-Type", "application/json")
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_recipient_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.publicComment, getConfig(), "public.updates.comments.create").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generatePublicReaction() []struct {
+	name               string
+	req                updateReactionRequest
+	recipient          *malak.UpdateRecipient
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+
+	notDelivered := testPublicRecipient()
+	notDelivered.UpdateRecipientStat = nil
+
+	return []struct {
+		name               string
+		req                updateReactionRequest
+		recipient          *malak.UpdateRecipient
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid reaction",
+			req:                updateReactionRequest{Reaction: "heart"},
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "update not sent to recipient",
+			req:  updateReactionRequest{Reaction: malak.ReactionStatusThumbsDown},
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(notDelivered, nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not update stat",
+			req:  updateReactionRequest{Reaction: malak.ReactionStatusThumbsDown},
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				update.EXPECT().
+					Stat(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateStat{}, nil)
+
+				update.EXPECT().
+					UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "reaction stored",
+			req:  updateReactionRequest{Reaction: malak.ReactionStatusThumbsDown},
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().
+					GetRecipient(gomock.Any(), gomock.Any()).
+					Return(testPublicRecipient(), nil)
+
+				update.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				update.EXPECT().
+					Stat(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateStat{TotalReactions: 1}, nil)
+
+				update.EXPECT().
+					UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, stat *malak.UpdateStat,
+						recipientStat *malak.UpdateRecipientStat) error {
+						if stat.TotalReactions != 1 ||
+							recipientStat.Reaction != malak.ReactionStatusThumbsDown {
+							return errors.New("unexpected reaction")
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_PublicReaction(t *testing.T) {
+	for _, v := range generatePublicReaction() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/?signature="+
+				malak.SignUpdateView(getConfig().Auth.JWT.Key, "update_recipient_test"), b)
+			req.Header.Add("Content-Type", "application/json")
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_recipient_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.publicReaction, getConfig(), "public.updates.reactions.create").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateListUpdateComments() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not list comments",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed comments",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					List(gomock.Any(), malak.ListUpdateCommentOptions{
+						UpdateID: testCommentUpdateID,
+					}).
+					Return(testCommentThread(), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ListComments(t *testing.T) {
+	for _, v := range generateListUpdateComments() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			commentRepo := malak_mocks.NewMockUpdateCommentRepository(controller)
+
+			v.mockFn(updateRepo, commentRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				commentRepo:        commentRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.listComments, getConfig(), "updates.comments.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateReplyUpdateComment() []struct {
+	name               string
+	req                createUpdateCommentRequest
+	mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		req                createUpdateCommentRequest
+		mockFn             func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler)
+		expectedStatusCode int
+	}{
+		{
+			name: "empty reply",
+			req:  createUpdateCommentRequest{},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "comment not found",
+			req:  createUpdateCommentRequest{Content: "Thanks for asking"},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateCommentNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not create reply",
+			req:  createUpdateCommentRequest{Content: "Thanks for asking"},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, queue *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				comment.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&testCommentThread()[0], nil)
+
+				comment.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "reply to a reply stays in the thread",
+			req:  createUpdateCommentRequest{Content: "Thanks for asking"},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, comment *malak_mocks.MockUpdateCommentRepository, q *malak_mocks.MockQueueHandler) {
+				update.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(testCommentUpdate(), nil)
+
+				reply := testCommentThread()[1]
+				reply.Recipient = testPublicRecipient()
+
+				comment.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&reply, nil)
+
+				comment.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *malak.UpdateComment) error {
+						if c.ParentID != testCommentThreadID ||
+							c.AuthorType != malak.UpdateCommentAuthorTeam {
+							return errors.New("reply was not added to the thread")
+						}
+						return nil
+					})
+
+				q.EXPECT().
+					Add(gomock.Any(), queue.QueueTopicUpdateCommentNotification,
+						gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ queue.QueueTopic, v any) error {
+						opts := v.(queue.UpdateCommentNotificationOptions)
+						if opts.Recipient != "ada@example.com" {
+							return errors.New("investor was not notified")
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ReplyComment(t *testing.T) {
+	for _, v := range generateReplyUpdateComment() {
+
+		t.Run(v.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			commentRepo := malak_mocks.NewMockUpdateCommentRepository(controller)
+			queueHandler := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(updateRepo, commentRepo, queueHandler)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				commentRepo:        commentRepo,
+				queueHandler:       queueHandler,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{FullName: "Lanre"}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			ctx.URLParams.Add("comment_reference", "update_comment_reply")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.replyComment, getConfig(), "updates.comments.reply").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -0,0 +1,799 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type createUpdateLinkRequest struct {
+	GenericRequest
+
+	ExpiresAt *time.Time     `json:"expires_at,omitempty" validate:"optional"`
+	Password  malak.Password `json:"password,omitempty" validate:"optional"`
+}
+
+func (c *createUpdateLinkRequest) Validate() error {
+	if c.ExpiresAt != nil && c.ExpiresAt.Before(time.Now()) {
+		return errors.New("expiry date must be in the future")
+	}
+
+	return nil
+}
+
+// fetchWorkspaceUpdate fetches an update in the current workspace from the
+// reference in the url
+func (u *updatesHandler) fetchWorkspaceUpdate(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.Update, render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return nil, newAPIStatus(http.StatusBadRequest, "reference required"), StatusFailed
+	}
+
+	update, err := u.updateRepo.Get(ctx, malak.FetchUpdateOptions{
+		Reference:   malak.Reference(ref),
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+	})
+	if errors.Is(err, malak.ErrUpdateNotFound) {
+		return nil, newAPIStatus(http.StatusNotFound,
+			"update does not exists"), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch update", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update"), StatusFailed
+	}
+
+	return update, nil, StatusSuccess
+}
+
+// @Description create a shareable link to the web version of a sent update
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body createUpdateLinkRequest true "update link request body"
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} fetchUpdateLinkResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/links [post]
+func (u *updatesHandler) createLink(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("creating update link")
+
+	req := new(createUpdateLinkRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if !update.IsSent() {
+		return newAPIStatus(http.StatusBadRequest,
+			"only sent updates can be shared"), StatusFailed
+	}
+
+	link := &malak.UpdateLink{
+		Reference:           u.referenceGenerator.Generate(malak.EntityTypeLink),
+		UpdateID:            update.ID,
+		WorkspaceID:         update.WorkspaceID,
+		CreatedBy:           getUserFromContext(ctx).ID,
+		ExpiresAt:           req.ExpiresAt,
+		IsPasswordProtected: !req.Password.IsZero(),
+		// hashed when it is written to the database
+		Password: req.Password,
+	}
+
+	if err := u.linkRepo.Create(ctx, link); err != nil {
+		logger.Error("could not create update link", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not create update link"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateLinkGenerated,
+		malak.EntityTypeLink, link.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+	entry.Metadata["password_protected"] = strconv.FormatBool(link.IsPasswordProtected)
+
+	if link.ExpiresAt != nil {
+		entry.Metadata["expires_at"] = link.ExpiresAt.Format(time.RFC3339)
+	}
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchUpdateLinkResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update link created"),
+		Link:      hermes.DeRef(link),
+	}, StatusSuccess
+}
+
+// @Description list the shareable links of an update
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} listUpdateLinksResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/links [get]
+func (u *updatesHandler) listLinks(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing update links")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	links, err := u.linkRepo.List(ctx, update.ID)
+	if err != nil {
+		logger.Error("could not list update links", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list update links"), StatusFailed
+	}
+
+	return listUpdateLinksResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update links fetched"),
+		Links:     links,
+	}, StatusSuccess
+}
+
+// @Description revoke a shareable link of an update
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param link_reference path string required "link unique reference.. e.g link_"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/links/{link_reference} [delete]
+func (u *updatesHandler) revokeLink(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("revoking update link")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	link, err := u.linkRepo.Get(ctx, malak.FetchUpdateLinkOptions{
+		UpdateID:  update.ID,
+		Reference: malak.Reference(chi.URLParam(r, "link_reference")),
+	})
+	if err != nil {
+		logger.Error("could not fetch update link", zap.Error(err))
+		status := http.StatusInternalServerError
+		msg := "an error occurred while fetching update link"
+
+		if errors.Is(err, malak.ErrUpdateLinkNotFound) {
+			status = http.StatusNotFound
+			msg = err.Error()
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	if err := u.linkRepo.Revoke(ctx, link); err != nil {
+		logger.Error("could not revoke update link", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not revoke update link"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateLinkRevoked,
+		malak.EntityTypeLink, link.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return newAPIStatus(http.StatusOK, "update link revoked"), StatusSuccess
+}
+
+type updateVisibilityRequest struct {
+	GenericRequest
+
+	IsPublic bool `json:"is_public"`
+}
+
+func (c *updateVisibilityRequest) Validate() error { return nil }
+
+// @Description list or remove an update from the public page of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body updateVisibilityRequest true "update visibility request body"
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} createdUpdateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/visibility [post]
+func (u *updatesHandler) updateVisibility(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("updating visibility of update")
+
+	req := new(updateVisibilityRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if req.IsPublic && !update.IsSent() {
+		return newAPIStatus(http.StatusBadRequest,
+			"only sent updates can be made public"), StatusFailed
+	}
+
+	update.IsPublic = req.IsPublic
+
+	if err := u.updateRepo.Update(ctx, update); err != nil {
+		logger.Error("could not update visibility of update", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not update visibility"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateVisibilityUpdated,
+		malak.EntityTypeUpdate, update.Reference.String())
+	entry.Metadata["is_public"] = strconv.FormatBool(update.IsPublic)
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return createdUpdateResponse{
+		Update:    hermes.DeRef(update),
+		APIStatus: newAPIStatus(http.StatusOK, "visibility updated"),
+	}, StatusSuccess
+}
+
+// @Description list the web views of an update
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param page query int false "Page to query data from. Defaults to 1"
+// @Param per_page query int false "Number to items to return. Defaults to 10 items"
+// @Success 200 {object} listUpdateViewerSessionsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/views [get]
+func (u *updatesHandler) listViewerSessions(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing update viewer sessions")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	opts := malak.ListUpdateViewerSessionOptions{
+		Paginator: malak.PaginatorFromRequest(r),
+		UpdateID:  update.ID,
+	}
+
+	span.SetAttributes(opts.Paginator.OTELAttributes()...)
+
+	sessions, total, err := u.linkRepo.ListSessions(ctx, opts)
+	if err != nil {
+		logger.Error("could not list update viewer sessions", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list viewer sessions"), StatusFailed
+	}
+
+	return listUpdateViewerSessionsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "viewer sessions fetched"),
+		Sessions:  sessions,
+		Meta: meta{
+			Paging: pagingInfo{
+				PerPage: opts.Paginator.PerPage,
+				Page:    opts.Paginator.Page,
+				Total:   total,
+			},
+		},
+	}, StatusSuccess
+}
+
+type createUpdateViewerSession struct {
+	OS         string         `json:"os,omitempty" validate:"required"`
+	DeviceInfo string         `json:"device_info,omitempty" validate:"required"`
+	Browser    string         `json:"browser,omitempty" validate:"required"`
+	Password   malak.Password `json:"password,omitempty" validate:"optional"`
+
+	GenericRequest
+}
+
+func (c *createUpdateViewerSession) Validate() error {
+	if hermes.IsStringEmpty(c.OS) {
+		return errors.New("provide operating system of viewer")
+	}
+
+	if hermes.IsStringEmpty(c.DeviceInfo) {
+		return errors.New("provide device information")
+	}
+
+	if hermes.IsStringEmpty(c.Browser) {
+		return errors.New("provide browser information")
+	}
+
+	return nil
+}
+
+// renderUpdate converts the content of a sent update to html. Sent updates
+// cannot be edited so the html is cached. The version that was sent is
+// rendered with its metrics at send time. Public viewers are not contacts so
+// blocks restricted to lists are dropped and merge fields are left blank
+func (u *updatesHandler) renderUpdate(ctx context.Context,
+	logger *zap.Logger, update *malak.Update) string {
+
+	cacheKey := fmt.Sprintf("updates:public-html:%s", update.ID)
+
+	b, err := u.cache.Get(ctx, cacheKey)
+	if err == nil {
+		return string(b)
+	}
+
+	content := update.Content
+	renderer := u.chartRenderer
+
+	// updates sent before revisions were stored do not have one
+	revision, err := u.updateRepo.GetSentRevision(ctx, update.ID)
+	switch {
+	case err == nil:
+		content = revision.Content
+		renderer = malak.FrozenMetricsRenderer(u.chartRenderer, revision.Metrics)
+	case !errors.Is(err, malak.ErrUpdateRevisionNotFound):
+		// not cached so the sent version is shown once it can be fetched
+		logger.Error("could not fetch sent revision", zap.Error(err))
+		return content.Personalize(nil).HTML(update.WorkspaceID, renderer, nil)
+	}
+
+	html := content.Personalize(nil).HTML(update.WorkspaceID, renderer, nil)
+
+	if err := u.cache.Add(ctx, cacheKey, []byte(html), time.Hour*24); err != nil {
+		logger.Error("could not cache rendered update", zap.Error(err))
+	}
+
+	return html
+}
+
+// createViewerSession records a web view of an update. Failing to look up
+// the location of the viewer does not stop them from reading the update
+func (u *updatesHandler) createViewerSession(ctx context.Context,
+	logger *zap.Logger, r *http.Request,
+	req *createUpdateViewerSession, session *malak.UpdateViewerSession) error {
+
+	ipAddr := hermes.GetIP(r)
+
+	session.Reference = u.referenceGenerator.Generate(malak.EntityTypeUpdateViewerSession)
+	session.SessionID = u.referenceGenerator.Generate(malak.EntityTypeSession)
+	session.DeviceInfo = req.DeviceInfo
+	session.OS = req.OS
+	session.Browser = req.Browser
+	session.IPAddress = ipAddr.String()
+
+	ip, err := netip.ParseAddr(ipAddr.String())
+	if err == nil {
+		session.Country, session.City, err = u.geolocationService.FindByIP(ctx, ip)
+	}
+
+	if err != nil {
+		logger.Error("could not find location of viewer", zap.Error(err))
+	}
+
+	return u.linkRepo.CreateSession(ctx, session)
+}
+
+func publicUpdateFromUpdate(update *malak.Update,
+	workspace *malak.Workspace, html string) malak.PublicUpdate {
+
+	return malak.PublicUpdate{
+		Reference:     update.Reference,
+		Title:         update.Title,
+		WorkspaceName: workspace.WorkspaceName,
+		LogoURL:       workspace.LogoURL,
+		SentAt:        update.SentAt,
+		HTML:          html,
+	}
+}
+
+// @Description public api to view an update through a shareable link
+// @Tags updates-viewer
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "link unique reference.. e.g link_"
+// @Param message body createUpdateViewerSession true "update session request body"
+// @Success 200 {object} fetchPublicUpdateLinkResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/updates/links/{reference} [post]
+func (u *updatesHandler) publicLinkDetails(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("fetching update through shared link")
+
+	ref := chi.URLParam(r, "reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return newAPIStatus(http.StatusBadRequest, "reference required"), StatusFailed
+	}
+
+	span.SetAttributes(attribute.String("reference", ref))
+	logger = logger.With(zap.String("reference", ref))
+
+	req := new(createUpdateViewerSession)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	link, err := u.linkRepo.PublicDetails(ctx, malak.Reference(ref))
+	if err != nil {
+		logger.Error("could not fetch update link", zap.Error(err))
+		status := http.StatusInternalServerError
+		msg := "an error occurred while fetching update"
+
+		if errors.Is(err, malak.ErrUpdateLinkNotFound) {
+			status = http.StatusNotFound
+			msg = "update does not exists"
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	if link.Update == nil || !link.Update.IsSent() {
+		return newAPIStatus(http.StatusNotFound, "update does not exists"), StatusFailed
+	}
+
+	if link.IsExpired() {
+		return newAPIStatus(http.StatusBadRequest, "link has expired"), StatusFailed
+	}
+
+	if link.IsPasswordProtected {
+		if req.Password.IsZero() {
+			return newAPIStatus(http.StatusUnauthorized, "password required to view update"), StatusFailed
+		}
+
+		if !malak.VerifyPassword(string(link.Password), string(req.Password)) {
+			return newAPIStatus(http.StatusUnauthorized, "update password not correct"), StatusFailed
+		}
+	}
+
+	workspace, err := u.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		ID: link.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update"), StatusFailed
+	}
+
+	session := &malak.UpdateViewerSession{
+		UpdateID: link.UpdateID,
+		LinkID:   link.ID,
+		Source:   malak.UpdateViewSourceLink,
+	}
+
+	if err := u.createViewerSession(ctx, logger, r, req, session); err != nil {
+		logger.Error("could not create update viewer session", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not create viewer session"), StatusFailed
+	}
+
+	return fetchPublicUpdateLinkResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "fetched update"),
+		Update:    publicUpdateFromUpdate(link.Update, workspace, u.renderUpdate(ctx, logger, link.Update)),
+		SessionID: session.SessionID,
+	}, StatusSuccess
+}
+
+// fetchPublicWorkspace fetches the workspace from the reference in the url
+func (u *updatesHandler) fetchPublicWorkspace(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.Workspace, render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return nil, newAPIStatus(http.StatusBadRequest, "reference required"), StatusFailed
+	}
+
+	workspace, err := u.workspaceRepo.Get(ctx, &malak.FindWorkspaceOptions{
+		Reference: malak.Reference(ref),
+	})
+	if err != nil {
+		logger.Error("could not fetch workspace", zap.Error(err))
+		status := http.StatusInternalServerError
+		msg := "an error occurred while fetching workspace"
+
+		if errors.Is(err, malak.ErrWorkspaceNotFound) {
+			status = http.StatusNotFound
+			msg = err.Error()
+		}
+
+		return nil, newAPIStatus(status, msg), StatusFailed
+	}
+
+	if workspace.IsBanned {
+		return nil, newAPIStatus(http.StatusNotFound, malak.ErrWorkspaceNotFound.Error()), StatusFailed
+	}
+
+	return workspace, nil, StatusSuccess
+}
+
+// @Description public api to list the updates a workspace has made public
+// @Tags updates-viewer
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "workspace unique reference.. e.g workspace_"
+// @Param page query int false "Page to query data from. Defaults to 1"
+// @Param per_page query int false "Number to items to return. Defaults to 10 items"
+// @Success 200 {object} listPublicUpdatesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/workspaces/{reference}/updates [get]
+func (u *updatesHandler) listPublicUpdates(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing public updates of workspace")
+
+	workspace, resp, status := u.fetchPublicWorkspace(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	opts := malak.ListPublicUpdatesOptions{
+		Paginator:   malak.PaginatorFromRequest(r),
+		WorkspaceID: workspace.ID,
+	}
+
+	span.SetAttributes(opts.Paginator.OTELAttributes()...)
+
+	updates, total, err := u.linkRepo.ListPublicUpdates(ctx, opts)
+	if err != nil {
+		logger.Error("could not list public updates", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list updates"), StatusFailed
+	}
+
+	publicUpdates := make([]malak.PublicUpdate, 0, len(updates))
+
+	for _, update := range updates {
+		publicUpdates = append(publicUpdates, publicUpdateFromUpdate(&update, workspace, ""))
+	}
+
+	return listPublicUpdatesResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "updates fetched"),
+		Updates:   publicUpdates,
+		Meta: meta{
+			Paging: pagingInfo{
+				PerPage: opts.Paginator.PerPage,
+				Page:    opts.Paginator.Page,
+				Total:   total,
+			},
+		},
+	}, StatusSuccess
+}
+
+// @Description public api to view an update listed on the public page of a workspace
+// @Tags updates-viewer
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "workspace unique reference.. e.g workspace_"
+// @Param update_reference path string required "update unique reference.. e.g update_"
+// @Param message body createUpdateViewerSession true "update session request body"
+// @Success 200 {object} fetchPublicUpdateLinkResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/workspaces/{reference}/updates/{update_reference} [post]
+func (u *updatesHandler) publicWorkspaceUpdate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("fetching public update of workspace")
+
+	req := new(createUpdateViewerSession)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	workspace, resp, status := u.fetchPublicWorkspace(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	update, err := u.updateRepo.Get(ctx, malak.FetchUpdateOptions{
+		Reference:   malak.Reference(chi.URLParam(r, "update_reference")),
+		WorkspaceID: workspace.ID,
+	})
+	if err != nil && !errors.Is(err, malak.ErrUpdateNotFound) {
+		logger.Error("could not fetch update", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update"), StatusFailed
+	}
+
+	// updates that have not been made public are treated as missing
+	if err != nil || !update.IsSent() || !update.IsPublic {
+		return newAPIStatus(http.StatusNotFound, "update does not exists"), StatusFailed
+	}
+
+	session := &malak.UpdateViewerSession{
+		UpdateID: update.ID,
+		Source:   malak.UpdateViewSourceWorkspacePage,
+	}
+
+	if err := u.createViewerSession(ctx, logger, r, req, session); err != nil {
+		logger.Error("could not create update viewer session", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not create viewer session"), StatusFailed
+	}
+
+	return fetchPublicUpdateLinkResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "fetched update"),
+		Update:    publicUpdateFromUpdate(update, workspace, u.renderUpdate(ctx, logger, update)),
+		SessionID: session.SessionID,
+	}, StatusSuccess
+}
+
+type updateUpdateViewerSession struct {
+	TimeSpent int64           `json:"time_spent,omitempty" validate:"required"`
+	SessionID malak.Reference `json:"session_id,omitempty" validate:"required"`
+
+	GenericRequest
+}
+
+func (c *updateUpdateViewerSession) Validate() error {
+	if hermes.IsStringEmpty(c.SessionID.String()) {
+		return errors.New("provide the session id")
+	}
+
+	if c.TimeSpent < 0 {
+		return errors.New("time spent cannot be negative")
+	}
+
+	return nil
+}
+
+// @Description public api to record how long an update was viewed for
+// @Tags updates-viewer
+// @Accept  json
+// @Produce  json
+// @Param message body updateUpdateViewerSession true "update session request body"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /public/updates/sessions [put]
+func (u *updatesHandler) updateViewerSession(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("updating update viewer session")
+
+	req := new(updateUpdateViewerSession)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	logger = logger.With(zap.String("session_id", req.SessionID.String()))
+
+	session, err := u.linkRepo.FindSession(ctx, req.SessionID)
+	if err != nil {
+		logger.Error("could not find update viewer session", zap.Error(err))
+		status := http.StatusInternalServerError
+		msg := "an error occurred while fetching session"
+
+		if errors.Is(err, malak.ErrUpdateViewerSessionNotFound) {
+			status = http.StatusNotFound
+			msg = err.Error()
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	session.TimeSpentSeconds = req.TimeSpent
+
+	if err := u.linkRepo.UpdateSession(ctx, session); err != nil {
+		logger.Error("could not update viewer session", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not update viewer session"), StatusFailed
+	}
+
+	return newAPIStatus(http.StatusOK, "session updated"), StatusSuccess
+}
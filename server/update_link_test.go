@@ -0,0 +1,1020 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testLinkUpdateID    = uuid.MustParse("3f0c2b5e-8d4a-4c1e-9b7f-2a6d5e4c3b21")
+	testLinkWorkspaceID = uuid.MustParse("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	testLinkSentAt      = time.Date(2025, time.November, 16, 9, 0, 0, 0, time.UTC)
+)
+
+func testLinkUpdate() *malak.Update {
+	return &malak.Update{
+		ID:          testLinkUpdateID,
+		WorkspaceID: testLinkWorkspaceID,
+		Reference:   "update_test",
+		Title:       "November update",
+		Status:      malak.UpdateStatusSent,
+		SentAt:      hermes.Ref(testLinkSentAt),
+		Content: malak.BlockContents{
+			{
+				ID:   "block",
+				Type: "paragraph",
+				Content: []interface{}{
+					map[string]interface{}{
+						"type":   "text",
+						"text":   "Revenue grew by 20%",
+						"styles": map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testLinkWorkspace() *malak.Workspace {
+	return &malak.Workspace{
+		ID:            testLinkWorkspaceID,
+		Reference:     "workspace_test",
+		WorkspaceName: "Malak",
+		LogoURL:       "https://example.com/logo.png",
+	}
+}
+
+func generateCreateUpdateLink() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository)
+	expectedStatusCode int
+	req                createUpdateLinkRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository)
+		expectedStatusCode int
+		req                createUpdateLinkRequest
+	}{
+		{
+			name:               "expiry date in the past",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createUpdateLinkRequest{
+				ExpiresAt: hermes.Ref(time.Now().Add(-time.Hour)),
+			},
+		},
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "update has not been sent",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				u := testLinkUpdate()
+				u.Status = malak.UpdateStatusDraft
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(u, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not create link",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				link.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not create link"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "created password protected link",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				link.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: createUpdateLinkRequest{
+				Password: "secret",
+			},
+		},
+	}
+}
+
+func TestUpdatesHandler_CreateLink(t *testing.T) {
+	for _, v := range generateCreateUpdateLink() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+
+			v.mockFn(updateRepo, linkRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				linkRepo:           linkRepo,
+				auditLogRepo:       newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.createLink, getConfig(), "updates.links.create").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateRevokeUpdateLink() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "link not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				link.EXPECT().Get(gomock.Any(), malak.FetchUpdateLinkOptions{
+					UpdateID:  testLinkUpdateID,
+					Reference: "link_test",
+				}).Return(nil, malak.ErrUpdateLinkNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not revoke link",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				link.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateLink{Reference: "link_test"}, nil)
+
+				link.EXPECT().Revoke(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not revoke link"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "revoked link",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				link.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateLink{Reference: "link_test"}, nil)
+
+				link.EXPECT().Revoke(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_RevokeLink(t *testing.T) {
+	for _, v := range generateRevokeUpdateLink() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+
+			v.mockFn(updateRepo, linkRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				updateRepo:   updateRepo,
+				linkRepo:     linkRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			ctx.URLParams.Add("link_reference", "link_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.revokeLink, getConfig(), "updates.links.revoke").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateVisibility() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+	req                updateVisibilityRequest
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+		req                updateVisibilityRequest
+	}{
+		{
+			name: "draft cannot be made public",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				u := testLinkUpdate()
+				u.Status = malak.UpdateStatusDraft
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(u, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req:                updateVisibilityRequest{IsPublic: true},
+		},
+		{
+			name: "could not update visibility",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not update"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                updateVisibilityRequest{IsPublic: true},
+		},
+		{
+			name: "made update public",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                updateVisibilityRequest{IsPublic: true},
+		},
+	}
+}
+
+func TestUpdatesHandler_UpdateVisibility(t *testing.T) {
+	for _, v := range generateUpdateVisibility() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				updateRepo:   updateRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.updateVisibility, getConfig(), "updates.visibility").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func testPasswordProtectedLink(t *testing.T) *malak.UpdateLink {
+	hashed, err := malak.HashPassword("secret")
+	require.NoError(t, err)
+
+	link := testUpdateLink()
+	link.IsPasswordProtected = true
+	link.Password = malak.Password(hashed)
+	return link
+}
+
+func testUpdateLink() *malak.UpdateLink {
+	return &malak.UpdateLink{
+		ID:          uuid.MustParse("c6a1f0e2-3b4d-4e5f-9a8b-7c6d5e4f3a2b"),
+		Reference:   "link_test",
+		UpdateID:    testLinkUpdateID,
+		WorkspaceID: testLinkWorkspaceID,
+		Update:      testLinkUpdate(),
+	}
+}
+
+var validViewerSession = createUpdateViewerSession{
+	OS:         "iOS",
+	DeviceInfo: "iPhone",
+	Browser:    "Safari",
+}
+
+func generatePublicLinkDetails(t *testing.T) []struct {
+	name   string
+	mockFn func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+		workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+		cacheRepo *malak_mocks.MockCache)
+	expectedStatusCode int
+	req                createUpdateViewerSession
+} {
+
+	withPassword := validViewerSession
+	withPassword.Password = "secret"
+
+	withWrongPassword := validViewerSession
+	withWrongPassword.Password = "not-secret"
+
+	return []struct {
+		name   string
+		mockFn func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+			workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+			cacheRepo *malak_mocks.MockCache)
+		expectedStatusCode int
+		req                createUpdateViewerSession
+	}{
+		{
+			name: "no browser provided",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createUpdateViewerSession{
+				OS:         "iOS",
+				DeviceInfo: "iPhone",
+			},
+		},
+		{
+			name: "link not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), malak.Reference("link_test")).
+					Return(nil, malak.ErrUpdateLinkNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validViewerSession,
+		},
+		{
+			name: "link has expired",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				l := testUpdateLink()
+				l.ExpiresAt = hermes.Ref(time.Now().Add(-time.Minute))
+
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(l, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req:                validViewerSession,
+		},
+		{
+			name: "password required",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(testPasswordProtectedLink(t), nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req:                validViewerSession,
+		},
+		{
+			name: "wrong password",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(testPasswordProtectedLink(t), nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			req:                withWrongPassword,
+		},
+		{
+			name: "could not create session",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(testUpdateLink(), nil)
+
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("US", "New York", nil)
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not create session"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validViewerSession,
+		},
+		{
+			name: "viewer location not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(testUpdateLink(), nil)
+
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("", "", errors.New("geolocation error"))
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				cacheRepo.EXPECT().Get(gomock.Any(), "updates:public-html:"+testLinkUpdateID.String()).
+					Return([]byte("<p>cached</p>"), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                validViewerSession,
+		},
+		{
+			name: "viewed password protected update",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				link.EXPECT().PublicDetails(gomock.Any(), gomock.Any()).
+					Return(testPasswordProtectedLink(t), nil)
+
+				workspace.EXPECT().Get(gomock.Any(), &malak.FindWorkspaceOptions{
+					ID: testLinkWorkspaceID,
+				}).Return(testLinkWorkspace(), nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("US", "New York", nil)
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				cacheRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, cache.ErrCacheMiss)
+
+				update.EXPECT().GetSentRevision(gomock.Any(), testLinkUpdateID).
+					Return(nil, malak.ErrUpdateRevisionNotFound)
+
+				cacheRepo.EXPECT().Add(gomock.Any(), "updates:public-html:"+testLinkUpdateID.String(),
+					gomock.Any(), time.Hour*24).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                withPassword,
+		},
+	}
+}
+
+func TestUpdatesHandler_PublicLinkDetails(t *testing.T) {
+	for _, v := range generatePublicLinkDetails(t) {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+			workspaceRepo := malak_mocks.NewMockWorkspaceRepository(controller)
+			geoService := malak_mocks.NewMockGeolocationService(controller)
+			cacheRepo := malak_mocks.NewMockCache(controller)
+
+			v.mockFn(updateRepo, linkRepo, workspaceRepo, geoService, cacheRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				linkRepo:           linkRepo,
+				workspaceRepo:      workspaceRepo,
+				geolocationService: geoService,
+				cache:              cacheRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "link_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.publicLinkDetails, getConfig(), "public.updates.links.fetch").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateListPublicUpdates() []struct {
+	name               string
+	mockFn             func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "workspace not found",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository) {
+				workspace.EXPECT().Get(gomock.Any(), &malak.FindWorkspaceOptions{
+					Reference: "workspace_test",
+				}).Return(nil, malak.ErrWorkspaceNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "banned workspace",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository) {
+				w := testLinkWorkspace()
+				w.IsBanned = true
+
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(w, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not list updates",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				link.EXPECT().ListPublicUpdates(gomock.Any(), gomock.Any()).
+					Return(nil, int64(0), errors.New("could not list updates"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed public updates",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository, workspace *malak_mocks.MockWorkspaceRepository) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				link.EXPECT().ListPublicUpdates(gomock.Any(), gomock.Any()).
+					Return([]malak.Update{*testLinkUpdate()}, int64(1), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ListPublicUpdates(t *testing.T) {
+	for _, v := range generateListPublicUpdates() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+			workspaceRepo := malak_mocks.NewMockWorkspaceRepository(controller)
+
+			v.mockFn(linkRepo, workspaceRepo)
+
+			u := &updatesHandler{
+				cfg:           getConfig(),
+				linkRepo:      linkRepo,
+				workspaceRepo: workspaceRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "workspace_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.listPublicUpdates, getConfig(), "public.workspaces.updates.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generatePublicWorkspaceUpdate() []struct {
+	name   string
+	mockFn func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+		workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+		cacheRepo *malak_mocks.MockCache)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name   string
+		mockFn func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+			workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+			cacheRepo *malak_mocks.MockCache)
+		expectedStatusCode int
+	}{
+		{
+			name: "update is not public",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				update.EXPECT().Get(gomock.Any(), malak.FetchUpdateOptions{
+					Reference:   "update_test",
+					WorkspaceID: testLinkWorkspaceID,
+				}).Return(testLinkUpdate(), nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "viewed public update",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				u := testLinkUpdate()
+				u.IsPublic = true
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(u, nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("US", "New York", nil)
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				cacheRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, cache.ErrCacheMiss)
+
+				update.EXPECT().GetSentRevision(gomock.Any(), testLinkUpdateID).
+					Return(nil, malak.ErrUpdateRevisionNotFound)
+
+				cacheRepo.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "viewed public update with personalized blocks",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				u := testLinkUpdate()
+				u.IsPublic = true
+				u.Content = malak.BlockContents{
+					{
+						ID:   "greeting",
+						Type: "paragraph",
+						Content: []interface{}{
+							map[string]interface{}{
+								"type":   "text",
+								"text":   "Hi {{ first_name }}, revenue grew by 20%",
+								"styles": map[string]interface{}{},
+							},
+						},
+					},
+					{
+						ID:   "board",
+						Type: "paragraph",
+						Props: map[string]interface{}{
+							malak.BlockPropVisibleToList: "list_board",
+						},
+						Content: []interface{}{
+							map[string]interface{}{
+								"type":   "text",
+								"text":   "We are raising a bridge round",
+								"styles": map[string]interface{}{},
+							},
+						},
+					},
+				}
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(u, nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("US", "New York", nil)
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				cacheRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, cache.ErrCacheMiss)
+
+				update.EXPECT().GetSentRevision(gomock.Any(), testLinkUpdateID).
+					Return(nil, malak.ErrUpdateRevisionNotFound)
+
+				cacheRepo.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "viewed sent version with metrics at send time",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, link *malak_mocks.MockUpdateLinkRepository,
+				workspace *malak_mocks.MockWorkspaceRepository, geo *malak_mocks.MockGeolocationService,
+				cacheRepo *malak_mocks.MockCache) {
+				workspace.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkWorkspace(), nil)
+
+				u := testLinkUpdate()
+				u.IsPublic = true
+
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(u, nil)
+
+				geo.EXPECT().FindByIP(gomock.Any(), gomock.Any()).
+					Return("US", "New York", nil)
+
+				link.EXPECT().CreateSession(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				cacheRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, cache.ErrCacheMiss)
+
+				update.EXPECT().GetSentRevision(gomock.Any(), testLinkUpdateID).
+					Return(&malak.UpdateRevision{
+						UpdateID: testLinkUpdateID,
+						IsSent:   true,
+						Content: malak.BlockContents{
+							{
+								ID:   "revenue",
+								Type: "paragraph",
+								Content: []interface{}{
+									map[string]interface{}{
+										"type":   "text",
+										"text":   "MRR is now ",
+										"styles": map[string]interface{}{},
+									},
+									map[string]interface{}{
+										"type": "metric",
+										"props": map[string]interface{}{
+											"chartReference": "integration_chart_mrr",
+											"value":          "latest",
+										},
+									},
+								},
+							},
+						},
+						Metrics: malak.ResolvedMetrics{
+							"integration_chart_mrr:latest": "$12,000.00",
+						},
+					}, nil)
+
+				cacheRepo.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_PublicWorkspaceUpdate(t *testing.T) {
+	for _, v := range generatePublicWorkspaceUpdate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+			workspaceRepo := malak_mocks.NewMockWorkspaceRepository(controller)
+			geoService := malak_mocks.NewMockGeolocationService(controller)
+			cacheRepo := malak_mocks.NewMockCache(controller)
+
+			v.mockFn(updateRepo, linkRepo, workspaceRepo, geoService, cacheRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				linkRepo:           linkRepo,
+				workspaceRepo:      workspaceRepo,
+				geolocationService: geoService,
+				cache:              cacheRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(validViewerSession))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "workspace_test")
+			ctx.URLParams.Add("update_reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.publicWorkspaceUpdate, getConfig(), "public.workspaces.updates.fetch").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateViewerSession() []struct {
+	name               string
+	mockFn             func(link *malak_mocks.MockUpdateLinkRepository)
+	expectedStatusCode int
+	req                updateUpdateViewerSession
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(link *malak_mocks.MockUpdateLinkRepository)
+		expectedStatusCode int
+		req                updateUpdateViewerSession
+	}{
+		{
+			name:               "no session id",
+			mockFn:             func(link *malak_mocks.MockUpdateLinkRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+			req:                updateUpdateViewerSession{TimeSpent: 30},
+		},
+		{
+			name: "session not found",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository) {
+				link.EXPECT().FindSession(gomock.Any(), malak.Reference("session_test")).
+					Return(nil, malak.ErrUpdateViewerSessionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req: updateUpdateViewerSession{
+				SessionID: "session_test",
+				TimeSpent: 30,
+			},
+		},
+		{
+			name: "could not update session",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository) {
+				link.EXPECT().FindSession(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateViewerSession{}, nil)
+
+				link.EXPECT().UpdateSession(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not update session"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req: updateUpdateViewerSession{
+				SessionID: "session_test",
+				TimeSpent: 30,
+			},
+		},
+		{
+			name: "updated session",
+			mockFn: func(link *malak_mocks.MockUpdateLinkRepository) {
+				link.EXPECT().FindSession(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateViewerSession{}, nil)
+
+				link.EXPECT().UpdateSession(gomock.Any(), &malak.UpdateViewerSession{
+					TimeSpentSeconds: 30,
+				}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: updateUpdateViewerSession{
+				SessionID: "session_test",
+				TimeSpent: 30,
+			},
+		},
+	}
+}
+
+func TestUpdatesHandler_UpdateViewerSession(t *testing.T) {
+	for _, v := range generateUpdateViewerSession() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			linkRepo := malak_mocks.NewMockUpdateLinkRepository(controller)
+
+			v.mockFn(linkRepo)
+
+			u := &updatesHandler{
+				cfg:      getConfig(),
+				linkRepo: linkRepo,
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPut, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			WrapMalakHTTPHandler(getLogger(t), u.updateViewerSession, getConfig(), "public.updates.sessions.update").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
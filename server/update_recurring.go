@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const defaultRecurringLeadDays = 3
+
+type createRecurringScheduleRequest struct {
+	TemplateReference malak.Reference           `json:"template_reference,omitempty" validate:"required"`
+	IsSystemTemplate  bool                      `json:"is_system_template,omitempty" validate:"optional"`
+	Frequency         malak.RecurrenceFrequency `json:"frequency,omitempty" validate:"required"`
+	DayOfMonth        int                       `json:"day_of_month,omitempty" validate:"required"`
+	// Hour of the day in UTC
+	Hour     int           `json:"hour,omitempty" validate:"optional"`
+	LeadDays int           `json:"lead_days,omitempty" validate:"optional"`
+	Emails   []malak.Email `json:"emails,omitempty" validate:"required"`
+
+	GenericRequest
+}
+
+func (c *createRecurringScheduleRequest) Validate() error {
+	if hermes.IsStringEmpty(c.TemplateReference.String()) {
+		return errors.New("please provide the template to create drafts from")
+	}
+
+	if !c.Frequency.IsValid() {
+		return errors.New("please provide a valid frequency")
+	}
+
+	if c.DayOfMonth < 1 || c.DayOfMonth > malak.MaxRecurringDayOfMonth {
+		return fmt.Errorf("day of month must be between 1 and %d", malak.MaxRecurringDayOfMonth)
+	}
+
+	if c.Hour < 0 || c.Hour > 23 {
+		return errors.New("hour must be between 0 and 23")
+	}
+
+	if c.LeadDays == 0 {
+		c.LeadDays = defaultRecurringLeadDays
+	}
+
+	if c.LeadDays < 1 || c.LeadDays > malak.MaxRecurringLeadDays {
+		return fmt.Errorf("lead days must be between 1 and %d", malak.MaxRecurringLeadDays)
+	}
+
+	if len(c.Emails) == 0 {
+		return errors.New("please provide atleast one email")
+	}
+
+	for _, v := range c.Emails {
+		_, err := mail.ParseAddress(v.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// @Description create a recurring schedule. A draft is created from the template ahead of every send
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body createRecurringScheduleRequest true "recurring schedule request body"
+// @Success 200 {object} fetchRecurringScheduleResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/recurring [post]
+func (u *updatesHandler) createRecurringSchedule(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("creating recurring schedule")
+
+	req := new(createRecurringScheduleRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	var err error
+
+	if req.IsSystemTemplate {
+		_, err = u.templateRepo.Get(ctx, req.TemplateReference)
+	} else {
+		_, err = u.templateRepo.GetWorkspaceTemplate(ctx, malak.FetchWorkspaceTemplateOptions{
+			WorkspaceID: getWorkspaceFromContext(ctx).ID,
+			Reference:   req.TemplateReference,
+		})
+	}
+
+	if err != nil {
+		var msg = "could not fetch template"
+		var status = http.StatusInternalServerError
+
+		if errors.Is(err, malak.ErrTemplateNotFound) {
+			msg = err.Error()
+			status = http.StatusNotFound
+		}
+
+		logger.Error("could not fetch template", zap.Error(err))
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	schedule := &malak.RecurringUpdateSchedule{
+		Reference:         u.referenceGenerator.Generate(malak.EntityTypeRecurringUpdateSchedule),
+		WorkspaceID:       getWorkspaceFromContext(ctx).ID,
+		CreatedBy:         getUserFromContext(ctx).ID,
+		TemplateReference: req.TemplateReference,
+		IsSystemTemplate:  req.IsSystemTemplate,
+		Frequency:         req.Frequency,
+		DayOfMonth:        req.DayOfMonth,
+		Hour:              req.Hour,
+		LeadDays:          req.LeadDays,
+		Emails:            req.Emails,
+		IsActive:          true,
+	}
+
+	schedule.NextSendAt = schedule.NextOccurrence(time.Now())
+
+	if err := u.recurringRepo.Create(ctx, schedule); err != nil {
+		logger.Error("could not create recurring schedule", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not create recurring schedule"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionRecurringScheduleCreated,
+		malak.EntityTypeRecurringUpdateSchedule, schedule.Reference.String())
+	entry.Metadata["frequency"] = schedule.Frequency.String()
+	entry.Metadata["recipients"] = strconv.Itoa(len(schedule.Emails))
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchRecurringScheduleResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "recurring schedule created"),
+		Schedule:  hermes.DeRef(schedule),
+	}, StatusSuccess
+}
+
+// @Description list the recurring schedules of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} listRecurringSchedulesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/recurring [get]
+func (u *updatesHandler) listRecurringSchedules(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing recurring schedules")
+
+	schedules, err := u.recurringRepo.List(ctx, getWorkspaceFromContext(ctx).ID)
+	if err != nil {
+		logger.Error("could not list recurring schedules", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list recurring schedules"), StatusFailed
+	}
+
+	return listRecurringSchedulesResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "recurring schedules fetched"),
+		Schedules: schedules,
+	}, StatusSuccess
+}
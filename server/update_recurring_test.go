@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func generateCreateRecurringSchedule() []struct {
+	name               string
+	mockFn             func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository)
+	expectedStatusCode int
+	req                createRecurringScheduleRequest
+} {
+
+	validRequest := createRecurringScheduleRequest{
+		TemplateReference: "system_template_test",
+		IsSystemTemplate:  true,
+		Frequency:         malak.RecurrenceFrequencyMonthly,
+		DayOfMonth:        5,
+		Hour:              9,
+		Emails:            []malak.Email{"investor@example.com"},
+	}
+
+	return []struct {
+		name               string
+		mockFn             func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository)
+		expectedStatusCode int
+		req                createRecurringScheduleRequest
+	}{
+		{
+			name: "no template provided",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid frequency",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createRecurringScheduleRequest{
+				TemplateReference: "system_template_test",
+				Frequency:         "weekly",
+			},
+		},
+		{
+			name: "day of month not in every month",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createRecurringScheduleRequest{
+				TemplateReference: "system_template_test",
+				Frequency:         malak.RecurrenceFrequencyMonthly,
+				DayOfMonth:        31,
+			},
+		},
+		{
+			name: "lead days too long",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createRecurringScheduleRequest{
+				TemplateReference: "system_template_test",
+				Frequency:         malak.RecurrenceFrequencyQuarterly,
+				DayOfMonth:        1,
+				LeadDays:          30,
+				Emails:            []malak.Email{"investor@example.com"},
+			},
+		},
+		{
+			name: "invalid email",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: createRecurringScheduleRequest{
+				TemplateReference: "system_template_test",
+				Frequency:         malak.RecurrenceFrequencyMonthly,
+				DayOfMonth:        1,
+				Emails:            []malak.Email{"investor"},
+			},
+		},
+		{
+			name: "template not found",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				template.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrTemplateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validRequest,
+		},
+		{
+			name: "could not create recurring schedule",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				template.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.SystemTemplate{}, nil)
+
+				recurring.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not create schedule"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validRequest,
+		},
+		{
+			name: "workspace template not found",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrTemplateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req: createRecurringScheduleRequest{
+				TemplateReference: "workspace_template_test",
+				Frequency:         malak.RecurrenceFrequencyMonthly,
+				DayOfMonth:        5,
+				Emails:            []malak.Email{"investor@example.com"},
+			},
+		},
+		{
+			name: "created recurring schedule",
+			mockFn: func(template *malak_mocks.MockTemplateRepository, recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				template.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&malak.SystemTemplate{}, nil)
+
+				recurring.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, schedule *malak.RecurringUpdateSchedule) error {
+						if !schedule.NextSendAt.After(time.Now()) || schedule.NextSendAt.Day() != 5 {
+							return errors.New("invalid next send date")
+						}
+
+						// fixed so the response can be compared
+						schedule.NextSendAt = time.Date(2025, time.December, 5, 9, 0, 0, 0, time.UTC)
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                validRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_CreateRecurringSchedule(t *testing.T) {
+	for _, v := range generateCreateRecurringSchedule() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			templateRepo := malak_mocks.NewMockTemplateRepository(controller)
+			recurringRepo := malak_mocks.NewMockRecurringUpdateScheduleRepository(controller)
+
+			v.mockFn(templateRepo, recurringRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				referenceGenerator: &mockReferenceGenerator{},
+				templateRepo:       templateRepo,
+				recurringRepo:      recurringRepo,
+				auditLogRepo:       newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			WrapMalakHTTPHandler(getLogger(t), u.createRecurringSchedule, getConfig(), "updates.recurring.create").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestUpdatesHandler_ListRecurringSchedules(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		mockFn             func(recurring *malak_mocks.MockRecurringUpdateScheduleRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "could not list recurring schedules",
+			mockFn: func(recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				recurring.EXPECT().List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("could not list schedules"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed recurring schedules",
+			mockFn: func(recurring *malak_mocks.MockRecurringUpdateScheduleRepository) {
+				recurring.EXPECT().List(gomock.Any(), gomock.Any()).
+					Return([]malak.RecurringUpdateSchedule{
+						{
+							Reference:         "recurring_update_schedule_test",
+							TemplateReference: "system_template_test",
+							IsSystemTemplate:  true,
+							Frequency:         malak.RecurrenceFrequencyQuarterly,
+							DayOfMonth:        1,
+							Hour:              9,
+							LeadDays:          3,
+							Emails:            []malak.Email{"investor@example.com"},
+							NextSendAt:        time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC),
+							IsActive:          true,
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			recurringRepo := malak_mocks.NewMockRecurringUpdateScheduleRepository(controller)
+
+			v.mockFn(recurringRepo)
+
+			u := &updatesHandler{
+				cfg:           getConfig(),
+				recurringRepo: recurringRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			WrapMalakHTTPHandler(getLogger(t), u.listRecurringSchedules, getConfig(), "updates.recurring.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
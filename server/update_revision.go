@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func (u *updatesHandler) fetchUpdateRevision(ctx context.Context,
+	logger *zap.Logger, update *malak.Update, ref string) (*malak.UpdateRevision, render.Renderer, Status) {
+
+	revision, err := u.updateRepo.GetRevision(ctx, malak.FetchUpdateRevisionOptions{
+		UpdateID:  update.ID,
+		Reference: malak.Reference(ref),
+	})
+	if errors.Is(err, malak.ErrUpdateRevisionNotFound) {
+		return nil, newAPIStatus(http.StatusNotFound, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch update revision", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching revision"), StatusFailed
+	}
+
+	return revision, nil, StatusSuccess
+}
+
+// @Description list the revisions of an update. The most recent comes first
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} listUpdateRevisionsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/revisions [get]
+func (u *updatesHandler) listRevisions(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing update revisions")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	revisions, err := u.updateRepo.ListRevisions(ctx, update.ID)
+	if err != nil {
+		logger.Error("could not list update revisions", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list update revisions"), StatusFailed
+	}
+
+	return listUpdateRevisionsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update revisions fetched"),
+		Revisions: revisions,
+	}, StatusSuccess
+}
+
+// @Description compare two revisions of an update block by block. The current content is used if to is not provided
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param from query string required "revision to compare from"
+// @Param to query string false "revision to compare to"
+// @Success 200 {object} diffUpdateRevisionsResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/revisions/diff [get]
+func (u *updatesHandler) diffRevisions(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("comparing update revisions")
+
+	fromRef := r.URL.Query().Get("from")
+	toRef := r.URL.Query().Get("to")
+
+	if hermes.IsStringEmpty(fromRef) {
+		return newAPIStatus(http.StatusBadRequest,
+			"please provide the revision to compare from"), StatusFailed
+	}
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	from, resp, status := u.fetchUpdateRevision(ctx, logger, update, fromRef)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	to := update.Content
+
+	if !hermes.IsStringEmpty(toRef) {
+		revision, resp, status := u.fetchUpdateRevision(ctx, logger, update, toRef)
+		if status == StatusFailed {
+			return resp, status
+		}
+
+		to = revision.Content
+	}
+
+	return diffUpdateRevisionsResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update revisions compared"),
+		Diff:      malak.DiffBlocks(from.Content, to),
+	}, StatusSuccess
+}
+
+// @Description restore the content of an update to an older revision. A new revision is stored for the restore
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param revision_reference path string required "revision unique reference.. e.g update_revision_"
+// @Success 200 {object} fetchUpdateReponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/revisions/{revision_reference}/restore [post]
+func (u *updatesHandler) restoreRevision(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("restoring update revision")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if update.IsSent() {
+		return newAPIStatus(http.StatusBadRequest,
+			"update has been sent already. you cannot edit it"), StatusFailed
+	}
+
+	ref := chi.URLParam(r, "revision_reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return newAPIStatus(http.StatusBadRequest, "revision reference required"), StatusFailed
+	}
+
+	revision, resp, status := u.fetchUpdateRevision(ctx, logger, update, ref)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	span.SetAttributes(attribute.String("revision.id", revision.ID.String()))
+
+	update.Title = revision.Title
+	update.Content = revision.Content
+	update.ContentChanged()
+
+	restored := &malak.UpdateRevision{
+		Reference:    u.referenceGenerator.Generate(malak.EntityTypeUpdateRevision),
+		UpdateID:     update.ID,
+		WorkspaceID:  update.WorkspaceID,
+		CreatedBy:    getUserFromContext(ctx).ID,
+		Title:        revision.Title,
+		Content:      revision.Content,
+		RestoredFrom: revision.ID,
+	}
+
+	if err := u.updateRepo.UpdateContent(ctx, update, restored); err != nil {
+		if errors.Is(err, malak.ErrUpdateSent) {
+			return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+		}
+
+		logger.Error("could not restore update revision", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not restore revision"), StatusFailed
+	}
+
+	u.reloadCollaborators(ctx, update)
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateRevisionRestored,
+		malak.EntityTypeUpdateRevision, revision.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchUpdateReponse{
+		APIStatus: newAPIStatus(http.StatusOK, "revision restored"),
+		Update:    hermes.DeRef(update),
+	}, StatusSuccess
+}
@@ -0,0 +1,348 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/collaboration"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func testDraftUpdate() *malak.Update {
+	update := testLinkUpdate()
+	update.Status = malak.UpdateStatusDraft
+	update.SentAt = nil
+	return update
+}
+
+func testUpdateRevision(ref, text string) *malak.UpdateRevision {
+	return &malak.UpdateRevision{
+		ID:          testLinkUpdateID,
+		Reference:   malak.Reference(ref),
+		UpdateID:    testLinkUpdateID,
+		WorkspaceID: testLinkWorkspaceID,
+		Title:       "November update",
+		Content: malak.BlockContents{
+			{
+				ID:   "block",
+				Type: "paragraph",
+				Content: []interface{}{
+					map[string]interface{}{
+						"type": "text",
+						"text": text,
+					},
+				},
+			},
+		},
+		CreatedAt: testLinkSentAt,
+	}
+}
+
+func generateListUpdateRevisions() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not list revisions",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().ListRevisions(gomock.Any(), testLinkUpdateID).
+					Return(nil, errors.New("could not list revisions"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "listed revisions",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().ListRevisions(gomock.Any(), testLinkUpdateID).
+					Return([]malak.UpdateRevision{
+						*testUpdateRevision("update_revision_second", "Revenue grew by 20%"),
+						*testUpdateRevision("update_revision_first", "Revenue grew by 10%"),
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_ListRevisions(t *testing.T) {
+	for _, v := range generateListUpdateRevisions() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:        getConfig(),
+				updateRepo: updateRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.listRevisions, getConfig(), "updates.revisions.list").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateDiffUpdateRevisions() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	query              string
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		query              string
+		expectedStatusCode int
+	}{
+		{
+			name:               "no revision to compare from",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateRevisionNotFound)
+			},
+			query:              "from=update_revision_first",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "compared revision with current content",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), malak.FetchUpdateRevisionOptions{
+					UpdateID:  testLinkUpdateID,
+					Reference: "update_revision_first",
+				}).Return(testUpdateRevision("update_revision_first", "Revenue grew by 10%"), nil)
+			},
+			query:              "from=update_revision_first",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "compared two revisions",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), malak.FetchUpdateRevisionOptions{
+					UpdateID:  testLinkUpdateID,
+					Reference: "update_revision_first",
+				}).Return(testUpdateRevision("update_revision_first", "Revenue grew by 10%"), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), malak.FetchUpdateRevisionOptions{
+					UpdateID:  testLinkUpdateID,
+					Reference: "update_revision_second",
+				}).Return(testUpdateRevision("update_revision_second", "Revenue grew by 15%"), nil)
+			},
+			query:              "from=update_revision_first&to=update_revision_second",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_DiffRevisions(t *testing.T) {
+	for _, v := range generateDiffUpdateRevisions() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:        getConfig(),
+				updateRepo: updateRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+v.query, nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.diffRevisions, getConfig(), "updates.revisions.diff").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateRestoreUpdateRevision() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "update has been sent",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateRevisionNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not restore revision",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), gomock.Any()).
+					Return(testUpdateRevision("update_revision_first", "Revenue grew by 10%"), nil)
+
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("could not restore"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "restored revision",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testDraftUpdate(), nil)
+
+				update.EXPECT().GetRevision(gomock.Any(), gomock.Any()).
+					Return(testUpdateRevision("update_revision_first", "Revenue grew by 10%"), nil)
+
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *malak.Update, revision *malak.UpdateRevision) error {
+						if revision.RestoredFrom != testLinkUpdateID {
+							return errors.New("restored revision not tracked")
+						}
+
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_RestoreRevision(t *testing.T) {
+	for _, v := range generateRestoreUpdateRevision() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			pubsub := malak_mocks.NewMockPubSub(controller)
+
+			v.mockFn(updateRepo)
+
+			var reloaded bool
+
+			pubsub.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, _ string, _ []byte) error {
+					reloaded = true
+					return nil
+				})
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				auditLogRepo:       newMockAuditLogRepository(controller),
+				collaborationHub:   collaboration.New(zap.NewNop(), pubsub, updateRepo, collaboration.Options{}),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			ctx.URLParams.Add("revision_reference", "update_revision_first")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.restoreRevision, getConfig(), "updates.revisions.restore").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			// anyone editing the draft gets the restored content
+			require.Equal(t, v.expectedStatusCode == http.StatusOK, reloaded)
+			verifyMatch(t, rr)
+		})
+	}
+}
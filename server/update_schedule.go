@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type rescheduleUpdateRequest struct {
+	SendAt int64 `json:"send_at,omitempty" validate:"required"`
+
+	GenericRequest
+}
+
+func (s *rescheduleUpdateRequest) Validate() error {
+	if s.SendAt == 0 {
+		return errors.New("please provide the new time to send the update")
+	}
+
+	if !time.Unix(s.SendAt, 0).After(time.Now()) {
+		return errors.New("you can only schedule to the future not past")
+	}
+
+	return nil
+}
+
+// fetchUpdateSchedule fetches a schedule of an update in the current
+// workspace from the references in the url. Only schedules that can still
+// be changed are returned
+func (u *updatesHandler) fetchUpdateSchedule(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.Update, *malak.UpdateSchedule, render.Renderer, Status) {
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return nil, nil, resp, status
+	}
+
+	ref := chi.URLParam(r, "schedule_reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return nil, nil, newAPIStatus(http.StatusBadRequest, "schedule reference required"), StatusFailed
+	}
+
+	schedule, err := u.updateRepo.FindSchedule(ctx, malak.FetchUpdateScheduleOptions{
+		UpdateID:  update.ID,
+		Reference: malak.Reference(ref),
+	})
+	if errors.Is(err, malak.ErrUpdateScheduleNotFound) {
+		return nil, nil, newAPIStatus(http.StatusNotFound, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch update schedule", zap.Error(err))
+		return nil, nil, newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching update schedule"), StatusFailed
+	}
+
+	if !schedule.CanBeModified() {
+		return nil, nil, newAPIStatus(http.StatusBadRequest,
+			malak.ErrUpdateScheduleNotModifiable.Error()), StatusFailed
+	}
+
+	return update, schedule, nil, StatusSuccess
+}
+
+// @Description list the send schedules of an update
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} listUpdateSchedulesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/schedules [get]
+func (u *updatesHandler) listSchedules(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing update schedules")
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	schedules, err := u.updateRepo.ListSchedules(ctx, update.ID)
+	if err != nil {
+		logger.Error("could not list update schedules", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list update schedules"), StatusFailed
+	}
+
+	return listUpdateSchedulesResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update schedules fetched"),
+		Schedules: schedules,
+	}, StatusSuccess
+}
+
+// @Description change the time a scheduled update will be sent
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body rescheduleUpdateRequest true "reschedule request body"
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param schedule_reference path string required "schedule unique reference.. e.g schedule_"
+// @Success 200 {object} fetchUpdateScheduleResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/schedules/{schedule_reference} [patch]
+func (u *updatesHandler) reschedule(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("rescheduling update")
+
+	req := new(rescheduleUpdateRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	update, schedule, resp, status := u.fetchUpdateSchedule(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	previousSendAt := schedule.SendAt
+	schedule.SendAt = time.Unix(req.SendAt, 0).UTC()
+
+	span.SetAttributes(attribute.String("schedule.id", schedule.ID.String()))
+
+	if err := u.updateRepo.RescheduleSend(ctx, schedule); err != nil {
+		var msg = "could not reschedule update"
+		var status = http.StatusInternalServerError
+
+		if errors.Is(err, malak.ErrUpdateScheduleNotModifiable) {
+			msg = err.Error()
+			status = http.StatusBadRequest
+		}
+
+		logger.Error("could not reschedule update", zap.Error(err))
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateRescheduled,
+		malak.EntityTypeSchedule, schedule.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+	entry.Metadata["previous_send_at"] = previousSendAt.Format(time.RFC3339)
+	entry.Metadata["send_at"] = schedule.SendAt.Format(time.RFC3339)
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchUpdateScheduleResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update has been rescheduled"),
+		Schedule:  hermes.DeRef(schedule),
+	}, StatusSuccess
+}
+
+// @Description cancel a scheduled update. The update becomes a draft again
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Param schedule_reference path string required "schedule unique reference.. e.g schedule_"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/schedules/{schedule_reference} [delete]
+func (u *updatesHandler) cancelSchedule(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("cancelling update schedule")
+
+	update, schedule, resp, status := u.fetchUpdateSchedule(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	span.SetAttributes(attribute.String("schedule.id", schedule.ID.String()))
+
+	if err := u.updateRepo.CancelSchedule(ctx, schedule); err != nil {
+		var msg = "could not cancel scheduled update"
+		var status = http.StatusInternalServerError
+
+		if errors.Is(err, malak.ErrUpdateScheduleNotModifiable) {
+			msg = err.Error()
+			status = http.StatusBadRequest
+		}
+
+		logger.Error("could not cancel scheduled update", zap.Error(err))
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionUpdateScheduleCancelled,
+		malak.EntityTypeSchedule, schedule.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+	entry.Metadata["send_at"] = schedule.SendAt.Format(time.RFC3339)
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return newAPIStatus(http.StatusOK, "scheduled update has been cancelled"), StatusSuccess
+}
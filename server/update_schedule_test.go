@@ -0,0 +1,296 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testScheduleSendAt = time.Date(2025, time.November, 20, 9, 0, 0, 0, time.UTC)
+
+func testUpdateSchedule(status malak.UpdateSendSchedule) *malak.UpdateSchedule {
+	return &malak.UpdateSchedule{
+		ID:         testLinkUpdateID,
+		Reference:  "schedule_test",
+		UpdateID:   testLinkUpdateID,
+		Status:     status,
+		UpdateType: malak.UpdateTypeLive,
+		SendAt:     testScheduleSendAt,
+	}
+}
+
+func generateRescheduleUpdate() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+	req                rescheduleUpdateRequest
+} {
+
+	validRequest := rescheduleUpdateRequest{
+		SendAt: time.Now().Add(time.Hour * 24).Unix(),
+	}
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+		req                rescheduleUpdateRequest
+	}{
+		{
+			name:               "no send time provided",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "send time in the past",
+			mockFn:             func(update *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+			req: rescheduleUpdateRequest{
+				SendAt: time.Now().Add(-time.Hour).Unix(),
+			},
+		},
+		{
+			name: "schedule not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateScheduleNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validRequest,
+		},
+		{
+			name: "schedule is already processing",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleProcessing), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req:                validRequest,
+		},
+		{
+			name: "schedule was picked up for processing",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().RescheduleSend(gomock.Any(), gomock.Any()).
+					Return(malak.ErrUpdateScheduleNotModifiable)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req:                validRequest,
+		},
+		{
+			name: "could not reschedule",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().RescheduleSend(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not reschedule"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validRequest,
+		},
+		{
+			name: "rescheduled update",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().RescheduleSend(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, schedule *malak.UpdateSchedule) error {
+						if !schedule.SendAt.After(time.Now()) {
+							return errors.New("invalid send date")
+						}
+
+						// fixed so the response can be compared
+						schedule.SendAt = testScheduleSendAt.Add(time.Hour * 24)
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                validRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_Reschedule(t *testing.T) {
+	for _, v := range generateRescheduleUpdate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				updateRepo:   updateRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPatch, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			ctx.URLParams.Add("schedule_reference", "schedule_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.reschedule, getConfig(), "updates.schedules.update").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateCancelUpdateSchedule() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	expectedStatusCode int
+} {
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "schedule has been sent",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleSent), nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "schedule was picked up for processing",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().CancelSchedule(gomock.Any(), gomock.Any()).
+					Return(malak.ErrUpdateScheduleNotModifiable)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not cancel schedule",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().CancelSchedule(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not cancel schedule"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "cancelled schedule",
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				update.EXPECT().FindSchedule(gomock.Any(), gomock.Any()).
+					Return(testUpdateSchedule(malak.UpdateSendScheduleScheduled), nil)
+
+				update.EXPECT().CancelSchedule(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_CancelSchedule(t *testing.T) {
+	for _, v := range generateCancelUpdateSchedule() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				updateRepo:   updateRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			ctx.URLParams.Add("schedule_reference", "schedule_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.cancelSchedule, getConfig(), "updates.schedules.cancel").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/mail"
+	"strconv"
 	"time"
 
 	"github.com/ayinke-llc/hermes"
@@ -182,7 +183,7 @@ func (s *sendUpdateRequest) Validate() error {
 
 	scheduledTime := hermes.DeRef(s.SendAt)
 
-	if time.Now().Before(time.Unix(scheduledTime, 0)) {
+	if !time.Unix(scheduledTime, 0).After(time.Now()) {
 		return errors.New("you can only schedule to the future not past")
 	}
 
@@ -247,6 +248,18 @@ func (u *updatesHandler) sendUpdate(
 			"an error occurred while fetching update"), StatusFailed
 	}
 
+	preferences, err := u.preferenceRepo.Get(ctx, workspace)
+	if err != nil {
+		logger.Error("could not fetch preferences", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not fetch workspace preferences"), StatusFailed
+	}
+
+	if !preferences.Approval.CanSend(update) {
+		return newAPIStatus(http.StatusBadRequest,
+			malak.ErrUpdateNotApproved.Error()), StatusFailed
+	}
+
 	var sendAt = time.Now()
 	if req.SendAt != nil {
 		sendAt = time.Unix(hermes.DeRef(req.SendAt), 0)
@@ -296,6 +309,13 @@ func (u *updatesHandler) sendUpdate(
 
 	span.AddEvent("update.sending.live")
 
+	entry := newAuditLog(r, malak.AuditLogActionUpdateSent,
+		malak.EntityTypeUpdate, update.Reference.String())
+	entry.Metadata["recipients"] = strconv.Itoa(len(req.Emails))
+	entry.Metadata["send_at"] = sendAt.Format(time.RFC3339)
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
 	return newAPIStatus(http.StatusOK, "Your update is now scheduled and will be sent out"),
 		StatusSuccess
 }
@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/microcosm-cc/bluemonday"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type createWorkspaceTemplateRequest struct {
+	Title       string `json:"title,omitempty" validate:"required"`
+	Description string `json:"description,omitempty" validate:"optional"`
+
+	GenericRequest
+}
+
+func (c *createWorkspaceTemplateRequest) Validate() error {
+	p := bluemonday.StrictPolicy()
+
+	c.Title = p.Sanitize(c.Title)
+	c.Description = p.Sanitize(c.Description)
+
+	if util.IsStringEmpty(c.Title) {
+		return errors.New("please provide template title")
+	}
+
+	if len(c.Title) < 5 {
+		return errors.New("title must be more than 5 characters")
+	}
+
+	return nil
+}
+
+type updateWorkspaceTemplateRequest struct {
+	Title       string              `json:"title,omitempty" validate:"required"`
+	Description string              `json:"description,omitempty" validate:"optional"`
+	Content     malak.BlockContents `json:"content,omitempty" validate:"required"`
+
+	GenericRequest
+}
+
+func (c *updateWorkspaceTemplateRequest) Validate() error {
+	p := bluemonday.StrictPolicy()
+
+	c.Title = p.Sanitize(c.Title)
+	c.Description = p.Sanitize(c.Description)
+
+	if util.IsStringEmpty(c.Title) {
+		return errors.New("please provide template title")
+	}
+
+	if len(c.Title) < 5 {
+		return errors.New("title must be more than 5 characters")
+	}
+
+	sanitized, err := malak.SanitizeBlocks(c.Content)
+	if err != nil {
+		return err
+	}
+
+	if len(sanitized) == 0 {
+		return errors.New("please provide the content")
+	}
+
+	c.Content = sanitized
+
+	return nil
+}
+
+// templateContent fetches the content of the template an update is created
+// from with its variables substituted
+func (u *updatesHandler) templateContent(ctx context.Context, logger *zap.Logger,
+	workspace *malak.Workspace, opts *malak.TemplateCreateUpdateOptions) (malak.BlockContents, render.Renderer, Status) {
+
+	var content malak.BlockContents
+
+	var err error
+
+	if opts.IsSystemTemplate {
+		var tmpl *malak.SystemTemplate
+		tmpl, err = u.templateRepo.Get(ctx, opts.Reference)
+		if err == nil {
+			content = tmpl.Content
+		}
+	} else {
+		var tmpl *malak.WorkspaceTemplate
+		tmpl, err = u.templateRepo.GetWorkspaceTemplate(ctx, malak.FetchWorkspaceTemplateOptions{
+			WorkspaceID: workspace.ID,
+			Reference:   opts.Reference,
+		})
+		if err == nil {
+			content = tmpl.Content
+		}
+	}
+
+	if err != nil {
+		var msg = "could not fetch template"
+		var status = http.StatusInternalServerError
+
+		if errors.Is(err, malak.ErrTemplateNotFound) {
+			msg = err.Error()
+			status = http.StatusNotFound
+		}
+
+		logger.Error("could not fetch template", zap.Error(err))
+		return nil, newAPIStatus(status, msg), StatusFailed
+	}
+
+	metrics, err := malak.LoadTemplateMetrics(ctx, u.integrationRepo, workspace.ID, content)
+	if err != nil {
+		logger.Error("could not load template metrics", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError,
+			"could not load template metrics"), StatusFailed
+	}
+
+	return content.ReplaceVariables(malak.TemplateVariables{
+		WorkspaceName: workspace.WorkspaceName,
+		Date:          time.Now(),
+		Metrics:       metrics,
+	}), nil, StatusSuccess
+}
+
+func (u *updatesHandler) fetchWorkspaceTemplate(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.WorkspaceTemplate, render.Renderer, Status) {
+
+	ref := chi.URLParam(r, "reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return nil, newAPIStatus(http.StatusBadRequest, "reference required"), StatusFailed
+	}
+
+	tmpl, err := u.templateRepo.GetWorkspaceTemplate(ctx, malak.FetchWorkspaceTemplateOptions{
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+		Reference:   malak.Reference(ref),
+	})
+	if errors.Is(err, malak.ErrTemplateNotFound) {
+		return nil, newAPIStatus(http.StatusNotFound, err.Error()), StatusFailed
+	}
+
+	if err != nil {
+		logger.Error("could not fetch workspace template", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError,
+			"an error occurred while fetching template"), StatusFailed
+	}
+
+	return tmpl, nil, StatusSuccess
+}
+
+// @Description save an update as a template of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body createWorkspaceTemplateRequest true "template request body"
+// @Param reference path string required "update unique reference.. e.g update_"
+// @Success 200 {object} fetchWorkspaceTemplateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/{reference}/template [post]
+func (u *updatesHandler) saveAsTemplate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("saving update as template")
+
+	req := new(createWorkspaceTemplateRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	update, resp, status := u.fetchWorkspaceUpdate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	tmpl := &malak.WorkspaceTemplate{
+		WorkspaceID: update.WorkspaceID,
+		CreatedBy:   getUserFromContext(ctx).ID,
+		Reference:   u.referenceGenerator.Generate(malak.EntityTypeWorkspaceTemplate),
+		Content:     update.Content,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	span.SetAttributes(attribute.String("update.id", update.ID.String()))
+
+	if err := u.templateRepo.CreateWorkspaceTemplate(ctx, tmpl); err != nil {
+		logger.Error("could not create workspace template", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not save update as template"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionWorkspaceTemplateCreated,
+		malak.EntityTypeWorkspaceTemplate, tmpl.Reference.String())
+	entry.Metadata["update"] = update.Reference.String()
+
+	recordAuditLog(ctx, logger, u.auditLogRepo, entry)
+
+	return fetchWorkspaceTemplateResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "update saved as template"),
+		Template:  hermes.DeRef(tmpl),
+	}, StatusSuccess
+}
+
+// @Description fetch a template of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "template unique reference.. e.g workspace_template_"
+// @Success 200 {object} fetchWorkspaceTemplateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/templates/{reference} [get]
+func (u *updatesHandler) fetchTemplate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("fetching workspace template")
+
+	tmpl, resp, status := u.fetchWorkspaceTemplate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	return fetchWorkspaceTemplateResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "template fetched"),
+		Template:  hermes.DeRef(tmpl),
+	}, StatusSuccess
+}
+
+// @Description edit a template of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param message body updateWorkspaceTemplateRequest true "template request body"
+// @Param reference path string required "template unique reference.. e.g workspace_template_"
+// @Success 200 {object} fetchWorkspaceTemplateResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/templates/{reference} [put]
+func (u *updatesHandler) updateTemplate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("updating workspace template")
+
+	req := new(updateWorkspaceTemplateRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	tmpl, resp, status := u.fetchWorkspaceTemplate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	tmpl.Title = req.Title
+	tmpl.Description = req.Description
+	tmpl.Content = req.Content
+
+	if err := u.templateRepo.UpdateWorkspaceTemplate(ctx, tmpl); err != nil {
+		logger.Error("could not update workspace template", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not update template"), StatusFailed
+	}
+
+	recordAuditLog(ctx, logger, u.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionWorkspaceTemplateUpdated,
+			malak.EntityTypeWorkspaceTemplate, tmpl.Reference.String()))
+
+	return fetchWorkspaceTemplateResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "template updated"),
+		Template:  hermes.DeRef(tmpl),
+	}, StatusSuccess
+}
+
+// @Description delete a template of the workspace
+// @Tags updates
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "template unique reference.. e.g workspace_template_"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/updates/templates/{reference} [delete]
+func (u *updatesHandler) deleteTemplate(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("deleting workspace template")
+
+	tmpl, resp, status := u.fetchWorkspaceTemplate(ctx, logger, r)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if err := u.templateRepo.DeleteWorkspaceTemplate(ctx, tmpl); err != nil {
+		logger.Error("could not delete workspace template", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not delete template"), StatusFailed
+	}
+
+	recordAuditLog(ctx, logger, u.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionWorkspaceTemplateDeleted,
+			malak.EntityTypeWorkspaceTemplate, tmpl.Reference.String()))
+
+	return newAPIStatus(http.StatusOK, "template deleted"), StatusSuccess
+}
@@ -0,0 +1,464 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/malak"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testWorkspaceTemplate() *malak.WorkspaceTemplate {
+	return &malak.WorkspaceTemplate{
+		WorkspaceID: testLinkWorkspaceID,
+		Reference:   "workspace_template_test",
+		Title:       "Monthly investor update",
+		Content: malak.BlockContents{
+			{
+				ID:   "block",
+				Type: "paragraph",
+				Content: []interface{}{
+					map[string]interface{}{
+						"type":   "text",
+						"text":   "{{ workspace_name }} made {{ metric.chart_mrr }} in revenue",
+						"styles": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		CreatedAt: testLinkSentAt,
+		UpdatedAt: testLinkSentAt,
+	}
+}
+
+func generateSaveUpdateAsTemplate() []struct {
+	name               string
+	mockFn             func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository)
+	expectedStatusCode int
+	req                createWorkspaceTemplateRequest
+} {
+
+	validRequest := createWorkspaceTemplateRequest{
+		Title:       "Monthly investor update",
+		Description: "what we send every month",
+	}
+
+	return []struct {
+		name               string
+		mockFn             func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository)
+		expectedStatusCode int
+		req                createWorkspaceTemplateRequest
+	}{
+		{
+			name: "no title provided",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "update not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrUpdateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validRequest,
+		},
+		{
+			name: "could not create template",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				template.EXPECT().CreateWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not create template"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validRequest,
+		},
+		{
+			name: "saved update as template",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, template *malak_mocks.MockTemplateRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(testLinkUpdate(), nil)
+
+				template.EXPECT().CreateWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                validRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_SaveAsTemplate(t *testing.T) {
+	for _, v := range generateSaveUpdateAsTemplate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			templateRepo := malak_mocks.NewMockTemplateRepository(controller)
+
+			v.mockFn(updateRepo, templateRepo)
+
+			u := &updatesHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				templateRepo:       templateRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				auditLogRepo:       newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "update_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.saveAsTemplate, getConfig(), "updates.templates.create").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateWorkspaceTemplate() []struct {
+	name               string
+	mockFn             func(template *malak_mocks.MockTemplateRepository)
+	expectedStatusCode int
+	req                updateWorkspaceTemplateRequest
+} {
+
+	validRequest := updateWorkspaceTemplateRequest{
+		Title:   "Quarterly investor update",
+		Content: testWorkspaceTemplate().Content,
+	}
+
+	return []struct {
+		name               string
+		mockFn             func(template *malak_mocks.MockTemplateRepository)
+		expectedStatusCode int
+		req                updateWorkspaceTemplateRequest
+	}{
+		{
+			name:               "no title provided",
+			mockFn:             func(template *malak_mocks.MockTemplateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+			req: updateWorkspaceTemplateRequest{
+				Content: testWorkspaceTemplate().Content,
+			},
+		},
+		{
+			name:               "no content provided",
+			mockFn:             func(template *malak_mocks.MockTemplateRepository) {},
+			expectedStatusCode: http.StatusBadRequest,
+			req: updateWorkspaceTemplateRequest{
+				Title: "Quarterly investor update",
+			},
+		},
+		{
+			name: "template not found",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrTemplateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validRequest,
+		},
+		{
+			name: "could not update template",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				template.EXPECT().UpdateWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not update template"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validRequest,
+		},
+		{
+			name: "updated template",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				template.EXPECT().UpdateWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req:                validRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_UpdateTemplate(t *testing.T) {
+	for _, v := range generateUpdateWorkspaceTemplate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			templateRepo := malak_mocks.NewMockTemplateRepository(controller)
+
+			v.mockFn(templateRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				templateRepo: templateRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPut, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "workspace_template_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.updateTemplate, getConfig(), "updates.templates.update").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateDeleteWorkspaceTemplate() []struct {
+	name               string
+	mockFn             func(template *malak_mocks.MockTemplateRepository)
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(template *malak_mocks.MockTemplateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "template not found",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrTemplateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not delete template",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				template.EXPECT().DeleteWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(errors.New("could not delete template"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "deleted template",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				template.EXPECT().DeleteWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestUpdatesHandler_DeleteTemplate(t *testing.T) {
+	for _, v := range generateDeleteWorkspaceTemplate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			templateRepo := malak_mocks.NewMockTemplateRepository(controller)
+
+			v.mockFn(templateRepo)
+
+			u := &updatesHandler{
+				cfg:          getConfig(),
+				templateRepo: templateRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("reference", "workspace_template_test")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+
+			WrapMalakHTTPHandler(getLogger(t), u.deleteTemplate, getConfig(), "updates.templates.delete").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateCreateUpdateFromTemplate() []struct {
+	name   string
+	mockFn func(update *malak_mocks.MockUpdateRepository,
+		template *malak_mocks.MockTemplateRepository,
+		integration *malak_mocks.MockIntegrationRepository)
+	expectedStatusCode int
+	req                createUpdateContent
+} {
+
+	validRequest := createUpdateContent{
+		Title: "November update",
+	}
+	validRequest.Template.Reference = "workspace_template_test"
+
+	return []struct {
+		name   string
+		mockFn func(update *malak_mocks.MockUpdateRepository,
+			template *malak_mocks.MockTemplateRepository,
+			integration *malak_mocks.MockIntegrationRepository)
+		expectedStatusCode int
+		req                createUpdateContent
+	}{
+		{
+			name: "template not found",
+			mockFn: func(update *malak_mocks.MockUpdateRepository,
+				template *malak_mocks.MockTemplateRepository,
+				integration *malak_mocks.MockIntegrationRepository) {
+
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(nil, malak.ErrTemplateNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			req:                validRequest,
+		},
+		{
+			name: "could not load metrics",
+			mockFn: func(update *malak_mocks.MockUpdateRepository,
+				template *malak_mocks.MockTemplateRepository,
+				integration *malak_mocks.MockIntegrationRepository) {
+
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				integration.EXPECT().GetChart(gomock.Any(), gomock.Any()).
+					Return(malak.IntegrationChart{}, errors.New("could not fetch chart"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			req:                validRequest,
+		},
+		{
+			name: "created update from template",
+			mockFn: func(update *malak_mocks.MockUpdateRepository,
+				template *malak_mocks.MockTemplateRepository,
+				integration *malak_mocks.MockIntegrationRepository) {
+
+				template.EXPECT().GetWorkspaceTemplate(gomock.Any(), gomock.Any()).
+					Return(testWorkspaceTemplate(), nil)
+
+				chart := malak.IntegrationChart{
+					Reference:     "chart_mrr",
+					DataPointType: malak.IntegrationDataPointTypeCurrency,
+				}
+
+				integration.EXPECT().GetChart(gomock.Any(), malak.FetchChartOptions{
+					WorkspaceID: testLinkWorkspaceID,
+					Reference:   "chart_mrr",
+				}).Return(chart, nil)
+
+				integration.EXPECT().GetDataPoints(gomock.Any(), chart).
+					Return([]malak.IntegrationDataPoint{
+						{PointValue: 1000000},
+						{PointValue: 1250050},
+					}, nil)
+
+				update.EXPECT().Create(gomock.Any(), gomock.Any(), &malak.TemplateCreateUpdateOptions{
+					IsFromTemplate: true,
+					Reference:      "workspace_template_test",
+				}).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			req:                validRequest,
+		},
+	}
+}
+
+func TestUpdatesHandler_CreateFromTemplate(t *testing.T) {
+	for _, v := range generateCreateUpdateFromTemplate() {
+
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			templateRepo := malak_mocks.NewMockTemplateRepository(controller)
+			integrationRepo := malak_mocks.NewMockIntegrationRepository(controller)
+
+			v.mockFn(updateRepo, templateRepo, integrationRepo)
+
+			u := &updatesHandler{
+				referenceGenerator: &mockReferenceGenerator{},
+				updateRepo:         updateRepo,
+				templateRepo:       templateRepo,
+				integrationRepo:    integrationRepo,
+				uuidGenerator:      &mockUUIDGenerator{},
+			}
+
+			var b = bytes.NewBuffer(nil)
+			require.NoError(t, json.NewEncoder(b).Encode(v.req))
+
+			rr := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPost, "/", b)
+			req.Header.Add("Content-Type", "application/json")
+
+			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{
+				ID:            testLinkWorkspaceID,
+				WorkspaceName: "Malak",
+			}))
+
+			WrapMalakHTTPHandler(getLogger(t), u.create, getConfig(), "updates.new").
+				ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -150,12 +150,36 @@ func generateUpdateContentTable() []struct {
 					Times(1).
 					Return(&malak.Update{}, nil)
 
-				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("unknown error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "update sent while saving content",
+			req: contentUpdateRequest{
+				Title: "Valid title",
+				Update: []malak.Block{
+					{
+						ID:   "here is an id",
+						Type: "heading",
+					},
+				},
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.
+					EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Update{}, nil)
+
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(malak.ErrUpdateSent)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "updating content succeeds",
 			req: contentUpdateRequest{
@@ -174,12 +198,43 @@ func generateUpdateContentTable() []struct {
 					Times(1).
 					Return(&malak.Update{}, nil)
 
-				update.EXPECT().Update(gomock.Any(), gomock.Any()).
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "content did not change",
+			req: contentUpdateRequest{
+				Title: "Valid title",
+				Update: []malak.Block{
+					{
+						ID:   "here is an id",
+						Type: "heading",
+					},
+				},
+			},
+			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+				update.
+					EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Update{
+						Title: "Valid title",
+						Content: malak.BlockContents{
+							{
+								ID:   "here is an id",
+								Type: "heading",
+							},
+						},
+					}, nil)
+
+				update.EXPECT().UpdateContent(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 	}
 }
 
@@ -619,12 +674,15 @@ func TestUpdatesHandler_SendUpdate(t *testing.T) {
 			defer controller.Finish()
 
 			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			preferenceRepo := malak_mocks.NewMockPreferenceRepository(controller)
 
-			v.mockFn(updateRepo)
+			v.mockFn(updateRepo, preferenceRepo)
 
 			u := &updatesHandler{
+				auditLogRepo:       newMockAuditLogRepository(controller),
 				referenceGenerator: &mockReferenceGenerator{},
 				updateRepo:         updateRepo,
+				preferenceRepo:     preferenceRepo,
 			}
 
 			var b = bytes.NewBuffer(nil)
@@ -653,26 +711,26 @@ func TestUpdatesHandler_SendUpdate(t *testing.T) {
 
 func generateUpdateTestTable() []struct {
 	name               string
-	mockFn             func(update *malak_mocks.MockUpdateRepository)
+	mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository)
 	req                sendUpdateRequest
 	expectedStatusCode int
 } {
 	return []struct {
 		name               string
-		mockFn             func(update *malak_mocks.MockUpdateRepository)
+		mockFn             func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository)
 		req                sendUpdateRequest
 		expectedStatusCode int
 	}{
 		{
 			name: "email not provided",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 			},
 			req:                sendUpdateRequest{},
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:   "invalid email",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {},
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {},
 			req: sendUpdateRequest{
 				Emails: []malak.Email{
 					"oops@",
@@ -682,7 +740,7 @@ func generateUpdateTestTable() []struct {
 		},
 		{
 			name: "update not found",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, malak.ErrUpdateNotFound)
 			},
 			req: sendUpdateRequest{
@@ -694,7 +752,7 @@ func generateUpdateTestTable() []struct {
 		},
 		{
 			name: "error fetching update",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
 			},
 			req: sendUpdateRequest{
@@ -704,10 +762,65 @@ func generateUpdateTestTable() []struct {
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "error fetching preferences",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			req: sendUpdateRequest{
+				Emails: []malak.Email{
+					"oops@oops.com",
+				},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "update not approved",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{
+					ApprovalStatus: malak.UpdateApprovalStatusInReview,
+				}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Preference{
+					Approval: malak.ApprovalPreferences{
+						RequireApproval: true,
+						Approvers:       []uuid.UUID{testApproverID},
+					},
+				}, nil)
+			},
+			req: sendUpdateRequest{
+				Emails: []malak.Email{
+					"oops@oops.com",
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "approved update sending",
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
+				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{
+					ApprovalStatus: malak.UpdateApprovalStatusApproved,
+				}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Preference{
+					Approval: malak.ApprovalPreferences{
+						RequireApproval: true,
+						Approvers:       []uuid.UUID{testApproverID},
+					},
+				}, nil)
+				update.EXPECT().SendUpdate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			req: sendUpdateRequest{
+				Emails: []malak.Email{
+					"oops@oops.com",
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 		{
 			name: "error during update sending",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Preference{}, nil)
 				update.EXPECT().SendUpdate(gomock.Any(), gomock.Any()).Return(errors.New("oops"))
 			},
 			req: sendUpdateRequest{
@@ -719,8 +832,9 @@ func generateUpdateTestTable() []struct {
 		},
 		{
 			name: "update sending reached max recipients",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Preference{}, nil)
 				update.EXPECT().SendUpdate(gomock.Any(), gomock.Any()).
 					Return(malak.ErrCounterExhausted)
 			},
@@ -733,8 +847,9 @@ func generateUpdateTestTable() []struct {
 		},
 		{
 			name: "successful update sending",
-			mockFn: func(update *malak_mocks.MockUpdateRepository) {
+			mockFn: func(update *malak_mocks.MockUpdateRepository, preference *malak_mocks.MockPreferenceRepository) {
 				update.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Update{}, nil)
+				preference.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&malak.Preference{}, nil)
 				update.EXPECT().SendUpdate(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			req: sendUpdateRequest{
@@ -783,6 +898,20 @@ func generateTemplatesTestTable() []struct {
 							UpdatedAt: fixedTime,
 						},
 					}, nil)
+
+				template.
+					EXPECT().
+					Workspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]malak.WorkspaceTemplate{
+						{
+							Title:     "Investor update",
+							Content:   malak.BlockContents{},
+							Reference: "workspace_template_1",
+							CreatedAt: fixedTime,
+							UpdatedAt: fixedTime,
+						},
+					}, nil)
 			},
 			filter:             "",
 			expectedStatusCode: http.StatusCreated,
@@ -804,6 +933,12 @@ func generateTemplatesTestTable() []struct {
 							UpdatedAt:    fixedTime,
 						},
 					}, nil)
+
+				template.
+					EXPECT().
+					Workspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]malak.WorkspaceTemplate{}, nil)
 			},
 			filter:             "most_used",
 			expectedStatusCode: http.StatusCreated,
@@ -824,10 +959,34 @@ func generateTemplatesTestTable() []struct {
 							UpdatedAt: fixedTime,
 						},
 					}, nil)
+
+				template.
+					EXPECT().
+					Workspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]malak.WorkspaceTemplate{}, nil)
 			},
 			filter:             "recently_created",
 			expectedStatusCode: http.StatusCreated,
 		},
+		{
+			name: "error listing workspace templates",
+			mockFn: func(template *malak_mocks.MockTemplateRepository) {
+				template.
+					EXPECT().
+					System(gomock.Any(), malak.SystemTemplateFilterAll).
+					Times(1).
+					Return([]malak.SystemTemplate{}, nil)
+
+				template.
+					EXPECT().
+					Workspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("database error"))
+			},
+			filter:             "",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
 		{
 			name: "error listing templates",
 			mockFn: func(template *malak_mocks.MockTemplateRepository) {
@@ -858,6 +1017,7 @@ func TestUpdatesHandler_Templates(t *testing.T) {
 
 			rr := httptest.NewRecorder()
 			req := httptest.NewRequest(http.MethodGet, "/?filter="+v.filter, nil)
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
 
 			WrapMalakHTTPHandler(getLogger(t), u.templates, getConfig(), "updates.templates").
 				ServeHTTP(rr, req)
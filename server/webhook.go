@@ -2,16 +2,23 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ayinke-llc/hermes"
 	"github.com/ayinke-llc/malak"
 	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/cache"
+	"github.com/ayinke-llc/malak/internal/pkg/email/postmark"
+	"github.com/ayinke-llc/malak/internal/pkg/email/sendgrid"
+	"github.com/ayinke-llc/malak/internal/pkg/email/ses"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
 	"github.com/go-chi/render"
 	svix "github.com/svix/svix-webhooks/go"
 	"go.opentelemetry.io/otel/codes"
@@ -19,16 +26,42 @@ import (
 )
 
 type webhookHandler struct {
-	cfg                config.Config
-	userRepo           malak.UserRepository
-	workspaceRepo      malak.WorkspaceRepository
-	planRepo           malak.PlanRepository
-	updateRepo         malak.UpdateRepository
-	contactRepo        malak.ContactRepository
-	referenceGenerator malak.ReferenceGeneratorOperation
-	svixClient         *svix.Webhook
+	cfg                   config.Config
+	userRepo              malak.UserRepository
+	workspaceRepo         malak.WorkspaceRepository
+	planRepo              malak.PlanRepository
+	updateRepo            malak.UpdateRepository
+	contactRepo           malak.ContactRepository
+	referenceGenerator    malak.ReferenceGeneratorOperation
+	svixClient            *svix.Webhook
+	sendgridVerifier      *sendgrid.WebhookVerifier
+	sesVerifier           *ses.WebhookVerifier
+	postmarkAuthenticator *postmark.WebhookAuthenticator
+	suppressionRepo       malak.SuppressionRepository
+	queue                 queue.QueueHandler
+	cache                 cache.Cache
 }
 
+// sendgridEventTTL covers the three days sendgrid keeps retrying a batch of
+// events that was not acknowledged
+const sendgridEventTTL = 72 * time.Hour
+
+func sendgridEventKey(id string) string {
+	return "sendgrid-event-" + id
+}
+
+type emailEvent string
+
+const (
+	emailEventOpened    emailEvent = "opened"
+	emailEventDelivered emailEvent = "delivered"
+	// emailEventBounced is a permanent failure. The contact gets
+	// suppressed from future updates
+	emailEventBounced     emailEvent = "bounced"
+	emailEventSoftBounced emailEvent = "soft_bounced"
+	emailEventComplained  emailEvent = "complained"
+)
+
 type resendWebhookRequest struct {
 	CreatedAt time.Time `json:"created_at"`
 	Data      struct {
@@ -37,6 +70,10 @@ type resendWebhookRequest struct {
 		From      string   `json:"from"`
 		Subject   string   `json:"subject"`
 		To        []string `json:"to"`
+		Click     struct {
+			Link      string    `json:"link"`
+			Timestamp time.Time `json:"timestamp"`
+		} `json:"click"`
 	} `json:"data"`
 	Type string `json:"type"`
 }
@@ -85,68 +122,557 @@ func (we *webhookHandler) handleResend(
 			return
 		}
 
-		log, recipientStat, err := we.updateRepo.GetStatByEmailID(ctx, req.Data.EmailID, malak.UpdateRecipientLogProviderResend)
-		if errors.Is(err, sql.ErrNoRows) {
-			// other emails not an update email
-			// we cannot track those because it is just not needed
-			_ = render.Render(w, r, newAPIStatus(http.StatusOK, ""))
+		var event emailEvent
+
+		switch req.Type {
+		case "email.clicked":
+			if err := we.recordEmailClick(ctx, logger, malak.UpdateRecipientLogProviderResend,
+				req.Data.EmailID, req.Data.Click.Link); err != nil {
+				span.RecordError(err)
+				_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, err.Error()))
+				return
+			}
+
+			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "processed stat"))
+			return
+		case "email.opened":
+			event = emailEventOpened
+		case "email.bounced":
+			event = emailEventBounced
+		case "email.delivered":
+			event = emailEventDelivered
+		case "email.complained":
+			event = emailEventComplained
+		default:
+			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "unsupported event type"))
 			return
 		}
 
+		if err := we.recordEmailEvent(ctx, logger, malak.UpdateRecipientLogProviderResend,
+			req.Data.EmailID, event, time.Now()); err != nil {
+			span.RecordError(err)
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, err.Error()))
+			return
+		}
+
+		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "processed stat"))
+	}
+}
+
+func (we *webhookHandler) handleSendgrid(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "sendgrid.webhookHandler", we.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		logger = logger.With(zap.String("request_id", rid))
+
+		logger.Debug("Processing sendgrid webhook")
+
+		if we.sendgridVerifier == nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "sendgrid not active"))
+			return
+		}
+
+		rawBytes, err := io.ReadAll(r.Body)
 		if err != nil {
-			logger.Error("could not fetch recipient by id", zap.Error(err),
-				zap.String("email_reference", req.Data.EmailID))
-			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not find recipient"))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "could not read bytes data"))
 			return
 		}
 
-		if recipientStat == nil {
-			logger.Error("could not fetch recipient stat",
-				zap.String("provider", "Resend"), zap.String("email_id", req.Data.EmailID))
-			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not find recipient for weird reasons"))
+		if err := we.sendgridVerifier.Verify(rawBytes, r.Header); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, err.Error()))
 			return
 		}
 
-		update := &malak.Update{
-			ID: log.Recipient.UpdateID,
+		var events []sendgrid.Event
+
+		if err := json.Unmarshal(rawBytes, &events); err != nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid/unexpected sendgrid body"))
+			return
 		}
 
-		updateStat, err := we.updateRepo.Stat(ctx, update)
-		if err != nil {
-			logger.Error("could not fetch update stats by id", zap.Error(err),
-				zap.String("update_id", update.ID.String()))
-			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not find update stat"))
+		var hasFailed bool
+
+		for _, v := range events {
+			var event emailEvent
+
+			switch v.Event {
+			case "open":
+				event = emailEventOpened
+			case "bounce":
+				// blocked messages are temporary and get retried
+				event = emailEventBounced
+				if v.Type == "blocked" {
+					event = emailEventSoftBounced
+				}
+			case "delivered":
+				event = emailEventDelivered
+			case "spamreport":
+				event = emailEventComplained
+			default:
+				continue
+			}
+
+			occurredAt := time.Now()
+			if v.Timestamp > 0 {
+				occurredAt = time.Unix(v.Timestamp, 0)
+			}
+
+			// sendgrid retries the whole batch on a non 2xx response so
+			// events handled in an earlier delivery must not be counted again
+			if v.SGEventID != "" {
+				isNew, err := we.cache.AddIfNotExists(ctx, sendgridEventKey(v.SGEventID),
+					[]byte("processed"), sendgridEventTTL)
+				if err != nil {
+					logger.Error("could not check if sendgrid event was processed",
+						zap.Error(err), zap.String("event_id", v.SGEventID))
+					span.RecordError(err)
+					hasFailed = true
+					continue
+				}
+
+				if !isNew {
+					continue
+				}
+			}
+
+			if err := we.recordEmailEvent(ctx, logger, malak.UpdateRecipientLogProviderSendgrid,
+				v.MessageID(), event, occurredAt); err != nil {
+				span.RecordError(err)
+				hasFailed = true
+
+				if v.SGEventID == "" {
+					continue
+				}
+
+				// the retry has to process this event again
+				if err := we.cache.Delete(ctx, sendgridEventKey(v.SGEventID)); err != nil {
+					logger.Error("could not release sendgrid event",
+						zap.Error(err), zap.String("event_id", v.SGEventID))
+				}
+			}
+		}
+
+		if hasFailed {
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not process all events"))
 			return
 		}
 
-		switch req.Type {
-		case "email.opened":
-			updateStat.TotalOpens++
-			if recipientStat.LastOpenedAt == nil {
-				updateStat.UniqueOpens++
+		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "processed stat"))
+	}
+}
+
+func (we *webhookHandler) handleSES(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "ses.webhookHandler", we.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		logger = logger.With(zap.String("request_id", rid))
+
+		logger.Debug("Processing ses webhook")
+
+		if we.sesVerifier == nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "ses not active"))
+			return
+		}
+
+		// sns sends the json body as text/plain
+		var msg = new(ses.Message)
+
+		if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid/unexpected sns body"))
+			return
+		}
+
+		if err := we.sesVerifier.Verify(ctx, msg); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		switch msg.Type {
+		case ses.MessageTypeSubscriptionConfirmation:
+			if err := we.sesVerifier.ConfirmSubscription(ctx, msg); err != nil {
+				logger.Error("could not confirm sns subscription", zap.Error(err),
+					zap.String("topic_arn", msg.TopicArn))
+				_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not confirm subscription"))
+				return
 			}
 
-			recipientStat.LastOpenedAt = hermes.Ref(time.Now())
+			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "subscription confirmed"))
+			return
 
-		case "email.bounced":
-			recipientStat.IsBounced = true
+		case ses.MessageTypeNotification:
 
-		case "email.delivered":
-			recipientStat.IsDelivered = true
+		default:
+			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "unsupported message type"))
+			return
+		}
+
+		var sesEvent ses.Event
+
+		if err := json.Unmarshal([]byte(msg.Message), &sesEvent); err != nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid/unexpected ses notification"))
+			return
+		}
+
+		var event emailEvent
 
+		switch sesEvent.Type() {
+		case "Open":
+			event = emailEventOpened
+		case "Delivery":
+			event = emailEventDelivered
+		case "Complaint":
+			event = emailEventComplained
+		case "Bounce":
+			event = emailEventSoftBounced
+			if sesEvent.IsHardBounce() {
+				event = emailEventBounced
+			}
 		default:
 			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "unsupported event type"))
 			return
 		}
 
-		if err := we.updateRepo.UpdateStat(ctx, updateStat, recipientStat); err != nil {
-			logger.Error("could not update stat", zap.Error(err),
-				zap.String("recipient_stat", recipientStat.ID.String()),
-				zap.String("update_stat_id", update.ID.String()))
-			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, "could not update stat"))
+		if err := we.recordEmailEvent(ctx, logger, malak.UpdateRecipientLogProviderSes,
+			sesEvent.Mail.MessageID, event, sesEvent.OccurredAt()); err != nil {
+			span.RecordError(err)
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, err.Error()))
 			return
 		}
 
 		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "processed stat"))
 	}
 }
+
+func (we *webhookHandler) handlePostmark(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "postmark.webhookHandler", we.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		logger = logger.With(zap.String("request_id", rid))
+
+		logger.Debug("Processing postmark webhook")
+
+		if we.postmarkAuthenticator == nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "postmark not active"))
+			return
+		}
+
+		if err := we.postmarkAuthenticator.Verify(r); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			_ = render.Render(w, r, newAPIStatus(http.StatusUnauthorized, err.Error()))
+			return
+		}
+
+		var req postmark.Event
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			_ = render.Render(w, r, newAPIStatus(http.StatusBadRequest, "invalid/unexpected postmark body"))
+			return
+		}
+
+		var event emailEvent
+
+		switch req.RecordType {
+		case postmark.RecordTypeOpen:
+			event = emailEventOpened
+		case postmark.RecordTypeDelivery:
+			event = emailEventDelivered
+		case postmark.RecordTypeSpamComplaint:
+			event = emailEventComplained
+		case postmark.RecordTypeBounce:
+			event = emailEventSoftBounced
+			if req.IsHardBounce() {
+				event = emailEventBounced
+			}
+		default:
+			_ = render.Render(w, r, newAPIStatus(http.StatusOK, "unsupported event type"))
+			return
+		}
+
+		if err := we.recordEmailEvent(ctx, logger, malak.UpdateRecipientLogProviderPostmark,
+			req.MessageID, event, req.OccurredAt()); err != nil {
+			span.RecordError(err)
+			_ = render.Render(w, r, newAPIStatus(http.StatusInternalServerError, err.Error()))
+			return
+		}
+
+		_ = render.Render(w, r, newAPIStatus(http.StatusOK, "processed stat"))
+	}
+}
+
+// transparentPixel is a 1x1 transparent gif
+var transparentPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00,
+	0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00,
+	0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// @Tags updates
+// @Description Open tracking pixel embedded in updates sent through smtp
+// @Id trackOpen
+// @Produce  image/gif
+// @Success 200
+// @Param recipient query string true "recipient reference"
+// @Param signature query string true "signature from the email"
+// @Router /updates/open [get]
+func (we *webhookHandler) handleOpenPixel(
+	logger *zap.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx, span, rid := getTracer(r.Context(), r, "updates.open.handler", we.cfg.Otel.IsEnabled)
+		defer span.End()
+
+		ref := malak.Reference(r.URL.Query().Get("recipient"))
+		signature := r.URL.Query().Get("signature")
+
+		logger = logger.With(zap.String("request_id", rid),
+			zap.String("recipient_reference", ref.String()))
+
+		// the image is always served so mail clients do not show a
+		// broken image. Failures only mean the open is not counted
+		defer func() {
+			w.Header().Set("Content-Type", "image/gif")
+			w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(transparentPixel)
+		}()
+
+		if !malak.VerifyOpen(we.cfg.Auth.JWT.Key, ref, signature) {
+			logger.Debug("invalid open tracking signature")
+			return
+		}
+
+		recipient, err := we.updateRepo.GetRecipient(ctx, ref)
+		if err != nil {
+			logger.Error("could not fetch recipient", zap.Error(err))
+			return
+		}
+
+		if recipient.UpdateRecipientStat == nil {
+			logger.Error("recipient has no stat. update may not have been sent")
+			return
+		}
+
+		if err := we.applyEmailEvent(ctx, logger, malak.UpdateRecipientLogProviderSmtp,
+			recipient, recipient.UpdateRecipientStat, emailEventOpened, time.Now()); err != nil {
+			span.RecordError(err)
+		}
+	}
+}
+
+// recordEmailEvent updates the recipient and update stats for an email
+// sent out by any of the supported providers. Emails we have no record of
+// are not update emails and are ignored
+func (we *webhookHandler) recordEmailEvent(ctx context.Context,
+	logger *zap.Logger, provider malak.UpdateRecipientLogProvider,
+	emailID string, event emailEvent, occurredAt time.Time) error {
+
+	logger = logger.With(zap.String("provider", provider.String()),
+		zap.String("email_id", emailID))
+
+	log, recipientStat, err := we.updateRepo.GetStatByEmailID(ctx, emailID, provider)
+	if errors.Is(err, sql.ErrNoRows) {
+		// other emails not an update email
+		// we cannot track those because it is just not needed
+		return nil
+	}
+
+	if err != nil {
+		logger.Error("could not fetch recipient by id", zap.Error(err))
+		return errors.New("could not find recipient")
+	}
+
+	if recipientStat == nil {
+		logger.Error("could not fetch recipient stat")
+		return errors.New("could not find recipient for weird reasons")
+	}
+
+	return we.applyEmailEvent(ctx, logger, provider, log.Recipient, recipientStat, event, occurredAt)
+}
+
+// applyEmailEvent updates the stats of a recipient we already know about.
+// Shared by the provider webhooks and the self hosted open pixel
+func (we *webhookHandler) applyEmailEvent(ctx context.Context,
+	logger *zap.Logger, provider malak.UpdateRecipientLogProvider,
+	recipient *malak.UpdateRecipient, recipientStat *malak.UpdateRecipientStat,
+	event emailEvent, occurredAt time.Time) error {
+
+	update := &malak.Update{
+		ID: recipient.UpdateID,
+	}
+
+	updateStat, err := we.updateRepo.Stat(ctx, update)
+	if err != nil {
+		logger.Error("could not fetch update stats by id", zap.Error(err),
+			zap.String("update_id", update.ID.String()))
+		return errors.New("could not find update stat")
+	}
+
+	var isFirstOpen bool
+
+	switch event {
+	case emailEventOpened:
+		updateStat.TotalOpens++
+		if recipientStat.LastOpenedAt == nil {
+			updateStat.UniqueOpens++
+			isFirstOpen = true
+		}
+
+		recipientStat.LastOpenedAt = hermes.Ref(occurredAt)
+
+	case emailEventBounced, emailEventSoftBounced:
+		recipientStat.IsBounced = true
+
+	case emailEventComplained:
+		recipientStat.IsComplained = true
+
+	case emailEventDelivered:
+		recipientStat.IsDelivered = true
+	}
+
+	if err := we.updateRepo.UpdateStat(ctx, updateStat, recipientStat); err != nil {
+		logger.Error("could not update stat", zap.Error(err),
+			zap.String("recipient_stat", recipientStat.ID.String()),
+			zap.String("update_stat_id", update.ID.String()))
+		return errors.New("could not update stat")
+	}
+
+	if isFirstOpen {
+		we.dispatchUpdateOpened(ctx, logger, recipient, occurredAt)
+	}
+
+	switch event {
+	case emailEventBounced:
+		return we.suppressRecipient(ctx, logger, recipient, provider, malak.SuppressionReasonBounced)
+
+	case emailEventComplained:
+		return we.suppressRecipient(ctx, logger, recipient, provider, malak.SuppressionReasonComplained)
+	}
+
+	return nil
+}
+
+// recordEmailClick stores clicks reported by the email provider. Links in
+// updates already go through the click endpoint so clicks on them are
+// skipped here to avoid counting them twice
+func (we *webhookHandler) recordEmailClick(ctx context.Context,
+	logger *zap.Logger, provider malak.UpdateRecipientLogProvider,
+	emailID, link string) error {
+
+	if hermes.IsStringEmpty(link) || isTrackedClickURL(we.cfg, link) {
+		return nil
+	}
+
+	logger = logger.With(zap.String("provider", provider.String()),
+		zap.String("email_id", emailID))
+
+	log, _, err := we.updateRepo.GetStatByEmailID(ctx, emailID, provider)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		logger.Error("could not fetch recipient by id", zap.Error(err))
+		return errors.New("could not find recipient")
+	}
+
+	err = we.updateRepo.RecordClick(ctx, &malak.UpdateLinkClick{
+		UpdateID:    log.Recipient.UpdateID,
+		RecipientID: log.RecipientID,
+		URL:         link,
+	})
+	if err != nil {
+		logger.Error("could not record click", zap.Error(err))
+		return errors.New("could not record click")
+	}
+
+	return nil
+}
+
+func isTrackedClickURL(cfg config.Config, link string) bool {
+	return strings.HasPrefix(link, strings.TrimSuffix(cfg.HTTP.PublicURL, "/")+"/updates/click")
+}
+
+// suppressRecipient makes sure contacts that hard bounce or mark an update
+// as spam are skipped the next time an update is sent
+func (we *webhookHandler) suppressRecipient(ctx context.Context,
+	logger *zap.Logger, recipient *malak.UpdateRecipient,
+	provider malak.UpdateRecipientLogProvider, reason malak.SuppressionReason) error {
+
+	update, err := we.updateRepo.GetByID(ctx, recipient.UpdateID)
+	if err != nil {
+		logger.Error("could not fetch update to suppress contact", zap.Error(err),
+			zap.String("update_id", recipient.UpdateID.String()))
+		return errors.New("could not suppress contact")
+	}
+
+	err = we.suppressionRepo.Create(ctx, &malak.Suppression{
+		WorkspaceID: update.WorkspaceID,
+		ContactID:   recipient.ContactID,
+		Reference:   we.referenceGenerator.Generate(malak.EntityTypeSuppression),
+		Reason:      reason,
+		Provider:    provider,
+	})
+	if err != nil {
+		logger.Error("could not suppress contact", zap.Error(err),
+			zap.String("contact_id", recipient.ContactID.String()))
+		return errors.New("could not suppress contact")
+	}
+
+	return nil
+}
+
+// dispatchUpdateOpened only notifies webhooks the first time a recipient
+// opens an update
+func (we *webhookHandler) dispatchUpdateOpened(ctx context.Context,
+	logger *zap.Logger, recipient *malak.UpdateRecipient, openedAt time.Time) {
+
+	update, err := we.updateRepo.GetByID(ctx, recipient.UpdateID)
+	if err != nil {
+		logger.Error("could not fetch update for webhook", zap.Error(err),
+			zap.String("update_id", recipient.UpdateID.String()))
+		return
+	}
+
+	contact, err := we.contactRepo.Get(ctx, malak.FetchContactOptions{
+		ID:          recipient.ContactID,
+		WorkspaceID: update.WorkspaceID,
+	})
+	if err != nil {
+		logger.Error("could not fetch contact for webhook", zap.Error(err),
+			zap.String("contact_id", recipient.ContactID.String()))
+		return
+	}
+
+	dispatchWebhookEvent(ctx, logger, we.queue, update.WorkspaceID,
+		malak.WebhookEventUpdateOpened, malak.WebhookUpdateOpenedData{
+			UpdateReference:    update.Reference,
+			RecipientReference: recipient.Reference,
+			Email:              contact.Email,
+			OpenedAt:           openedAt,
+		})
+}
@@ -0,0 +1,562 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/config"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	"github.com/ayinke-llc/malak/internal/pkg/webhook"
+	"github.com/ayinke-llc/malak/internal/secret"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/microcosm-cc/bluemonday"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const webhookSecretPrefix = "whsec_"
+
+// webhookEndpointHandler manages the endpoints a workspace wants events
+// sent to. Incoming provider webhooks are handled by webhookHandler
+type webhookEndpointHandler struct {
+	cfg           config.Config
+	webhookRepo   malak.WebhookRepository
+	generator     malak.ReferenceGeneratorOperation
+	queue         queue.QueueHandler
+	auditLogRepo  malak.AuditLogRepository
+	secretsClient secret.SecretClient
+}
+
+// dispatchWebhookEvent hands the event over to the queue so the request
+// is not slowed down by looking up subscribed webhooks. Failures are only
+// logged as webhooks should never break the action that triggered them
+func dispatchWebhookEvent(ctx context.Context,
+	logger *zap.Logger,
+	q queue.QueueHandler,
+	workspaceID uuid.UUID,
+	event malak.WebhookEvent,
+	data any) {
+
+	err := q.Add(ctx, queue.QueueTopicDispatchWebhook, queue.DispatchWebhookOptions{
+		WorkspaceID: workspaceID,
+		Event:       event,
+		Data:        data,
+	})
+	if err != nil {
+		logger.Error("could not dispatch webhook event",
+			zap.String("event", event.String()),
+			zap.Error(err))
+	}
+}
+
+func validateWebhookURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || hermes.IsStringEmpty(u.Host) {
+		return errors.New("please provide a valid webhook url")
+	}
+
+	if u.Scheme != "https" {
+		return errors.New("webhook url must use https")
+	}
+
+	// hostnames are checked again once resolved when the webhook is
+	// delivered. This only catches the obvious cases early
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("webhook url must be publicly reachable")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !webhook.IsPublicAddress(addr) {
+		return errors.New("webhook url must be publicly reachable")
+	}
+
+	return nil
+}
+
+func validateWebhookEvents(events []malak.WebhookEvent) ([]malak.WebhookEvent, error) {
+	if len(events) == 0 {
+		return nil, errors.New("please subscribe to at least one event")
+	}
+
+	seen := make(map[malak.WebhookEvent]bool, len(events))
+	valid := make([]malak.WebhookEvent, 0, len(events))
+
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%s is not a valid webhook event", event)
+		}
+
+		if seen[event] {
+			continue
+		}
+
+		seen[event] = true
+		valid = append(valid, event)
+	}
+
+	return valid, nil
+}
+
+func sanitizeWebhookDescription(s string) (string, error) {
+	if len(s) > 200 {
+		return "", errors.New("description cannot be more than 200 characters")
+	}
+
+	return bluemonday.StrictPolicy().Sanitize(strings.TrimSpace(s)), nil
+}
+
+type createWebhookRequest struct {
+	GenericRequest
+
+	URL         string               `json:"url,omitempty" validate:"required"`
+	Description string               `json:"description,omitempty" validate:"optional"`
+	Events      []malak.WebhookEvent `json:"events,omitempty" validate:"required"`
+}
+
+func (c *createWebhookRequest) Validate() error {
+	if err := validateWebhookURL(c.URL); err != nil {
+		return err
+	}
+
+	events, err := validateWebhookEvents(c.Events)
+	if err != nil {
+		return err
+	}
+
+	c.Events = events
+
+	c.Description, err = sanitizeWebhookDescription(c.Description)
+	return err
+}
+
+// @Description Creates a new webhook endpoint. The signing secret is only returned here
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Param message body createWebhookRequest true "webhook request body"
+// @Success 200 {object} createdWebhookResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks [post]
+func (d *webhookEndpointHandler) create(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("creating webhook")
+
+	req := new(createWebhookRequest)
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	user := getUserFromContext(r.Context())
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	reference := d.generator.Generate(malak.EntityTypeWebhook)
+
+	signingSecret := webhookSecretPrefix + d.generator.Token()
+
+	key, err := d.secretsClient.Create(ctx, &secret.CreateSecretOptions{
+		Value:       signingSecret,
+		WorkspaceID: workspace.ID,
+		Name:        fmt.Sprintf("webhooks/%s", reference),
+	})
+	if err != nil {
+		logger.Error("could not store webhook secret in secrets provider", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not store webhook secret"), StatusFailed
+	}
+
+	webhook := &malak.Webhook{
+		WorkspaceID: workspace.ID,
+		CreatedBy:   user.ID,
+		Reference:   reference,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		IsActive:    true,
+		Secret:      key,
+	}
+
+	if err := d.webhookRepo.Create(ctx, webhook); err != nil {
+		logger.Error("could not create webhook",
+			zap.Error(err))
+
+		var status = http.StatusInternalServerError
+		var msg = "could not create webhook"
+
+		if errors.Is(err, malak.ErrWebhookMaxLimit) {
+			status = http.StatusBadRequest
+			msg = err.Error()
+		}
+
+		return newAPIStatus(status, msg), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionWebhookCreated,
+		malak.EntityTypeWebhook, webhook.Reference.String())
+	entry.Metadata["url"] = webhook.URL
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
+	return createdWebhookResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "webhook created"),
+		Webhook:   hermes.DeRef(webhook),
+		Secret:    signingSecret,
+	}, StatusSuccess
+}
+
+// @Description list webhooks
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} listWebhooksResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks [get]
+func (d *webhookEndpointHandler) list(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing webhooks")
+
+	workspace := getWorkspaceFromContext(r.Context())
+
+	webhooks, err := d.webhookRepo.List(ctx, workspace.ID)
+	if err != nil {
+		logger.Error("could not list webhooks",
+			zap.Error(err))
+
+		return newAPIStatus(http.StatusInternalServerError, "could not list webhooks"), StatusFailed
+	}
+
+	return listWebhooksResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "webhooks fetched"),
+		Webhooks:  webhooks,
+	}, StatusSuccess
+}
+
+func (d *webhookEndpointHandler) fetchWebhook(ctx context.Context,
+	logger *zap.Logger, r *http.Request) (*malak.Webhook, render.Renderer) {
+
+	ref := chi.URLParam(r, "reference")
+
+	if hermes.IsStringEmpty(ref) {
+		return nil, newAPIStatus(http.StatusBadRequest, "reference required")
+	}
+
+	webhook, err := d.webhookRepo.Get(ctx, malak.FetchWebhookOptions{
+		Reference:   malak.Reference(ref),
+		WorkspaceID: getWorkspaceFromContext(ctx).ID,
+	})
+	if err != nil {
+		if errors.Is(err, malak.ErrWebhookNotFound) {
+			return nil, newAPIStatus(http.StatusNotFound, err.Error())
+		}
+
+		logger.Error("could not fetch webhook", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError, "could not fetch webhook")
+	}
+
+	return webhook, nil
+}
+
+type updateWebhookRequest struct {
+	GenericRequest
+
+	URL         *string              `json:"url,omitempty" validate:"optional"`
+	Description *string              `json:"description,omitempty" validate:"optional"`
+	Events      []malak.WebhookEvent `json:"events,omitempty" validate:"optional"`
+	IsActive    *bool                `json:"is_active,omitempty" validate:"optional"`
+}
+
+func (u *updateWebhookRequest) Validate() error {
+	if u.URL != nil {
+		if err := validateWebhookURL(hermes.DeRef(u.URL)); err != nil {
+			return err
+		}
+	}
+
+	if u.Events != nil {
+		events, err := validateWebhookEvents(u.Events)
+		if err != nil {
+			return err
+		}
+
+		u.Events = events
+	}
+
+	if u.Description != nil {
+		description, err := sanitizeWebhookDescription(hermes.DeRef(u.Description))
+		if err != nil {
+			return err
+		}
+
+		u.Description = hermes.Ref(description)
+	}
+
+	return nil
+}
+
+// @Description update a webhook. Only the provided fields are changed
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "webhook unique reference.. e.g webhook_"
+// @Param message body updateWebhookRequest true "webhook request body"
+// @Success 200 {object} fetchWebhookResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks/{reference} [patch]
+func (d *webhookEndpointHandler) update(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("updating webhook")
+
+	req := new(updateWebhookRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	webhook, errResp := d.fetchWebhook(ctx, logger, r)
+	if errResp != nil {
+		return errResp, StatusFailed
+	}
+
+	if req.URL != nil {
+		webhook.URL = hermes.DeRef(req.URL)
+	}
+
+	if req.Description != nil {
+		webhook.Description = hermes.DeRef(req.Description)
+	}
+
+	if req.Events != nil {
+		webhook.Events = req.Events
+	}
+
+	if req.IsActive != nil {
+		webhook.IsActive = hermes.DeRef(req.IsActive)
+	}
+
+	if err := d.webhookRepo.Update(ctx, webhook); err != nil {
+		logger.Error("could not update webhook", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not update webhook"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionWebhookUpdated,
+		malak.EntityTypeWebhook, webhook.Reference.String())
+	entry.Metadata["url"] = webhook.URL
+
+	recordAuditLog(ctx, logger, d.auditLogRepo, entry)
+
+	return fetchWebhookResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "webhook updated"),
+		Webhook:   hermes.DeRef(webhook),
+	}, StatusSuccess
+}
+
+// @Description delete a webhook. Pending deliveries will not be sent
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "webhook unique reference.. e.g webhook_"
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks/{reference} [delete]
+func (d *webhookEndpointHandler) delete(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("deleting webhook")
+
+	webhook, errResp := d.fetchWebhook(ctx, logger, r)
+	if errResp != nil {
+		return errResp, StatusFailed
+	}
+
+	if err := d.webhookRepo.Delete(ctx, webhook); err != nil {
+		logger.Error("could not delete webhook", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not delete webhook"), StatusFailed
+	}
+
+	recordAuditLog(ctx, logger, d.auditLogRepo,
+		newAuditLog(r, malak.AuditLogActionWebhookDeleted,
+			malak.EntityTypeWebhook, webhook.Reference.String()))
+
+	return newAPIStatus(http.StatusOK, "webhook deleted"), StatusSuccess
+}
+
+// @Description list the deliveries of a webhook
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "webhook unique reference.. e.g webhook_"
+// @Param page query int false "Page to query data from. Defaults to 1"
+// @Param per_page query int false "Number to items to return. Defaults to 10 items"
+// @Success 200 {object} listWebhookDeliveriesResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks/{reference}/deliveries [get]
+func (d *webhookEndpointHandler) listDeliveries(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("listing webhook deliveries")
+
+	webhook, errResp := d.fetchWebhook(ctx, logger, r)
+	if errResp != nil {
+		return errResp, StatusFailed
+	}
+
+	opts := malak.ListWebhookDeliveryOptions{
+		Paginator: malak.PaginatorFromRequest(r),
+		WebhookID: webhook.ID,
+	}
+
+	span.SetAttributes(opts.Paginator.OTELAttributes()...)
+
+	deliveries, total, err := d.webhookRepo.ListDeliveries(ctx, opts)
+	if err != nil {
+		logger.Error("could not list webhook deliveries", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not list webhook deliveries"), StatusFailed
+	}
+
+	return listWebhookDeliveriesResponse{
+		APIStatus:  newAPIStatus(http.StatusOK, "webhook deliveries fetched"),
+		Deliveries: deliveries,
+		Meta: meta{
+			Paging: pagingInfo{
+				PerPage: opts.Paginator.PerPage,
+				Page:    opts.Paginator.Page,
+				Total:   total,
+			},
+		},
+	}, StatusSuccess
+}
+
+// @Description send a delivery again. This works for failed and succeeded deliveries
+// @Tags developers
+// @Accept  json
+// @Produce  json
+// @Param reference path string required "webhook unique reference.. e.g webhook_"
+// @Param delivery_reference path string required "delivery unique reference.. e.g webhook_delivery_"
+// @Success 200 {object} fetchWebhookDeliveryResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /developers/webhooks/{reference}/deliveries/{delivery_reference}/redeliver [post]
+func (d *webhookEndpointHandler) redeliver(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("redelivering webhook")
+
+	deliveryRef := chi.URLParam(r, "delivery_reference")
+
+	if hermes.IsStringEmpty(deliveryRef) {
+		return newAPIStatus(http.StatusBadRequest, "delivery reference required"), StatusFailed
+	}
+
+	webhook, errResp := d.fetchWebhook(ctx, logger, r)
+	if errResp != nil {
+		return errResp, StatusFailed
+	}
+
+	if !webhook.IsActive {
+		return newAPIStatus(http.StatusBadRequest,
+			"webhook is disabled. Enable it before redelivering"), StatusFailed
+	}
+
+	logger = logger.With(zap.String("delivery_reference", deliveryRef))
+
+	delivery, err := d.webhookRepo.GetDelivery(ctx, malak.FetchWebhookDeliveryOptions{
+		WebhookID: webhook.ID,
+		Reference: malak.Reference(deliveryRef),
+	})
+	if err != nil {
+		if errors.Is(err, malak.ErrWebhookDeliveryNotFound) {
+			return newAPIStatus(http.StatusNotFound, err.Error()), StatusFailed
+		}
+
+		logger.Error("could not fetch webhook delivery", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not fetch webhook delivery"), StatusFailed
+	}
+
+	if delivery.Status == malak.WebhookDeliveryStatusPending {
+		return newAPIStatus(http.StatusBadRequest,
+			"delivery is still pending. Wait for it to complete before redelivering"), StatusFailed
+	}
+
+	delivery.Redeliver(time.Now())
+
+	if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		logger.Error("could not update webhook delivery", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError, "could not redeliver webhook"), StatusFailed
+	}
+
+	// the webhooks cron job will still pick it up if this fails
+	if err := d.queue.Add(ctx, queue.QueueTopicDeliverWebhook, queue.DeliverWebhookOptions{
+		DeliveryID: delivery.ID,
+	}); err != nil {
+		logger.Error("could not queue webhook delivery", zap.Error(err))
+	}
+
+	return fetchWebhookDeliveryResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "webhook delivery queued"),
+		Delivery:  hermes.DeRef(delivery),
+	}, StatusSuccess
+}
@@ -0,0 +1,704 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/queue"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var webhookTestWorkspaceID = uuid.MustParse("56670b6d-48d4-4b17-bc8f-d101b7d0b53c")
+
+func newMockWebhookDispatchQueue(controller *gomock.Controller) *malak_mocks.MockQueueHandler {
+	q := malak_mocks.NewMockQueueHandler(controller)
+
+	q.EXPECT().
+		Add(gomock.Any(), queue.QueueTopicDispatchWebhook, gomock.Any()).
+		AnyTimes().
+		Return(nil)
+
+	return q
+}
+
+func newWebhookEndpointTestRequest(method string, body any, params map[string]string) *http.Request {
+	var b = bytes.NewBuffer(nil)
+	if body != nil {
+		_ = json.NewEncoder(b).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, "/", b)
+	req.Header.Add("Content-Type", "application/json")
+
+	ctx := chi.NewRouteContext()
+	for k, v := range params {
+		ctx.URLParams.Add(k, v)
+	}
+
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+	req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
+	return req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{
+		ID: webhookTestWorkspaceID,
+	}))
+}
+
+func generateCreateWebhookTestTable() []struct {
+	name               string
+	mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient)
+	req                createWebhookRequest
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient)
+		req                createWebhookRequest
+		expectedStatusCode int
+	}{
+		{
+			name:   "invalid url",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL:    "oops",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "url without https",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL:    "http://example.com/hooks",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "url to an internal address",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL:    "https://169.254.169.254/latest/meta-data",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "url to localhost",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL:    "https://localhost:8080/hooks",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "no events",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL: "https://example.com/hooks",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid event",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {},
+			req: createWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []malak.WebhookEvent{"update_deleted"},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not store webhook secret",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {
+				secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", errors.New("could not store secret"))
+
+				webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			req: createWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "could not create webhook",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {
+				secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("webhooks/webhook_test_reference", nil)
+
+				webhookRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not create webhook"))
+			},
+			req: createWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "max limit reached",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {
+				secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("webhooks/webhook_test_reference", nil)
+
+				webhookRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(malak.ErrWebhookMaxLimit)
+			},
+			req: createWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "webhook created",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, secretsClient *malak_mocks.MockSecretClient) {
+				secretsClient.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("webhooks/webhook_test_reference", nil)
+
+				webhookRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, webhook *malak.Webhook) error {
+						// duplicates are dropped
+						if len(webhook.Events) != 2 {
+							return errors.New("unexpected events")
+						}
+
+						// only the key of the signing secret is stored
+						if webhook.Secret != "webhooks/webhook_test_reference" {
+							return errors.New("unexpected secret")
+						}
+
+						return nil
+					})
+			},
+			req: createWebhookRequest{
+				URL:         "https://example.com/hooks",
+				Description: "crm sync",
+				Events: []malak.WebhookEvent{
+					malak.WebhookEventUpdateOpened,
+					malak.WebhookEventFundraisingContactMoved,
+					malak.WebhookEventUpdateOpened,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestWebhookEndpointHandler_Create(t *testing.T) {
+	for _, v := range generateCreateWebhookTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+			secretsClient := malak_mocks.NewMockSecretClient(controller)
+
+			v.mockFn(webhookRepo, secretsClient)
+
+			h := &webhookEndpointHandler{
+				cfg:           getConfig(),
+				webhookRepo:   webhookRepo,
+				generator:     &mockReferenceGenerator{},
+				auditLogRepo:  newMockAuditLogRepository(controller),
+				secretsClient: secretsClient,
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.create, getConfig(), "developers.webhooks.create").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodPost, v.req, nil))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookEndpointHandler_List(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "could not list webhooks",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					List(gomock.Any(), webhookTestWorkspaceID).
+					Times(1).
+					Return(nil, errors.New("could not list webhooks"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "webhooks fetched",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					List(gomock.Any(), webhookTestWorkspaceID).
+					Times(1).
+					Return([]malak.Webhook{
+						{
+							Reference: "webhook_123",
+							URL:       "https://example.com/hooks",
+							Events:    []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+							IsActive:  true,
+							Secret:    "whsec_never_returned",
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+
+			v.mockFn(webhookRepo)
+
+			h := &webhookEndpointHandler{
+				cfg:         getConfig(),
+				webhookRepo: webhookRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.list, getConfig(), "developers.webhooks.list").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodGet, nil, nil))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func generateUpdateWebhookTestTable() []struct {
+	name               string
+	mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository)
+	req                updateWebhookRequest
+	expectedStatusCode int
+} {
+	return []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository)
+		req                updateWebhookRequest
+		expectedStatusCode int
+	}{
+		{
+			name:   "invalid url",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {},
+			req: updateWebhookRequest{
+				URL: hermes.Ref("ftp://example.com"),
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "webhook not found",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrWebhookNotFound)
+			},
+			req: updateWebhookRequest{
+				IsActive: hermes.Ref(false),
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not update webhook",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{}, nil)
+
+				webhookRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not update webhook"))
+			},
+			req: updateWebhookRequest{
+				IsActive: hermes.Ref(false),
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "webhook updated",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), malak.FetchWebhookOptions{
+						WorkspaceID: webhookTestWorkspaceID,
+						Reference:   "webhook_123",
+					}).
+					Times(1).
+					Return(&malak.Webhook{
+						Reference: "webhook_123",
+						URL:       "https://example.com/hooks",
+						Events:    []malak.WebhookEvent{malak.WebhookEventUpdateOpened},
+						IsActive:  true,
+					}, nil)
+
+				webhookRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			req: updateWebhookRequest{
+				Events:   []malak.WebhookEvent{malak.WebhookEventIntegrationSyncFailed},
+				IsActive: hermes.Ref(false),
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+}
+
+func TestWebhookEndpointHandler_Update(t *testing.T) {
+	for _, v := range generateUpdateWebhookTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+
+			v.mockFn(webhookRepo)
+
+			h := &webhookEndpointHandler{
+				cfg:          getConfig(),
+				webhookRepo:  webhookRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.update, getConfig(), "developers.webhooks.update").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodPatch, v.req,
+					map[string]string{"reference": "webhook_123"}))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookEndpointHandler_Delete(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "webhook not found",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrWebhookNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not delete webhook",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{}, nil)
+
+				webhookRepo.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not delete webhook"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "webhook deleted",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{}, nil)
+
+				webhookRepo.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+
+			v.mockFn(webhookRepo)
+
+			h := &webhookEndpointHandler{
+				cfg:          getConfig(),
+				webhookRepo:  webhookRepo,
+				auditLogRepo: newMockAuditLogRepository(controller),
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.delete, getConfig(), "developers.webhooks.delete").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodDelete, nil,
+					map[string]string{"reference": "webhook_123"}))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookEndpointHandler_ListDeliveries(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "webhook not found",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrWebhookNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "could not list deliveries",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{}, nil)
+
+				webhookRepo.EXPECT().
+					ListDeliveries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, int64(0), errors.New("could not list deliveries"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "deliveries fetched",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{}, nil)
+
+				webhookRepo.EXPECT().
+					ListDeliveries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]malak.WebhookDelivery{
+						{
+							Reference:          "webhook_delivery_123",
+							Event:              malak.WebhookEventUpdateOpened,
+							Status:             malak.WebhookDeliveryStatusFailed,
+							Attempts:           6,
+							ResponseStatusCode: http.StatusBadGateway,
+							LastError:          "endpoint responded with status code 502",
+						},
+					}, int64(1), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+
+			v.mockFn(webhookRepo)
+
+			h := &webhookEndpointHandler{
+				cfg:         getConfig(),
+				webhookRepo: webhookRepo,
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.listDeliveries, getConfig(), "developers.webhooks.deliveries.list").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodGet, nil,
+					map[string]string{"reference": "webhook_123"}))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookEndpointHandler_Redeliver(t *testing.T) {
+	for _, v := range []struct {
+		name               string
+		mockFn             func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler)
+		expectedStatusCode int
+	}{
+		{
+			name: "webhook is disabled",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{IsActive: false}, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "delivery not found",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{IsActive: true}, nil)
+
+				webhookRepo.EXPECT().
+					GetDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrWebhookDeliveryNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "delivery still pending",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{IsActive: true}, nil)
+
+				webhookRepo.EXPECT().
+					GetDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.WebhookDelivery{
+						Status: malak.WebhookDeliveryStatusPending,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "could not update delivery",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{IsActive: true}, nil)
+
+				webhookRepo.EXPECT().
+					GetDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.WebhookDelivery{
+						Status: malak.WebhookDeliveryStatusFailed,
+					}, nil)
+
+				webhookRepo.EXPECT().
+					UpdateDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not update delivery"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "delivery queued",
+			mockFn: func(webhookRepo *malak_mocks.MockWebhookRepository, queueHandler *malak_mocks.MockQueueHandler) {
+				webhookRepo.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Webhook{IsActive: true}, nil)
+
+				webhookRepo.EXPECT().
+					GetDelivery(gomock.Any(), malak.FetchWebhookDeliveryOptions{
+						Reference: "webhook_delivery_123",
+					}).
+					Times(1).
+					Return(&malak.WebhookDelivery{
+						Reference: "webhook_delivery_123",
+						Event:     malak.WebhookEventUpdateOpened,
+						Status:    malak.WebhookDeliveryStatusFailed,
+						Attempts:  6,
+						LastError: "endpoint responded with status code 502",
+					}, nil)
+
+				webhookRepo.EXPECT().
+					UpdateDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, delivery *malak.WebhookDelivery) error {
+						if delivery.Status != malak.WebhookDeliveryStatusPending || delivery.Attempts != 0 {
+							return errors.New("delivery was not reset")
+						}
+
+						// keep the golden file stable
+						delivery.NextAttemptAt = nil
+						delivery.UpdatedAt = delivery.CreatedAt
+						return nil
+					})
+
+				queueHandler.EXPECT().
+					Add(gomock.Any(), queue.QueueTopicDeliverWebhook, gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			webhookRepo := malak_mocks.NewMockWebhookRepository(controller)
+			queueHandler := malak_mocks.NewMockQueueHandler(controller)
+
+			v.mockFn(webhookRepo, queueHandler)
+
+			h := &webhookEndpointHandler{
+				cfg:         getConfig(),
+				webhookRepo: webhookRepo,
+				queue:       queueHandler,
+			}
+
+			rr := httptest.NewRecorder()
+
+			WrapMalakHTTPHandler(getLogger(t), h.redeliver, getConfig(), "developers.webhooks.deliveries.redeliver").
+				ServeHTTP(rr, newWebhookEndpointTestRequest(http.MethodPost, nil,
+					map[string]string{
+						"reference":          "webhook_123",
+						"delivery_reference": "webhook_delivery_123",
+					}))
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
@@ -0,0 +1,829 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/gif"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/email/postmark"
+	"github.com/ayinke-llc/malak/internal/pkg/email/sendgrid"
+	"github.com/ayinke-llc/malak/internal/pkg/email/ses"
+	malak_mocks "github.com/ayinke-llc/malak/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	svix "github.com/svix/svix-webhooks/go"
+	"go.uber.org/mock/gomock"
+)
+
+func generateSendgridSignature(t *testing.T, key *ecdsa.PrivateKey,
+	timestamp string, payload []byte) string {
+
+	hash := sha256.Sum256(append([]byte(timestamp), payload...))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func generateSendgridWebhookTestTable() []struct {
+	name               string
+	mockFn             func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache)
+	expectedStatusCode int
+	payload            []byte
+	signed             bool
+} {
+	recipient := &malak.UpdateRecipient{
+		ID:        uuid.MustParse("8ce0f580-4d6b-4b02-9d3e-1c8f7b0d0a3e"),
+		UpdateID:  uuid.MustParse("0a3f4b1e-7f2d-4b8e-9a6c-2d1e3f4a5b6c"),
+		ContactID: uuid.MustParse("5b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"),
+		Reference: "update_recipient_test",
+	}
+
+	workspaceID := uuid.MustParse("a4ae79a2-9b76-40d7-b5a1-661e60a02cb0")
+
+	return []struct {
+		name               string
+		mockFn             func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache)
+		expectedStatusCode int
+		payload            []byte
+		signed             bool
+	}{
+		{
+			name: "missing signature",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			payload:            []byte(`[]`),
+		},
+		{
+			name: "invalid body",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			payload:            []byte(`{"event":"open"}`),
+			signed:             true,
+		},
+		{
+			name: "email is not an update email",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "message_123", malak.UpdateRecipientLogProviderSendgrid).
+					Times(1).
+					Return(nil, nil, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusOK,
+			payload:            []byte(`[{"email":"investor@example.com","event":"delivered","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "unsupported events are skipped",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedStatusCode: http.StatusOK,
+			payload:            []byte(`[{"email":"investor@example.com","event":"processed","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "could not update stat",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "message_123", malak.UpdateRecipientLogProviderSendgrid).
+					Times(1).
+					Return(&malak.UpdateRecipientLog{Recipient: recipient}, &malak.UpdateRecipientStat{}, nil)
+
+				updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UpdateStat{}, nil)
+
+				updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not update stat"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			payload:            []byte(`[{"email":"investor@example.com","event":"bounce","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "events from an earlier delivery are skipped",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), "sendgrid-event-event_123", gomock.Any(), sendgridEventTTL).
+					Times(1).
+					Return(false, nil)
+
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedStatusCode: http.StatusOK,
+			payload:            []byte(`[{"email":"investor@example.com","event":"open","sg_event_id":"event_123","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "failed events are released for the retry",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				cacheMock.EXPECT().AddIfNotExists(gomock.Any(), "sendgrid-event-event_123", gomock.Any(), sendgridEventTTL).
+					Times(1).
+					Return(true, nil)
+
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "message_123", malak.UpdateRecipientLogProviderSendgrid).
+					Times(1).
+					Return(nil, nil, errors.New("could not fetch stat"))
+
+				cacheMock.EXPECT().Delete(gomock.Any(), "sendgrid-event-event_123").
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			payload:            []byte(`[{"email":"investor@example.com","event":"open","sg_event_id":"event_123","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "delivered and bounced events are recorded",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "message_123", malak.UpdateRecipientLogProviderSendgrid).
+					Times(2).
+					Return(&malak.UpdateRecipientLog{Recipient: recipient}, &malak.UpdateRecipientStat{}, nil)
+
+				updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(&malak.UpdateStat{}, nil)
+
+				updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(nil)
+
+				updateRepo.EXPECT().GetByID(gomock.Any(), recipient.UpdateID).
+					Times(1).
+					Return(&malak.Update{ID: recipient.UpdateID, WorkspaceID: workspaceID}, nil)
+
+				suppressionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, suppression *malak.Suppression) error {
+						if suppression.ContactID != recipient.ContactID ||
+							suppression.WorkspaceID != workspaceID ||
+							suppression.Reason != malak.SuppressionReasonBounced {
+							return errors.New("unexpected suppression")
+						}
+
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			payload:            []byte(`[{"email":"investor@example.com","event":"delivered","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000},{"email":"investor@example.com","event":"bounce","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+		{
+			name: "first open is recorded",
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository, cacheMock *malak_mocks.MockCache) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "message_123", malak.UpdateRecipientLogProviderSendgrid).
+					Times(1).
+					Return(&malak.UpdateRecipientLog{Recipient: recipient}, &malak.UpdateRecipientStat{}, nil)
+
+				updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.UpdateStat{}, nil)
+
+				updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, updateStat *malak.UpdateStat, recipientStat *malak.UpdateRecipientStat) error {
+						if updateStat.UniqueOpens != 1 || updateStat.TotalOpens != 1 || recipientStat.LastOpenedAt == nil {
+							return errors.New("open was not recorded")
+						}
+
+						return nil
+					})
+
+				updateRepo.EXPECT().GetByID(gomock.Any(), recipient.UpdateID).
+					Times(1).
+					Return(&malak.Update{
+						ID:          recipient.UpdateID,
+						WorkspaceID: uuid.New(),
+						Reference:   "update_test",
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			payload:            []byte(`[{"email":"investor@example.com","event":"open","sg_message_id":"message_123.filterdrecv-0","timestamp":1700000000}]`),
+			signed:             true,
+		},
+	}
+}
+
+func TestWebhookHandler_HandleSendgrid(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	verifier, err := sendgrid.NewWebhookVerifier(base64.StdEncoding.EncodeToString(der))
+	require.NoError(t, err)
+
+	for _, v := range generateSendgridWebhookTestTable() {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			contactRepo := malak_mocks.NewMockContactRepository(controller)
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+			cacheMock := malak_mocks.NewMockCache(controller)
+
+			contactRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(&malak.Contact{Email: malak.Email("investor@example.com")}, nil)
+
+			v.mockFn(updateRepo, suppressionRepo, cacheMock)
+
+			h := &webhookHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				contactRepo:        contactRepo,
+				suppressionRepo:    suppressionRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				sendgridVerifier:   verifier,
+				queue:              newMockWebhookDispatchQueue(controller),
+				cache:              cacheMock,
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/hooks/sendgrid", bytes.NewBuffer(v.payload))
+
+			if v.signed {
+				timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+				req.Header.Set(sendgrid.TimestampHeader, timestamp)
+				req.Header.Set(sendgrid.SignatureHeader, generateSendgridSignature(t, key, timestamp, v.payload))
+			}
+
+			h.handleSendgrid(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func signSNSMessage(t *testing.T, key *rsa.PrivateKey, msg *ses.Message) {
+	var s string
+
+	if msg.Type == ses.MessageTypeNotification {
+		s = fmt.Sprintf("Message\n%s\nMessageId\n%s\nTimestamp\n%s\nTopicArn\n%s\nType\n%s\n",
+			msg.Message, msg.MessageID, msg.Timestamp, msg.TopicArn, msg.Type)
+	} else {
+		s = fmt.Sprintf("Message\n%s\nMessageId\n%s\nSubscribeURL\n%s\nTimestamp\n%s\nToken\n%s\nTopicArn\n%s\nType\n%s\n",
+			msg.Message, msg.MessageID, msg.SubscribeURL, msg.Timestamp, msg.Token, msg.TopicArn, msg.Type)
+	}
+
+	hash := sha256.Sum256([]byte(s))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	msg.SignatureVersion = "2"
+	msg.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+func newSESNotification(event string) *ses.Message {
+	return &ses.Message{
+		Type:           ses.MessageTypeNotification,
+		MessageID:      "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:       "arn:aws:sns:us-east-1:123456789012:ses-events",
+		Message:        event,
+		Timestamp:      "2025-11-10T09:00:00.000Z",
+		SigningCertURL: "https://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem",
+	}
+}
+
+func expectSuppression(updateRepo *malak_mocks.MockUpdateRepository,
+	suppressionRepo *malak_mocks.MockSuppressionRepository,
+	recipient *malak.UpdateRecipient, reason malak.SuppressionReason,
+	provider malak.UpdateRecipientLogProvider) {
+
+	updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), provider).
+		Times(1).
+		Return(&malak.UpdateRecipientLog{Recipient: recipient}, &malak.UpdateRecipientStat{}, nil)
+
+	updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&malak.UpdateStat{}, nil)
+
+	updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	updateRepo.EXPECT().GetByID(gomock.Any(), recipient.UpdateID).
+		Times(1).
+		Return(&malak.Update{ID: recipient.UpdateID, WorkspaceID: uuid.New()}, nil)
+
+	suppressionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, suppression *malak.Suppression) error {
+			if suppression.Reason != reason || suppression.Provider != provider {
+				return errors.New("unexpected suppression")
+			}
+
+			return nil
+		})
+}
+
+func expectStatWithoutSuppression(updateRepo *malak_mocks.MockUpdateRepository,
+	recipient *malak.UpdateRecipient, provider malak.UpdateRecipientLogProvider) {
+
+	updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), provider).
+		Times(1).
+		Return(&malak.UpdateRecipientLog{Recipient: recipient}, &malak.UpdateRecipientStat{}, nil)
+
+	updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&malak.UpdateStat{}, nil)
+
+	updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+}
+
+func TestWebhookHandler_HandleSES(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	snsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer snsServer.Close()
+
+	verifier := ses.NewWebhookVerifier(snsServer.Client(),
+		func(_ context.Context, _ string) (*x509.Certificate, error) {
+			return cert, nil
+		}, []string{"arn:aws:sns:us-east-1:123456789012:ses-events"})
+
+	recipient := &malak.UpdateRecipient{
+		ID:        uuid.MustParse("8ce0f580-4d6b-4b02-9d3e-1c8f7b0d0a3e"),
+		UpdateID:  uuid.MustParse("0a3f4b1e-7f2d-4b8e-9a6c-2d1e3f4a5b6c"),
+		ContactID: uuid.MustParse("5b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"),
+	}
+
+	tt := []struct {
+		name               string
+		msg                *ses.Message
+		tamper             bool
+		mockFn             func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:   "invalid signature",
+			msg:    newSESNotification(`{"eventType":"Delivery","mail":{"messageId":"ses_message"}}`),
+			tamper: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "subscription confirmation",
+			msg: &ses.Message{
+				Type:           ses.MessageTypeSubscriptionConfirmation,
+				MessageID:      "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+				Token:          "token",
+				TopicArn:       "arn:aws:sns:us-east-1:123456789012:ses-events",
+				Message:        "You have chosen to subscribe to the topic",
+				SubscribeURL:   snsServer.URL + "/?Action=ConfirmSubscription",
+				Timestamp:      "2025-11-10T09:00:00.000Z",
+				SigningCertURL: "https://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem",
+			},
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "subscription confirmation for another topic",
+			msg: &ses.Message{
+				Type:           ses.MessageTypeSubscriptionConfirmation,
+				MessageID:      "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+				Token:          "token",
+				TopicArn:       "arn:aws:sns:us-east-1:999999999999:someone-elses-topic",
+				Message:        "You have chosen to subscribe to the topic",
+				SubscribeURL:   snsServer.URL + "/?Action=ConfirmSubscription",
+				Timestamp:      "2025-11-10T09:00:00.000Z",
+				SigningCertURL: "https://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem",
+			},
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "permanent bounce suppresses contact",
+			msg:  newSESNotification(`{"eventType":"Bounce","mail":{"messageId":"ses_message"},"bounce":{"bounceType":"Permanent"}}`),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectSuppression(updateRepo, suppressionRepo, recipient,
+					malak.SuppressionReasonBounced, malak.UpdateRecipientLogProviderSes)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "transient bounce does not suppress contact",
+			msg:  newSESNotification(`{"notificationType":"Bounce","mail":{"messageId":"ses_message"},"bounce":{"bounceType":"Transient"}}`),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectStatWithoutSuppression(updateRepo, recipient, malak.UpdateRecipientLogProviderSes)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "complaint suppresses contact",
+			msg:  newSESNotification(`{"eventType":"Complaint","mail":{"messageId":"ses_message"}}`),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectSuppression(updateRepo, suppressionRepo, recipient,
+					malak.SuppressionReasonComplained, malak.UpdateRecipientLogProviderSes)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "could not suppress contact",
+			msg:  newSESNotification(`{"eventType":"Complaint","mail":{"messageId":"ses_message"}}`),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectStatWithoutSuppression(updateRepo, recipient, malak.UpdateRecipientLogProviderSes)
+
+				updateRepo.EXPECT().GetByID(gomock.Any(), recipient.UpdateID).
+					Times(1).
+					Return(&malak.Update{ID: recipient.UpdateID, WorkspaceID: uuid.New()}, nil)
+
+				suppressionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("could not create suppression"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+
+			v.mockFn(updateRepo, suppressionRepo)
+
+			h := &webhookHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				suppressionRepo:    suppressionRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				sesVerifier:        verifier,
+				queue:              newMockWebhookDispatchQueue(controller),
+			}
+
+			signSNSMessage(t, key, v.msg)
+
+			if v.tamper {
+				v.msg.Message = `{"eventType":"Bounce"}`
+			}
+
+			b, err := json.Marshal(v.msg)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/hooks/ses", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+
+			h.handleSES(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookHandler_HandlePostmark(t *testing.T) {
+	authenticator, err := postmark.NewWebhookAuthenticator("postmark", "secret")
+	require.NoError(t, err)
+
+	recipient := &malak.UpdateRecipient{
+		ID:        uuid.MustParse("8ce0f580-4d6b-4b02-9d3e-1c8f7b0d0a3e"),
+		UpdateID:  uuid.MustParse("0a3f4b1e-7f2d-4b8e-9a6c-2d1e3f4a5b6c"),
+		ContactID: uuid.MustParse("5b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"),
+	}
+
+	tt := []struct {
+		name               string
+		payload            string
+		authenticated      bool
+		mockFn             func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:    "missing credentials",
+			payload: `{"RecordType":"Delivery","MessageID":"postmark_message"}`,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "unsupported record type",
+			payload:       `{"RecordType":"LinkClick","MessageID":"postmark_message"}`,
+			authenticated: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:          "delivery is recorded",
+			payload:       `{"RecordType":"Delivery","MessageID":"postmark_message","DeliveredAt":"2025-11-10T09:00:00Z"}`,
+			authenticated: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectStatWithoutSuppression(updateRepo, recipient, malak.UpdateRecipientLogProviderPostmark)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:          "soft bounce does not suppress contact",
+			payload:       `{"RecordType":"Bounce","Type":"SoftBounce","MessageID":"postmark_message","BouncedAt":"2025-11-10T09:00:00Z"}`,
+			authenticated: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectStatWithoutSuppression(updateRepo, recipient, malak.UpdateRecipientLogProviderPostmark)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:          "hard bounce suppresses contact",
+			payload:       `{"RecordType":"Bounce","Type":"HardBounce","MessageID":"postmark_message","BouncedAt":"2025-11-10T09:00:00Z"}`,
+			authenticated: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectSuppression(updateRepo, suppressionRepo, recipient,
+					malak.SuppressionReasonBounced, malak.UpdateRecipientLogProviderPostmark)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:          "spam complaint suppresses contact",
+			payload:       `{"RecordType":"SpamComplaint","MessageID":"postmark_message","BouncedAt":"2025-11-10T09:00:00Z"}`,
+			authenticated: true,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository, suppressionRepo *malak_mocks.MockSuppressionRepository) {
+				expectSuppression(updateRepo, suppressionRepo, recipient,
+					malak.SuppressionReasonComplained, malak.UpdateRecipientLogProviderPostmark)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+			suppressionRepo := malak_mocks.NewMockSuppressionRepository(controller)
+
+			v.mockFn(updateRepo, suppressionRepo)
+
+			h := &webhookHandler{
+				cfg:                   getConfig(),
+				updateRepo:            updateRepo,
+				suppressionRepo:       suppressionRepo,
+				referenceGenerator:    &mockReferenceGenerator{},
+				postmarkAuthenticator: authenticator,
+				queue:                 newMockWebhookDispatchQueue(controller),
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/hooks/postmark", bytes.NewBufferString(v.payload))
+
+			if v.authenticated {
+				req.SetBasicAuth("postmark", "secret")
+			}
+
+			h.handlePostmark(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookHandler_HandleResend(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("resend-webhook-secret"))
+
+	svixClient, err := svix.NewWebhook(secret)
+	require.NoError(t, err)
+
+	recipientID := uuid.MustParse("7d0a2d0a-5a4b-4fb4-9b1b-2a0a57f7c0a1")
+	updateID := uuid.MustParse("ed3daf26-5839-4b91-bf19-64c8a6b3bb7a")
+
+	newClick := func(link string) []byte {
+		return []byte(fmt.Sprintf(`{"type":"email.clicked","created_at":"2025-11-12T10:00:00Z","data":{"email_id":"email_123","click":{"link":%q,"timestamp":"2025-11-12T10:00:00Z"}}}`, link))
+	}
+
+	tt := []struct {
+		name               string
+		payload            []byte
+		mockFn             func(updateRepo *malak_mocks.MockUpdateRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:               "click on tracked link is skipped",
+			payload:            newClick("https://api.malak.vc/updates/click?recipient=recipient_123"),
+			mockFn:             func(updateRepo *malak_mocks.MockUpdateRepository) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "click on email that is not an update",
+			payload: newClick("https://malak.vc"),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), "email_123", malak.UpdateRecipientLogProviderResend).
+					Return(nil, nil, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "could not record click",
+			payload: newClick("https://malak.vc"),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipientLog{
+						RecipientID: recipientID,
+						Recipient:   &malak.UpdateRecipient{UpdateID: updateID},
+					}, &malak.UpdateRecipientStat{}, nil)
+
+				updateRepo.EXPECT().RecordClick(gomock.Any(), gomock.Any()).
+					Return(errors.New("oops"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "click is recorded",
+			payload: newClick("https://malak.vc"),
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				updateRepo.EXPECT().GetStatByEmailID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipientLog{
+						RecipientID: recipientID,
+						Recipient:   &malak.UpdateRecipient{UpdateID: updateID},
+					}, &malak.UpdateRecipientStat{}, nil)
+
+				updateRepo.EXPECT().RecordClick(gomock.Any(), &malak.UpdateLinkClick{
+					UpdateID:    updateID,
+					RecipientID: recipientID,
+					URL:         "https://malak.vc",
+				}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			cfg := getConfig()
+			cfg.HTTP.PublicURL = "https://api.malak.vc/"
+
+			h := &webhookHandler{
+				cfg:                cfg,
+				updateRepo:         updateRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				svixClient:         svixClient,
+			}
+
+			now := time.Now()
+
+			signature, err := svixClient.Sign("msg_123", now, v.payload)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/hooks/resend", bytes.NewBuffer(v.payload))
+			req.Header.Set("svix-id", "msg_123")
+			req.Header.Set("svix-timestamp", fmt.Sprintf("%d", now.Unix()))
+			req.Header.Set("svix-signature", signature)
+
+			h.handleResend(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, v.expectedStatusCode, rr.Code)
+			verifyMatch(t, rr)
+		})
+	}
+}
+
+func TestWebhookHandler_HandleOpenPixel(t *testing.T) {
+	recipient := &malak.UpdateRecipient{
+		ID:       uuid.MustParse("7d0a2d0a-5a4b-4fb4-9b1b-2a0a57f7c0a1"),
+		UpdateID: uuid.MustParse("ed3daf26-5839-4b91-bf19-64c8a6b3bb7a"),
+	}
+
+	validSignature := malak.SignOpen(getConfig().Auth.JWT.Key, "recipient_test")
+
+	tt := []struct {
+		name      string
+		signature string
+		mockFn    func(updateRepo *malak_mocks.MockUpdateRepository)
+	}{
+		{
+			name:      "invalid signature is not recorded",
+			signature: "oops",
+			mockFn:    func(updateRepo *malak_mocks.MockUpdateRepository) {},
+		},
+		{
+			name:      "recipient not found",
+			signature: validSignature,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				updateRepo.EXPECT().GetRecipient(gomock.Any(), malak.Reference("recipient_test")).
+					Return(nil, malak.ErrUpdateRecipientNotFound)
+			},
+		},
+		{
+			name:      "recipient without stat",
+			signature: validSignature,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				updateRepo.EXPECT().GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateRecipient{}, nil)
+			},
+		},
+		{
+			name:      "open is recorded",
+			signature: validSignature,
+			mockFn: func(updateRepo *malak_mocks.MockUpdateRepository) {
+				r := *recipient
+				r.UpdateRecipientStat = &malak.UpdateRecipientStat{
+					LastOpenedAt: hermes.Ref(time.Now().Add(-time.Hour)),
+				}
+
+				updateRepo.EXPECT().GetRecipient(gomock.Any(), gomock.Any()).
+					Return(&r, nil)
+
+				updateRepo.EXPECT().Stat(gomock.Any(), gomock.Any()).
+					Return(&malak.UpdateStat{TotalOpens: 1, UniqueOpens: 1}, nil)
+
+				updateRepo.EXPECT().UpdateStat(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, updateStat *malak.UpdateStat, recipientStat *malak.UpdateRecipientStat) error {
+						// second open by the same recipient
+						if updateStat.UniqueOpens != 1 || updateStat.TotalOpens != 2 {
+							return errors.New("open was not recorded")
+						}
+
+						return nil
+					})
+			},
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			updateRepo := malak_mocks.NewMockUpdateRepository(controller)
+
+			v.mockFn(updateRepo)
+
+			h := &webhookHandler{
+				cfg:                getConfig(),
+				updateRepo:         updateRepo,
+				referenceGenerator: &mockReferenceGenerator{},
+				queue:              newMockWebhookDispatchQueue(controller),
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet,
+				"/updates/open?recipient=recipient_test&signature="+v.signature, nil)
+
+			h.handleOpenPixel(getLogger(t)).ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Equal(t, "image/gif", rr.Header().Get("Content-Type"))
+
+			_, err := gif.Decode(rr.Body)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,8 +3,10 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/ayinke-llc/malak/internal/secret"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -37,6 +40,10 @@ type workspaceHandler struct {
 	secretsClient           secret.SecretClient
 	referenceGenerator      malak.ReferenceGeneratorOperation
 	fundingRepo             malak.FundraisingPipelineRepository
+	sendingDomainRepo       malak.SendingDomainRepository
+	auditLogRepo            malak.AuditLogRepository
+	dnsResolver             malak.TXTResolver
+	dkimKeyGenerator        func() ([]byte, string, error)
 
 	shareRepo   malak.ContactShareRepository
 	deckRepo    malak.DeckRepository
@@ -209,6 +216,10 @@ type updateWorkspaceRequest struct {
 	Website       *string `json:"website,omitempty"`
 	Logo          *string `json:"logo,omitempty"`
 
+	// RequireTwoFactor forces every member to set up 2FA before they can
+	// access the workspace
+	RequireTwoFactor *bool `json:"require_two_factor,omitempty"`
+
 	GenericRequest
 }
 
@@ -313,6 +324,17 @@ func (wo *workspaceHandler) updateWorkspace(
 		workspace.LogoURL = logo
 	}
 
+	if req.RequireTwoFactor != nil {
+
+		// make sure whoever turns it on does not lock themselves out
+		if *req.RequireTwoFactor && !getUserFromContext(ctx).IsTwoFactorEnabled() {
+			return newAPIStatus(http.StatusBadRequest,
+				"you must enable two factor authentication on your account before requiring it for your workspace"), StatusFailed
+		}
+
+		workspace.Metadata.RequireTwoFactor = *req.RequireTwoFactor
+	}
+
 	if err := wo.workspaceRepo.Update(ctx, workspace); err != nil {
 		logger.Error("could not update workspace",
 			zap.Error(err))
@@ -366,11 +388,41 @@ type updatePreferencesRequest struct {
 	Preferences struct {
 		Billing    malak.BillingPreferences       `json:"billing,omitempty" validate:"required"`
 		Newsletter malak.CommunicationPreferences `json:"newsletter,omitempty" validate:"required"`
+		Approval   *malak.ApprovalPreferences     `json:"approval,omitempty" validate:"optional"`
 	} `json:"preferences,omitempty" validate:"required"`
 	GenericRequest
 }
 
-func (u *updatePreferencesRequest) Validate() error { return nil }
+const maxUpdateApprovers = 10
+
+func (u *updatePreferencesRequest) Validate() error {
+	if u.Preferences.Approval == nil {
+		return nil
+	}
+
+	approvers := make([]uuid.UUID, 0, len(u.Preferences.Approval.Approvers))
+	for _, approver := range u.Preferences.Approval.Approvers {
+		if approver == uuid.Nil {
+			return errors.New("invalid approver")
+		}
+
+		if !slices.Contains(approvers, approver) {
+			approvers = append(approvers, approver)
+		}
+	}
+
+	u.Preferences.Approval.Approvers = approvers
+
+	if u.Preferences.Approval.RequireApproval && len(approvers) == 0 {
+		return errors.New("please provide at least one approver")
+	}
+
+	if len(approvers) > maxUpdateApprovers {
+		return fmt.Errorf("you can only have a maximum of %d approvers", maxUpdateApprovers)
+	}
+
+	return nil
+}
 
 func (u *updatePreferencesRequest) Make(current *malak.Preference) *malak.Preference {
 
@@ -382,6 +434,10 @@ func (u *updatePreferencesRequest) Make(current *malak.Preference) *malak.Prefer
 		current.Communication.EnableProductUpdates = u.Preferences.Newsletter.EnableProductUpdates
 	}
 
+	if u.Preferences.Approval != nil {
+		current.Approval = *u.Preferences.Approval
+	}
+
 	return current
 }
 
@@ -423,6 +479,26 @@ func (wo *workspaceHandler) updatePreferences(
 		return newAPIStatus(http.StatusInternalServerError, "could not fetch preferences"), StatusFailed
 	}
 
+	if req.Preferences.Approval != nil {
+		for _, approver := range req.Preferences.Approval.Approvers {
+			user, err := wo.userRepo.Get(ctx, &malak.FindUserOptions{
+				ID: approver,
+			})
+			if err != nil {
+				if errors.Is(err, malak.ErrUserNotFound) {
+					return newAPIStatus(http.StatusBadRequest, "approver does not exists"), StatusFailed
+				}
+
+				logger.Error("could not fetch approver", zap.Error(err))
+				return newAPIStatus(http.StatusInternalServerError, "could not fetch approver"), StatusFailed
+			}
+
+			if !user.CanAccessWorkspace(workspace.ID) {
+				return newAPIStatus(http.StatusBadRequest, "approver is not a member of this workspace"), StatusFailed
+			}
+		}
+	}
+
 	pref := req.Make(preferences)
 
 	if err := wo.preferenceRepo.Update(ctx, pref); err != nil {
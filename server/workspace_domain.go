@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/ayinke-llc/malak"
+	"github.com/ayinke-llc/malak/internal/pkg/email/dkim"
+	"github.com/ayinke-llc/malak/internal/secret"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type createSendingDomainRequest struct {
+	// Sender is the address updates are sent from. The sending domain is
+	// the domain of this address
+	Sender malak.Email `json:"sender,omitempty" validate:"required"`
+
+	domain string
+
+	GenericRequest
+}
+
+func (c *createSendingDomainRequest) Validate() error {
+	c.Sender = malak.Email(strings.TrimSpace(c.Sender.String()))
+
+	if hermes.IsStringEmpty(c.Sender.String()) {
+		return errors.New("please provide the sender email")
+	}
+
+	domain, err := malak.DomainFromEmail(c.Sender)
+	if err != nil {
+		return err
+	}
+
+	c.domain = domain
+	return nil
+}
+
+// @Description register the domain updates are sent from. A DKIM key is generated for the domain and the DNS records to add are returned
+// @Tags workspace
+// @Accept  json
+// @Produce  json
+// @Param message body createSendingDomainRequest true "sending domain request body"
+// @Success 200 {object} fetchSendingDomainResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/domain [post]
+func (wo *workspaceHandler) createSendingDomain(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("creating sending domain")
+
+	workspace := getWorkspaceFromContext(ctx)
+
+	if !workspace.Plan.Metadata.Updates.CustomDomain {
+		return newAPIStatus(http.StatusBadRequest,
+			"you need to be on a plan that supports sending updates from your own domain"), StatusFailed
+	}
+
+	req := new(createSendingDomainRequest)
+
+	if err := render.Bind(r, req); err != nil {
+		return newAPIStatus(http.StatusBadRequest, "invalid request body"), StatusFailed
+	}
+
+	if err := req.Validate(); err != nil {
+		return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+	}
+
+	span.SetAttributes(attribute.String("domain", req.domain))
+
+	_, err := wo.sendingDomainRepo.Get(ctx, workspace.ID)
+	if err == nil {
+		return newAPIStatus(http.StatusBadRequest,
+			malak.ErrSendingDomainExists.Error()), StatusFailed
+	}
+
+	if !errors.Is(err, malak.ErrSendingDomainNotFound) {
+		logger.Error("could not fetch sending domain", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not fetch sending domain"), StatusFailed
+	}
+
+	privateKey, publicKey, err := wo.dkimKeyGenerator()
+	if err != nil {
+		logger.Error("could not generate dkim key", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not generate dkim key"), StatusFailed
+	}
+
+	reference := wo.referenceGenerator.Generate(malak.EntityTypeSendingDomain)
+
+	// every registration gets its own secret. Some providers refuse to
+	// overwrite an existing one so a domain could never be registered again
+	key, err := wo.secretsClient.Create(ctx, &secret.CreateSecretOptions{
+		Value:       string(privateKey),
+		WorkspaceID: workspace.ID,
+		Name:        fmt.Sprintf("dkim/%s", reference),
+	})
+	if err != nil {
+		logger.Error("could not store dkim key in secrets provider", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not store dkim key"), StatusFailed
+	}
+
+	domain := &malak.SendingDomain{
+		Reference:   reference,
+		WorkspaceID: workspace.ID,
+		Domain:      req.domain,
+		Sender:      req.Sender,
+		Selector:    dkim.Selector,
+		PublicKey:   publicKey,
+		PrivateKey:  key,
+		Status:      malak.SendingDomainStatusPending,
+	}
+
+	if err := wo.sendingDomainRepo.Create(ctx, domain); err != nil {
+		if errors.Is(err, malak.ErrSendingDomainExists) {
+			return newAPIStatus(http.StatusBadRequest, err.Error()), StatusFailed
+		}
+
+		logger.Error("could not create sending domain", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not create sending domain"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionSendingDomainCreated,
+		malak.EntityTypeSendingDomain, domain.Reference.String())
+	entry.Metadata["domain"] = domain.Domain
+
+	recordAuditLog(ctx, logger, wo.auditLogRepo, entry)
+
+	return fetchSendingDomainResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "sending domain created. Add the dns records to verify it"),
+		Domain:    hermes.DeRef(domain),
+		Records:   domain.DNSRecords(),
+	}, StatusSuccess
+}
+
+// @Description fetch the sending domain of the workspace and the DNS records it needs
+// @Tags workspace
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} fetchSendingDomainResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/domain [get]
+func (wo *workspaceHandler) fetchSendingDomain(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("fetching sending domain")
+
+	domain, resp, status := wo.getSendingDomain(ctx, logger)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	return fetchSendingDomainResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "sending domain fetched"),
+		Domain:    hermes.DeRef(domain),
+		Records:   domain.DNSRecords(),
+	}, StatusSuccess
+}
+
+// @Description check the DNS records of the sending domain. Updates are sent from the domain once it is verified
+// @Tags workspace
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} fetchSendingDomainResponse
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/domain/verify [post]
+func (wo *workspaceHandler) verifySendingDomain(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("verifying sending domain")
+
+	domain, resp, status := wo.getSendingDomain(ctx, logger)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	span.SetAttributes(attribute.String("domain", domain.Domain))
+
+	now := time.Now()
+	domain.LastCheckedAt = &now
+
+	verifyErr := domain.Verify(ctx, wo.dnsResolver)
+
+	wasVerified := domain.IsVerified()
+
+	if verifyErr != nil {
+		domain.Status = malak.SendingDomainStatusFailed
+		domain.VerifiedAt = nil
+	} else {
+		domain.Status = malak.SendingDomainStatusVerified
+		if !wasVerified {
+			domain.VerifiedAt = &now
+		}
+	}
+
+	if err := wo.sendingDomainRepo.Update(ctx, domain); err != nil {
+		logger.Error("could not update sending domain", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not update sending domain"), StatusFailed
+	}
+
+	if verifyErr != nil {
+		return newAPIStatus(http.StatusBadRequest, verifyErr.Error()), StatusFailed
+	}
+
+	if !wasVerified {
+		entry := newAuditLog(r, malak.AuditLogActionSendingDomainVerified,
+			malak.EntityTypeSendingDomain, domain.Reference.String())
+		entry.Metadata["domain"] = domain.Domain
+
+		recordAuditLog(ctx, logger, wo.auditLogRepo, entry)
+	}
+
+	return fetchSendingDomainResponse{
+		APIStatus: newAPIStatus(http.StatusOK, "sending domain verified"),
+		Domain:    hermes.DeRef(domain),
+		Records:   domain.DNSRecords(),
+	}, StatusSuccess
+}
+
+// @Description remove the sending domain. Updates are sent from the default sender afterwards
+// @Tags workspace
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} APIStatus
+// @Failure 400 {object} APIStatus
+// @Failure 401 {object} APIStatus
+// @Failure 404 {object} APIStatus
+// @Failure 500 {object} APIStatus
+// @Router /workspaces/domain [delete]
+func (wo *workspaceHandler) deleteSendingDomain(
+	ctx context.Context,
+	span trace.Span,
+	logger *zap.Logger,
+	w http.ResponseWriter,
+	r *http.Request) (render.Renderer, Status) {
+
+	logger.Debug("deleting sending domain")
+
+	domain, resp, status := wo.getSendingDomain(ctx, logger)
+	if status == StatusFailed {
+		return resp, status
+	}
+
+	if err := wo.sendingDomainRepo.Delete(ctx, domain); err != nil {
+		logger.Error("could not delete sending domain", zap.Error(err))
+		return newAPIStatus(http.StatusInternalServerError,
+			"could not delete sending domain"), StatusFailed
+	}
+
+	entry := newAuditLog(r, malak.AuditLogActionSendingDomainDeleted,
+		malak.EntityTypeSendingDomain, domain.Reference.String())
+	entry.Metadata["domain"] = domain.Domain
+
+	recordAuditLog(ctx, logger, wo.auditLogRepo, entry)
+
+	return newAPIStatus(http.StatusOK, "sending domain deleted"), StatusSuccess
+}
+
+func (wo *workspaceHandler) getSendingDomain(ctx context.Context,
+	logger *zap.Logger) (*malak.SendingDomain, render.Renderer, Status) {
+
+	domain, err := wo.sendingDomainRepo.Get(ctx, getWorkspaceFromContext(ctx).ID)
+	if err != nil {
+		if errors.Is(err, malak.ErrSendingDomainNotFound) {
+			return nil, newAPIStatus(http.StatusNotFound, err.Error()), StatusFailed
+		}
+
+		logger.Error("could not fetch sending domain", zap.Error(err))
+		return nil, newAPIStatus(http.StatusInternalServerError,
+			"could not fetch sending domain"), StatusFailed
+	}
+
+	return domain, nil, StatusSuccess
+}
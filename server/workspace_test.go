@@ -290,13 +290,15 @@ func TestWorkspaceHandler_UpdatePreferences(t *testing.T) {
 			defer controller.Finish()
 
 			prefRepo := malak_mocks.NewMockPreferenceRepository(controller)
+			userRepo := malak_mocks.NewMockUserRepository(controller)
 			queueRepo := malak_mocks.NewMockQueueHandler(controller)
 
-			v.mockFn(prefRepo)
+			v.mockFn(prefRepo, userRepo)
 
 			a := &workspaceHandler{
 				cfg:            getConfig(),
 				preferenceRepo: prefRepo,
+				userRepo:       userRepo,
 				queueClient:    queueRepo,
 				referenceGenerationFunc: func(e malak.EntityType) string {
 					return "workspace_tt7-YieIgz"
@@ -313,7 +315,7 @@ func TestWorkspaceHandler_UpdatePreferences(t *testing.T) {
 			req.Header.Add("Content-Type", "application/json")
 
 			req = req.WithContext(writeUserToCtx(req.Context(), &malak.User{}))
-			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{}))
+			req = req.WithContext(writeWorkspaceToCtx(req.Context(), &malak.Workspace{ID: testLinkWorkspaceID}))
 
 			WrapMalakHTTPHandler(getLogger(t),
 				a.updatePreferences, getConfig(), "workspaces.update").
@@ -541,20 +543,20 @@ func generateWorkspaceGetIntegrationsTestTable() []struct {
 
 func generateWorkspacePreferencesUpdateTestTable() []struct {
 	name               string
-	mockFn             func(preferencesRepo *malak_mocks.MockPreferenceRepository)
+	mockFn             func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository)
 	expectedStatusCode int
 	req                updatePreferencesRequest
 } {
 
 	return []struct {
 		name               string
-		mockFn             func(preferencesRepo *malak_mocks.MockPreferenceRepository)
+		mockFn             func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository)
 		expectedStatusCode int
 		req                updatePreferencesRequest
 	}{
 		{
 			name: "could not fetch workspace preferences",
-			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository) {
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
 				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("could not fetch preferences"))
@@ -563,7 +565,7 @@ func generateWorkspacePreferencesUpdateTestTable() []struct {
 		},
 		{
 			name: "update fails",
-			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository) {
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
 				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&malak.Preference{}, nil)
@@ -576,7 +578,7 @@ func generateWorkspacePreferencesUpdateTestTable() []struct {
 		},
 		{
 			name: "update succeeds",
-			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository) {
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
 				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&malak.Preference{}, nil)
@@ -589,7 +591,7 @@ func generateWorkspacePreferencesUpdateTestTable() []struct {
 		},
 		{
 			name: "update succeeds with request data",
-			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository) {
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
 				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&malak.Preference{}, nil)
@@ -603,6 +605,7 @@ func generateWorkspacePreferencesUpdateTestTable() []struct {
 				Preferences: struct {
 					Billing    malak.BillingPreferences       "json:\"billing,omitempty\" validate:\"required\""
 					Newsletter malak.CommunicationPreferences "json:\"newsletter,omitempty\" validate:\"required\""
+					Approval   *malak.ApprovalPreferences     "json:\"approval,omitempty\" validate:\"optional\""
 				}{
 					Newsletter: malak.CommunicationPreferences{
 						EnableMarketing:      true,
@@ -611,9 +614,84 @@ func generateWorkspacePreferencesUpdateTestTable() []struct {
 				},
 			},
 		},
+		{
+			name: "approval required without approvers",
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: approvalPreferencesRequest(&malak.ApprovalPreferences{
+				RequireApproval: true,
+			}),
+		},
+		{
+			name: "approver not found",
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
+				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Preference{}, nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, malak.ErrUserNotFound)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: approvalPreferencesRequest(&malak.ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{testApproverID},
+			}),
+		},
+		{
+			name: "approver is not a member of the workspace",
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
+				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Preference{}, nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.User{ID: testApproverID}, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: approvalPreferencesRequest(&malak.ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{testApproverID},
+			}),
+		},
+		{
+			name: "approval policy updated",
+			mockFn: func(preferencesRepo *malak_mocks.MockPreferenceRepository, userRepo *malak_mocks.MockUserRepository) {
+				preferencesRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.Preference{}, nil)
+
+				userRepo.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&malak.User{
+						ID: testApproverID,
+						Roles: malak.UserRoles{
+							{WorkspaceID: testLinkWorkspaceID, Role: malak.RoleAdmin},
+						},
+					}, nil)
+
+				preferencesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: approvalPreferencesRequest(&malak.ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{testApproverID, testApproverID},
+			}),
+		},
 	}
 }
 
+func approvalPreferencesRequest(approval *malak.ApprovalPreferences) updatePreferencesRequest {
+	var req updatePreferencesRequest
+	req.Preferences.Approval = approval
+	return req
+}
+
 func generateWorkspacePreferencesTestTable() []struct {
 	name               string
 	mockFn             func(preferencesRepo *malak_mocks.MockPreferenceRepository)
@@ -735,6 +813,31 @@ func generateWorkspaceUpdateTestTable() []struct {
 			expectedStatusCode: http.StatusOK,
 			req:                updateWorkspaceRequest{},
 		},
+		{
+			name: "cannot require two factor without enabling it",
+			mockFn: func(workspaceRepo *malak_mocks.MockWorkspaceRepository, planRepo *malak_mocks.MockPlanRepository) {
+				workspaceRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			req: updateWorkspaceRequest{
+				RequireTwoFactor: hermes.Ref(true),
+			},
+		},
+		{
+			name: "two factor requirement can be turned off",
+			mockFn: func(workspaceRepo *malak_mocks.MockWorkspaceRepository, planRepo *malak_mocks.MockPlanRepository) {
+				workspaceRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			req: updateWorkspaceRequest{
+				RequireTwoFactor: hermes.Ref(false),
+			},
+		},
 	}
 }
 
@@ -0,0 +1,70 @@
+package malak
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrSuppressionNotFound = MalakError("suppression not found")
+)
+
+// ENUM(bounced,complained,unsubscribed)
+type SuppressionReason string
+
+// Suppression stops a contact from receiving any further updates from the
+// workspace
+type Suppression struct {
+	ID          uuid.UUID                  `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	WorkspaceID uuid.UUID                  `json:"workspace_id,omitempty"`
+	ContactID   uuid.UUID                  `json:"contact_id,omitempty"`
+	Reference   Reference                  `json:"reference,omitempty"`
+	Reason      SuppressionReason          `json:"reason,omitempty"`
+	Provider    UpdateRecipientLogProvider `bun:",nullzero" json:"provider,omitempty"`
+
+	Contact *Contact `bun:"rel:belongs-to,join:contact_id=id" json:"contact,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	bun.BaseModel `bun:"table:email_suppressions,alias:suppression" json:"-"`
+}
+
+// SignUnsubscribe generates the signature that goes into the unsubscribe
+// links of an update email so recipients cannot unsubscribe other people
+func SignUnsubscribe(secret string, recipient Reference) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("unsubscribe:"))
+	mac.Write([]byte(recipient))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyUnsubscribe(secret string, recipient Reference, signature string) bool {
+	expected := SignUnsubscribe(secret, recipient)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+type ListSuppressionOptions struct {
+	Paginator   Paginator
+	WorkspaceID uuid.UUID
+}
+
+type FetchSuppressionOptions struct {
+	WorkspaceID uuid.UUID
+	Reference   Reference
+}
+
+type SuppressionRepository interface {
+	// Create is a no-op if the contact is already suppressed
+	Create(context.Context, *Suppression) error
+	Get(context.Context, FetchSuppressionOptions) (*Suppression, error)
+	List(context.Context, ListSuppressionOptions) ([]Suppression, int64, error)
+	// Delete lets the contact receive updates again
+	Delete(context.Context, *Suppression) error
+}
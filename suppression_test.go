@@ -0,0 +1,17 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeSignature(t *testing.T) {
+	signature := SignUnsubscribe("secret", "recipient_123")
+
+	require.True(t, VerifyUnsubscribe("secret", "recipient_123", signature))
+
+	require.False(t, VerifyUnsubscribe("other", "recipient_123", signature))
+	require.False(t, VerifyUnsubscribe("secret", "recipient_456", signature))
+	require.False(t, VerifyUnsubscribe("secret", "recipient_123", ""))
+}
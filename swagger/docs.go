@@ -18,9 +18,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/connect/{provider}": {
+        "/auth/2fa/disable": {
             "post": {
-                "description": "Sign in with a social login provider",
+                "description": "Turn off two factor authentication",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,27 +32,20 @@ const docTemplate = `{
                 ],
                 "parameters": [
                     {
-                        "description": "auth exchange data",
+                        "description": "two factor code",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.authenticateUserRequest"
+                            "$ref": "#/definitions/server.twoFactorCodeRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "oauth2 provider",
-                        "name": "provider",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUserResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -82,9 +75,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/2fa/enable": {
             "post": {
-                "description": "Sign up with your email address and password",
+                "description": "Confirm the two factor setup with a code from the authenticator app. Recovery codes are only returned once",
                 "consumes": [
                     "application/json"
                 ],
@@ -96,12 +89,12 @@ const docTemplate = `{
                 ],
                 "parameters": [
                     {
-                        "description": "auth exchange data",
+                        "description": "authenticator code",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.signupRequest"
+                            "$ref": "#/definitions/server.enableTwoFactorRequest"
                         }
                     }
                 ],
@@ -109,7 +102,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUserResponse"
+                            "$ref": "#/definitions/server.twoFactorRecoveryCodesResponse"
                         }
                     },
                     "400": {
@@ -139,9 +132,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts": {
-            "get": {
-                "description": "list your contacts",
+        "/auth/2fa/recovery-codes": {
+            "post": {
+                "description": "Generate a new set of recovery codes. Previous codes stop working",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,27 +142,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page to query data from. Defaults to 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number to items to return. Defaults to 10 items",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "two factor code",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.twoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listContactsResponse"
+                            "$ref": "#/definitions/server.twoFactorRecoveryCodesResponse"
                         }
                     },
                     "400": {
@@ -197,9 +187,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/2fa/setup": {
             "post": {
-                "description": "Creates a new contact",
+                "description": "Start setting up two factor authentication. The provisioning uri should be displayed as a QR code",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,24 +199,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
-                ],
-                "parameters": [
-                    {
-                        "description": "contact request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createContactRequest"
-                        }
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchContactResponse"
+                            "$ref": "#/definitions/server.setupTwoFactorResponse"
                         }
                     },
                     "400": {
@@ -254,9 +235,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/all": {
-            "get": {
-                "description": "List all contacts",
+        "/auth/2fa/verify": {
+            "post": {
+                "description": "Complete sign in by providing a two factor code",
                 "consumes": [
                     "application/json"
                 ],
@@ -264,14 +245,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
+                ],
+                "parameters": [
+                    {
+                        "description": "challenge data",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.verifyTwoFactorRequest"
+                        }
+                    }
                 ],
-                "operationId": "listAllContacts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listContactsResponse"
+                            "$ref": "#/definitions/server.createdUserResponse"
                         }
                     },
                     "400": {
@@ -301,9 +292,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/batch": {
+        "/auth/connect/{provider}": {
             "post": {
-                "description": "batch create a new contact",
+                "description": "Sign in with a social login provider",
                 "consumes": [
                     "application/json"
                 ],
@@ -311,24 +302,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "contact request body",
+                        "description": "auth exchange data",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createContactRequestBatch"
+                            "$ref": "#/definitions/server.authenticateUserRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "oauth2 provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.createdUserResponse"
                         }
                     },
                     "400": {
@@ -358,9 +356,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/lists": {
-            "get": {
-                "description": "List all created contact lists",
+        "/auth/login": {
+            "post": {
+                "description": "Sign in with your email address and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -368,22 +366,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
-                "operationId": "fetchContactLists",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "show emails inside the list",
-                        "name": "include_emails",
-                        "in": "query"
+                        "description": "login data",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.loginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchContactListsResponse"
+                            "$ref": "#/definitions/server.createdUserResponse"
                         }
                     },
                     "400": {
@@ -411,9 +411,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/logout": {
             "post": {
-                "description": "Create a new contact list",
+                "description": "Sign out of the current session",
                 "consumes": [
                     "application/json"
                 ],
@@ -421,25 +423,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
-                ],
-                "operationId": "createContactList",
-                "parameters": [
-                    {
-                        "description": "contact list body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createContactListRequest"
-                        }
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchContactListResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -469,9 +459,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/lists/{reference}": {
-            "put": {
-                "description": "Edit a contact list",
+        "/auth/logout/all": {
+            "post": {
+                "description": "Sign out of every session across all devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -479,32 +469,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
-                ],
-                "operationId": "editContactList",
-                "parameters": [
-                    {
-                        "description": "contact list body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createContactListRequest"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "list unique reference.. e.g list_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchContactListResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -532,9 +503,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password/forgot": {
             "post": {
-                "description": "add a new contact to a list",
+                "description": "Request a password reset link",
                 "consumes": [
                     "application/json"
                 ],
@@ -542,25 +515,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
-                "operationId": "addEmailToContactList",
                 "parameters": [
                     {
-                        "description": "contact body",
+                        "description": "account email",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.addContactToListRequest"
+                            "$ref": "#/definitions/server.forgotPasswordRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "list unique reference.. e.g list_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -595,9 +560,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete a contact list",
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Set a new password using a password reset token",
                 "consumes": [
                     "application/json"
                 ],
@@ -605,16 +572,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
-                "operationId": "deleteContactList",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "list unique reference.. e.g list_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "reset data",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.resetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -651,9 +619,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/search": {
+        "/auth/providers": {
             "get": {
-                "description": "Search contacts",
+                "description": "List the enabled social login providers",
                 "consumes": [
                     "application/json"
                 ],
@@ -661,22 +629,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "search term",
-                        "name": "search",
-                        "in": "query",
-                        "required": true
-                    }
+                    "auth"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listContactsResponse"
+                            "$ref": "#/definitions/server.listSocialAuthProvidersResponse"
                         }
                     },
                     "400": {
@@ -706,9 +665,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/{reference}": {
-            "get": {
-                "description": "fetch a contact by reference",
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -716,22 +675,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "contact unique reference.. e.g contact_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "refresh token",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.refreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDetailedContactResponse"
+                            "$ref": "#/definitions/server.refreshTokenResponse"
                         }
                     },
                     "400": {
@@ -759,9 +720,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "edit a contact",
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Sign up with your email address and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -769,85 +732,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "auth"
                 ],
                 "parameters": [
                     {
-                        "description": "contact request body",
+                        "description": "auth exchange data",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.editContactRequest"
+                            "$ref": "#/definitions/server.signupRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "contact unique reference.. e.g contact_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchContactResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "delete a contact",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "contacts"
-                ],
-                "operationId": "deleteContact",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "contact unique reference.. e.g contact_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.createdUserResponse"
                         }
                     },
                     "400": {
@@ -877,9 +779,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards": {
+        "/contacts": {
             "get": {
-                "description": "List dashboards",
+                "description": "list your contacts",
                 "consumes": [
                     "application/json"
                 ],
@@ -887,7 +789,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
                 "parameters": [
                     {
@@ -907,7 +809,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listDashboardResponse"
+                            "$ref": "#/definitions/server.listContactsResponse"
                         }
                     },
                     "400": {
@@ -937,7 +839,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "create a new dashboard",
+                "description": "Creates a new contact",
                 "consumes": [
                     "application/json"
                 ],
@@ -945,16 +847,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
                 "parameters": [
                     {
-                        "description": "dashboard request body",
+                        "description": "contact request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createDashboardRequest"
+                            "$ref": "#/definitions/server.createContactRequest"
                         }
                     }
                 ],
@@ -962,7 +864,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDashboardResponse"
+                            "$ref": "#/definitions/server.fetchContactResponse"
                         }
                     },
                     "400": {
@@ -992,9 +894,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards/charts": {
+        "/contacts/all": {
             "get": {
-                "description": "List charts",
+                "description": "List all contacts",
                 "consumes": [
                     "application/json"
                 ],
@@ -1002,13 +904,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "listAllContacts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listIntegrationChartsResponse"
+                            "$ref": "#/definitions/server.listContactsResponse"
                         }
                     },
                     "400": {
@@ -1038,9 +941,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards/charts/{reference}": {
-            "get": {
-                "description": "fetch charting data",
+        "/contacts/batch": {
+            "post": {
+                "description": "batch create a new contact",
                 "consumes": [
                     "application/json"
                 ],
@@ -1048,22 +951,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "chart unique reference.. e.g integration_chart_km31C.e6xV",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "contact request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createContactRequestBatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listChartDataPointsResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -1093,9 +998,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards/{reference}": {
+        "/contacts/lists": {
             "get": {
-                "description": "fetch dashboard",
+                "description": "List all created contact lists",
                 "consumes": [
                     "application/json"
                 ],
@@ -1103,22 +1008,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "fetchContactLists",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "show emails inside the list",
+                        "name": "include_emails",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listDashboardChartsResponse"
+                            "$ref": "#/definitions/server.fetchContactListsResponse"
                         }
                     },
                     "400": {
@@ -1146,11 +1051,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/dashboards/{reference}/access-control": {
-            "get": {
-                "description": "list access controls",
+            },
+            "post": {
+                "description": "Create a new contact list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1158,34 +1061,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "createContactList",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page to query data from. Defaults to 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number to items to return. Defaults to 10 items",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "contact list body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createContactListRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listDashboardLinkResponse"
+                            "$ref": "#/definitions/server.fetchContactListResponse"
                         }
                     },
                     "400": {
@@ -1215,9 +1109,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards/{reference}/access-control/link": {
-            "post": {
-                "description": "regenerate the default link for a dashboard",
+        "/contacts/lists/{reference}": {
+            "put": {
+                "description": "Edit a contact list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1225,20 +1119,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "editContactList",
                 "parameters": [
                     {
-                        "description": "Request body to generate link",
+                        "description": "contact list body",
                         "name": "message",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.generateDashboardLinkRequest"
+                            "$ref": "#/definitions/server.createContactListRequest"
                         }
                     },
                     {
                         "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "description": "list unique reference.. e.g list_",
                         "name": "reference",
                         "in": "path",
                         "required": true
@@ -1248,7 +1144,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.regenerateLinkResponse"
+                            "$ref": "#/definitions/server.fetchContactListResponse"
                         }
                     },
                     "400": {
@@ -1276,11 +1172,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/dashboards/{reference}/access-control/{link_reference}": {
-            "delete": {
-                "description": "delete access controls",
+            },
+            "post": {
+                "description": "add a new contact to a list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1288,20 +1182,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "addEmailToContactList",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "contact body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.addContactToListRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "link unique reference.. e.g dashboard_link_",
-                        "name": "link_reference",
+                        "description": "list unique reference.. e.g list_",
+                        "name": "reference",
                         "in": "path",
                         "required": true
                     }
@@ -1338,11 +1235,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/dashboards/{reference}/charts": {
-            "put": {
-                "description": "add a chart to a dashboard",
+            },
+            "delete": {
+                "description": "delete a contact list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1350,21 +1245,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
+                "operationId": "deleteContactList",
                 "parameters": [
-                    {
-                        "description": "dashboard request chart data",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.addChartToDashboardRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "description": "list unique reference.. e.g list_",
                         "name": "reference",
                         "in": "path",
                         "required": true
@@ -1402,9 +1289,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "remove a chart from a dashboard",
+            }
+        },
+        "/contacts/search": {
+            "get": {
+                "description": "Search contacts",
                 "consumes": [
                     "application/json"
                 ],
@@ -1412,23 +1301,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
                 "parameters": [
-                    {
-                        "description": "dashboard request chart data",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.addChartToDashboardRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
-                        "name": "reference",
-                        "in": "path",
+                        "description": "search term",
+                        "name": "search",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1436,7 +1316,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.listContactsResponse"
                         }
                     },
                     "400": {
@@ -1466,9 +1346,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboards/{reference}/positions": {
-            "post": {
-                "description": "update dashboard chart positioning",
+        "/contacts/suppressions": {
+            "get": {
+                "description": "list contacts that will not receive updates because they bounced, complained or unsubscribed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1476,20 +1356,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "contacts"
                 ],
                 "parameters": [
                     {
-                        "description": "dashboard chart positions",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.updateDashboardPositionsRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listSuppressionsResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -1517,9 +1406,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/decks": {
-            "get": {
-                "description": "list all decks. No pagination",
+        "/contacts/suppressions/{reference}": {
+            "delete": {
+                "description": "remove a contact from the suppression list so they can receive updates again",
                 "consumes": [
                     "application/json"
                 ],
@@ -1527,13 +1416,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "contacts"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "suppression unique reference.. e.g suppression_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDecksResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -1561,9 +1459,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new deck",
+            }
+        },
+        "/contacts/{reference}": {
+            "get": {
+                "description": "fetch a contact by reference",
                 "consumes": [
                     "application/json"
                 ],
@@ -1571,24 +1471,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "contacts"
                 ],
                 "parameters": [
                     {
-                        "description": "deck request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createDeckRequest"
-                        }
+                        "type": "string",
+                        "description": "contact unique reference.. e.g contact_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDeckResponse"
+                            "$ref": "#/definitions/server.fetchDetailedContactResponse"
                         }
                     },
                     "400": {
@@ -1616,11 +1514,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/decks/{reference}": {
-            "get": {
-                "description": "fetch a deck",
+            },
+            "put": {
+                "description": "edit a contact",
                 "consumes": [
                     "application/json"
                 ],
@@ -1628,12 +1524,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "contacts"
                 ],
                 "parameters": [
+                    {
+                        "description": "contact request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.editContactRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
+                        "description": "contact unique reference.. e.g contact_",
                         "name": "reference",
                         "in": "path",
                         "required": true
@@ -1643,7 +1548,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDeckResponse"
+                            "$ref": "#/definitions/server.fetchContactResponse"
                         }
                     },
                     "400": {
@@ -1673,7 +1578,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete a deck",
+                "description": "delete a contact",
                 "consumes": [
                     "application/json"
                 ],
@@ -1681,12 +1586,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "contacts"
                 ],
+                "operationId": "deleteContact",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
+                        "description": "contact unique reference.. e.g contact_",
                         "name": "reference",
                         "in": "path",
                         "required": true
@@ -1726,9 +1632,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/decks/{reference}/analytics": {
+        "/dashboards": {
             "get": {
-                "description": "fetch deck engagements and geographic stats",
+                "description": "List dashboards",
                 "consumes": [
                     "application/json"
                 ],
@@ -1736,22 +1642,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchEngagementsResponse"
+                            "$ref": "#/definitions/server.listDashboardResponse"
                         }
                     },
                     "400": {
@@ -1779,11 +1690,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/decks/{reference}/archive": {
+            },
             "post": {
-                "description": "toggle archive status of a deck",
+                "description": "create a new dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -1791,23 +1700,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "dashboards"
                 ],
-                "operationId": "toggleArchive",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "dashboard request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createDashboardRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDeckResponse"
+                            "$ref": "#/definitions/server.fetchDashboardResponse"
                         }
                     },
                     "400": {
@@ -1837,9 +1747,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/decks/{reference}/pin": {
-            "post": {
-                "description": "toggle pinned status of a deck",
+        "/dashboards/charts": {
+            "get": {
+                "description": "List charts",
                 "consumes": [
                     "application/json"
                 ],
@@ -1847,23 +1757,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
-                ],
-                "operationId": "togglePin",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    }
+                    "dashboards"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDeckResponse"
+                            "$ref": "#/definitions/server.listIntegrationChartsResponse"
                         }
                     },
                     "400": {
@@ -1893,9 +1793,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/decks/{reference}/preferences": {
-            "put": {
-                "description": "update a deck preferences",
+        "/dashboards/charts/{reference}": {
+            "get": {
+                "description": "fetch charting data",
                 "consumes": [
                     "application/json"
                 ],
@@ -1903,31 +1803,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
+                        "description": "chart unique reference.. e.g integration_chart_km31C.e6xV",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "deck preferences request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.updateDeckPreferencesRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchDeckResponse"
+                            "$ref": "#/definitions/server.listChartDataPointsResponse"
                         }
                     },
                     "400": {
@@ -1957,9 +1848,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/decks/{reference}/sessions": {
+        "/dashboards/{reference}": {
             "get": {
-                "description": "fetch deck viewing sessions on dashboard",
+                "description": "fetch dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -1967,40 +1858,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "deck unique reference.. e.g deck_",
+                        "description": "dashboard unique reference.. e.g dashboard_",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page to query data from. Defaults to 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number to items to return. Defaults to 10 items",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "number of days to fetch deck sessions",
-                        "name": "days",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchSessionsDeck"
+                            "$ref": "#/definitions/server.listDashboardChartsResponse"
                         }
                     },
                     "400": {
@@ -2030,9 +1903,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/developers/keys": {
+        "/dashboards/{reference}/access-control": {
             "get": {
-                "description": "list api keys",
+                "description": "list access controls",
                 "consumes": [
                     "application/json"
                 ],
@@ -2040,13 +1913,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "developers"
+                    "dashboards"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listAPIKeysResponse"
+                            "$ref": "#/definitions/server.listDashboardLinkResponse"
                         }
                     },
                     "400": {
@@ -2074,9 +1968,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/dashboards/{reference}/access-control/link": {
             "post": {
-                "description": "Creates a new api key",
+                "description": "regenerate the default link for a dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2084,24 +1980,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "developers"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "description": "api key request body",
+                        "description": "Request body to generate link",
                         "name": "message",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createAPIKeyRequest"
+                            "$ref": "#/definitions/server.generateDashboardLinkRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdAPIKeyResponse"
+                            "$ref": "#/definitions/server.regenerateLinkResponse"
                         }
                     },
                     "400": {
@@ -2131,9 +2033,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/developers/keys/{reference}": {
+        "/dashboards/{reference}/access-control/{link_reference}": {
             "delete": {
-                "description": "revoke a specific api key",
+                "description": "delete access controls",
                 "consumes": [
                     "application/json"
                 ],
@@ -2141,24 +2043,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "developers"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "api key unique reference.. e.g api_key_",
+                        "description": "dashboard unique reference.. e.g dashboard_",
                         "name": "reference",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "api key request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.revokeAPIKeyRequest"
-                        }
+                        "type": "string",
+                        "description": "link unique reference.. e.g dashboard_link_",
+                        "name": "link_reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2195,9 +2095,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/pipelines": {
-            "get": {
-                "description": "list all fundraising pipelines with pagination and filtering",
+        "/dashboards/{reference}/charts": {
+            "put": {
+                "description": "add a chart to a dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2205,33 +2105,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page to query data from. Defaults to 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number to items to return. Defaults to 10 items",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "dashboard request chart data",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.addChartToDashboardRequest"
+                        }
                     },
                     {
-                        "type": "boolean",
-                        "description": "Whether to return only active pipelines. Defaults to false",
-                        "name": "active_only",
-                        "in": "query"
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchPipelinesResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -2260,8 +2158,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Creates a new fundraising pipeline entry",
+            "delete": {
+                "description": "remove a chart from a dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2269,17 +2167,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "description": "pipeline request body",
+                        "description": "dashboard request chart data",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createNewPipelineRequest"
+                            "$ref": "#/definitions/server.addChartToDashboardRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2316,9 +2221,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/pipelines/{reference}": {
-            "get": {
-                "description": "Fetch a fundraising board with its columns",
+        "/dashboards/{reference}/positions": {
+            "post": {
+                "description": "update dashboard chart positioning",
                 "consumes": [
                     "application/json"
                 ],
@@ -2326,24 +2231,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pipeline reference",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
+                        "description": "dashboard chart positions",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateDashboardPositionsRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/server.fetchBoardResponse"
-                        }
-                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -2369,9 +2270,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Close a fundraising board permanently",
+            }
+        },
+        "/decks": {
+            "get": {
+                "description": "list all decks. No pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -2379,22 +2282,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Pipeline reference",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    }
+                    "decks"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchDecksResponse"
                         }
                     },
                     "400": {
@@ -2422,11 +2316,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/pipelines/{reference}/contacts": {
+            },
             "post": {
-                "description": "Add a contact to a fundraising board",
+                "description": "Creates a new deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -2434,23 +2326,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "decks"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pipeline reference",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "contact request body",
+                        "description": "deck request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.addContactRequest"
+                            "$ref": "#/definitions/server.createDeckRequest"
                         }
                     }
                 ],
@@ -2458,7 +2343,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchDeckResponse"
                         }
                     },
                     "400": {
@@ -2488,9 +2373,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/pipelines/{reference}/contacts/board": {
-            "post": {
-                "description": "move contact across board",
+        "/decks/{reference}": {
+            "get": {
+                "description": "fetch a deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -2498,31 +2383,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "decks"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pipeline reference",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "move cotnact across board",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.moveContactAcrossBoardRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchDeckResponse"
                         }
                     },
                     "400": {
@@ -2550,11 +2426,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/pipelines/{reference}/contacts/{contact_id}": {
-            "patch": {
-                "description": "Update deal details for a contact on the fundraising board",
+            },
+            "delete": {
+                "description": "delete a deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -2562,31 +2436,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fundraising"
+                    "decks"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pipeline reference",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Contact ID",
-                        "name": "contact_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "update deal request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.updateContactDealRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -2623,9 +2481,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/dashboards/{reference}": {
+        "/decks/{reference}/analytics": {
             "get": {
-                "description": "fetch public dashboard and charting data points",
+                "description": "fetch deck engagements and geographic stats",
                 "consumes": [
                     "application/json"
                 ],
@@ -2633,12 +2491,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "decks"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
@@ -2648,7 +2506,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listDashboardChartsResponse"
+                            "$ref": "#/definitions/server.fetchEngagementsResponse"
                         }
                     },
                     "400": {
@@ -2678,9 +2536,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/dashboards/{reference}/charts/{chart_reference}": {
-            "get": {
-                "description": "fetch charting data",
+        "/decks/{reference}/archive": {
+            "post": {
+                "description": "toggle archive status of a deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -2688,29 +2546,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboards"
+                    "decks"
                 ],
+                "operationId": "toggleArchive",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "chart unique reference.. e.g integration_chart_km31C.e6xV",
-                        "name": "chart_reference",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listChartDataPointsResponse"
+                            "$ref": "#/definitions/server.fetchDeckResponse"
                         }
                     },
                     "400": {
@@ -2740,9 +2592,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/decks/{reference}": {
-            "put": {
-                "description": "update the session details",
+        "/decks/{reference}/pin": {
+            "post": {
+                "description": "toggle pinned status of a deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -2750,31 +2602,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks-viewer"
+                    "decks"
                 ],
+                "operationId": "togglePin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "session unique reference.. ",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "deck session request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createDeckViewerSession"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchPublicDeckResponse"
+                            "$ref": "#/definitions/server.fetchDeckResponse"
                         }
                     },
                     "400": {
@@ -2802,9 +2646,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "public api to fetch a deck",
+            }
+        },
+        "/decks/{reference}/preferences": {
+            "put": {
+                "description": "update a deck preferences",
                 "consumes": [
                     "application/json"
                 ],
@@ -2812,23 +2658,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks-viewer"
+                    "decks"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "deck unique reference.. ",
+                        "description": "deck unique reference.. e.g deck_",
                         "name": "reference",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "deck session request body",
+                        "description": "deck preferences request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createDeckViewerSession"
+                            "$ref": "#/definitions/server.updateDeckPreferencesRequest"
                         }
                     }
                 ],
@@ -2836,7 +2682,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchPublicDeckResponse"
+                            "$ref": "#/definitions/server.fetchDeckResponse"
                         }
                     },
                     "400": {
@@ -2866,9 +2712,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/updates/react": {
+        "/decks/{reference}/sessions": {
             "get": {
-                "description": "Fetch a specific update",
+                "description": "fetch deck viewing sessions on dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2876,30 +2722,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "decks"
                 ],
-                "operationId": "reactPost",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "provider type",
-                        "name": "provider",
-                        "in": "query",
+                        "description": "deck unique reference.. e.g deck_",
+                        "name": "reference",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "email id",
-                        "name": "email_id",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of days to fetch deck sessions",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchSessionsDeck"
                         }
                     },
                     "400": {
@@ -2929,9 +2785,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/uploads/decks": {
-            "post": {
-                "description": "Upload a deck",
+        "/developers/keys": {
+            "get": {
+                "description": "list api keys",
                 "consumes": [
                     "application/json"
                 ],
@@ -2939,23 +2795,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "decks"
-                ],
-                "operationId": "uploadDeck",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "image body",
-                        "name": "image_body",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "developers"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.uploadImageResponse"
+                            "$ref": "#/definitions/server.listAPIKeysResponse"
                         }
                     },
                     "400": {
@@ -2983,11 +2829,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/uploads/images": {
+            },
             "post": {
-                "description": "Upload an image",
+                "description": "Creates a new api key",
                 "consumes": [
                     "application/json"
                 ],
@@ -2995,23 +2839,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "developers"
                 ],
-                "operationId": "uploadImage",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "image body",
-                        "name": "image_body",
-                        "in": "formData",
-                        "required": true
+                        "description": "api key request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createAPIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.uploadImageResponse"
+                            "$ref": "#/definitions/server.createdAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -3041,9 +2886,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
-            "get": {
-                "description": "Fetch current user. This api should also double as a token validation api",
+        "/developers/keys/{reference}": {
+            "delete": {
+                "description": "revoke a specific api key",
                 "consumes": [
                     "application/json"
                 ],
@@ -3051,13 +2896,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "developers"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "api key unique reference.. e.g api_key_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "api key request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.revokeAPIKeyRequest"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUserResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3087,9 +2950,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces": {
-            "post": {
-                "description": "Create a new workspace",
+        "/developers/webhooks": {
+            "get": {
+                "description": "list webhooks",
                 "consumes": [
                     "application/json"
                 ],
@@ -3097,24 +2960,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspace"
-                ],
-                "parameters": [
-                    {
-                        "description": "request body to create a workspace",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createWorkspaceRequest"
-                        }
-                    }
+                    "developers"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                            "$ref": "#/definitions/server.listWebhooksResponse"
                         }
                     },
                     "400": {
@@ -3143,8 +2995,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "update workspace details",
+            "post": {
+                "description": "Creates a new webhook endpoint. The signing secret is only returned here",
                 "consumes": [
                     "application/json"
                 ],
@@ -3152,16 +3004,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspace"
+                    "developers"
                 ],
                 "parameters": [
                     {
-                        "description": "request body to create a workspace",
+                        "description": "webhook request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.updateWorkspaceRequest"
+                            "$ref": "#/definitions/server.createWebhookRequest"
                         }
                     }
                 ],
@@ -3169,7 +3021,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                            "$ref": "#/definitions/server.createdWebhookResponse"
                         }
                     },
                     "400": {
@@ -3199,9 +3051,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/billing": {
-            "post": {
-                "description": "get billing portal",
+        "/developers/webhooks/{reference}": {
+            "delete": {
+                "description": "delete a webhook. Pending deliveries will not be sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -3209,13 +3061,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "billing"
+                    "developers"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook unique reference.. e.g webhook_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchBillingPortalResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3243,11 +3104,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/integrations": {
-            "get": {
-                "description": "fetch workspace preferences",
+            },
+            "patch": {
+                "description": "update a webhook. Only the provided fields are changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -3255,13 +3114,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "developers"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook unique reference.. e.g webhook_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateWebhookRequest"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listIntegrationResponse"
+                            "$ref": "#/definitions/server.fetchWebhookResponse"
                         }
                     },
                     "400": {
@@ -3291,9 +3168,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/integrations/{reference}": {
-            "put": {
-                "description": "update integration api key",
+        "/developers/webhooks/{reference}/deliveries": {
+            "get": {
+                "description": "list the deliveries of a webhook",
                 "consumes": [
                     "application/json"
                 ],
@@ -3301,24 +3178,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "developers"
                 ],
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
-                        }
+                        "type": "string",
+                        "description": "webhook unique reference.. e.g webhook_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.listWebhookDeliveriesResponse"
                         }
                     },
                     "400": {
@@ -3346,9 +3233,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/developers/webhooks/{reference}/deliveries/{delivery_reference}/redeliver": {
             "post": {
-                "description": "enable integration",
+                "description": "send a delivery again. This works for failed and succeeded deliveries",
                 "consumes": [
                     "application/json"
                 ],
@@ -3356,24 +3245,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "developers"
                 ],
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
-                        }
+                        "type": "string",
+                        "description": "webhook unique reference.. e.g webhook_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivery unique reference.. e.g webhook_delivery_",
+                        "name": "delivery_reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchWebhookDeliveryResponse"
                         }
                     },
                     "400": {
@@ -3401,9 +3295,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "disable integration",
+            }
+        },
+        "/invites/accept": {
+            "post": {
+                "description": "accept an invite to join a workspace",
                 "consumes": [
                     "application/json"
                 ],
@@ -3411,7 +3307,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "team"
+                ],
+                "parameters": [
+                    {
+                        "description": "invite token",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.teamInviteRequest"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -3447,9 +3354,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/integrations/{reference}/charts": {
+        "/invites/decline": {
             "post": {
-                "description": "create chart",
+                "description": "decline an invite to join a workspace",
                 "consumes": [
                     "application/json"
                 ],
@@ -3457,16 +3364,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "team"
                 ],
                 "parameters": [
                     {
-                        "description": "request body",
+                        "description": "invite token",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.createChartRequest"
+                            "$ref": "#/definitions/server.teamInviteRequest"
                         }
                     }
                 ],
@@ -3504,9 +3411,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/integrations/{reference}/charts/{chart_reference}/points": {
-            "post": {
-                "description": "add data point values to chart",
+        "/pipelines": {
+            "get": {
+                "description": "list all fundraising pipelines with pagination and filtering",
                 "consumes": [
                     "application/json"
                 ],
@@ -3514,24 +3421,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "fundraising"
                 ],
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.addDataPointRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to return only active pipelines. Defaults to false",
+                        "name": "active_only",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchPipelinesResponse"
                         }
                     },
                     "400": {
@@ -3559,11 +3475,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/integrations/{reference}/ping": {
+            },
             "post": {
-                "description": "test an api key is valid and can reach the integration",
+                "description": "Creates a new fundraising pipeline entry",
                 "consumes": [
                     "application/json"
                 ],
@@ -3571,16 +3485,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "fundraising"
                 ],
                 "parameters": [
                     {
-                        "description": "request body to test an integration",
+                        "description": "pipeline request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
+                            "$ref": "#/definitions/server.createNewPipelineRequest"
                         }
                     }
                 ],
@@ -3618,9 +3532,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/overview": {
+        "/pipelines/{reference}": {
             "get": {
-                "description": "fetch workspace overview",
+                "description": "Fetch a fundraising board with its columns",
                 "consumes": [
                     "application/json"
                 ],
@@ -3628,13 +3542,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspaces"
+                    "fundraising"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pipeline reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.workspaceOverviewResponse"
+                            "$ref": "#/definitions/server.fetchBoardResponse"
                         }
                     },
                     "400": {
@@ -3662,11 +3585,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/preferences": {
-            "get": {
-                "description": "fetch workspace preferences",
+            },
+            "delete": {
+                "description": "Close a fundraising board permanently",
                 "consumes": [
                     "application/json"
                 ],
@@ -3674,13 +3595,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspace"
+                    "fundraising"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pipeline reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.preferenceResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3708,9 +3638,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "update workspace preferences",
+            }
+        },
+        "/pipelines/{reference}/contacts": {
+            "post": {
+                "description": "Add a contact to a fundraising board",
                 "consumes": [
                     "application/json"
                 ],
@@ -3718,16 +3650,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspace"
+                    "fundraising"
                 ],
                 "parameters": [
                     {
-                        "description": "request body to updare a workspace preference",
+                        "type": "string",
+                        "description": "Pipeline reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "contact request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.updatePreferencesRequest"
+                            "$ref": "#/definitions/server.addContactRequest"
                         }
                     }
                 ],
@@ -3735,7 +3674,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.preferenceResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3765,9 +3704,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/switch/{reference}": {
+        "/pipelines/{reference}/contacts/board": {
             "post": {
-                "description": "Switch current workspace",
+                "description": "move contact across board",
                 "consumes": [
                     "application/json"
                 ],
@@ -3775,23 +3714,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspace"
+                    "fundraising"
                 ],
-                "operationId": "switchworkspace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace unique reference.. e.g update_",
+                        "description": "Pipeline reference",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "move cotnact across board",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.moveContactAcrossBoardRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3821,9 +3768,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates": {
-            "get": {
-                "description": "List updates",
+        "/pipelines/{reference}/contacts/{contact_id}": {
+            "patch": {
+                "description": "Update deal details for a contact on the fundraising board",
                 "consumes": [
                     "application/json"
                 ],
@@ -3831,33 +3778,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "fundraising"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page to query data from. Defaults to 1",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Pipeline reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number to items to return. Defaults to 10 items",
-                        "name": "per_page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "filter results by the status of the update.",
-                        "name": "status",
-                        "in": "query"
+                        "description": "update deal request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateContactDealRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listUpdateResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -3885,9 +3837,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new update",
+            }
+        },
+        "/public/dashboards/{reference}": {
+            "get": {
+                "description": "fetch public dashboard and charting data points",
                 "consumes": [
                     "application/json"
                 ],
@@ -3895,24 +3849,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "dashboards"
                 ],
                 "parameters": [
                     {
-                        "description": "update content body",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/server.createUpdateContent"
-                        }
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUpdateResponse"
+                            "$ref": "#/definitions/server.listDashboardChartsResponse"
                         }
                     },
                     "400": {
@@ -3942,9 +3894,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/pins": {
+        "/public/dashboards/{reference}/charts/{chart_reference}": {
             "get": {
-                "description": "List pinned updates",
+                "description": "fetch charting data",
                 "consumes": [
                     "application/json"
                 ],
@@ -3952,13 +3904,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "dashboards"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "dashboard unique reference.. e.g dashboard_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "chart unique reference.. e.g integration_chart_km31C.e6xV",
+                        "name": "chart_reference",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.listUpdateResponse"
+                            "$ref": "#/definitions/server.listChartDataPointsResponse"
                         }
                     },
                     "400": {
@@ -3988,9 +3956,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/templates": {
-            "get": {
-                "description": "list all templates. this will include both systems and your own created templates",
+        "/public/decks/{reference}": {
+            "put": {
+                "description": "update the session details",
                 "consumes": [
                     "application/json"
                 ],
@@ -3998,13 +3966,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "decks-viewer"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session unique reference.. ",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "deck session request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createDeckViewerSession"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchTemplatesResponse"
+                            "$ref": "#/definitions/server.fetchPublicDeckResponse"
                         }
                     },
                     "400": {
@@ -4032,11 +4018,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/updates/{reference}": {
-            "get": {
-                "description": "Fetch a specific update",
+            },
+            "post": {
+                "description": "public api to fetch a deck",
                 "consumes": [
                     "application/json"
                 ],
@@ -4044,23 +4028,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "decks-viewer"
                 ],
-                "operationId": "fetchUpdate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "deck unique reference.. ",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "deck session request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createDeckViewerSession"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchUpdateReponse"
+                            "$ref": "#/definitions/server.fetchPublicDeckResponse"
                         }
                     },
                     "400": {
@@ -4088,9 +4080,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a specific update",
+            }
+        },
+        "/public/updates/links/{reference}": {
+            "post": {
+                "description": "public api to view an update through a shareable link",
                 "consumes": [
                     "application/json"
                 ],
@@ -4098,24 +4092,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "updates-viewer"
                 ],
-                "operationId": "updateContent",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "link unique reference.. e.g link_",
                         "name": "reference",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "update content body",
+                        "description": "update session request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.contentUpdateRequest"
+                            "$ref": "#/definitions/server.createUpdateViewerSession"
                         }
                     }
                 ],
@@ -4123,7 +4116,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchPublicUpdateLinkResponse"
                         }
                     },
                     "400": {
@@ -4151,9 +4144,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Send an update to real users",
+            }
+        },
+        "/public/updates/sessions": {
+            "put": {
+                "description": "public api to record how long an update was viewed for",
                 "consumes": [
                     "application/json"
                 ],
@@ -4161,24 +4156,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "updates-viewer"
                 ],
-                "operationId": "sendUpdate",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "update unique reference.. e.g update_",
-                        "name": "reference",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "request body to send an update",
+                        "description": "update session request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.sendUpdateRequest"
+                            "$ref": "#/definitions/server.updateUpdateViewerSession"
                         }
                     }
                 ],
@@ -4214,33 +4201,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a specific update",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/public/updates/{reference}": {
+            "get": {
+                "description": "Fetch the public view of an update from the signed link in the email",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "updates"
                 ],
-                "operationId": "deleteUpdate",
+                "operationId": "fetchPublicUpdate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "recipient unique reference.. e.g update_recipient_",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchPublicUpdateResponse"
                         }
                     },
                     "400": {
@@ -4249,12 +4242,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.APIStatus"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4270,9 +4257,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/{reference}/analytics": {
-            "get": {
-                "description": "Fetch analytics for a specific update",
+        "/public/updates/{reference}/comments": {
+            "post": {
+                "description": "Comment on an update from the signed link in the email",
                 "consumes": [
                     "application/json"
                 ],
@@ -4282,21 +4269,37 @@ const docTemplate = `{
                 "tags": [
                     "updates"
                 ],
-                "operationId": "fetchUpdateAnalytics",
+                "operationId": "createPublicUpdateComment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "recipient unique reference.. e.g update_recipient_",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "comment body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createUpdateCommentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.fetchUpdateAnalyticsResponse"
+                            "$ref": "#/definitions/server.fetchPublicUpdateResponse"
                         }
                     },
                     "400": {
@@ -4305,12 +4308,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.APIStatus"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4326,9 +4323,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/{reference}/duplicate": {
+        "/public/updates/{reference}/reactions": {
             "post": {
-                "description": "Duplicate a specific update",
+                "description": "React to an update from the signed link in the email",
                 "consumes": [
                     "application/json"
                 ],
@@ -4338,21 +4335,37 @@ const docTemplate = `{
                 "tags": [
                     "updates"
                 ],
-                "operationId": "duplicateUpdate",
+                "operationId": "createPublicUpdateReaction",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "recipient unique reference.. e.g update_recipient_",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "reaction body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateReactionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUpdateResponse"
+                            "$ref": "#/definitions/server.APIStatus"
                         }
                     },
                     "400": {
@@ -4361,12 +4374,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.APIStatus"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4382,9 +4389,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/{reference}/pin": {
-            "post": {
-                "description": "Toggle pinned status a specific update",
+        "/public/workspaces/{reference}/updates": {
+            "get": {
+                "description": "public api to list the updates a workspace has made public",
                 "consumes": [
                     "application/json"
                 ],
@@ -4392,23 +4399,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "updates-viewer"
                 ],
-                "operationId": "toggleUpdatePin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "workspace unique reference.. e.g workspace_",
                         "name": "reference",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.createdUpdateResponse"
+                            "$ref": "#/definitions/server.listPublicUpdatesResponse"
                         }
                     },
                     "400": {
@@ -4438,9 +4456,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/updates/{reference}/preview": {
+        "/public/workspaces/{reference}/updates/{update_reference}": {
             "post": {
-                "description": "Send preview of an update",
+                "description": "public api to view an update listed on the public page of a workspace",
                 "consumes": [
                     "application/json"
                 ],
@@ -4448,24 +4466,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "updates"
+                    "updates-viewer"
                 ],
-                "operationId": "previewUpdate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "update unique reference.. e.g update_",
+                        "description": "workspace unique reference.. e.g workspace_",
                         "name": "reference",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "request body to create a workspace",
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "update_reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update session request body",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/server.previewUpdateRequest"
+                            "$ref": "#/definitions/server.createUpdateViewerSession"
                         }
                     }
                 ],
@@ -4473,7 +4497,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.APIStatus"
+                            "$ref": "#/definitions/server.fetchPublicUpdateLinkResponse"
                         }
                     },
                     "400": {
@@ -4502,130 +4526,5425 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "malak.APIKey": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "expires_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "key_name": {
-                    "type": "string"
-                },
-                "reference": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "workspace_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.BillingPreferences": {
-            "type": "object",
-            "properties": {
-                "finance_email": {
-                    "type": "string"
-                }
-            }
         },
-        "malak.Block": {
-            "type": "object",
-            "properties": {
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Block"
+        "/updates/click": {
+            "get": {
+                "description": "Record a click on a link in an update and redirect to the link",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "trackClick",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipient reference",
+                        "name": "recipient",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "link that was clicked",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
                     }
-                },
-                "content": {},
-                "id": {
-                    "type": "string"
-                },
-                "props": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "type": {
-                    "type": "string"
                 }
             }
         },
-        "malak.CommunicationPreferences": {
-            "type": "object",
-            "properties": {
-                "enable_marketing": {
-                    "type": "boolean"
-                },
-                "enable_product_updates": {
-                    "type": "boolean"
+        "/updates/open": {
+            "get": {
+                "description": "Open tracking pixel embedded in updates sent through smtp",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "trackOpen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipient reference",
+                        "name": "recipient",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
                 }
             }
         },
-        "malak.Contact": {
-            "type": "object",
+        "/updates/react": {
+            "get": {
+                "description": "Fetch a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "reactPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "provider type",
+                        "name": "provider",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "email id",
+                        "name": "email_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "thumbs up or thumbs down",
+                        "name": "reaction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/updates/unsubscribe": {
+            "get": {
+                "description": "Confirm an unsubscribe from the link in an update email. This does not unsubscribe the recipient",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "confirmUnsubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipient reference",
+                        "name": "recipient",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "One-click unsubscribe from all updates sent by a workspace",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "unsubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipient reference",
+                        "name": "recipient",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "signature from the email",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "must be One-Click",
+                        "name": "List-Unsubscribe",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/decks": {
+            "post": {
+                "description": "Upload a deck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "decks"
+                ],
+                "operationId": "uploadDeck",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "image body",
+                        "name": "image_body",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.uploadImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/images": {
+            "post": {
+                "description": "Upload an image",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "operationId": "uploadImage",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "image body",
+                        "name": "image_body",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.uploadImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "description": "Fetch current user. This api should also double as a token validation api",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.createdUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "post": {
+                "description": "Create a new workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body to create a workspace",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update workspace details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body to create a workspace",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/audit-logs": {
+            "get": {
+                "description": "list the workspace audit logs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only show actions carried out by this user or api key",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user or api_key",
+                        "name": "actor_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only show actions on this entity type. e.g deck",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start date. Either RFC3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end date. Either RFC3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listAuditLogsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/billing": {
+            "post": {
+                "description": "get billing portal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "billing"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchBillingPortalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/domain": {
+            "get": {
+                "description": "fetch the sending domain of the workspace and the DNS records it needs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchSendingDomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register the domain updates are sent from. A DKIM key is generated for the domain and the DNS records to add are returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "parameters": [
+                    {
+                        "description": "sending domain request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createSendingDomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchSendingDomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove the sending domain. Updates are sent from the default sender afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/domain/verify": {
+            "post": {
+                "description": "check the DNS records of the sending domain. Updates are sent from the domain once it is verified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchSendingDomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/integrations": {
+            "get": {
+                "description": "fetch workspace preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listIntegrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/integrations/{reference}": {
+            "put": {
+                "description": "update integration api key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "enable integration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "disable integration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/integrations/{reference}/charts": {
+            "post": {
+                "description": "create chart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createChartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/integrations/{reference}/charts/{chart_reference}/points": {
+            "post": {
+                "description": "add data point values to chart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.addDataPointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/integrations/{reference}/ping": {
+            "post": {
+                "description": "test an api key is valid and can reach the integration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body to test an integration",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.testAPIIntegrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/members": {
+            "get": {
+                "description": "list the members of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listTeamMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/members/invite": {
+            "post": {
+                "description": "invite a new team member to the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "parameters": [
+                    {
+                        "description": "invite request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.inviteTeamMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/members/{user_id}": {
+            "put": {
+                "description": "change the role of a team member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id of the team member",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "role request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateTeamMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchTeamMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove a team member from the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id of the team member",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/overview": {
+            "get": {
+                "description": "fetch workspace overview",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.workspaceOverviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/preferences": {
+            "get": {
+                "description": "fetch workspace preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.preferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update workspace preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "parameters": [
+                    {
+                        "description": "request body to updare a workspace preference",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.preferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/switch/{reference}": {
+            "post": {
+                "description": "Switch current workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "operationId": "switchworkspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates": {
+            "get": {
+                "description": "List updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter results by the status of the update.",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "update content body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createUpdateContent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.createdUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/pins": {
+            "get": {
+                "description": "List pinned updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/recurring": {
+            "get": {
+                "description": "list the recurring schedules of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listRecurringSchedulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a recurring schedule. A draft is created from the template ahead of every send",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "recurring schedule request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createRecurringScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchRecurringScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/templates": {
+            "get": {
+                "description": "list all templates. this will include both systems and your own created templates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchTemplatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/templates/{reference}": {
+            "get": {
+                "description": "fetch a template of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template unique reference.. e.g workspace_template_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "edit a template of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "template request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateWorkspaceTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "template unique reference.. e.g workspace_template_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a template of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template unique reference.. e.g workspace_template_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}": {
+            "get": {
+                "description": "Fetch a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "fetchUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateReponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "updateContent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update content body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.contentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Send an update to real users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "sendUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body to send an update",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.sendUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "deleteUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/analytics": {
+            "get": {
+                "description": "Fetch analytics for a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "fetchUpdateAnalytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateAnalyticsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/approvals": {
+            "get": {
+                "description": "list the reviews of an update. The most recent comes first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateApprovalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "approve an update in review or request changes to it. Requesting changes moves the update back to a draft",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body to review an update",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.reviewUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/collaborate": {
+            "get": {
+                "description": "Join the collaborative editing of a draft over a websocket. Editors send operations messages and receive sync, operations, presence and error messages",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "collaborateOnUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "access token. browsers cannot set the Authorization header on websockets",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/comments": {
+            "get": {
+                "description": "List the comment threads on an update",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "listUpdateComments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/comments/{comment_reference}": {
+            "post": {
+                "description": "Reply to a comment thread on an update. The investor is notified by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "replyUpdateComment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comment unique reference.. e.g update_comment_",
+                        "name": "comment_reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reply body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createUpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/duplicate": {
+            "post": {
+                "description": "Duplicate a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "duplicateUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.createdUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/links": {
+            "get": {
+                "description": "list the shareable links of an update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateLinksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a shareable link to the web version of a sent update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "update link request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createUpdateLinkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/links/{link_reference}": {
+            "delete": {
+                "description": "revoke a shareable link of an update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "link unique reference.. e.g link_",
+                        "name": "link_reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/pin": {
+            "post": {
+                "description": "Toggle pinned status a specific update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "toggleUpdatePin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.createdUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/preview": {
+            "post": {
+                "description": "Send preview of an update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "operationId": "previewUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body to create a workspace",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.previewUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/review": {
+            "post": {
+                "description": "submit an update for review. The approvers of the workspace are notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateReponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/revisions": {
+            "get": {
+                "description": "list the revisions of an update. The most recent comes first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateRevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/revisions/diff": {
+            "get": {
+                "description": "compare two revisions of an update block by block. The current content is used if to is not provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "revision to compare to",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.diffUpdateRevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/revisions/{revision_reference}/restore": {
+            "post": {
+                "description": "restore the content of an update to an older revision. A new revision is stored for the restore",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "revision unique reference.. e.g update_revision_",
+                        "name": "revision_reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateReponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/schedules": {
+            "get": {
+                "description": "list the send schedules of an update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateSchedulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/schedules/{schedule_reference}": {
+            "delete": {
+                "description": "cancel a scheduled update. The update becomes a draft again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schedule unique reference.. e.g schedule_",
+                        "name": "schedule_reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "change the time a scheduled update will be sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "reschedule request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.rescheduleUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schedule unique reference.. e.g schedule_",
+                        "name": "schedule_reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchUpdateScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/template": {
+            "post": {
+                "description": "save an update as a template of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "template request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.createWorkspaceTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.fetchWorkspaceTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/views": {
+            "get": {
+                "description": "list the web views of an update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page to query data from. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number to items to return. Defaults to 10 items",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.listUpdateViewerSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/updates/{reference}/visibility": {
+            "post": {
+                "description": "list or remove an update from the public page of the workspace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "updates"
+                ],
+                "parameters": [
+                    {
+                        "description": "update visibility request body",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.updateVisibilityRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "update unique reference.. e.g update_",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.createdUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIStatus"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "malak.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Permission"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ApprovalPreferences": {
+            "type": "object",
+            "properties": {
+                "approvers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "require_approval": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "malak.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/malak.AuditLogAction"
+                },
+                "actor_id": {
+                    "description": "ActorID is either the id of the user or the api key",
+                    "type": "string"
+                },
+                "actor_type": {
+                    "$ref": "#/definitions/malak.AuditLogActorType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_reference": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "$ref": "#/definitions/malak.EntityType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.AuditLogMetadata"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.AuditLogAction": {
+            "type": "string",
+            "enum": [
+                "api_key_created",
+                "api_key_revoked",
+                "deck_deleted",
+                "deck_preferences_updated",
+                "update_sent",
+                "dashboard_link_generated",
+                "dashboard_link_revoked",
+                "team_member_invited",
+                "team_member_role_updated",
+                "team_member_removed",
+                "webhook_created",
+                "webhook_updated",
+                "webhook_deleted",
+                "suppression_deleted",
+                "update_link_generated",
+                "update_link_revoked",
+                "update_visibility_updated",
+                "recurring_schedule_created",
+                "update_rescheduled",
+                "update_schedule_cancelled",
+                "workspace_template_created",
+                "workspace_template_updated",
+                "workspace_template_deleted",
+                "update_revision_restored",
+                "update_review_requested",
+                "update_approved",
+                "update_changes_requested",
+                "sending_domain_created",
+                "sending_domain_verified",
+                "sending_domain_deleted"
+            ],
+            "x-enum-varnames": [
+                "AuditLogActionApiKeyCreated",
+                "AuditLogActionApiKeyRevoked",
+                "AuditLogActionDeckDeleted",
+                "AuditLogActionDeckPreferencesUpdated",
+                "AuditLogActionUpdateSent",
+                "AuditLogActionDashboardLinkGenerated",
+                "AuditLogActionDashboardLinkRevoked",
+                "AuditLogActionTeamMemberInvited",
+                "AuditLogActionTeamMemberRoleUpdated",
+                "AuditLogActionTeamMemberRemoved",
+                "AuditLogActionWebhookCreated",
+                "AuditLogActionWebhookUpdated",
+                "AuditLogActionWebhookDeleted",
+                "AuditLogActionSuppressionDeleted",
+                "AuditLogActionUpdateLinkGenerated",
+                "AuditLogActionUpdateLinkRevoked",
+                "AuditLogActionUpdateVisibilityUpdated",
+                "AuditLogActionRecurringScheduleCreated",
+                "AuditLogActionUpdateRescheduled",
+                "AuditLogActionUpdateScheduleCancelled",
+                "AuditLogActionWorkspaceTemplateCreated",
+                "AuditLogActionWorkspaceTemplateUpdated",
+                "AuditLogActionWorkspaceTemplateDeleted",
+                "AuditLogActionUpdateRevisionRestored",
+                "AuditLogActionUpdateReviewRequested",
+                "AuditLogActionUpdateApproved",
+                "AuditLogActionUpdateChangesRequested",
+                "AuditLogActionSendingDomainCreated",
+                "AuditLogActionSendingDomainVerified",
+                "AuditLogActionSendingDomainDeleted"
+            ]
+        },
+        "malak.AuditLogActorType": {
+            "type": "string",
+            "enum": [
+                "user",
+                "api_key"
+            ],
+            "x-enum-varnames": [
+                "AuditLogActorTypeUser",
+                "AuditLogActorTypeApiKey"
+            ]
+        },
+        "malak.AuditLogMetadata": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "malak.BillingPreferences": {
+            "type": "object",
+            "properties": {
+                "finance_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.Block": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
+                },
+                "content": {},
+                "id": {
+                    "type": "string"
+                },
+                "props": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.BlockDiff": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "$ref": "#/definitions/malak.Block"
+                },
+                "before": {
+                    "$ref": "#/definitions/malak.Block"
+                },
+                "block_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/malak.BlockDiffStatus"
+                }
+            }
+        },
+        "malak.BlockDiffStatus": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "modified",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "BlockDiffStatusAdded",
+                "BlockDiffStatusRemoved",
+                "BlockDiffStatusModified",
+                "BlockDiffStatusUnchanged"
+            ]
+        },
+        "malak.CommunicationPreferences": {
+            "type": "object",
+            "properties": {
+                "enable_marketing": {
+                    "type": "boolean"
+                },
+                "enable_product_updates": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "malak.Contact": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Legacy lmao. should be address but migrations bit ugh :))",
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "User who added/created this contact",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.ContactListMapping"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.CustomContactMetadata"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "User who owns the contact.\nDoes not mean who added the contact but who chases\nor follows up officially with the contact",
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ContactList": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ContactListMapping": {
+            "type": "object",
+            "properties": {
+                "contact_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "list": {
+                    "$ref": "#/definitions/malak.ContactList"
+                },
+                "list_id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ContactListMappingWithContact": {
+            "type": "object",
+            "properties": {
+                "contact_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Contact fields",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "list_id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ContactOverview": {
+            "type": "object",
+            "properties": {
+                "total_contacts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.ContactShareItem": {
+            "type": "object",
+            "properties": {
+                "contact_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "item_reference": {
+                    "type": "string"
+                },
+                "item_type": {
+                    "$ref": "#/definitions/malak.ContactShareItemType"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "shared_at": {
+                    "type": "string"
+                },
+                "shared_by": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ContactShareItemType": {
+            "type": "string",
+            "enum": [
+                "update",
+                "dashboard",
+                "deck"
+            ],
+            "x-enum-varnames": [
+                "ContactShareItemTypeUpdate",
+                "ContactShareItemTypeDashboard",
+                "ContactShareItemTypeDeck"
+            ]
+        },
+        "malak.CustomContactMetadata": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "malak.DNSRecord": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.Dashboard": {
+            "type": "object",
+            "properties": {
+                "chart_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DashboardChart": {
+            "type": "object",
+            "properties": {
+                "chart": {
+                    "$ref": "#/definitions/malak.IntegrationChart"
+                },
+                "chart_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dashboard_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                },
+                "workspace_integration_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DashboardChartPosition": {
+            "type": "object",
+            "properties": {
+                "chart_id": {
+                    "type": "string"
+                },
+                "dashboard_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.DashboardLink": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
+                "contact_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dashboard_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link_type": {
+                    "$ref": "#/definitions/malak.DashboardLinkType"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DashboardLinkType": {
+            "type": "string",
+            "enum": [
+                "default",
+                "contact"
+            ],
+            "x-enum-varnames": [
+                "DashboardLinkTypeDefault",
+                "DashboardLinkTypeContact"
+            ]
+        },
+        "malak.Deck": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deck_size": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "is_pinned": {
+                    "type": "boolean"
+                },
+                "object_key": {
+                    "type": "string"
+                },
+                "preferences": {
+                    "$ref": "#/definitions/malak.DeckPreference"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "short_link": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DeckDailyEngagement": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "engagement_count": {
+                    "type": "integer"
+                },
+                "engagement_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DeckEngagementResponse": {
+            "type": "object",
+            "required": [
+                "daily_engagements",
+                "geographic_stats"
+            ],
+            "properties": {
+                "daily_engagements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.DeckDailyEngagement"
+                    }
+                },
+                "geographic_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.DeckGeographicStat"
+                    }
+                }
+            }
+        },
+        "malak.DeckGeographicStat": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "stat_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "view_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.DeckOverview": {
+            "type": "object",
+            "properties": {
+                "total_decks": {
+                    "type": "integer"
+                },
+                "total_viewer_sessions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.DeckPreference": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "enable_downloading": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "password": {
+                    "$ref": "#/definitions/malak.PasswordDeckPreferences"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "require_email": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.DeckViewerSession": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
+                "contact_id": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deck_id": {
+                    "type": "string"
+                },
+                "device_info": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "time_spent_seconds": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "viewed_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.EntityType": {
+            "type": "string",
+            "enum": [
+                "workspace",
+                "invoice",
+                "team",
+                "invite",
+                "contact",
+                "update",
+                "link",
+                "room",
+                "recipient",
+                "schedule",
+                "list",
+                "list_email",
+                "update_stat",
+                "recipient_stat",
+                "recipient_log",
+                "deck",
+                "deck_preference",
+                "contact_share",
+                "dashboard",
+                "plan",
+                "price",
+                "integration",
+                "workspace_integration",
+                "integration_datapoint",
+                "integration_chart",
+                "integration_sync_checkpoint",
+                "dashboard_chart",
+                "system_template",
+                "deck_daily_engagement",
+                "deck_analytic",
+                "deck_viewer_session",
+                "deck_geographic_stat",
+                "session",
+                "dashboard_link",
+                "dashboard_link_access_log",
+                "api_key",
+                "fundraising_pipeline",
+                "fundraising_pipeline_column",
+                "fundraising_pipeline_column_contact_document",
+                "fundraising_pipeline_column_contact",
+                "fundraising_pipeline_column_contact_activity",
+                "fundraising_pipeline_column_contact_deal",
+                "fundraising_pipeline_column_contact_position",
+                "webhook",
+                "webhook_delivery",
+                "suppression",
+                "update_comment",
+                "update_viewer_session",
+                "recurring_update_schedule",
+                "workspace_template",
+                "update_revision",
+                "update_approval",
+                "sending_domain"
+            ],
+            "x-enum-varnames": [
+                "EntityTypeWorkspace",
+                "EntityTypeInvoice",
+                "EntityTypeTeam",
+                "EntityTypeInvite",
+                "EntityTypeContact",
+                "EntityTypeUpdate",
+                "EntityTypeLink",
+                "EntityTypeRoom",
+                "EntityTypeRecipient",
+                "EntityTypeSchedule",
+                "EntityTypeList",
+                "EntityTypeListEmail",
+                "EntityTypeUpdateStat",
+                "EntityTypeRecipientStat",
+                "EntityTypeRecipientLog",
+                "EntityTypeDeck",
+                "EntityTypeDeckPreference",
+                "EntityTypeContactShare",
+                "EntityTypeDashboard",
+                "EntityTypePlan",
+                "EntityTypePrice",
+                "EntityTypeIntegration",
+                "EntityTypeWorkspaceIntegration",
+                "EntityTypeIntegrationDatapoint",
+                "EntityTypeIntegrationChart",
+                "EntityTypeIntegrationSyncCheckpoint",
+                "EntityTypeDashboardChart",
+                "EntityTypeSystemTemplate",
+                "EntityTypeDeckDailyEngagement",
+                "EntityTypeDeckAnalytic",
+                "EntityTypeDeckViewerSession",
+                "EntityTypeDeckGeographicStat",
+                "EntityTypeSession",
+                "EntityTypeDashboardLink",
+                "EntityTypeDashboardLinkAccessLog",
+                "EntityTypeApiKey",
+                "EntityTypeFundraisingPipeline",
+                "EntityTypeFundraisingPipelineColumn",
+                "EntityTypeFundraisingPipelineColumnContactDocument",
+                "EntityTypeFundraisingPipelineColumnContact",
+                "EntityTypeFundraisingPipelineColumnContactActivity",
+                "EntityTypeFundraisingPipelineColumnContactDeal",
+                "EntityTypeFundraisingPipelineColumnContactPosition",
+                "EntityTypeWebhook",
+                "EntityTypeWebhookDelivery",
+                "EntityTypeSuppression",
+                "EntityTypeUpdateComment",
+                "EntityTypeUpdateViewerSession",
+                "EntityTypeRecurringUpdateSchedule",
+                "EntityTypeWorkspaceTemplate",
+                "EntityTypeUpdateRevision",
+                "EntityTypeUpdateApproval",
+                "EntityTypeSendingDomain"
+            ]
+        },
+        "malak.FundingPipelineOverview": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.FundraiseContact": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
+                "contact_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deal_details": {
+                    "$ref": "#/definitions/malak.FundraiseContactDealDetails"
+                },
+                "fundraising_pipeline_column_id": {
+                    "type": "string"
+                },
+                "fundraising_pipeline_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.FundraiseContactDealDetails": {
+            "type": "object",
+            "properties": {
+                "can_lead_round": {
+                    "type": "boolean"
+                },
+                "check_size": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fundraising_pipeline_column_contact_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initial_contact": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.FundraiseContactPosition": {
+            "type": "object",
+            "properties": {
+                "fundraising_pipeline_column_contact_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_index": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.FundraisePipelineColumnType": {
+            "type": "string",
+            "enum": [
+                "normal",
+                "closed"
+            ],
+            "x-enum-varnames": [
+                "FundraisePipelineColumnTypeNormal",
+                "FundraisePipelineColumnTypeClosed"
+            ]
+        },
+        "malak.FundraisePipelineStage": {
+            "type": "string",
+            "enum": [
+                "family_and_friend",
+                "pre_seed",
+                "bridge_round",
+                "seed",
+                "series_a",
+                "series_b",
+                "series_c"
+            ],
+            "x-enum-varnames": [
+                "FundraisePipelineStageFamilyAndFriend",
+                "FundraisePipelineStagePreSeed",
+                "FundraisePipelineStageBridgeRound",
+                "FundraisePipelineStageSeed",
+                "FundraisePipelineStageSeriesA",
+                "FundraisePipelineStageSeriesB",
+                "FundraisePipelineStageSeriesC"
+            ]
+        },
+        "malak.FundraisingPipeline": {
+            "type": "object",
+            "properties": {
+                "closed_amount": {
+                    "description": "this is being updated dynamically by postgres triggers\nWe also use to calculate progress",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expected_close_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_closed": {
+                    "type": "boolean"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "stage": {
+                    "$ref": "#/definitions/malak.FundraisePipelineStage"
+                },
+                "start_date": {
+                    "description": "Can be in the future",
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.FundraisingPipelineColumn": {
+            "type": "object",
+            "properties": {
+                "column_type": {
+                    "$ref": "#/definitions/malak.FundraisePipelineColumnType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fundraising_pipeline_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "investors_count": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.Integration": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "integration_name": {
+                    "type": "string"
+                },
+                "integration_type": {
+                    "$ref": "#/definitions/malak.IntegrationType"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.IntegrationMetadata"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.IntegrationChart": {
+            "type": "object",
+            "properties": {
+                "chart_type": {
+                    "$ref": "#/definitions/malak.IntegrationChartType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data_point_type": {
+                    "$ref": "#/definitions/malak.IntegrationDataPointType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "internal_name": {
+                    "$ref": "#/definitions/malak.IntegrationChartInternalNameType"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.IntegrationChartMetadata"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_facing_name": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                },
+                "workspace_integration_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.IntegrationChartInternalNameType": {
+            "type": "string",
+            "enum": [
+                "mercury_account",
+                "mercury_account_transaction",
+                "brex_account",
+                "brex_account_transaction"
+            ],
+            "x-enum-varnames": [
+                "IntegrationChartInternalNameTypeMercuryAccount",
+                "IntegrationChartInternalNameTypeMercuryAccountTransaction",
+                "IntegrationChartInternalNameTypeBrexAccount",
+                "IntegrationChartInternalNameTypeBrexAccountTransaction"
+            ]
+        },
+        "malak.IntegrationChartMetadata": {
+            "type": "object",
+            "properties": {
+                "provider_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.IntegrationChartType": {
+            "type": "string",
+            "enum": [
+                "bar",
+                "pie"
+            ],
+            "x-enum-varnames": [
+                "IntegrationChartTypeBar",
+                "IntegrationChartTypePie"
+            ]
+        },
+        "malak.IntegrationDataPoint": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "integration_chart_id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.IntegrationDataPointMetadata"
+                },
+                "point_name": {
+                    "type": "string"
+                },
+                "point_value": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                },
+                "workspace_integration_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.IntegrationDataPointMetadata": {
+            "type": "object"
+        },
+        "malak.IntegrationDataPointType": {
+            "type": "string",
+            "enum": [
+                "currency",
+                "others"
+            ],
+            "x-enum-varnames": [
+                "IntegrationDataPointTypeCurrency",
+                "IntegrationDataPointTypeOthers"
+            ]
+        },
+        "malak.IntegrationMetadata": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.IntegrationType": {
+            "type": "string",
+            "enum": [
+                "oauth2",
+                "api_key",
+                "system"
+            ],
+            "x-enum-varnames": [
+                "IntegrationTypeOauth2",
+                "IntegrationTypeApiKey",
+                "IntegrationTypeSystem"
+            ]
+        },
+        "malak.PasswordDeckPreferences": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.Permission": {
+            "type": "string",
+            "enum": [
+                "workspace:read",
+                "workspace:update",
+                "billing:manage",
+                "preferences:read",
+                "preferences:update",
+                "integrations:read",
+                "integrations:manage",
+                "updates:read",
+                "updates:write",
+                "updates:send",
+                "contacts:read",
+                "contacts:write",
+                "decks:read",
+                "decks:write",
+                "dashboards:read",
+                "dashboards:write",
+                "pipelines:read",
+                "pipelines:write",
+                "api_keys:manage",
+                "webhooks:manage",
+                "team:read",
+                "team:manage",
+                "audit_logs:read",
+                "ingest:write"
+            ],
+            "x-enum-varnames": [
+                "PermissionWorkspaceRead",
+                "PermissionWorkspaceUpdate",
+                "PermissionBillingManage",
+                "PermissionPreferencesRead",
+                "PermissionPreferencesUpdate",
+                "PermissionIntegrationsRead",
+                "PermissionIntegrationsManage",
+                "PermissionUpdatesRead",
+                "PermissionUpdatesWrite",
+                "PermissionUpdatesSend",
+                "PermissionContactsRead",
+                "PermissionContactsWrite",
+                "PermissionDecksRead",
+                "PermissionDecksWrite",
+                "PermissionDashboardsRead",
+                "PermissionDashboardsWrite",
+                "PermissionPipelinesRead",
+                "PermissionPipelinesWrite",
+                "PermissionAPIKeysManage",
+                "PermissionWebhooksManage",
+                "PermissionTeamRead",
+                "PermissionTeamManage",
+                "PermissionAuditLogsRead",
+                "PermissionIngestWrite"
+            ]
+        },
+        "malak.Plan": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Defaults to zero",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "default_price_id": {
+                    "description": "Stripe default price id. Again not needed if not using Stripe",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "description": "IsDefault if this is the default plan for the user to get signed up to\non sign up\n\nBetter to keep this here than to use config",
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.PlanMetadata"
+                },
+                "plan_name": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "Can use a fake id really\nAs this only matters if you turn on Stripe",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.PlanMetadata": {
+            "type": "object",
+            "properties": {
+                "dashboard": {
+                    "type": "object",
+                    "properties": {
+                        "embed_dashboard": {
+                            "type": "boolean"
+                        },
+                        "max_charts_per_dashboard": {
+                            "type": "integer"
+                        },
+                        "share_dashboard_via_link": {
+                            "type": "boolean"
+                        }
+                    }
+                },
+                "data_room": {
+                    "type": "object",
+                    "properties": {
+                        "share_via_link": {
+                            "type": "boolean"
+                        },
+                        "size": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "deck": {
+                    "type": "object",
+                    "properties": {
+                        "analytics": {
+                            "type": "object",
+                            "properties": {
+                                "can_view_historical_sessions": {
+                                    "type": "boolean"
+                                }
+                            }
+                        },
+                        "auto_terminate_link": {
+                            "type": "boolean"
+                        },
+                        "custom_domain": {
+                            "type": "boolean"
+                        }
+                    }
+                },
+                "integrations": {
+                    "type": "object",
+                    "properties": {
+                        "available_for_use": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "team": {
+                    "type": "object",
+                    "properties": {
+                        "size": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "updates": {
+                    "type": "object",
+                    "properties": {
+                        "custom_domain": {
+                            "type": "boolean"
+                        },
+                        "max_recipients": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "malak.Preference": {
+            "type": "object",
             "properties": {
-                "city": {
-                    "description": "Legacy lmao. should be address but migrations bit ugh :))",
+                "approval": {
+                    "$ref": "#/definitions/malak.ApprovalPreferences"
+                },
+                "billing": {
+                    "$ref": "#/definitions/malak.BillingPreferences"
+                },
+                "communication": {
+                    "$ref": "#/definitions/malak.CommunicationPreferences"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "company": {
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.PublicDeck": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
-                    "description": "User who added/created this contact",
+                "deck_size": {
+                    "type": "integer"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "object_link": {
                     "type": "string"
                 },
-                "email": {
+                "preferences": {
+                    "$ref": "#/definitions/malak.PublicDeckPreference"
+                },
+                "reference": {
                     "type": "string"
                 },
-                "first_name": {
+                "session": {
+                    "$ref": "#/definitions/malak.DeckViewerSession"
+                },
+                "short_link": {
                     "type": "string"
                 },
-                "id": {
+                "title": {
                     "type": "string"
                 },
-                "last_name": {
+                "updated_at": {
                     "type": "string"
                 },
-                "lists": {
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.PublicDeckPreference": {
+            "type": "object",
+            "properties": {
+                "enable_downloading": {
+                    "type": "boolean"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "require_email": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "malak.PublicUpdate": {
+            "type": "object",
+            "required": [
+                "reference",
+                "title",
+                "workspace_name"
+            ],
+            "properties": {
+                "html": {
+                    "description": "HTML is the rendered content of the update",
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "workspace_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.ReactionStatus": {
+            "type": "string",
+            "enum": [
+                "thumbs up",
+                "thumbs down"
+            ],
+            "x-enum-varnames": [
+                "ReactionStatusThumbsUp",
+                "ReactionStatusThumbsDown"
+            ]
+        },
+        "malak.RecipientStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed",
+                "skipped"
+            ],
+            "x-enum-varnames": [
+                "RecipientStatusPending",
+                "RecipientStatusSent",
+                "RecipientStatusFailed",
+                "RecipientStatusSkipped"
+            ]
+        },
+        "malak.RecurrenceFrequency": {
+            "type": "string",
+            "enum": [
+                "monthly",
+                "quarterly"
+            ],
+            "x-enum-varnames": [
+                "RecurrenceFrequencyMonthly",
+                "RecurrenceFrequencyQuarterly"
+            ]
+        },
+        "malak.RecurringUpdateSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "draft_update_id": {
+                    "description": "DraftUpdateID is the draft created for the upcoming occurrence. It is\nempty until the draft has been created",
+                    "type": "string"
+                },
+                "emails": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.ContactListMapping"
+                        "type": "string"
                     }
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.CustomContactMetadata"
+                "frequency": {
+                    "$ref": "#/definitions/malak.RecurrenceFrequency"
                 },
-                "notes": {
-                    "type": "string"
+                "hour": {
+                    "description": "Hour of the day in UTC",
+                    "type": "integer"
                 },
-                "owner_id": {
-                    "description": "User who owns the contact.\nDoes not mean who added the contact but who chases\nor follows up officially with the contact",
+                "id": {
                     "type": "string"
                 },
-                "phone": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_system_template": {
+                    "type": "boolean"
+                },
+                "lead_days": {
+                    "type": "integer"
+                },
+                "next_send_at": {
                     "type": "string"
                 },
                 "reference": {
                     "type": "string"
                 },
+                "template_reference": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -4634,161 +9953,160 @@ const docTemplate = `{
                 }
             }
         },
-        "malak.ContactList": {
+        "malak.ResolvedMetrics": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "malak.RevocationType": {
+            "type": "string",
+            "enum": [
+                "immediate",
+                "day",
+                "week"
+            ],
+            "x-enum-varnames": [
+                "RevocationTypeImmediate",
+                "RevocationTypeDay",
+                "RevocationTypeWeek"
+            ]
+        },
+        "malak.Role": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "member",
+                "billing",
+                "investor",
+                "guest"
+            ],
+            "x-enum-varnames": [
+                "RoleAdmin",
+                "RoleMember",
+                "RoleBilling",
+                "RoleInvestor",
+                "RoleGuest"
+            ]
+        },
+        "malak.SendingDomain": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
+                "domain": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "reference": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "workspace_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.ContactListMapping": {
-            "type": "object",
-            "properties": {
-                "contact_id": {
+                "last_checked_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "public_key": {
                     "type": "string"
                 },
-                "created_by": {
+                "reference": {
                     "type": "string"
                 },
-                "id": {
+                "selector": {
                     "type": "string"
                 },
-                "list": {
-                    "$ref": "#/definitions/malak.ContactList"
-                },
-                "list_id": {
+                "sender": {
                     "type": "string"
                 },
-                "reference": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/malak.SendingDomainStatus"
                 },
                 "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "malak.ContactListMappingWithContact": {
-            "type": "object",
-            "properties": {
-                "contact_id": {
-                    "type": "string"
-                },
-                "email": {
-                    "description": "Contact fields",
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
                 },
-                "list_id": {
+                "verified_at": {
                     "type": "string"
                 },
-                "reference": {
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "malak.ContactOverview": {
+        "malak.SendingDomainStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "verified",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SendingDomainStatusPending",
+                "SendingDomainStatusVerified",
+                "SendingDomainStatusFailed"
+            ]
+        },
+        "malak.ShareOverview": {
             "type": "object",
             "properties": {
-                "total_contacts": {
-                    "type": "integer"
+                "recent_shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.ContactShareItem"
+                    }
                 }
             }
         },
-        "malak.ContactShareItem": {
+        "malak.Suppression": {
             "type": "object",
             "properties": {
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
                 "contact_id": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "item_id": {
-                    "type": "string"
-                },
-                "item_reference": {
-                    "type": "string"
-                },
-                "item_type": {
-                    "$ref": "#/definitions/malak.ContactShareItemType"
+                "provider": {
+                    "$ref": "#/definitions/malak.UpdateRecipientLogProvider"
                 },
-                "last_name": {
-                    "type": "string"
+                "reason": {
+                    "$ref": "#/definitions/malak.SuppressionReason"
                 },
                 "reference": {
                     "type": "string"
                 },
-                "shared_at": {
-                    "type": "string"
-                },
-                "shared_by": {
-                    "type": "string"
-                },
-                "title": {
+                "updated_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "malak.ContactShareItemType": {
+        "malak.SuppressionReason": {
             "type": "string",
             "enum": [
-                "update",
-                "dashboard",
-                "deck"
+                "bounced",
+                "complained",
+                "unsubscribed"
             ],
             "x-enum-varnames": [
-                "ContactShareItemTypeUpdate",
-                "ContactShareItemTypeDashboard",
-                "ContactShareItemTypeDeck"
+                "SuppressionReasonBounced",
+                "SuppressionReasonComplained",
+                "SuppressionReasonUnsubscribed"
             ]
         },
-        "malak.CustomContactMetadata": {
-            "type": "object",
-            "additionalProperties": {
-                "type": "string"
-            }
-        },
-        "malak.Dashboard": {
+        "malak.SystemTemplate": {
             "type": "object",
             "properties": {
-                "chart_count": {
-                    "type": "integer"
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
                 },
                 "created_at": {
                     "type": "string"
@@ -4799,6 +10117,9 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "number_of_uses": {
+                    "type": "integer"
+                },
                 "reference": {
                     "type": "string"
                 },
@@ -4807,173 +10128,165 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
-                },
-                "workspace_id": {
-                    "type": "string"
                 }
             }
         },
-        "malak.DashboardChart": {
+        "malak.Update": {
             "type": "object",
             "properties": {
-                "chart": {
-                    "$ref": "#/definitions/malak.IntegrationChart"
+                "approval_status": {
+                    "description": "ApprovalStatus is only enforced when the workspace requires updates\nto be approved before they are sent",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/malak.UpdateApprovalStatus"
+                        }
+                    ]
                 },
-                "chart_id": {
-                    "type": "string"
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "dashboard_id": {
+                "created_by": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "is_pinned": {
+                    "description": "If this update is pinned",
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "description": "IsPublic lists the update on the public page of the workspace",
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/malak.UpdateMetadata"
+                },
                 "reference": {
                     "type": "string"
                 },
-                "updated_at": {
+                "sent_at": {
                     "type": "string"
                 },
-                "workspace_id": {
+                "sent_by": {
                     "type": "string"
                 },
-                "workspace_integration_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.DashboardChartPosition": {
-            "type": "object",
-            "properties": {
-                "chart_id": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/malak.UpdateStatus"
                 },
-                "dashboard_id": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
+                "updated_at": {
                     "type": "string"
                 },
-                "order_index": {
-                    "type": "integer"
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "malak.DashboardLink": {
+        "malak.UpdateApproval": {
             "type": "object",
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
-                },
-                "contact_id": {
+                "approver_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "comment": {
                     "type": "string"
                 },
-                "dashboard_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "expires_at": {
-                    "type": "string"
+                "decision": {
+                    "$ref": "#/definitions/malak.UpdateApprovalDecision"
                 },
                 "id": {
                     "type": "string"
                 },
-                "link_type": {
-                    "$ref": "#/definitions/malak.DashboardLinkType"
-                },
                 "reference": {
                     "type": "string"
                 },
-                "token": {
+                "update_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "malak.DashboardLinkType": {
+        "malak.UpdateApprovalDecision": {
             "type": "string",
             "enum": [
-                "default",
-                "contact"
+                "approved",
+                "changes_requested"
             ],
             "x-enum-varnames": [
-                "DashboardLinkTypeDefault",
-                "DashboardLinkTypeContact"
+                "UpdateApprovalDecisionApproved",
+                "UpdateApprovalDecisionChangesRequested"
             ]
         },
-        "malak.Deck": {
+        "malak.UpdateApprovalStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "in_review",
+                "approved"
+            ],
+            "x-enum-varnames": [
+                "UpdateApprovalStatusDraft",
+                "UpdateApprovalStatusInReview",
+                "UpdateApprovalStatusApproved"
+            ]
+        },
+        "malak.UpdateComment": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "deck_size": {
-                    "type": "integer"
-                },
-                "id": {
+                "author_name": {
+                    "description": "AuthorName is copied from the contact or team member when the\ncomment is made so the public view does not need either of them",
                     "type": "string"
                 },
-                "is_archived": {
-                    "type": "boolean"
-                },
-                "is_pinned": {
-                    "type": "boolean"
+                "author_type": {
+                    "$ref": "#/definitions/malak.UpdateCommentAuthor"
                 },
-                "object_key": {
+                "content": {
                     "type": "string"
                 },
-                "preferences": {
-                    "$ref": "#/definitions/malak.DeckPreference"
-                },
-                "reference": {
+                "created_at": {
                     "type": "string"
                 },
-                "short_link": {
+                "id": {
                     "type": "string"
                 },
-                "title": {
+                "parent_id": {
+                    "description": "ParentID is empty for the comment that starts a thread",
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "recipient": {
+                    "$ref": "#/definitions/malak.UpdateRecipient"
                 },
-                "workspace_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.DeckDailyEngagement": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                "recipient_id": {
                     "type": "string"
                 },
-                "deck_id": {
+                "reference": {
                     "type": "string"
                 },
-                "engagement_count": {
-                    "type": "integer"
-                },
-                "engagement_date": {
+                "update_id": {
                     "type": "string"
                 },
-                "id": {
+                "updated_at": {
                     "type": "string"
                 },
-                "reference": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/malak.User"
                 },
-                "updated_at": {
+                "user_id": {
+                    "description": "UserID is only set on replies from the team",
                     "type": "string"
                 },
                 "workspace_id": {
@@ -4981,350 +10294,450 @@ const docTemplate = `{
                 }
             }
         },
-        "malak.DeckEngagementResponse": {
+        "malak.UpdateCommentAuthor": {
+            "type": "string",
+            "enum": [
+                "investor",
+                "team"
+            ],
+            "x-enum-varnames": [
+                "UpdateCommentAuthorInvestor",
+                "UpdateCommentAuthorTeam"
+            ]
+        },
+        "malak.UpdateCommentThread": {
             "type": "object",
             "required": [
-                "daily_engagements",
-                "geographic_stats"
+                "comment",
+                "replies"
             ],
             "properties": {
-                "daily_engagements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.DeckDailyEngagement"
-                    }
+                "comment": {
+                    "$ref": "#/definitions/malak.UpdateComment"
                 },
-                "geographic_stats": {
+                "replies": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.DeckGeographicStat"
+                        "$ref": "#/definitions/malak.UpdateComment"
                     }
                 }
             }
         },
-        "malak.DeckGeographicStat": {
+        "malak.UpdateLink": {
             "type": "object",
             "properties": {
-                "country": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "created_by": {
                     "type": "string"
                 },
-                "deck_id": {
+                "expires_at": {
+                    "description": "Sometimes, you want to share a link containing a specific update\nfor a few minutes or seconds :)",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "is_password_protected": {
+                    "type": "boolean"
+                },
                 "reference": {
                     "type": "string"
                 },
-                "stat_date": {
+                "update_id": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "view_count": {
-                    "type": "integer"
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "malak.DeckOverview": {
+        "malak.UpdateLinkStat": {
             "type": "object",
+            "required": [
+                "total_clicks",
+                "unique_clicks",
+                "url"
+            ],
             "properties": {
-                "total_decks": {
+                "total_clicks": {
                     "type": "integer"
                 },
-                "total_viewer_sessions": {
+                "unique_clicks": {
                     "type": "integer"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "malak.DeckPreference": {
+        "malak.UpdateMetadata": {
+            "type": "object"
+        },
+        "malak.UpdateOverview": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "last_updates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Update"
+                    }
                 },
-                "created_by": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "malak.UpdateRecipient": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
                 },
-                "deck_id": {
+                "contact_id": {
                     "type": "string"
                 },
-                "enable_downloading": {
-                    "type": "boolean"
-                },
-                "expires_at": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "password": {
-                    "$ref": "#/definitions/malak.PasswordDeckPreferences"
-                },
                 "reference": {
                     "type": "string"
                 },
-                "require_email": {
-                    "type": "boolean"
+                "schedule_id": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "status": {
+                    "$ref": "#/definitions/malak.RecipientStatus"
+                },
+                "update_id": {
                     "type": "string"
                 },
-                "workspace_id": {
+                "update_recipient_stat": {
+                    "$ref": "#/definitions/malak.UpdateRecipientStat"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "malak.DeckViewerSession": {
+        "malak.UpdateRecipientLogProvider": {
+            "type": "string",
+            "enum": [
+                "resend",
+                "sendgrid",
+                "smtp",
+                "ses",
+                "postmark"
+            ],
+            "x-enum-varnames": [
+                "UpdateRecipientLogProviderResend",
+                "UpdateRecipientLogProviderSendgrid",
+                "UpdateRecipientLogProviderSmtp",
+                "UpdateRecipientLogProviderSes",
+                "UpdateRecipientLogProviderPostmark"
+            ]
+        },
+        "malak.UpdateRecipientStat": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "type": "string"
-                },
-                "city": {
+                "created_at": {
                     "type": "string"
                 },
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
+                "has_reaction": {
+                    "type": "boolean"
                 },
-                "contact_id": {
+                "id": {
                     "type": "string"
                 },
-                "country": {
-                    "type": "string"
+                "is_bounced": {
+                    "type": "boolean"
                 },
-                "created_at": {
-                    "type": "string"
+                "is_complained": {
+                    "type": "boolean"
                 },
-                "deck_id": {
-                    "type": "string"
+                "is_delivered": {
+                    "type": "boolean"
                 },
-                "device_info": {
+                "last_opened_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "reaction": {
+                    "$ref": "#/definitions/malak.ReactionStatus"
                 },
-                "ip_address": {
-                    "type": "string"
+                "recipient": {
+                    "$ref": "#/definitions/malak.UpdateRecipient"
                 },
-                "os": {
+                "recipient_id": {
                     "type": "string"
                 },
                 "reference": {
                     "type": "string"
                 },
-                "session_id": {
-                    "type": "string"
-                },
-                "time_spent_seconds": {
+                "total_clicks": {
                     "type": "integer"
                 },
                 "updated_at": {
                     "type": "string"
-                },
-                "viewed_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.FundingPipelineOverview": {
-            "type": "object",
-            "properties": {
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "malak.FundraiseContact": {
+        "malak.UpdateRevision": {
             "type": "object",
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
                 },
-                "contact_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "created_by": {
                     "type": "string"
                 },
-                "deal_details": {
-                    "$ref": "#/definitions/malak.FundraiseContactDealDetails"
+                "id": {
+                    "type": "string"
                 },
-                "fundraising_pipeline_column_id": {
+                "is_sent": {
+                    "description": "IsSent marks the exact version sent to investors. ScheduleID is the\nlive schedule it was sent with",
+                    "type": "boolean"
+                },
+                "metrics": {
+                    "description": "Metrics is the text of the inline metrics when the update was sent",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/malak.ResolvedMetrics"
+                        }
+                    ]
+                },
+                "reference": {
                     "type": "string"
                 },
-                "fundraising_pipeline_id": {
+                "restored_from": {
+                    "description": "RestoredFrom is the revision this one was restored from",
                     "type": "string"
                 },
-                "id": {
+                "schedule_id": {
                     "type": "string"
                 },
-                "reference": {
+                "title": {
                     "type": "string"
                 },
-                "updated_at": {
+                "update_id": {
+                    "type": "string"
+                },
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "malak.FundraiseContactDealDetails": {
+        "malak.UpdateSchedule": {
             "type": "object",
             "properties": {
-                "can_lead_round": {
-                    "type": "boolean"
-                },
-                "check_size": {
-                    "type": "integer"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "fundraising_pipeline_column_contact_id": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "reference": {
                     "type": "string"
                 },
-                "initial_contact": {
+                "scheduled_by": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "integer"
+                "send_at": {
+                    "description": "Time to send this update at?",
+                    "type": "string"
                 },
-                "reference": {
+                "status": {
+                    "$ref": "#/definitions/malak.UpdateSendSchedule"
+                },
+                "update_id": {
                     "type": "string"
                 },
+                "update_type": {
+                    "$ref": "#/definitions/malak.UpdateType"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "malak.FundraiseContactPosition": {
+        "malak.UpdateSendSchedule": {
+            "type": "string",
+            "enum": [
+                "scheduled",
+                "cancelled",
+                "sent",
+                "failed",
+                "processing"
+            ],
+            "x-enum-varnames": [
+                "UpdateSendScheduleScheduled",
+                "UpdateSendScheduleCancelled",
+                "UpdateSendScheduleSent",
+                "UpdateSendScheduleFailed",
+                "UpdateSendScheduleProcessing"
+            ]
+        },
+        "malak.UpdateStat": {
             "type": "object",
             "properties": {
-                "fundraising_pipeline_column_contact_id": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "order_index": {
+                "reference": {
+                    "type": "string"
+                },
+                "total_clicks": {
                     "type": "integer"
                 },
-                "reference": {
+                "total_opens": {
+                    "type": "integer"
+                },
+                "total_reactions": {
+                    "type": "integer"
+                },
+                "total_sent": {
+                    "type": "integer"
+                },
+                "unique_opens": {
+                    "type": "integer"
+                },
+                "update_id": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "malak.FundraisePipelineColumnType": {
+        "malak.UpdateStatus": {
             "type": "string",
             "enum": [
-                "normal",
-                "closed"
+                "draft",
+                "sent"
             ],
             "x-enum-varnames": [
-                "FundraisePipelineColumnTypeNormal",
-                "FundraisePipelineColumnTypeClosed"
+                "UpdateStatusDraft",
+                "UpdateStatusSent"
             ]
         },
-        "malak.FundraisePipelineStage": {
+        "malak.UpdateType": {
             "type": "string",
             "enum": [
-                "family_and_friend",
-                "pre_seed",
-                "bridge_round",
-                "seed",
-                "series_a",
-                "series_b",
-                "series_c"
+                "preview",
+                "live"
+            ],
+            "x-enum-varnames": [
+                "UpdateTypePreview",
+                "UpdateTypeLive"
+            ]
+        },
+        "malak.UpdateViewSource": {
+            "type": "string",
+            "enum": [
+                "link",
+                "workspace_page"
             ],
             "x-enum-varnames": [
-                "FundraisePipelineStageFamilyAndFriend",
-                "FundraisePipelineStagePreSeed",
-                "FundraisePipelineStageBridgeRound",
-                "FundraisePipelineStageSeed",
-                "FundraisePipelineStageSeriesA",
-                "FundraisePipelineStageSeriesB",
-                "FundraisePipelineStageSeriesC"
+                "UpdateViewSourceLink",
+                "UpdateViewSourceWorkspacePage"
             ]
         },
-        "malak.FundraisingPipeline": {
+        "malak.UpdateViewerSession": {
             "type": "object",
             "properties": {
-                "closed_amount": {
-                    "description": "this is being updated dynamically by postgres triggers\nWe also use to calculate progress",
-                    "type": "integer"
+                "browser": {
+                    "type": "string"
                 },
-                "created_at": {
+                "city": {
                     "type": "string"
                 },
-                "description": {
+                "country": {
                     "type": "string"
                 },
-                "expected_close_date": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_info": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_closed": {
-                    "type": "boolean"
+                "ip_address": {
+                    "type": "string"
                 },
-                "reference": {
+                "link_id": {
                     "type": "string"
                 },
-                "stage": {
-                    "$ref": "#/definitions/malak.FundraisePipelineStage"
+                "os": {
+                    "type": "string"
                 },
-                "start_date": {
-                    "description": "Can be in the future",
+                "reference": {
                     "type": "string"
                 },
-                "target_amount": {
+                "session_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/malak.UpdateViewSource"
+                },
+                "time_spent_seconds": {
                     "type": "integer"
                 },
-                "title": {
+                "update_id": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "workspace_id": {
+                "viewed_at": {
                     "type": "string"
                 }
             }
         },
-        "malak.FundraisingPipelineColumn": {
+        "malak.User": {
             "type": "object",
             "properties": {
-                "column_type": {
-                    "$ref": "#/definitions/malak.FundraisePipelineColumnType"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "email": {
                     "type": "string"
                 },
-                "fundraising_pipeline_id": {
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "full_name": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "investors_count": {
-                    "type": "integer"
+                "metadata": {
+                    "$ref": "#/definitions/malak.UserMetadata"
                 },
-                "reference": {
-                    "type": "string"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UserRole"
+                    }
                 },
-                "title": {
+                "two_factor_enabled_at": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -5332,114 +10745,163 @@ const docTemplate = `{
                 }
             }
         },
-        "malak.Integration": {
+        "malak.UserMetadata": {
+            "type": "object",
+            "properties": {
+                "current_workspace": {
+                    "description": "Used to keep track of the last used workspace\nIn the instance of multiple workspaces\nSo when next the user logs in, we remember and take them to the\nright place rather than always a list of all their workspaces and they\nhave to select one",
+                    "type": "string"
+                }
+            }
+        },
+        "malak.UserRole": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "role": {
+                    "$ref": "#/definitions/malak.Role"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "integration_name": {
+                "user": {
+                    "description": "Only loaded when listing the members of a workspace",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/malak.User"
+                        }
+                    ]
+                },
+                "user_id": {
                     "type": "string"
                 },
-                "integration_type": {
-                    "$ref": "#/definitions/malak.IntegrationType"
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "malak.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "created_by": {
+                    "type": "string"
                 },
-                "logo_url": {
+                "description": {
                     "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.IntegrationMetadata"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.WebhookEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
                 },
                 "reference": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "malak.IntegrationChart": {
+        "malak.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "chart_type": {
-                    "$ref": "#/definitions/malak.IntegrationChartType"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "data_point_type": {
-                    "$ref": "#/definitions/malak.IntegrationDataPointType"
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/malak.WebhookEvent"
                 },
                 "id": {
                     "type": "string"
                 },
-                "internal_name": {
-                    "$ref": "#/definitions/malak.IntegrationChartInternalNameType"
+                "last_error": {
+                    "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.IntegrationChartMetadata"
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
                 },
                 "reference": {
                     "type": "string"
                 },
-                "updated_at": {
+                "response_body": {
                     "type": "string"
                 },
-                "user_facing_name": {
+                "response_status_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/malak.WebhookDeliveryStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "workspace_id": {
+                "webhook_id": {
                     "type": "string"
                 },
-                "workspace_integration_id": {
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "malak.IntegrationChartInternalNameType": {
+        "malak.WebhookDeliveryStatus": {
             "type": "string",
             "enum": [
-                "mercury_account",
-                "mercury_account_transaction",
-                "brex_account",
-                "brex_account_transaction"
+                "pending",
+                "succeeded",
+                "failed"
             ],
             "x-enum-varnames": [
-                "IntegrationChartInternalNameTypeMercuryAccount",
-                "IntegrationChartInternalNameTypeMercuryAccountTransaction",
-                "IntegrationChartInternalNameTypeBrexAccount",
-                "IntegrationChartInternalNameTypeBrexAccountTransaction"
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusSucceeded",
+                "WebhookDeliveryStatusFailed"
             ]
         },
-        "malak.IntegrationChartMetadata": {
-            "type": "object",
-            "properties": {
-                "provider_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "malak.IntegrationChartType": {
+        "malak.WebhookEvent": {
             "type": "string",
             "enum": [
-                "bar",
-                "pie"
+                "update_opened",
+                "deck_viewer_session_created",
+                "fundraising_contact_moved",
+                "integration_sync_failed"
             ],
             "x-enum-varnames": [
-                "IntegrationChartTypeBar",
-                "IntegrationChartTypePie"
+                "WebhookEventUpdateOpened",
+                "WebhookEventDeckViewerSessionCreated",
+                "WebhookEventFundraisingContactMoved",
+                "WebhookEventIntegrationSyncFailed"
             ]
         },
-        "malak.IntegrationDataPoint": {
+        "malak.Workspace": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -5448,238 +10910,130 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "integration_chart_id": {
+                "is_banned": {
+                    "type": "boolean"
+                },
+                "is_subscription_active": {
+                    "type": "boolean"
+                },
+                "logo_url": {
                     "type": "string"
                 },
                 "metadata": {
-                    "$ref": "#/definitions/malak.IntegrationDataPointMetadata"
+                    "$ref": "#/definitions/malak.WorkspaceMetadata"
                 },
-                "point_name": {
-                    "type": "string"
+                "plan": {
+                    "$ref": "#/definitions/malak.Plan"
                 },
-                "point_value": {
-                    "type": "integer"
+                "plan_id": {
+                    "type": "string"
                 },
                 "reference": {
                     "type": "string"
                 },
-                "updated_at": {
+                "stripe_customer_id": {
+                    "description": "Not required\nDummy values work really if not using stripe",
                     "type": "string"
                 },
-                "workspace_id": {
+                "subscription_id": {
                     "type": "string"
                 },
-                "workspace_integration_id": {
+                "timezone": {
                     "type": "string"
-                }
-            }
-        },
-        "malak.IntegrationDataPointMetadata": {
-            "type": "object"
-        },
-        "malak.IntegrationDataPointType": {
-            "type": "string",
-            "enum": [
-                "currency",
-                "others"
-            ],
-            "x-enum-varnames": [
-                "IntegrationDataPointTypeCurrency",
-                "IntegrationDataPointTypeOthers"
-            ]
-        },
-        "malak.IntegrationMetadata": {
-            "type": "object",
-            "properties": {
-                "endpoint": {
+                },
+                "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "malak.IntegrationType": {
-            "type": "string",
-            "enum": [
-                "oauth2",
-                "api_key",
-                "system"
-            ],
-            "x-enum-varnames": [
-                "IntegrationTypeOauth2",
-                "IntegrationTypeApiKey",
-                "IntegrationTypeSystem"
-            ]
-        },
-        "malak.PasswordDeckPreferences": {
-            "type": "object",
-            "properties": {
-                "enabled": {
-                    "type": "boolean"
                 },
-                "password": {
+                "website": {
+                    "type": "string"
+                },
+                "workspace_name": {
                     "type": "string"
                 }
             }
         },
-        "malak.Plan": {
+        "malak.WorkspaceIntegration": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "description": "Defaults to zero",
-                    "type": "integer"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "default_price_id": {
-                    "description": "Stripe default price id. Again not needed if not using Stripe",
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "integration": {
+                    "$ref": "#/definitions/malak.Integration"
+                },
+                "integration_id": {
                     "type": "string"
                 },
-                "is_default": {
-                    "description": "IsDefault if this is the default plan for the user to get signed up to\non sign up\n\nBetter to keep this here than to use config",
+                "is_active": {
+                    "description": "IsActive determines if the connection to the integration has been tested and works",
                     "type": "boolean"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.PlanMetadata"
+                "is_enabled": {
+                    "description": "IsEnabled - this integration is enabled and data can be fetched",
+                    "type": "boolean"
                 },
-                "plan_name": {
-                    "type": "string"
+                "metadata": {
+                    "$ref": "#/definitions/malak.WorkspaceIntegrationMetadata"
                 },
                 "reference": {
-                    "description": "Can use a fake id really\nAs this only matters if you turn on Stripe",
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "malak.PlanMetadata": {
+        "malak.WorkspaceIntegrationMetadata": {
             "type": "object",
             "properties": {
-                "dashboard": {
-                    "type": "object",
-                    "properties": {
-                        "embed_dashboard": {
-                            "type": "boolean"
-                        },
-                        "max_charts_per_dashboard": {
-                            "type": "integer"
-                        },
-                        "share_dashboard_via_link": {
-                            "type": "boolean"
-                        }
-                    }
-                },
-                "data_room": {
-                    "type": "object",
-                    "properties": {
-                        "share_via_link": {
-                            "type": "boolean"
-                        },
-                        "size": {
-                            "type": "integer"
-                        }
-                    }
-                },
-                "deck": {
-                    "type": "object",
-                    "properties": {
-                        "analytics": {
-                            "type": "object",
-                            "properties": {
-                                "can_view_historical_sessions": {
-                                    "type": "boolean"
-                                }
-                            }
-                        },
-                        "auto_terminate_link": {
-                            "type": "boolean"
-                        },
-                        "custom_domain": {
-                            "type": "boolean"
-                        }
-                    }
-                },
-                "integrations": {
-                    "type": "object",
-                    "properties": {
-                        "available_for_use": {
-                            "type": "integer"
-                        }
-                    }
-                },
-                "team": {
-                    "type": "object",
-                    "properties": {
-                        "size": {
-                            "type": "integer"
-                        }
-                    }
+                "access_token": {
+                    "type": "string"
                 },
-                "updates": {
-                    "type": "object",
-                    "properties": {
-                        "custom_domain": {
-                            "type": "boolean"
-                        },
-                        "max_recipients": {
-                            "type": "integer"
-                        }
-                    }
+                "last_fetched_at": {
+                    "type": "string"
                 }
             }
         },
-        "malak.Preference": {
+        "malak.WorkspaceMetadata": {
             "type": "object",
             "properties": {
-                "billing": {
-                    "$ref": "#/definitions/malak.BillingPreferences"
-                },
-                "communication": {
-                    "$ref": "#/definitions/malak.CommunicationPreferences"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "workspace_id": {
-                    "type": "string"
+                "require_two_factor": {
+                    "description": "RequireTwoFactor blocks members without 2FA from accessing the\nworkspace until they set it up",
+                    "type": "boolean"
                 }
             }
         },
-        "malak.PublicDeck": {
+        "malak.WorkspaceTemplate": {
             "type": "object",
             "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "deck_size": {
-                    "type": "integer"
-                },
-                "is_archived": {
-                    "type": "boolean"
-                },
-                "object_link": {
+                "created_by": {
                     "type": "string"
                 },
-                "preferences": {
-                    "$ref": "#/definitions/malak.PublicDeckPreference"
+                "description": {
+                    "type": "string"
                 },
-                "reference": {
+                "id": {
                     "type": "string"
                 },
-                "session": {
-                    "$ref": "#/definitions/malak.DeckViewerSession"
+                "number_of_uses": {
+                    "type": "integer"
                 },
-                "short_link": {
+                "reference": {
                     "type": "string"
                 },
                 "title": {
@@ -5693,1067 +11047,1353 @@ const docTemplate = `{
                 }
             }
         },
-        "malak.PublicDeckPreference": {
+        "server.APIStatus": {
             "type": "object",
+            "required": [
+                "message"
+            ],
             "properties": {
-                "enable_downloading": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.addChartToDashboardRequest": {
+            "type": "object",
+            "required": [
+                "chart_reference"
+            ],
+            "properties": {
+                "chart_reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.addContactRequest": {
+            "type": "object",
+            "required": [
+                "check_size",
+                "contact_reference",
+                "initial_contact",
+                "rating"
+            ],
+            "properties": {
+                "can_lead_round": {
                     "type": "boolean"
                 },
-                "has_password": {
-                    "type": "boolean"
+                "check_size": {
+                    "type": "integer"
                 },
-                "require_email": {
-                    "type": "boolean"
+                "contact_reference": {
+                    "type": "string"
+                },
+                "initial_contact": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
                 }
             }
         },
-        "malak.RecipientStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "sent",
-                "failed"
-            ],
-            "x-enum-varnames": [
-                "RecipientStatusPending",
-                "RecipientStatusSent",
-                "RecipientStatusFailed"
-            ]
+        "server.addContactToListRequest": {
+            "type": "object",
+            "properties": {
+                "reference": {
+                    "type": "string"
+                }
+            }
         },
-        "malak.RevocationType": {
-            "type": "string",
-            "enum": [
-                "immediate",
-                "day",
-                "week"
+        "server.addDataPointRequest": {
+            "type": "object",
+            "required": [
+                "value"
             ],
-            "x-enum-varnames": [
-                "RevocationTypeImmediate",
-                "RevocationTypeDay",
-                "RevocationTypeWeek"
-            ]
+            "properties": {
+                "value": {
+                    "type": "integer"
+                }
+            }
         },
-        "malak.Role": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "member",
-                "billing",
-                "investor",
-                "guest"
+        "server.authenticateUserRequest": {
+            "type": "object",
+            "required": [
+                "code"
             ],
-            "x-enum-varnames": [
-                "RoleAdmin",
-                "RoleMember",
-                "RoleBilling",
-                "RoleInvestor",
-                "RoleGuest"
-            ]
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
         },
-        "malak.ShareOverview": {
+        "server.contentUpdateRequest": {
             "type": "object",
+            "required": [
+                "title",
+                "update"
+            ],
             "properties": {
-                "recent_shares": {
+                "title": {
+                    "type": "string"
+                },
+                "update": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.ContactShareItem"
+                        "$ref": "#/definitions/malak.Block"
                     }
                 }
             }
         },
-        "malak.SystemTemplate": {
+        "server.createAPIKeyRequest": {
             "type": "object",
+            "required": [
+                "title"
+            ],
             "properties": {
-                "content": {
+                "scopes": {
+                    "description": "Scopes default to ingest:write which is all keys could do before\nscopes were introduced",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.Block"
+                        "$ref": "#/definitions/malak.Permission"
                     }
                 },
-                "created_at": {
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "server.createChartRequest": {
+            "type": "object",
+            "required": [
+                "chart_type",
+                "datapoint",
+                "title"
+            ],
+            "properties": {
+                "chart_type": {
+                    "$ref": "#/definitions/malak.IntegrationChartType"
                 },
-                "description": {
-                    "type": "string"
+                "datapoint": {
+                    "$ref": "#/definitions/malak.IntegrationDataPointType"
                 },
-                "id": {
+                "title": {
                     "type": "string"
-                },
-                "number_of_uses": {
-                    "type": "integer"
-                },
-                "reference": {
+                }
+            }
+        },
+        "server.createContactListRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.createContactRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "title": {
+                "first_name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "last_name": {
                     "type": "string"
                 }
             }
         },
-        "malak.Update": {
+        "server.createContactRequestBatch": {
             "type": "object",
             "properties": {
-                "content": {
+                "contacts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.Block"
+                        "type": "object",
+                        "properties": {
+                            "email": {
+                                "type": "string"
+                            },
+                            "first_name": {
+                                "type": "string"
+                            },
+                            "last_name": {
+                                "type": "string"
+                            }
+                        }
                     }
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_pinned": {
-                    "description": "If this update is pinned",
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "$ref": "#/definitions/malak.UpdateMetadata"
-                },
-                "reference": {
-                    "type": "string"
-                },
-                "sent_at": {
-                    "type": "string"
-                },
-                "sent_by": {
+                }
+            }
+        },
+        "server.createDashboardRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/malak.UpdateStatus"
-                },
                 "title": {
                     "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "workspace_id": {
-                    "type": "string"
                 }
             }
         },
-        "malak.UpdateMetadata": {
-            "type": "object"
-        },
-        "malak.UpdateOverview": {
+        "server.createDeckRequest": {
             "type": "object",
             "properties": {
-                "last_updates": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Update"
-                    }
+                "deck_url": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "malak.UpdateRecipient": {
+        "server.createDeckViewerSession": {
             "type": "object",
+            "required": [
+                "browser",
+                "device_info",
+                "os",
+                "password"
+            ],
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
-                },
-                "contact_id": {
+                "browser": {
                     "type": "string"
                 },
-                "created_at": {
+                "device_info": {
                     "type": "string"
                 },
-                "id": {
+                "os": {
                     "type": "string"
                 },
-                "reference": {
+                "password": {
                     "type": "string"
+                }
+            }
+        },
+        "server.createNewPipelineRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "description",
+                "expected_close_date",
+                "stage",
+                "start_date",
+                "title"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
                 },
-                "schedule_id": {
+                "description": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/malak.RecipientStatus"
+                "expected_close_date": {
+                    "type": "integer"
                 },
-                "update_id": {
-                    "type": "string"
+                "stage": {
+                    "$ref": "#/definitions/malak.FundraisePipelineStage"
                 },
-                "update_recipient_stat": {
-                    "$ref": "#/definitions/malak.UpdateRecipientStat"
+                "start_date": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "malak.UpdateRecipientStat": {
+        "server.createRecurringScheduleRequest": {
             "type": "object",
+            "required": [
+                "day_of_month",
+                "emails",
+                "frequency",
+                "template_reference"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "day_of_month": {
+                    "type": "integer"
                 },
-                "has_reaction": {
-                    "type": "boolean"
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "frequency": {
+                    "$ref": "#/definitions/malak.RecurrenceFrequency"
                 },
-                "is_bounced": {
-                    "type": "boolean"
+                "hour": {
+                    "description": "Hour of the day in UTC",
+                    "type": "integer"
                 },
-                "is_delivered": {
+                "is_system_template": {
                     "type": "boolean"
                 },
-                "last_opened_at": {
-                    "type": "string"
-                },
-                "recipient": {
-                    "$ref": "#/definitions/malak.UpdateRecipient"
+                "lead_days": {
+                    "type": "integer"
                 },
-                "recipient_id": {
+                "template_reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.createSendingDomainRequest": {
+            "type": "object",
+            "required": [
+                "sender"
+            ],
+            "properties": {
+                "sender": {
+                    "description": "Sender is the address updates are sent from. The sending domain is\nthe domain of this address",
+                    "type": "string"
+                }
+            }
+        },
+        "server.createUpdateCommentRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
                     "type": "string"
                 },
-                "reference": {
+                "parent_reference": {
+                    "description": "ParentReference is only used by investors. It is the thread the\ncomment goes into. A new thread is started if empty",
                     "type": "string"
+                }
+            }
+        },
+        "server.createUpdateContent": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "template": {
+                    "type": "object",
+                    "properties": {
+                        "is_system_template": {
+                            "type": "boolean"
+                        },
+                        "reference": {
+                            "type": "string"
+                        }
+                    }
                 },
-                "updated_at": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "malak.UpdateStat": {
+        "server.createUpdateLinkRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "reference": {
-                    "type": "string"
-                },
-                "total_clicks": {
-                    "type": "integer"
-                },
-                "total_opens": {
-                    "type": "integer"
-                },
-                "total_reactions": {
-                    "type": "integer"
-                },
-                "total_sent": {
-                    "type": "integer"
-                },
-                "unique_opens": {
-                    "type": "integer"
-                },
-                "update_id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "malak.UpdateStatus": {
-            "type": "string",
-            "enum": [
-                "draft",
-                "sent"
-            ],
-            "x-enum-varnames": [
-                "UpdateStatusDraft",
-                "UpdateStatusSent"
-            ]
-        },
-        "malak.User": {
+        "server.createUpdateViewerSession": {
             "type": "object",
+            "required": [
+                "browser",
+                "device_info",
+                "os"
+            ],
             "properties": {
-                "created_at": {
+                "browser": {
                     "type": "string"
                 },
-                "email": {
+                "device_info": {
                     "type": "string"
                 },
-                "email_verified_at": {
+                "os": {
                     "type": "string"
                 },
-                "full_name": {
+                "password": {
                     "type": "string"
-                },
-                "id": {
+                }
+            }
+        },
+        "server.createWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.UserMetadata"
-                },
-                "roles": {
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.UserRole"
+                        "$ref": "#/definitions/malak.WebhookEvent"
                     }
                 },
-                "updated_at": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "malak.UserMetadata": {
+        "server.createWorkspaceRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "current_workspace": {
-                    "description": "Used to keep track of the last used workspace\nIn the instance of multiple workspaces\nSo when next the user logs in, we remember and take them to the\nright place rather than always a list of all their workspaces and they\nhave to select one",
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "malak.UserRole": {
+        "server.createWorkspaceTemplateRequest": {
             "type": "object",
+            "required": [
+                "title"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/malak.Role"
-                },
-                "updated_at": {
+                "title": {
                     "type": "string"
-                },
-                "user_id": {
+                }
+            }
+        },
+        "server.createdAPIKeyResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "value"
+            ],
+            "properties": {
+                "message": {
                     "type": "string"
                 },
-                "workspace_id": {
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "malak.Workspace": {
+        "server.createdUpdateResponse": {
             "type": "object",
+            "required": [
+                "message",
+                "update"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "message": {
                     "type": "string"
                 },
-                "is_banned": {
-                    "type": "boolean"
+                "update": {
+                    "$ref": "#/definitions/malak.Update"
+                }
+            }
+        },
+        "server.createdUserResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "refresh_token",
+                "token",
+                "user",
+                "workspaces"
+            ],
+            "properties": {
+                "current_workspace": {
+                    "$ref": "#/definitions/malak.Workspace"
                 },
-                "is_subscription_active": {
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
                 },
-                "logo_url": {
+                "refresh_token": {
                     "type": "string"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/malak.WorkspaceMetadata"
+                "token": {
+                    "type": "string"
                 },
-                "plan": {
-                    "$ref": "#/definitions/malak.Plan"
+                "user": {
+                    "$ref": "#/definitions/malak.User"
                 },
-                "plan_id": {
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Workspace"
+                    }
+                }
+            }
+        },
+        "server.createdWebhookResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "secret",
+                "webhook"
+            ],
+            "properties": {
+                "message": {
                     "type": "string"
                 },
-                "reference": {
+                "secret": {
+                    "description": "Secret is only returned when the webhook is created",
                     "type": "string"
                 },
-                "stripe_customer_id": {
-                    "description": "Not required\nDummy values work really if not using stripe",
-                    "type": "string"
+                "webhook": {
+                    "$ref": "#/definitions/malak.Webhook"
+                }
+            }
+        },
+        "server.diffUpdateRevisionsResponse": {
+            "type": "object",
+            "required": [
+                "diff",
+                "message"
+            ],
+            "properties": {
+                "diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.BlockDiff"
+                    }
                 },
-                "subscription_id": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.editContactRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "company",
+                "notes"
+            ],
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "timezone": {
+                "company": {
                     "type": "string"
                 },
-                "updated_at": {
+                "first_name": {
                     "type": "string"
                 },
-                "website": {
+                "last_name": {
                     "type": "string"
                 },
-                "workspace_name": {
+                "notes": {
                     "type": "string"
                 }
             }
         },
-        "malak.WorkspaceIntegration": {
+        "server.enableTwoFactorRequest": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
-                "created_at": {
+                "code": {
                     "type": "string"
-                },
-                "id": {
+                }
+            }
+        },
+        "server.fetchBillingPortalResponse": {
+            "type": "object",
+            "required": [
+                "link",
+                "message"
+            ],
+            "properties": {
+                "link": {
                     "type": "string"
                 },
-                "integration": {
-                    "$ref": "#/definitions/malak.Integration"
-                },
-                "integration_id": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "server.fetchBoardResponse": {
+            "type": "object",
+            "required": [
+                "columns",
+                "contacts",
+                "message",
+                "pipeline",
+                "positions"
+            ],
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.FundraisingPipelineColumn"
+                    }
                 },
-                "is_active": {
-                    "description": "IsActive determines if the connection to the integration has been tested and works",
-                    "type": "boolean"
-                },
-                "is_enabled": {
-                    "description": "IsEnabled - this integration is enabled and data can be fetched",
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "$ref": "#/definitions/malak.WorkspaceIntegrationMetadata"
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.FundraiseContact"
+                    }
                 },
-                "reference": {
+                "message": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "pipeline": {
+                    "$ref": "#/definitions/malak.FundraisingPipeline"
                 },
-                "workspace_id": {
-                    "type": "string"
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.FundraiseContactPosition"
+                    }
                 }
             }
         },
-        "malak.WorkspaceIntegrationMetadata": {
+        "server.fetchContactListResponse": {
             "type": "object",
+            "required": [
+                "list",
+                "message"
+            ],
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "list": {
+                    "$ref": "#/definitions/malak.ContactList"
                 },
-                "last_fetched_at": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "malak.WorkspaceMetadata": {
-            "type": "object"
-        },
-        "server.APIStatus": {
+        "server.fetchContactListsResponse": {
             "type": "object",
             "required": [
+                "lists",
                 "message"
             ],
             "properties": {
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "required": [
+                            "list",
+                            "mappings"
+                        ],
+                        "properties": {
+                            "list": {
+                                "$ref": "#/definitions/malak.ContactList"
+                            },
+                            "mappings": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/malak.ContactListMappingWithContact"
+                                }
+                            }
+                        }
+                    }
+                },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "server.addChartToDashboardRequest": {
+        "server.fetchContactResponse": {
             "type": "object",
             "required": [
-                "chart_reference"
+                "contact",
+                "message"
             ],
             "properties": {
-                "chart_reference": {
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.addContactRequest": {
+        "server.fetchDashboardResponse": {
             "type": "object",
             "required": [
-                "check_size",
-                "contact_reference",
-                "initial_contact",
-                "rating"
+                "dashboard",
+                "message"
             ],
             "properties": {
-                "can_lead_round": {
-                    "type": "boolean"
-                },
-                "check_size": {
-                    "type": "integer"
+                "dashboard": {
+                    "$ref": "#/definitions/malak.Dashboard"
                 },
-                "contact_reference": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "server.fetchDeckResponse": {
+            "type": "object",
+            "required": [
+                "deck",
+                "message"
+            ],
+            "properties": {
+                "deck": {
+                    "$ref": "#/definitions/malak.Deck"
                 },
-                "initial_contact": {
-                    "type": "integer"
-                },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 0
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "server.addContactToListRequest": {
+        "server.fetchDecksResponse": {
             "type": "object",
+            "required": [
+                "decks",
+                "message"
+            ],
             "properties": {
-                "reference": {
+                "decks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Deck"
+                    }
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.addDataPointRequest": {
+        "server.fetchDetailedContactResponse": {
             "type": "object",
             "required": [
-                "value"
+                "contact",
+                "message",
+                "shared_items"
             ],
             "properties": {
-                "value": {
-                    "type": "integer"
+                "contact": {
+                    "$ref": "#/definitions/malak.Contact"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "shared_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.ContactShareItem"
+                    }
                 }
             }
         },
-        "server.authenticateUserRequest": {
+        "server.fetchEngagementsResponse": {
             "type": "object",
             "required": [
-                "code"
+                "engagements",
+                "message"
             ],
             "properties": {
-                "code": {
+                "engagements": {
+                    "$ref": "#/definitions/malak.DeckEngagementResponse"
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.contentUpdateRequest": {
+        "server.fetchPipelinesResponse": {
             "type": "object",
             "required": [
-                "title",
-                "update"
+                "message",
+                "meta",
+                "pipelines"
             ],
             "properties": {
-                "title": {
+                "message": {
                     "type": "string"
                 },
-                "update": {
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
+                },
+                "pipelines": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.Block"
+                        "$ref": "#/definitions/malak.FundraisingPipeline"
                     }
                 }
             }
         },
-        "server.createAPIKeyRequest": {
+        "server.fetchPublicDeckResponse": {
             "type": "object",
             "required": [
-                "title"
+                "deck",
+                "message"
             ],
             "properties": {
-                "title": {
+                "deck": {
+                    "$ref": "#/definitions/malak.PublicDeck"
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.createChartRequest": {
+        "server.fetchPublicUpdateLinkResponse": {
             "type": "object",
             "required": [
-                "chart_type",
-                "datapoint",
-                "title"
+                "message",
+                "session_id",
+                "update"
             ],
             "properties": {
-                "chart_type": {
-                    "$ref": "#/definitions/malak.IntegrationChartType"
-                },
-                "datapoint": {
-                    "$ref": "#/definitions/malak.IntegrationDataPointType"
+                "message": {
+                    "type": "string"
                 },
-                "title": {
+                "session_id": {
                     "type": "string"
+                },
+                "update": {
+                    "$ref": "#/definitions/malak.PublicUpdate"
                 }
             }
         },
-        "server.createContactListRequest": {
+        "server.fetchPublicUpdateResponse": {
             "type": "object",
             "required": [
-                "name"
+                "message",
+                "threads",
+                "update"
             ],
             "properties": {
-                "name": {
+                "message": {
                     "type": "string"
+                },
+                "reaction": {
+                    "$ref": "#/definitions/malak.ReactionStatus"
+                },
+                "threads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateCommentThread"
+                    }
+                },
+                "update": {
+                    "$ref": "#/definitions/malak.Update"
                 }
             }
         },
-        "server.createContactRequest": {
+        "server.fetchRecurringScheduleResponse": {
             "type": "object",
+            "required": [
+                "message",
+                "schedule"
+            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "first_name": {
+                "message": {
                     "type": "string"
                 },
-                "last_name": {
-                    "type": "string"
+                "schedule": {
+                    "$ref": "#/definitions/malak.RecurringUpdateSchedule"
                 }
             }
         },
-        "server.createContactRequestBatch": {
+        "server.fetchSendingDomainResponse": {
             "type": "object",
+            "required": [
+                "domain",
+                "message",
+                "records"
+            ],
             "properties": {
-                "contacts": {
+                "domain": {
+                    "$ref": "#/definitions/malak.SendingDomain"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "records": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "email": {
-                                "type": "string"
-                            },
-                            "first_name": {
-                                "type": "string"
-                            },
-                            "last_name": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/malak.DNSRecord"
+                    }
+                }
+            }
+        },
+        "server.fetchSessionsDeck": {
+            "type": "object",
+            "required": [
+                "message",
+                "meta",
+                "sessions"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.DeckViewerSession"
                     }
                 }
             }
         },
-        "server.createDashboardRequest": {
+        "server.fetchTeamMemberResponse": {
             "type": "object",
             "required": [
-                "description",
-                "title"
+                "member",
+                "message"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "member": {
+                    "$ref": "#/definitions/malak.UserRole"
                 },
-                "title": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.createDeckRequest": {
+        "server.fetchTemplatesResponse": {
             "type": "object",
+            "required": [
+                "message",
+                "templates"
+            ],
             "properties": {
-                "deck_url": {
+                "message": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string"
+                "templates": {
+                    "type": "object",
+                    "required": [
+                        "system",
+                        "workspace"
+                    ],
+                    "properties": {
+                        "system": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/malak.SystemTemplate"
+                            }
+                        },
+                        "workspace": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/malak.WorkspaceTemplate"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "server.createDeckViewerSession": {
+        "server.fetchUpdateAnalyticsResponse": {
             "type": "object",
             "required": [
-                "browser",
-                "device_info",
-                "os",
-                "password"
+                "links",
+                "message",
+                "recipients",
+                "update"
             ],
             "properties": {
-                "browser": {
-                    "type": "string"
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateLinkStat"
+                    }
                 },
-                "device_info": {
+                "message": {
                     "type": "string"
                 },
-                "os": {
-                    "type": "string"
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateRecipient"
+                    }
                 },
-                "password": {
-                    "type": "string"
+                "update": {
+                    "$ref": "#/definitions/malak.UpdateStat"
                 }
             }
         },
-        "server.createNewPipelineRequest": {
+        "server.fetchUpdateApprovalResponse": {
             "type": "object",
             "required": [
-                "amount",
-                "description",
-                "expected_close_date",
-                "stage",
-                "start_date",
-                "title"
+                "approval",
+                "message"
             ],
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "expected_close_date": {
-                    "type": "integer"
-                },
-                "stage": {
-                    "$ref": "#/definitions/malak.FundraisePipelineStage"
-                },
-                "start_date": {
-                    "type": "integer"
+                "approval": {
+                    "$ref": "#/definitions/malak.UpdateApproval"
                 },
-                "title": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.createUpdateContent": {
+        "server.fetchUpdateCommentResponse": {
             "type": "object",
             "required": [
-                "title"
+                "comment",
+                "message"
             ],
             "properties": {
-                "template": {
-                    "type": "object",
-                    "properties": {
-                        "is_system_template": {
-                            "type": "boolean"
-                        },
-                        "reference": {
-                            "type": "string"
-                        }
-                    }
+                "comment": {
+                    "$ref": "#/definitions/malak.UpdateComment"
                 },
-                "title": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.createWorkspaceRequest": {
+        "server.fetchUpdateLinkResponse": {
             "type": "object",
             "required": [
-                "name"
+                "link",
+                "message"
             ],
             "properties": {
-                "name": {
+                "link": {
+                    "$ref": "#/definitions/malak.UpdateLink"
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.createdAPIKeyResponse": {
+        "server.fetchUpdateReponse": {
             "type": "object",
             "required": [
                 "message",
-                "value"
+                "update"
             ],
             "properties": {
                 "message": {
                     "type": "string"
                 },
-                "value": {
-                    "type": "string"
+                "update": {
+                    "$ref": "#/definitions/malak.Update"
                 }
             }
         },
-        "server.createdUpdateResponse": {
+        "server.fetchUpdateScheduleResponse": {
             "type": "object",
             "required": [
                 "message",
-                "update"
+                "schedule"
             ],
             "properties": {
                 "message": {
                     "type": "string"
                 },
-                "update": {
-                    "$ref": "#/definitions/malak.Update"
+                "schedule": {
+                    "$ref": "#/definitions/malak.UpdateSchedule"
                 }
             }
         },
-        "server.createdUserResponse": {
+        "server.fetchWebhookDeliveryResponse": {
             "type": "object",
             "required": [
-                "message",
-                "token",
-                "user",
-                "workspaces"
+                "delivery",
+                "message"
             ],
             "properties": {
-                "current_workspace": {
-                    "$ref": "#/definitions/malak.Workspace"
+                "delivery": {
+                    "$ref": "#/definitions/malak.WebhookDelivery"
                 },
                 "message": {
                     "type": "string"
-                },
-                "token": {
-                    "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/malak.User"
-                },
-                "workspaces": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Workspace"
-                    }
                 }
             }
         },
-        "server.editContactRequest": {
+        "server.fetchWebhookResponse": {
             "type": "object",
             "required": [
-                "address",
-                "company",
-                "notes"
+                "message",
+                "webhook"
             ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "company": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "last_name": {
+                "message": {
                     "type": "string"
                 },
-                "notes": {
-                    "type": "string"
+                "webhook": {
+                    "$ref": "#/definitions/malak.Webhook"
                 }
             }
         },
-        "server.fetchBillingPortalResponse": {
+        "server.fetchWorkspaceResponse": {
             "type": "object",
             "required": [
-                "link",
-                "message"
+                "message",
+                "workspace"
             ],
             "properties": {
-                "link": {
-                    "type": "string"
-                },
                 "message": {
                     "type": "string"
+                },
+                "workspace": {
+                    "$ref": "#/definitions/malak.Workspace"
                 }
             }
         },
-        "server.fetchBoardResponse": {
+        "server.fetchWorkspaceTemplateResponse": {
             "type": "object",
             "required": [
-                "columns",
-                "contacts",
                 "message",
-                "pipeline",
-                "positions"
+                "template"
             ],
             "properties": {
-                "columns": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.FundraisingPipelineColumn"
-                    }
-                },
-                "contacts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.FundraiseContact"
-                    }
-                },
                 "message": {
                     "type": "string"
                 },
-                "pipeline": {
-                    "$ref": "#/definitions/malak.FundraisingPipeline"
-                },
-                "positions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.FundraiseContactPosition"
-                    }
+                "template": {
+                    "$ref": "#/definitions/malak.WorkspaceTemplate"
                 }
             }
         },
-        "server.fetchContactListResponse": {
+        "server.forgotPasswordRequest": {
             "type": "object",
             "required": [
-                "list",
-                "message"
+                "email"
             ],
             "properties": {
-                "list": {
-                    "$ref": "#/definitions/malak.ContactList"
-                },
-                "message": {
+                "email": {
                     "type": "string"
                 }
             }
         },
-        "server.fetchContactListsResponse": {
+        "server.generateDashboardLinkRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.inviteTeamMemberRequest": {
             "type": "object",
             "required": [
-                "lists",
-                "message"
+                "email",
+                "role"
             ],
             "properties": {
-                "lists": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "required": [
-                            "list",
-                            "mappings"
-                        ],
-                        "properties": {
-                            "list": {
-                                "$ref": "#/definitions/malak.ContactList"
-                            },
-                            "mappings": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/malak.ContactListMappingWithContact"
-                                }
-                            }
-                        }
-                    }
-                },
-                "message": {
+                "email": {
                     "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/malak.Role"
                 }
             }
         },
-        "server.fetchContactResponse": {
+        "server.listAPIKeysResponse": {
             "type": "object",
             "required": [
-                "contact",
+                "keys",
                 "message"
             ],
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.APIKey"
+                    }
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "server.fetchDashboardResponse": {
+        "server.listAuditLogsResponse": {
             "type": "object",
             "required": [
-                "dashboard",
-                "message"
+                "audit_logs",
+                "message",
+                "meta"
             ],
             "properties": {
-                "dashboard": {
-                    "$ref": "#/definitions/malak.Dashboard"
+                "audit_logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.AuditLog"
+                    }
                 },
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
                 }
             }
         },
-        "server.fetchDeckResponse": {
+        "server.listChartDataPointsResponse": {
             "type": "object",
             "required": [
-                "deck",
+                "data_points",
                 "message"
             ],
             "properties": {
-                "deck": {
-                    "$ref": "#/definitions/malak.Deck"
+                "data_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.IntegrationDataPoint"
+                    }
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "server.fetchDecksResponse": {
+        "server.listContactsResponse": {
             "type": "object",
             "required": [
-                "decks",
-                "message"
+                "contacts",
+                "message",
+                "meta"
             ],
             "properties": {
-                "decks": {
+                "contacts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.Deck"
+                        "$ref": "#/definitions/malak.Contact"
                     }
                 },
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
                 }
             }
         },
-        "server.fetchDetailedContactResponse": {
+        "server.listDashboardChartsResponse": {
             "type": "object",
             "required": [
-                "contact",
+                "charts",
+                "dashboard",
+                "link",
                 "message",
-                "shared_items"
+                "positions"
             ],
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/malak.Contact"
+                "charts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.DashboardChart"
+                    }
+                },
+                "dashboard": {
+                    "$ref": "#/definitions/malak.Dashboard"
+                },
+                "link": {
+                    "$ref": "#/definitions/malak.DashboardLink"
                 },
                 "message": {
                     "type": "string"
                 },
-                "shared_items": {
+                "positions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.ContactShareItem"
+                        "$ref": "#/definitions/malak.DashboardChartPosition"
                     }
                 }
             }
         },
-        "server.fetchEngagementsResponse": {
+        "server.listDashboardLinkResponse": {
             "type": "object",
             "required": [
-                "engagements",
-                "message"
+                "links",
+                "message",
+                "meta"
             ],
             "properties": {
-                "engagements": {
-                    "$ref": "#/definitions/malak.DeckEngagementResponse"
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.DashboardLink"
+                    }
                 },
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
                 }
             }
         },
-        "server.fetchPipelinesResponse": {
+        "server.listDashboardResponse": {
             "type": "object",
             "required": [
+                "dashboards",
                 "message",
-                "meta",
-                "pipelines"
+                "meta"
             ],
             "properties": {
+                "dashboards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Dashboard"
+                    }
+                },
                 "message": {
                     "type": "string"
                 },
                 "meta": {
                     "$ref": "#/definitions/server.meta"
-                },
-                "pipelines": {
+                }
+            }
+        },
+        "server.listIntegrationChartsResponse": {
+            "type": "object",
+            "required": [
+                "charts",
+                "message"
+            ],
+            "properties": {
+                "charts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.FundraisingPipeline"
+                        "$ref": "#/definitions/malak.IntegrationChart"
                     }
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "server.fetchPublicDeckResponse": {
+        "server.listIntegrationResponse": {
             "type": "object",
             "required": [
-                "deck",
+                "integrations",
                 "message"
             ],
             "properties": {
-                "deck": {
-                    "$ref": "#/definitions/malak.PublicDeck"
+                "integrations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.WorkspaceIntegration"
+                    }
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "server.fetchSessionsDeck": {
+        "server.listPublicUpdatesResponse": {
             "type": "object",
             "required": [
                 "message",
                 "meta",
-                "sessions"
+                "updates"
             ],
             "properties": {
                 "message": {
@@ -6762,136 +12402,137 @@ const docTemplate = `{
                 "meta": {
                     "$ref": "#/definitions/server.meta"
                 },
-                "sessions": {
+                "updates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.DeckViewerSession"
+                        "$ref": "#/definitions/malak.PublicUpdate"
                     }
                 }
             }
         },
-        "server.fetchTemplatesResponse": {
+        "server.listRecurringSchedulesResponse": {
             "type": "object",
             "required": [
                 "message",
-                "templates"
+                "schedules"
             ],
             "properties": {
                 "message": {
                     "type": "string"
                 },
-                "templates": {
-                    "type": "object",
-                    "required": [
-                        "system",
-                        "workspace"
-                    ],
-                    "properties": {
-                        "system": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/malak.SystemTemplate"
-                            }
-                        },
-                        "workspace": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/malak.SystemTemplate"
-                            }
-                        }
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.RecurringUpdateSchedule"
                     }
                 }
             }
         },
-        "server.fetchUpdateAnalyticsResponse": {
+        "server.listSocialAuthProvidersResponse": {
             "type": "object",
             "required": [
                 "message",
-                "recipients",
-                "update"
+                "providers"
             ],
             "properties": {
                 "message": {
                     "type": "string"
                 },
-                "recipients": {
+                "providers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.UpdateRecipient"
+                        "type": "string"
                     }
-                },
-                "update": {
-                    "$ref": "#/definitions/malak.UpdateStat"
                 }
             }
         },
-        "server.fetchUpdateReponse": {
+        "server.listSuppressionsResponse": {
             "type": "object",
             "required": [
                 "message",
-                "update"
+                "meta",
+                "suppressions"
             ],
             "properties": {
                 "message": {
                     "type": "string"
                 },
-                "update": {
-                    "$ref": "#/definitions/malak.Update"
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
+                },
+                "suppressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Suppression"
+                    }
                 }
             }
         },
-        "server.fetchWorkspaceResponse": {
+        "server.listTeamMembersResponse": {
             "type": "object",
             "required": [
-                "message",
-                "workspace"
+                "members",
+                "message"
             ],
             "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UserRole"
+                    }
+                },
                 "message": {
                     "type": "string"
-                },
-                "workspace": {
-                    "$ref": "#/definitions/malak.Workspace"
                 }
             }
         },
-        "server.generateDashboardLinkRequest": {
+        "server.listUpdateApprovalsResponse": {
             "type": "object",
+            "required": [
+                "approvals",
+                "message"
+            ],
             "properties": {
-                "email": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateApproval"
+                    }
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "server.listAPIKeysResponse": {
+        "server.listUpdateCommentsResponse": {
             "type": "object",
             "required": [
-                "keys",
-                "message"
+                "message",
+                "threads"
             ],
             "properties": {
-                "keys": {
+                "message": {
+                    "type": "string"
+                },
+                "threads": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.APIKey"
+                        "$ref": "#/definitions/malak.UpdateCommentThread"
                     }
-                },
-                "message": {
-                    "type": "string"
                 }
             }
         },
-        "server.listChartDataPointsResponse": {
+        "server.listUpdateLinksResponse": {
             "type": "object",
             "required": [
-                "data_points",
+                "links",
                 "message"
             ],
             "properties": {
-                "data_points": {
+                "links": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.IntegrationDataPoint"
+                        "$ref": "#/definitions/malak.UpdateLink"
                     }
                 },
                 "message": {
@@ -6899,160 +12540,138 @@ const docTemplate = `{
                 }
             }
         },
-        "server.listContactsResponse": {
+        "server.listUpdateResponse": {
             "type": "object",
             "required": [
-                "contacts",
                 "message",
-                "meta"
+                "meta",
+                "updates"
             ],
             "properties": {
-                "contacts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Contact"
-                    }
-                },
                 "message": {
                     "type": "string"
                 },
                 "meta": {
                     "$ref": "#/definitions/server.meta"
+                },
+                "updates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Update"
+                    }
                 }
             }
         },
-        "server.listDashboardChartsResponse": {
+        "server.listUpdateRevisionsResponse": {
             "type": "object",
             "required": [
-                "charts",
-                "dashboard",
-                "link",
                 "message",
-                "positions"
+                "revisions"
             ],
             "properties": {
-                "charts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.DashboardChart"
-                    }
-                },
-                "dashboard": {
-                    "$ref": "#/definitions/malak.Dashboard"
-                },
-                "link": {
-                    "$ref": "#/definitions/malak.DashboardLink"
-                },
                 "message": {
                     "type": "string"
                 },
-                "positions": {
+                "revisions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.DashboardChartPosition"
+                        "$ref": "#/definitions/malak.UpdateRevision"
                     }
                 }
             }
         },
-        "server.listDashboardLinkResponse": {
+        "server.listUpdateSchedulesResponse": {
             "type": "object",
             "required": [
-                "links",
                 "message",
-                "meta"
+                "schedules"
             ],
             "properties": {
-                "links": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.DashboardLink"
-                    }
-                },
                 "message": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/server.meta"
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateSchedule"
+                    }
                 }
             }
         },
-        "server.listDashboardResponse": {
+        "server.listUpdateViewerSessionsResponse": {
             "type": "object",
             "required": [
-                "dashboards",
                 "message",
-                "meta"
+                "meta",
+                "sessions"
             ],
             "properties": {
-                "dashboards": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Dashboard"
-                    }
-                },
                 "message": {
                     "type": "string"
                 },
                 "meta": {
                     "$ref": "#/definitions/server.meta"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.UpdateViewerSession"
+                    }
                 }
             }
         },
-        "server.listIntegrationChartsResponse": {
+        "server.listWebhookDeliveriesResponse": {
             "type": "object",
             "required": [
-                "charts",
-                "message"
+                "deliveries",
+                "message",
+                "meta"
             ],
             "properties": {
-                "charts": {
+                "deliveries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.IntegrationChart"
+                        "$ref": "#/definitions/malak.WebhookDelivery"
                     }
                 },
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/server.meta"
                 }
             }
         },
-        "server.listIntegrationResponse": {
+        "server.listWebhooksResponse": {
             "type": "object",
             "required": [
-                "integrations",
-                "message"
+                "message",
+                "webhooks"
             ],
             "properties": {
-                "integrations": {
+                "message": {
+                    "type": "string"
+                },
+                "webhooks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/malak.WorkspaceIntegration"
+                        "$ref": "#/definitions/malak.Webhook"
                     }
-                },
-                "message": {
-                    "type": "string"
                 }
             }
         },
-        "server.listUpdateResponse": {
+        "server.loginRequest": {
             "type": "object",
             "required": [
-                "message",
-                "meta",
-                "updates"
+                "email",
+                "password"
             ],
             "properties": {
-                "message": {
+                "email": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/server.meta"
-                },
-                "updates": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/malak.Update"
-                    }
+                "password": {
+                    "type": "string"
                 }
             }
         },
@@ -7127,6 +12746,36 @@ const docTemplate = `{
                 }
             }
         },
+        "server.refreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.refreshTokenResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "refresh_token",
+                "token"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "server.regenerateLinkResponse": {
             "type": "object",
             "required": [
@@ -7142,6 +12791,46 @@ const docTemplate = `{
                 }
             }
         },
+        "server.rescheduleUpdateRequest": {
+            "type": "object",
+            "required": [
+                "send_at"
+            ],
+            "properties": {
+                "send_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.resetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.reviewUpdateRequest": {
+            "type": "object",
+            "required": [
+                "decision"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "decision": {
+                    "$ref": "#/definitions/malak.UpdateApprovalDecision"
+                }
+            }
+        },
         "server.revokeAPIKeyRequest": {
             "type": "object",
             "required": [
@@ -7167,6 +12856,25 @@ const docTemplate = `{
                 }
             }
         },
+        "server.setupTwoFactorResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "provisioning_uri",
+                "secret"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "provisioning_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
         "server.signupRequest": {
             "type": "object",
             "properties": {
@@ -7181,6 +12889,17 @@ const docTemplate = `{
                 }
             }
         },
+        "server.teamInviteRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "server.testAPIIntegrationRequest": {
             "type": "object",
             "required": [
@@ -7192,6 +12911,35 @@ const docTemplate = `{
                 }
             }
         },
+        "server.twoFactorCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "recovery_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.twoFactorRecoveryCodesResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "recovery_codes"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "server.updateContactDealRequest": {
             "type": "object",
             "required": [
@@ -7274,6 +13022,9 @@ const docTemplate = `{
                         "newsletter"
                     ],
                     "properties": {
+                        "approval": {
+                            "$ref": "#/definitions/malak.ApprovalPreferences"
+                        },
                         "billing": {
                             "$ref": "#/definitions/malak.BillingPreferences"
                         },
@@ -7284,12 +13035,81 @@ const docTemplate = `{
                 }
             }
         },
+        "server.updateReactionRequest": {
+            "type": "object",
+            "required": [
+                "reaction"
+            ],
+            "properties": {
+                "reaction": {
+                    "$ref": "#/definitions/malak.ReactionStatus"
+                }
+            }
+        },
+        "server.updateTeamMemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/malak.Role"
+                }
+            }
+        },
+        "server.updateUpdateViewerSession": {
+            "type": "object",
+            "required": [
+                "session_id",
+                "time_spent"
+            ],
+            "properties": {
+                "session_id": {
+                    "type": "string"
+                },
+                "time_spent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.updateVisibilityRequest": {
+            "type": "object",
+            "properties": {
+                "is_public": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.updateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.WebhookEvent"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "server.updateWorkspaceRequest": {
             "type": "object",
             "properties": {
                 "logo": {
                     "type": "string"
                 },
+                "require_two_factor": {
+                    "description": "RequireTwoFactor forces every member to set up 2FA before they can\naccess the workspace",
+                    "type": "boolean"
+                },
                 "timezone": {
                     "type": "string"
                 },
@@ -7301,6 +13121,27 @@ const docTemplate = `{
                 }
             }
         },
+        "server.updateWorkspaceTemplateRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/malak.Block"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "server.uploadImageResponse": {
             "type": "object",
             "required": [
@@ -7316,6 +13157,23 @@ const docTemplate = `{
                 }
             }
         },
+        "server.verifyTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "recovery_code": {
+                    "type": "string"
+                }
+            }
+        },
         "server.workspaceOverviewResponse": {
             "type": "object",
             "required": [
@@ -0,0 +1,104 @@
+package malak
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ayinke-llc/hermes"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrTeamInviteNotFound = MalakError("invite not found")
+	ErrTeamInviteExpired  = MalakError("invite has expired")
+	ErrTeamMemberNotFound = MalakError("team member not found")
+	ErrTeamMemberExists   = MalakError("user is already a member of this workspace")
+	ErrTeamSeatsExhausted = MalakError("all seats on your plan have been used up")
+)
+
+// How long an invite stays valid for
+const TeamInviteExpiration = time.Hour * 24 * 7
+
+// ENUM(pending,accepted,declined)
+type TeamInviteStatus string
+
+type TeamInvite struct {
+	ID        uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference Reference `json:"reference,omitempty"`
+
+	Email Email `json:"email,omitempty"`
+	Role  Role  `json:"role,omitempty"`
+
+	// Token is only ever sent in the invite email and is only available
+	// when the invite is created. Just the hash is stored
+	Token     string `bun:"-" json:"-"`
+	TokenHash string `json:"-"`
+
+	Status TeamInviteStatus `json:"status,omitempty"`
+
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	InvitedBy   uuid.UUID `json:"invited_by,omitempty"`
+
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	bun.BaseModel `bun:"table:team_invites" json:"-"`
+}
+
+// NewTeamInvite creates a pending invite. The email is trimmed and
+// lowercased since invites are matched by it
+func NewTeamInvite(workspace *Workspace, invitedBy *User,
+	email Email, role Role, reference Reference) (*TeamInvite, error) {
+	val, err := hermes.Random(40)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamInvite{
+		Reference:   reference,
+		Email:       Email(strings.TrimSpace(email.String())),
+		Role:        role,
+		Token:       val,
+		TokenHash:   HashToken(val),
+		Status:      TeamInviteStatusPending,
+		WorkspaceID: workspace.ID,
+		InvitedBy:   invitedBy.ID,
+		ExpiresAt:   time.Now().Add(TeamInviteExpiration),
+	}, nil
+}
+
+func (t *TeamInvite) IsExpired() bool { return time.Now().After(t.ExpiresAt) }
+
+type CreateTeamInviteOptions struct {
+	Invite *TeamInvite
+	Plan   *Plan
+}
+
+type FetchTeamInviteOptions struct {
+	Token string
+}
+
+type FetchTeamMemberOptions struct {
+	WorkspaceID uuid.UUID
+	UserID      uuid.UUID
+}
+
+type AcceptTeamInviteOptions struct {
+	Invite *TeamInvite
+	User   *User
+}
+
+type TeamRepository interface {
+	CreateInvite(context.Context, *CreateTeamInviteOptions) error
+	GetInvite(context.Context, FetchTeamInviteOptions) (*TeamInvite, error)
+	AcceptInvite(context.Context, *AcceptTeamInviteOptions) error
+	DeclineInvite(context.Context, *TeamInvite) error
+
+	ListMembers(context.Context, uuid.UUID) ([]UserRole, error)
+	GetMember(context.Context, FetchTeamMemberOptions) (*UserRole, error)
+	UpdateMemberRole(context.Context, *UserRole) error
+	RemoveMember(context.Context, *UserRole) error
+}
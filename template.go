@@ -8,6 +8,10 @@ import (
 	"github.com/uptrace/bun"
 )
 
+const (
+	ErrTemplateNotFound = MalakError("template not found")
+)
+
 // ENUM(most_used,recently_created,all)
 type SystemTemplateFilter string
 
@@ -28,6 +32,44 @@ type SystemTemplate struct {
 	bun.BaseModel `json:"-"`
 }
 
+// WorkspaceTemplate is created by a team from one of its updates. It is
+// private to the workspace
+type WorkspaceTemplate struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+
+	Reference    Reference     `json:"reference,omitempty"`
+	Content      BlockContents `json:"content,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	NumberOfUses int           `json:"number_of_uses,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	DeletedAt *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
+
+	bun.BaseModel `bun:"table:workspace_templates,alias:workspace_template" json:"-"`
+}
+
+type ListWorkspaceTemplateOptions struct {
+	WorkspaceID uuid.UUID
+	Filter      SystemTemplateFilter
+}
+
+type FetchWorkspaceTemplateOptions struct {
+	WorkspaceID uuid.UUID
+	Reference   Reference
+}
+
 type TemplateRepository interface {
 	System(context.Context, SystemTemplateFilter) ([]SystemTemplate, error)
+	Get(context.Context, Reference) (*SystemTemplate, error)
+
+	Workspace(context.Context, ListWorkspaceTemplateOptions) ([]WorkspaceTemplate, error)
+	GetWorkspaceTemplate(context.Context, FetchWorkspaceTemplateOptions) (*WorkspaceTemplate, error)
+	CreateWorkspaceTemplate(context.Context, *WorkspaceTemplate) error
+	UpdateWorkspaceTemplate(context.Context, *WorkspaceTemplate) error
+	DeleteWorkspaceTemplate(context.Context, *WorkspaceTemplate) error
 }
@@ -0,0 +1,190 @@
+package malak
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const templateMetricPrefix = "metric."
+
+var templateVariableRegex = regexp.MustCompile(`{{\s*([a-zA-Z0-9_.]+)\s*}}`)
+
+// TemplateVariables are substituted in the text of a template when a draft
+// is created from it. Variables are written as {{ workspace_name }},
+// {{ month }} and {{ year }}. The latest value of a chart is written as
+// {{ metric.<chart_reference> }}. Unknown variables are left untouched
+type TemplateVariables struct {
+	WorkspaceName string
+	Date          time.Time
+	// Metrics maps a chart reference to its formatted latest value
+	Metrics map[string]string
+}
+
+func (t TemplateVariables) lookup(name string) (string, bool) {
+	switch name {
+	case "workspace_name":
+		return t.WorkspaceName, true
+	case "month":
+		return t.Date.Format("January"), true
+	case "year":
+		return t.Date.Format("2006"), true
+	}
+
+	if ref, ok := strings.CutPrefix(name, templateMetricPrefix); ok {
+		v, ok := t.Metrics[ref]
+		return v, ok
+	}
+
+	return "", false
+}
+
+func (t TemplateVariables) replace(s string) string {
+	return replaceVariables(s, t.lookup)
+}
+
+// replaceVariables substitutes every variable lookup knows about
+func replaceVariables(s string, lookup func(name string) (string, bool)) string {
+	return templateVariableRegex.ReplaceAllStringFunc(s, func(match string) string {
+		name := templateVariableRegex.FindStringSubmatch(match)[1]
+
+		if v, ok := lookup(name); ok {
+			return v
+		}
+
+		return match
+	})
+}
+
+// ReplaceVariables substitutes the variables in the text of every block
+func (bc BlockContents) ReplaceVariables(vars TemplateVariables) BlockContents {
+	return bc.replaceText(vars.replace)
+}
+
+func (bc BlockContents) replaceText(replace func(string) string) BlockContents {
+	for i := range bc {
+		replaceBlockText(&bc[i], replace)
+	}
+
+	return bc
+}
+
+func replaceBlockText(block *Block, replace func(string) string) {
+	block.Content = replaceContentText(block.Content, replace)
+
+	for i := range block.Children {
+		replaceBlockText(&block.Children[i], replace)
+	}
+}
+
+// content is decoded from json so it is made of maps and slices. Links
+// and tables nest their text deeper
+func replaceContentText(content interface{}, replace func(string) string) interface{} {
+	switch v := content.(type) {
+	case string:
+		return replace(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = replaceContentText(v[i], replace)
+		}
+	case []map[string]interface{}:
+		for i := range v {
+			replaceContentText(v[i], replace)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			if key == "text" || key == "content" || key == "rows" || key == "cells" {
+				v[key] = replaceContentText(value, replace)
+			}
+		}
+	}
+
+	return content
+}
+
+// MetricReferences lists the charts referenced by metric variables in the
+// text of the blocks
+func (bc BlockContents) MetricReferences() []Reference {
+	seen := make(map[string]bool)
+	refs := make([]Reference, 0)
+
+	var walk func(content interface{})
+	walk = func(content interface{}) {
+		switch v := content.(type) {
+		case string:
+			for _, match := range templateVariableRegex.FindAllStringSubmatch(v, -1) {
+				ref, ok := strings.CutPrefix(match[1], templateMetricPrefix)
+				if !ok || seen[ref] {
+					continue
+				}
+
+				seen[ref] = true
+				refs = append(refs, Reference(ref))
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case []map[string]interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]interface{}:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+
+	var walkBlocks func(blocks []Block)
+	walkBlocks = func(blocks []Block) {
+		for _, block := range blocks {
+			walk(block.Content)
+			walkBlocks(block.Children)
+		}
+	}
+
+	walkBlocks(bc)
+
+	return refs
+}
+
+// LoadTemplateMetrics fetches the latest value of every chart referenced in
+// the blocks. Charts that do not exist or have no data yet are skipped so
+// their variables stay visible in the draft
+func LoadTemplateMetrics(ctx context.Context, repo IntegrationRepository,
+	workspaceID uuid.UUID, bc BlockContents) (map[string]string, error) {
+
+	metrics := make(map[string]string)
+
+	for _, ref := range bc.MetricReferences() {
+		chart, err := repo.GetChart(ctx, FetchChartOptions{
+			WorkspaceID: workspaceID,
+			Reference:   ref,
+		})
+		if errors.Is(err, ErrChartNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		points, err := repo.GetDataPoints(ctx, chart)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(points) == 0 {
+			continue
+		}
+
+		metrics[ref.String()] = chart.FormatValue(points[len(points)-1].PointValue)
+	}
+
+	return metrics, nil
+}
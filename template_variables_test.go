@@ -0,0 +1,83 @@
+package malak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockContents_ReplaceVariables(t *testing.T) {
+	content := BlockContents{
+		{
+			Type: "heading",
+			Content: []interface{}{
+				map[string]interface{}{
+					"type": "text",
+					"text": "{{ workspace_name }} update for {{month}} {{ year }}",
+				},
+			},
+			Children: []Block{
+				{
+					Type: "paragraph",
+					Content: []interface{}{
+						map[string]interface{}{
+							"type": "link",
+							"href": "https://malak.vc",
+							"content": []interface{}{
+								map[string]interface{}{
+									"type": "text",
+									"text": "MRR is {{ metric.chart_mrr }}",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Type: "paragraph",
+			Content: []map[string]interface{}{
+				{
+					"type": "text",
+					"text": "Runway {{ metric.chart_unknown }} {{ unknown }}",
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []Reference{"chart_mrr", "chart_unknown"}, content.MetricReferences())
+
+	content = content.ReplaceVariables(TemplateVariables{
+		WorkspaceName: "Malak",
+		Date:          time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Metrics: map[string]string{
+			"chart_mrr": "$1,200.00",
+		},
+	})
+
+	heading := content[0].Content.([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "Malak update for March 2025", heading["text"])
+
+	link := content[0].Children[0].Content.([]interface{})[0].(map[string]interface{})
+	text := link["content"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "MRR is $1,200.00", text["text"])
+
+	// the href is never touched
+	require.Equal(t, "https://malak.vc", link["href"])
+
+	paragraph := content[1].Content.([]map[string]interface{})[0]
+	require.Equal(t, "Runway {{ metric.chart_unknown }} {{ unknown }}", paragraph["text"])
+}
+
+func TestIntegrationChart_FormatValue(t *testing.T) {
+	currency := IntegrationChart{DataPointType: IntegrationDataPointTypeCurrency}
+	others := IntegrationChart{DataPointType: IntegrationDataPointTypeOthers}
+
+	require.Equal(t, "$1,234,567.89", currency.FormatValue(123456789))
+	require.Equal(t, "$0.05", currency.FormatValue(5))
+	require.Equal(t, "-$12.50", currency.FormatValue(-1250))
+	require.Equal(t, "1,000", others.FormatValue(1000))
+	require.Equal(t, "999", others.FormatValue(999))
+	require.Equal(t, "-1,234", others.FormatValue(-1234))
+}
@@ -0,0 +1,100 @@
+package malak
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrTwoFactorRecoveryCodeNotFound = MalakError("recovery code does not exist or has already been used")
+)
+
+const (
+	// NumberOfRecoveryCodes is how many recovery codes are generated
+	// whenever 2FA is enabled or the codes are regenerated
+	NumberOfRecoveryCodes = 10
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+)
+
+type TwoFactorRecoveryCode struct {
+	ID       uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id"`
+	UserID   uuid.UUID `json:"user_id"`
+	CodeHash string    `json:"-"`
+
+	UsedAt    *time.Time `bun:",nullzero" json:"used_at"`
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+
+	bun.BaseModel `bun:"table:two_factor_recovery_codes" json:"-"`
+}
+
+// HashRecoveryCode normalizes the code so users can type it in with or
+// without the separator and in any case
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	code = strings.ReplaceAll(code, " ", "")
+
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+// NewRecoveryCodes generates a fresh set of recovery codes for the user.
+// The plain text codes are only ever shown once, we store the hashes
+func NewRecoveryCodes(user *User) ([]string, []TwoFactorRecoveryCode, error) {
+	codes := make([]string, 0, NumberOfRecoveryCodes)
+	records := make([]TwoFactorRecoveryCode, 0, NumberOfRecoveryCodes)
+
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < NumberOfRecoveryCodes; i++ {
+		var sb strings.Builder
+
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			sb.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+
+		code := sb.String()
+
+		codes = append(codes, code)
+		records = append(records, TwoFactorRecoveryCode{
+			UserID:    user.ID,
+			CodeHash:  HashRecoveryCode(code),
+			CreatedAt: time.Now(),
+		})
+	}
+
+	return codes, records, nil
+}
+
+type TwoFactorRepository interface {
+	// SaveSecret stores a pending secret for the user. 2FA is not enforced
+	// until Enable is called
+	SaveSecret(context.Context, *User, string) error
+	// Enable turns on 2FA for the user and replaces any existing recovery codes
+	Enable(context.Context, *User, []TwoFactorRecoveryCode) error
+	// Disable removes the secret and all recovery codes
+	Disable(context.Context, *User) error
+	ReplaceRecoveryCodes(context.Context, *User, []TwoFactorRecoveryCode) error
+	// UseRecoveryCode marks a matching unused code as used. Each code can only
+	// be used once
+	UseRecoveryCode(context.Context, *User, string) error
+}
@@ -0,0 +1,38 @@
+package malak
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	user := &User{ID: uuid.New()}
+
+	codes, records, err := NewRecoveryCodes(user)
+	require.NoError(t, err)
+	require.Len(t, codes, NumberOfRecoveryCodes)
+	require.Len(t, records, NumberOfRecoveryCodes)
+
+	seen := make(map[string]struct{})
+
+	for i, code := range codes {
+		require.Len(t, code, recoveryCodeLength+1)
+		require.Equal(t, user.ID, records[i].UserID)
+		require.Equal(t, HashRecoveryCode(code), records[i].CodeHash)
+
+		seen[code] = struct{}{}
+	}
+
+	require.Len(t, seen, NumberOfRecoveryCodes)
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	hash := HashRecoveryCode("abcde-fghjk")
+
+	require.Equal(t, hash, HashRecoveryCode("ABCDEFGHJK"))
+	require.Equal(t, hash, HashRecoveryCode(" abcde fghjk "))
+	require.NotEqual(t, hash, HashRecoveryCode(strings.Repeat("a", 10)))
+}
@@ -19,7 +19,13 @@ const (
 	ErrPinnedDeckCapacityExceeded = MalakError(
 		`you have exceeded the maximum number of pinned updates. Please unpin an update and pin this again`)
 
-	ErrUpdateScheduleNotFound = MalakError("update schedule not found")
+	ErrUpdateScheduleNotFound      = MalakError("update schedule not found")
+	ErrUpdateScheduleNotModifiable = MalakError(
+		"only scheduled sends that have not started processing can be changed")
+
+	ErrUpdateRecipientNotFound = MalakError("update recipient not found")
+
+	ErrUpdateSent = MalakError("update has been sent already. you cannot edit it")
 
 	MaximumNumberOfPinnedUpdates = 4
 )
@@ -52,6 +58,12 @@ type Update struct {
 	// If this update is pinned
 	IsPinned bool   `json:"is_pinned,omitempty"`
 	Title    string `json:"title,omitempty"`
+	// IsPublic lists the update on the public page of the workspace
+	IsPublic bool `json:"is_public,omitempty"`
+
+	// ApprovalStatus is only enforced when the workspace requires updates
+	// to be approved before they are sent
+	ApprovalStatus UpdateApprovalStatus `bun:",nullzero" json:"approval_status,omitempty"`
 
 	Metadata UpdateMetadata `json:"metadata,omitempty"`
 
@@ -65,26 +77,19 @@ type Update struct {
 
 func (u *Update) IsSent() bool { return u.Status == UpdateStatusSent }
 
-type UpdateLink struct {
-	ID        uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
-	Reference Reference `json:"reference,omitempty"`
-	UpdateID  uuid.UUID `json:"update_id,omitempty"`
-
-	// Sometimes, you want to share a link containing a specific update
-	// for a few minutes or seconds :)
-	ExpiresAt *time.Time `json:"expires_at,omitempty" bun:",nullzero,notnull,default:current_timestamp"`
-
-	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at" `
-	UpdatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at" `
-	DeletedAt     *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
-	bun.BaseModel `json:"-"`
+// ContentChanged withdraws the approval of the update. Approvers signed off
+// on the previous content
+func (u *Update) ContentChanged() {
+	if u.ApprovalStatus == UpdateApprovalStatusApproved {
+		u.ApprovalStatus = UpdateApprovalStatusDraft
+	}
 }
 
 // ENUM(list,email)
 // List is a flattened group that can contain infinite amount of emails
 type RecipientType string
 
-// ENUM(pending,sent,failed)
+// ENUM(pending,sent,failed,skipped)
 type RecipientStatus string
 
 type UpdateRecipient struct {
@@ -104,7 +109,7 @@ type UpdateRecipient struct {
 	bun.BaseModel `json:"-"`
 }
 
-// ENUM(resend,sendgrid,smtp)
+// ENUM(resend,sendgrid,smtp,ses,postmark)
 type UpdateRecipientLogProvider string
 
 type UpdateRecipientLog struct {
@@ -128,10 +133,13 @@ type UpdateRecipientStat struct {
 	RecipientID uuid.UUID        `json:"recipient_id,omitempty"`
 	Recipient   *UpdateRecipient `json:"recipient" bun:"rel:has-one,join:recipient_id=id"`
 
-	LastOpenedAt *time.Time `bun:",soft_delete,nullzero" json:"last_opened_at,omitempty"`
-	HasReaction  bool       `json:"has_reaction,omitempty"`
-	IsDelivered  bool       `json:"is_delivered,omitempty"`
-	IsBounced    bool       `json:"is_bounced,omitempty"`
+	LastOpenedAt *time.Time     `bun:",soft_delete,nullzero" json:"last_opened_at,omitempty"`
+	HasReaction  bool           `json:"has_reaction,omitempty"`
+	Reaction     ReactionStatus `bun:",nullzero" json:"reaction,omitempty"`
+	IsDelivered  bool           `json:"is_delivered,omitempty"`
+	IsBounced    bool           `json:"is_bounced,omitempty"`
+	IsComplained bool           `json:"is_complained,omitempty"`
+	TotalClicks  int64          `json:"total_clicks,omitempty"`
 
 	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
 	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
@@ -177,6 +185,15 @@ type UpdateSchedule struct {
 	bun.BaseModel `json:"-"`
 }
 
+func (u *UpdateSchedule) CanBeModified() bool {
+	return u.Status == UpdateSendScheduleScheduled
+}
+
+type FetchUpdateScheduleOptions struct {
+	UpdateID  uuid.UUID
+	Reference Reference
+}
+
 type FetchUpdateOptions struct {
 	Status      UpdateStatus
 	Reference   Reference
@@ -202,6 +219,8 @@ type CreateUpdateOptions struct {
 	Plan            *Plan
 }
 
+// TemplateCreateUpdateOptions tracks the template an update was created
+// from. IsFromTemplate without IsSystemTemplate is a workspace template
 type TemplateCreateUpdateOptions struct {
 	IsFromTemplate   bool
 	IsSystemTemplate bool
@@ -216,6 +235,18 @@ type UpdateOverview struct {
 type UpdateRepository interface {
 	Create(context.Context, *Update, *TemplateCreateUpdateOptions) error
 	Update(context.Context, *Update) error
+	// UpdateContent saves the title, content and approval status of the
+	// update and stores the revision in the same transaction. It returns
+	// ErrUpdateSent if the update has been sent
+	UpdateContent(context.Context, *Update, *UpdateRevision) error
+	ListRevisions(context.Context, uuid.UUID) ([]UpdateRevision, error)
+	GetRevision(context.Context, FetchUpdateRevisionOptions) (*UpdateRevision, error)
+	// GetSentRevision returns the version of the update that was last sent
+	GetSentRevision(context.Context, uuid.UUID) (*UpdateRevision, error)
+	// AddApproval stores the decision of the approver and the resulting
+	// approval status of the update in the same transaction
+	AddApproval(context.Context, *Update, *UpdateApproval) error
+	ListApprovals(context.Context, uuid.UUID) ([]UpdateApproval, error)
 	Get(context.Context, FetchUpdateOptions) (*Update, error)
 	GetByID(context.Context, uuid.UUID) (*Update, error)
 	List(context.Context, ListUpdateOptions) ([]Update, int64, error)
@@ -223,12 +254,30 @@ type UpdateRepository interface {
 	Delete(context.Context, *Update) error
 	TogglePinned(context.Context, *Update) error
 	GetSchedule(context.Context, uuid.UUID) (*UpdateSchedule, error)
+	FindSchedule(context.Context, FetchUpdateScheduleOptions) (*UpdateSchedule, error)
+	ListSchedules(context.Context, uuid.UUID) ([]UpdateSchedule, error)
+	// RescheduleSend and CancelSchedule only change a schedule that is
+	// still scheduled. ErrUpdateScheduleNotModifiable is returned if it
+	// has been picked up for processing in the meantime
+	RescheduleSend(context.Context, *UpdateSchedule) error
+	// CancelSchedule also removes the pending recipients of the schedule
+	// and moves the update back to a draft
+	CancelSchedule(context.Context, *UpdateSchedule) error
+	// SendUpdate also freezes the content of the update in a sent revision
+	// when it is a live schedule
 	SendUpdate(context.Context, *CreateUpdateOptions) error
 	GetStatByEmailID(context.Context, string,
 		UpdateRecipientLogProvider) (*UpdateRecipientLog, *UpdateRecipientStat, error)
 	Stat(context.Context, *Update) (*UpdateStat, error)
 	UpdateStat(context.Context, *UpdateStat, *UpdateRecipientStat) error
 	RecipientStat(context.Context, *Update) ([]UpdateRecipient, error)
+	// GetRecipient also loads the contact and the stat of the recipient
+	// if the update has been sent to them
+	GetRecipient(context.Context, Reference) (*UpdateRecipient, error)
+	// RecordClick stores the click and bumps the click counters of both
+	// the update and the recipient
+	RecordClick(context.Context, *UpdateLinkClick) error
+	LinkStats(context.Context, *Update) ([]UpdateLinkStat, error)
 
 	Overview(context.Context, uuid.UUID) (*UpdateOverview, error)
 }
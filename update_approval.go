@@ -0,0 +1,56 @@
+package malak
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrUpdateNotApproved = MalakError("update must be approved before it can be sent")
+	ErrUpdateNotInReview = MalakError("update is not in review")
+)
+
+// ENUM(draft,in_review,approved)
+type UpdateApprovalStatus string
+
+// ENUM(approved,changes_requested)
+type UpdateApprovalDecision string
+
+// UpdateApproval is the sign off of an approver on an update that is in
+// review. Requesting changes moves the update back to a draft
+type UpdateApproval struct {
+	ID          uuid.UUID              `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference              `json:"reference,omitempty"`
+	UpdateID    uuid.UUID              `json:"update_id,omitempty"`
+	WorkspaceID uuid.UUID              `json:"workspace_id,omitempty"`
+	ApproverID  uuid.UUID              `json:"approver_id,omitempty"`
+	Decision    UpdateApprovalDecision `json:"decision,omitempty"`
+	Comment     string                 `json:"comment,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+
+	bun.BaseModel `bun:"table:update_approvals,alias:update_approval" json:"-"`
+}
+
+// ApprovalPreferences is the review policy of a workspace. When approval
+// is required, updates can only be sent once one of the approvers signs off
+type ApprovalPreferences struct {
+	RequireApproval bool        `json:"require_approval"`
+	Approvers       []uuid.UUID `json:"approvers,omitempty"`
+}
+
+func (a ApprovalPreferences) IsApprover(userID uuid.UUID) bool {
+	return slices.Contains(a.Approvers, userID)
+}
+
+// CanSend reports if the update can be sent to investors under the policy
+func (a ApprovalPreferences) CanSend(update *Update) bool {
+	if !a.RequireApproval {
+		return true
+	}
+
+	return update.ApprovalStatus == UpdateApprovalStatusApproved
+}
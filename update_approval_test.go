@@ -0,0 +1,82 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalPreferences_CanSend(t *testing.T) {
+	approver := uuid.New()
+
+	tt := []struct {
+		name        string
+		preferences ApprovalPreferences
+		status      UpdateApprovalStatus
+		canSend     bool
+	}{
+		{
+			name:        "approval not required",
+			preferences: ApprovalPreferences{},
+			status:      UpdateApprovalStatusDraft,
+			canSend:     true,
+		},
+		{
+			name: "draft",
+			preferences: ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{approver},
+			},
+			status: UpdateApprovalStatusDraft,
+		},
+		{
+			name: "in review",
+			preferences: ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{approver},
+			},
+			status: UpdateApprovalStatusInReview,
+		},
+		{
+			name: "approved",
+			preferences: ApprovalPreferences{
+				RequireApproval: true,
+				Approvers:       []uuid.UUID{approver},
+			},
+			status:  UpdateApprovalStatusApproved,
+			canSend: true,
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			require.Equal(t, v.canSend, v.preferences.CanSend(&Update{
+				ApprovalStatus: v.status,
+			}))
+		})
+	}
+}
+
+func TestApprovalPreferences_IsApprover(t *testing.T) {
+	approver := uuid.New()
+
+	preferences := ApprovalPreferences{
+		RequireApproval: true,
+		Approvers:       []uuid.UUID{approver},
+	}
+
+	require.True(t, preferences.IsApprover(approver))
+	require.False(t, preferences.IsApprover(uuid.New()))
+}
+
+func TestUpdate_ContentChanged(t *testing.T) {
+	update := &Update{ApprovalStatus: UpdateApprovalStatusApproved}
+	update.ContentChanged()
+	require.Equal(t, UpdateApprovalStatusDraft, update.ApprovalStatus)
+
+	// approvers are reviewing the latest content already
+	update = &Update{ApprovalStatus: UpdateApprovalStatusInReview}
+	update.ContentChanged()
+	require.Equal(t, UpdateApprovalStatusInReview, update.ApprovalStatus)
+}
@@ -0,0 +1,61 @@
+package malak
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// UpdateLinkClick is recorded every time a recipient clicks a link in an
+// update email
+type UpdateLinkClick struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	UpdateID    uuid.UUID `json:"update_id,omitempty"`
+	RecipientID uuid.UUID `json:"recipient_id,omitempty"`
+	URL         string    `json:"url,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+
+	bun.BaseModel `bun:"table:update_link_clicks" json:"-"`
+}
+
+// UpdateLinkStat is the click breakdown for a single link in an update
+type UpdateLinkStat struct {
+	URL          string `json:"url,omitempty" validate:"required"`
+	TotalClicks  int64  `json:"total_clicks,omitempty" validate:"required"`
+	UniqueClicks int64  `json:"unique_clicks,omitempty" validate:"required"`
+}
+
+// SignClick generates the signature for a tracked link so the redirect
+// endpoint cannot be used to send people to arbitrary urls
+func SignClick(secret string, recipient Reference, url string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("click:"))
+	mac.Write([]byte(recipient))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(url))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyClick(secret string, recipient Reference, url, signature string) bool {
+	expected := SignClick(secret, recipient, url)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// SignOpen generates the signature for the open tracking pixel of a
+// recipient
+func SignOpen(secret string, recipient Reference) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("open:"))
+	mac.Write([]byte(recipient))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyOpen(secret string, recipient Reference, signature string) bool {
+	expected := SignOpen(secret, recipient)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,29 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClickSignature(t *testing.T) {
+	signature := SignClick("secret", "recipient_123", "https://malak.vc")
+
+	require.True(t, VerifyClick("secret", "recipient_123", "https://malak.vc", signature))
+
+	require.False(t, VerifyClick("other", "recipient_123", "https://malak.vc", signature))
+	require.False(t, VerifyClick("secret", "recipient_456", "https://malak.vc", signature))
+	require.False(t, VerifyClick("secret", "recipient_123", "https://evil.com", signature))
+}
+
+func TestOpenSignature(t *testing.T) {
+	signature := SignOpen("secret", "recipient_123")
+
+	require.True(t, VerifyOpen("secret", "recipient_123", signature))
+
+	require.False(t, VerifyOpen("other", "recipient_123", signature))
+	require.False(t, VerifyOpen("secret", "recipient_456", signature))
+
+	// click and open signatures are not interchangeable
+	require.False(t, VerifyOpen("secret", "recipient_123", SignUnsubscribe("secret", "recipient_123")))
+}
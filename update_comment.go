@@ -0,0 +1,130 @@
+package malak
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrUpdateCommentNotFound = MalakError("comment not found")
+)
+
+// ENUM(investor,team)
+type UpdateCommentAuthor string
+
+// UpdateComment is a message left on a sent update. Investors start threads
+// from the public view of the update and the team replies in them.
+// Every comment in a thread belongs to the recipient that started it
+type UpdateComment struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference `json:"reference,omitempty"`
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	UpdateID    uuid.UUID `json:"update_id,omitempty"`
+	RecipientID uuid.UUID `json:"recipient_id,omitempty"`
+
+	// ParentID is empty for the comment that starts a thread
+	ParentID uuid.UUID `bun:",nullzero" json:"parent_id,omitempty"`
+
+	AuthorType UpdateCommentAuthor `json:"author_type,omitempty"`
+	// AuthorName is copied from the contact or team member when the
+	// comment is made so the public view does not need either of them
+	AuthorName string `json:"author_name,omitempty"`
+	// UserID is only set on replies from the team
+	UserID  uuid.UUID `bun:",nullzero" json:"user_id,omitempty"`
+	Content string    `json:"content,omitempty"`
+
+	Recipient *UpdateRecipient `bun:"rel:belongs-to,join:recipient_id=id" json:"recipient,omitempty"`
+	User      *User            `bun:"rel:belongs-to,join:user_id=id" json:"user,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	bun.BaseModel `bun:"table:update_comments,alias:update_comment" json:"-"`
+}
+
+func (u *UpdateComment) IsThread() bool { return u.ParentID == uuid.Nil }
+
+// ContactDisplayName is the name investors comment with. The email is used
+// if the contact has no name
+func ContactDisplayName(contact *Contact) string {
+	name := strings.TrimSpace(contact.FirstName + " " + contact.LastName)
+	if name == "" {
+		return contact.Email.String()
+	}
+
+	return name
+}
+
+// UpdateCommentThread is a comment from an investor and every reply to it
+type UpdateCommentThread struct {
+	Comment UpdateComment   `json:"comment,omitempty" validate:"required"`
+	Replies []UpdateComment `json:"replies" validate:"required"`
+}
+
+// GroupUpdateComments arranges comments into threads. Comments must be
+// sorted from the oldest. Replies to threads that are not in the list are
+// dropped
+func GroupUpdateComments(comments []UpdateComment) []UpdateCommentThread {
+	threads := make([]UpdateCommentThread, 0)
+	positions := make(map[uuid.UUID]int)
+
+	for _, comment := range comments {
+		if comment.IsThread() {
+			positions[comment.ID] = len(threads)
+			threads = append(threads, UpdateCommentThread{
+				Comment: comment,
+				Replies: make([]UpdateComment, 0),
+			})
+			continue
+		}
+
+		idx, ok := positions[comment.ParentID]
+		if !ok {
+			continue
+		}
+
+		threads[idx].Replies = append(threads[idx].Replies, comment)
+	}
+
+	return threads
+}
+
+// SignUpdateView generates the signature for the link to the public view
+// of an update. The link lets the recipient react and comment as
+// themselves
+func SignUpdateView(secret string, recipient Reference) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("view:"))
+	mac.Write([]byte(recipient))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyUpdateView(secret string, recipient Reference, signature string) bool {
+	expected := SignUpdateView(secret, recipient)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+type FetchUpdateCommentOptions struct {
+	UpdateID  uuid.UUID
+	Reference Reference
+}
+
+type ListUpdateCommentOptions struct {
+	UpdateID uuid.UUID
+	// RecipientID limits the comments to the threads of a single recipient
+	RecipientID uuid.UUID
+}
+
+type UpdateCommentRepository interface {
+	Create(context.Context, *UpdateComment) error
+	Get(context.Context, FetchUpdateCommentOptions) (*UpdateComment, error)
+	// List returns the comments sorted from the oldest
+	List(context.Context, ListUpdateCommentOptions) ([]UpdateComment, error)
+}
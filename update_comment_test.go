@@ -0,0 +1,59 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupUpdateComments(t *testing.T) {
+	first := UpdateComment{ID: uuid.New()}
+	second := UpdateComment{ID: uuid.New()}
+
+	threads := GroupUpdateComments([]UpdateComment{
+		first,
+		{ID: uuid.New(), ParentID: first.ID},
+		second,
+		{ID: uuid.New(), ParentID: first.ID},
+		// reply to a thread that was not fetched
+		{ID: uuid.New(), ParentID: uuid.New()},
+	})
+
+	require.Len(t, threads, 2)
+	require.Equal(t, first.ID, threads[0].Comment.ID)
+	require.Len(t, threads[0].Replies, 2)
+	require.Equal(t, second.ID, threads[1].Comment.ID)
+	require.Empty(t, threads[1].Replies)
+
+	require.Empty(t, GroupUpdateComments(nil))
+}
+
+func TestUpdateViewSignature(t *testing.T) {
+	signature := SignUpdateView("secret", "update_recipient_123")
+
+	require.True(t, VerifyUpdateView("secret", "update_recipient_123", signature))
+	require.False(t, VerifyUpdateView("secret", "update_recipient_456", signature))
+	require.False(t, VerifyUpdateView("other", "update_recipient_123", signature))
+
+	// links for other actions cannot be reused to view the update
+	require.False(t, VerifyUpdateView("secret", "update_recipient_123",
+		SignUnsubscribe("secret", "update_recipient_123")))
+}
+
+func TestContactDisplayName(t *testing.T) {
+	require.Equal(t, "Lanre Adelowo", ContactDisplayName(&Contact{
+		FirstName: "Lanre",
+		LastName:  "Adelowo",
+		Email:     "lanre@example.com",
+	}))
+
+	require.Equal(t, "Lanre", ContactDisplayName(&Contact{
+		FirstName: "Lanre",
+		Email:     "lanre@example.com",
+	}))
+
+	require.Equal(t, "lanre@example.com", ContactDisplayName(&Contact{
+		Email: "lanre@example.com",
+	}))
+}
@@ -89,6 +89,8 @@ const (
 	RecipientStatusSent RecipientStatus = "sent"
 	// RecipientStatusFailed is a RecipientStatus of type failed.
 	RecipientStatusFailed RecipientStatus = "failed"
+	// RecipientStatusSkipped is a RecipientStatus of type skipped.
+	RecipientStatusSkipped RecipientStatus = "skipped"
 )
 
 var ErrInvalidRecipientStatus = errors.New("not a valid RecipientStatus")
@@ -109,6 +111,7 @@ var _RecipientStatusValue = map[string]RecipientStatus{
 	"pending": RecipientStatusPending,
 	"sent":    RecipientStatusSent,
 	"failed":  RecipientStatusFailed,
+	"skipped": RecipientStatusSkipped,
 }
 
 // ParseRecipientStatus attempts to convert a string to a RecipientStatus.
@@ -160,6 +163,10 @@ const (
 	UpdateRecipientLogProviderSendgrid UpdateRecipientLogProvider = "sendgrid"
 	// UpdateRecipientLogProviderSmtp is a UpdateRecipientLogProvider of type smtp.
 	UpdateRecipientLogProviderSmtp UpdateRecipientLogProvider = "smtp"
+	// UpdateRecipientLogProviderSes is a UpdateRecipientLogProvider of type ses.
+	UpdateRecipientLogProviderSes UpdateRecipientLogProvider = "ses"
+	// UpdateRecipientLogProviderPostmark is a UpdateRecipientLogProvider of type postmark.
+	UpdateRecipientLogProviderPostmark UpdateRecipientLogProvider = "postmark"
 )
 
 var ErrInvalidUpdateRecipientLogProvider = errors.New("not a valid UpdateRecipientLogProvider")
@@ -180,6 +187,8 @@ var _UpdateRecipientLogProviderValue = map[string]UpdateRecipientLogProvider{
 	"resend":   UpdateRecipientLogProviderResend,
 	"sendgrid": UpdateRecipientLogProviderSendgrid,
 	"smtp":     UpdateRecipientLogProviderSmtp,
+	"ses":      UpdateRecipientLogProviderSes,
+	"postmark": UpdateRecipientLogProviderPostmark,
 }
 
 // ParseUpdateRecipientLogProvider attempts to convert a string to a UpdateRecipientLogProvider.
@@ -0,0 +1,115 @@
+package malak
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrUpdateLinkNotFound          = MalakError("update link not found")
+	ErrUpdateViewerSessionNotFound = MalakError("update viewer session not found")
+)
+
+// UpdateLink is a shareable link to the web version of a sent update
+type UpdateLink struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference `json:"reference,omitempty"`
+	UpdateID    uuid.UUID `json:"update_id,omitempty"`
+	Update      *Update   `json:"-" bun:"rel:belongs-to,join:update_id=id"`
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+
+	IsPasswordProtected bool `json:"is_password_protected"`
+	// Password is hashed. It is empty if the link is not password protected
+	Password Password `bun:",nullzero" json:"-"`
+
+	// Sometimes, you want to share a link containing a specific update
+	// for a few minutes or seconds :)
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bun:",nullzero"`
+
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at" `
+	UpdatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at" `
+	DeletedAt     *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
+	bun.BaseModel `bun:"table:update_links,alias:update_link" json:"-"`
+}
+
+func (u *UpdateLink) IsExpired() bool {
+	return u.ExpiresAt != nil && u.ExpiresAt.Before(time.Now())
+}
+
+// ENUM(link,workspace_page)
+type UpdateViewSource string
+
+// UpdateViewerSession is recorded every time the web version of an update
+// is opened either from a shared link or the public page of the workspace
+type UpdateViewerSession struct {
+	ID uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+
+	Reference Reference        `json:"reference,omitempty"`
+	UpdateID  uuid.UUID        `json:"update_id,omitempty"`
+	LinkID    uuid.UUID        `json:"link_id,omitempty" bun:",nullzero"`
+	Source    UpdateViewSource `json:"source,omitempty"`
+
+	SessionID Reference `json:"session_id,omitempty"`
+
+	DeviceInfo       string    `json:"device_info,omitempty"`
+	OS               string    `json:"os,omitempty"`
+	Browser          string    `json:"browser,omitempty"`
+	IPAddress        string    `json:"ip_address,omitempty"`
+	Country          string    `json:"country,omitempty"`
+	City             string    `json:"city,omitempty"`
+	ViewedAt         time.Time `json:"viewed_at,omitempty" bun:",nullzero,notnull,default:current_timestamp"`
+	TimeSpentSeconds int64     `json:"time_spent_seconds,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	bun.BaseModel `bun:"table:update_viewer_sessions,alias:update_viewer_session" json:"-"`
+}
+
+// PublicUpdate is the web version of a sent update
+type PublicUpdate struct {
+	Reference     Reference  `json:"reference,omitempty" validate:"required"`
+	Title         string     `json:"title,omitempty" validate:"required"`
+	WorkspaceName string     `json:"workspace_name,omitempty" validate:"required"`
+	LogoURL       string     `json:"logo_url,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	// HTML is the rendered content of the update
+	HTML string `json:"html,omitempty"`
+}
+
+type FetchUpdateLinkOptions struct {
+	UpdateID  uuid.UUID
+	Reference Reference
+}
+
+type ListUpdateViewerSessionOptions struct {
+	Paginator Paginator
+	UpdateID  uuid.UUID
+}
+
+type ListPublicUpdatesOptions struct {
+	Paginator   Paginator
+	WorkspaceID uuid.UUID
+}
+
+type UpdateLinkRepository interface {
+	Create(context.Context, *UpdateLink) error
+	Get(context.Context, FetchUpdateLinkOptions) (*UpdateLink, error)
+	List(context.Context, uuid.UUID) ([]UpdateLink, error)
+	Revoke(context.Context, *UpdateLink) error
+	// PublicDetails loads a link alongside its update. Revoked links are
+	// not returned
+	PublicDetails(context.Context, Reference) (*UpdateLink, error)
+	// ListPublicUpdates lists the sent updates of a workspace that have
+	// been made public. The most recently sent comes first
+	ListPublicUpdates(context.Context, ListPublicUpdatesOptions) ([]Update, int64, error)
+
+	CreateSession(context.Context, *UpdateViewerSession) error
+	UpdateSession(context.Context, *UpdateViewerSession) error
+	FindSession(context.Context, Reference) (*UpdateViewerSession, error)
+	ListSessions(context.Context, ListUpdateViewerSessionOptions) ([]UpdateViewerSession, int64, error)
+}
@@ -0,0 +1,152 @@
+package malak
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// BlockPropVisibleToList holds the reference of the contact list a block is
+// restricted to. Blocks without it are shown to every recipient
+const BlockPropVisibleToList = "visibleToList"
+
+const mergeFieldMetadataPrefix = "metadata."
+
+// MergeFields are the details of a contact substituted in the text of an
+// update for each recipient. They are written as {{ first_name }},
+// {{ last_name }}, {{ company }}, {{ email }} and {{ metadata.<key> }}.
+// Fields the contact does not have are left empty
+type MergeFields struct {
+	FirstName string
+	LastName  string
+	Company   string
+	Email     Email
+	Metadata  CustomContactMetadata
+}
+
+func NewMergeFields(contact *Contact) MergeFields {
+	if contact == nil {
+		return MergeFields{}
+	}
+
+	return MergeFields{
+		FirstName: contact.FirstName,
+		LastName:  contact.LastName,
+		Company:   contact.Company,
+		Email:     contact.Email,
+		Metadata:  contact.Metadata,
+	}
+}
+
+func (m MergeFields) lookup(name string) (string, bool) {
+	switch name {
+	case "first_name":
+		return m.FirstName, true
+	case "last_name":
+		return m.LastName, true
+	case "company":
+		return m.Company, true
+	case "email":
+		return m.Email.String(), true
+	}
+
+	if key, ok := strings.CutPrefix(name, mergeFieldMetadataPrefix); ok {
+		return m.Metadata[key], true
+	}
+
+	return "", false
+}
+
+// Replace substitutes the merge fields in s. Other variables are left
+// untouched
+func (m MergeFields) Replace(s string) string {
+	return replaceVariables(s, m.lookup)
+}
+
+// ReplaceHTML substitutes the merge fields in an already rendered update.
+// The values come from contacts so they are escaped
+func (m MergeFields) ReplaceHTML(s string) string {
+	return replaceVariables(s, func(name string) (string, bool) {
+		v, ok := m.lookup(name)
+		return html.EscapeString(v), ok
+	})
+}
+
+// ListReferences returns the references of the lists the contact belongs
+// to. The lists have to be loaded with the contact
+func (c *Contact) ListReferences() []Reference {
+	refs := make([]Reference, 0, len(c.Lists))
+
+	for _, mapping := range c.Lists {
+		if mapping.List != nil {
+			refs = append(refs, mapping.List.Reference)
+		}
+	}
+
+	return refs
+}
+
+func visibleToList(block Block) (Reference, bool) {
+	ref, ok := block.Props[BlockPropVisibleToList].(string)
+	if !ok || strings.TrimSpace(ref) == "" {
+		return "", false
+	}
+
+	return Reference(strings.TrimSpace(ref)), true
+}
+
+// ConditionalLists returns the lists blocks are restricted to. Recipients
+// in the same subset of these lists see the same blocks
+func (bc BlockContents) ConditionalLists() []Reference {
+	refs := make([]Reference, 0)
+
+	var walk func(blocks []Block)
+	walk = func(blocks []Block) {
+		for _, block := range blocks {
+			if ref, ok := visibleToList(block); ok && !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+
+			walk(block.Children)
+		}
+	}
+
+	walk(bc)
+
+	return refs
+}
+
+// ForLists drops the blocks restricted to lists that are not given. The
+// children of a dropped block are dropped with it
+func (bc BlockContents) ForLists(lists []Reference) BlockContents {
+	if len(bc) == 0 {
+		return bc
+	}
+
+	filtered := make(BlockContents, 0, len(bc))
+
+	for _, block := range bc {
+		if ref, ok := visibleToList(block); ok && !slices.Contains(lists, ref) {
+			continue
+		}
+
+		if len(block.Children) > 0 {
+			block.Children = BlockContents(block.Children).ForLists(lists)
+		}
+
+		filtered = append(filtered, block)
+	}
+
+	return filtered
+}
+
+// Personalize returns the blocks as the contact sees them. The text of the
+// blocks is modified in place
+func (bc BlockContents) Personalize(contact *Contact) BlockContents {
+	var lists []Reference
+	if contact != nil {
+		lists = contact.ListReferences()
+	}
+
+	return bc.ForLists(lists).replaceText(NewMergeFields(contact).Replace)
+}
@@ -0,0 +1,103 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFields(t *testing.T) {
+	fields := NewMergeFields(&Contact{
+		FirstName: "Ada",
+		Company:   "Lovelace & Co",
+		Email:     "ada@example.com",
+		Metadata:  CustomContactMetadata{"check_size": "$50k"},
+	})
+
+	require.Equal(t, "Hi Ada from Lovelace & Co, thanks for the $50k. {{ month }}",
+		fields.Replace("Hi {{first_name}} from {{ company }}, thanks for the {{metadata.check_size}}. {{ month }}"))
+
+	// missing fields are left empty
+	require.Equal(t, "Hi ,", fields.Replace("Hi {{ last_name }}{{ metadata.unknown }},"))
+
+	require.Equal(t, "<p>Lovelace &amp; Co</p>", fields.ReplaceHTML("<p>{{ company }}</p>"))
+
+	require.Equal(t, "Hi ", NewMergeFields(nil).Replace("Hi {{ first_name }}"))
+}
+
+func TestBlockContents_ForLists(t *testing.T) {
+	paragraph := func(id, list string, children ...Block) Block {
+		block := Block{
+			ID:       id,
+			Type:     "paragraph",
+			Children: children,
+		}
+
+		if list != "" {
+			block.Props = map[string]interface{}{BlockPropVisibleToList: list}
+		}
+
+		return block
+	}
+
+	content := BlockContents{
+		paragraph("intro", ""),
+		paragraph("leads", "list_leads",
+			paragraph("leads-note", "")),
+		paragraph("closing", "",
+			paragraph("angels-note", "list_angels")),
+	}
+
+	require.Equal(t, []Reference{"list_leads", "list_angels"}, content.ConditionalLists())
+
+	ids := func(bc BlockContents) []string {
+		var ids []string
+		var walk func(blocks []Block)
+		walk = func(blocks []Block) {
+			for _, b := range blocks {
+				ids = append(ids, b.ID)
+				walk(b.Children)
+			}
+		}
+
+		walk(bc)
+		return ids
+	}
+
+	require.Equal(t, []string{"intro", "closing"}, ids(content.ForLists(nil)))
+	require.Equal(t, []string{"intro", "leads", "leads-note", "closing"},
+		ids(content.ForLists([]Reference{"list_leads"})))
+	require.Equal(t, []string{"intro", "closing", "angels-note"},
+		ids(content.ForLists([]Reference{"list_angels"})))
+
+	// the original blocks are not modified
+	require.Equal(t, []string{"intro", "leads", "leads-note", "closing", "angels-note"}, ids(content))
+}
+
+func TestBlockContents_Personalize(t *testing.T) {
+	content := BlockContents{
+		{
+			ID:   "greeting",
+			Type: "paragraph",
+			Content: []interface{}{
+				map[string]interface{}{"type": "text", "text": "Hi {{ first_name }}"},
+			},
+		},
+		{
+			ID:    "leads",
+			Type:  "paragraph",
+			Props: map[string]interface{}{BlockPropVisibleToList: "list_leads"},
+		},
+	}
+
+	personalized := content.Personalize(&Contact{
+		FirstName: "Ada",
+		Lists: []ContactListMapping{
+			{List: &ContactList{Reference: "list_angels"}},
+		},
+	})
+
+	require.Len(t, personalized, 1)
+	require.Equal(t, "Hi Ada",
+		personalized[0].Content.([]interface{})[0].(map[string]interface{})["text"])
+}
@@ -0,0 +1,109 @@
+package malak
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrRecurringScheduleNotFound = MalakError("recurring schedule not found")
+)
+
+const (
+	// MaxRecurringDayOfMonth is capped so every month has the day
+	MaxRecurringDayOfMonth = 28
+	MaxRecurringLeadDays   = 14
+)
+
+// ENUM(monthly,quarterly)
+type RecurrenceFrequency string
+
+// Months returns the number of months between two occurrences
+func (r RecurrenceFrequency) Months() int {
+	switch r {
+	case RecurrenceFrequencyQuarterly:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// RecurringUpdateSchedule sends an update on a fixed cadence.
+// LeadDays before every occurrence, a draft is created from the template
+// and the owner is reminded to review it. At NextSendAt, the draft is
+// scheduled to the same recipients
+type RecurringUpdateSchedule struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference `json:"reference,omitempty"`
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+
+	TemplateReference Reference `json:"template_reference,omitempty"`
+	IsSystemTemplate  bool      `json:"is_system_template"`
+
+	Frequency  RecurrenceFrequency `json:"frequency,omitempty"`
+	DayOfMonth int                 `json:"day_of_month,omitempty"`
+	// Hour of the day in UTC
+	Hour     int     `json:"hour"`
+	LeadDays int     `json:"lead_days,omitempty"`
+	Emails   []Email `bun:"type:jsonb" json:"emails,omitempty"`
+
+	NextSendAt time.Time `json:"next_send_at,omitempty"`
+	// DraftUpdateID is the draft created for the upcoming occurrence. It is
+	// empty until the draft has been created
+	DraftUpdateID uuid.UUID `bun:",nullzero" json:"draft_update_id,omitempty"`
+	IsActive      bool      `json:"is_active"`
+
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+	DeletedAt *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
+
+	bun.BaseModel `bun:"table:recurring_update_schedules,alias:recurring_update_schedule" json:"-"`
+}
+
+// NextOccurrence returns the first occurrence strictly after t
+func (r *RecurringUpdateSchedule) NextOccurrence(t time.Time) time.Time {
+	t = t.UTC()
+
+	next := time.Date(t.Year(), t.Month(), r.DayOfMonth, r.Hour, 0, 0, 0, time.UTC)
+	for !next.After(t) {
+		next = next.AddDate(0, r.Frequency.Months(), 0)
+	}
+
+	return next
+}
+
+// DraftAt is when the draft for the upcoming occurrence should be created
+func (r *RecurringUpdateSchedule) DraftAt() time.Time {
+	return r.NextSendAt.AddDate(0, 0, -r.LeadDays)
+}
+
+// Advance moves the schedule to the occurrence after the current one.
+// Occurrences missed while the cron was not running are skipped
+func (r *RecurringUpdateSchedule) Advance(now time.Time) {
+	next := r.NextSendAt.AddDate(0, r.Frequency.Months(), 0)
+	for !next.After(now) {
+		next = next.AddDate(0, r.Frequency.Months(), 0)
+	}
+
+	r.NextSendAt = next
+	r.DraftUpdateID = uuid.Nil
+}
+
+type FetchRecurringScheduleOptions struct {
+	WorkspaceID uuid.UUID
+	Reference   Reference
+}
+
+type RecurringUpdateScheduleRepository interface {
+	Create(context.Context, *RecurringUpdateSchedule) error
+	Get(context.Context, FetchRecurringScheduleOptions) (*RecurringUpdateSchedule, error)
+	List(context.Context, uuid.UUID) ([]RecurringUpdateSchedule, error)
+	Update(context.Context, *RecurringUpdateSchedule) error
+	// Due lists active schedules whose draft should already have been
+	// created
+	Due(context.Context, time.Time) ([]RecurringUpdateSchedule, error)
+}
@@ -0,0 +1,78 @@
+package malak
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringUpdateSchedule_NextOccurrence(t *testing.T) {
+	tt := []struct {
+		name      string
+		frequency RecurrenceFrequency
+		day       int
+		after     time.Time
+		expected  time.Time
+	}{
+		{
+			name:      "later this month",
+			frequency: RecurrenceFrequencyMonthly,
+			day:       15,
+			after:     time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC),
+			expected:  time.Date(2025, time.January, 15, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "day already passed this month",
+			frequency: RecurrenceFrequencyMonthly,
+			day:       5,
+			after:     time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC),
+			expected:  time.Date(2025, time.February, 5, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "exact time is not included",
+			frequency: RecurrenceFrequencyMonthly,
+			day:       10,
+			after:     time.Date(2025, time.January, 10, 9, 0, 0, 0, time.UTC),
+			expected:  time.Date(2025, time.February, 10, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "quarterly rolls over the year",
+			frequency: RecurrenceFrequencyQuarterly,
+			day:       1,
+			after:     time.Date(2025, time.December, 2, 0, 0, 0, 0, time.UTC),
+			expected:  time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, v := range tt {
+		t.Run(v.name, func(t *testing.T) {
+			schedule := &RecurringUpdateSchedule{
+				Frequency:  v.frequency,
+				DayOfMonth: v.day,
+				Hour:       9,
+			}
+
+			require.Equal(t, v.expected, schedule.NextOccurrence(v.after))
+		})
+	}
+}
+
+func TestRecurringUpdateSchedule_Advance(t *testing.T) {
+	schedule := &RecurringUpdateSchedule{
+		Frequency:  RecurrenceFrequencyQuarterly,
+		DayOfMonth: 1,
+		Hour:       9,
+		LeadDays:   3,
+		NextSendAt: time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	require.Equal(t, time.Date(2024, time.December, 29, 9, 0, 0, 0, time.UTC), schedule.DraftAt())
+
+	schedule.Advance(time.Date(2025, time.January, 1, 9, 5, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC), schedule.NextSendAt)
+
+	// missed occurrences are skipped
+	schedule.Advance(time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2025, time.October, 1, 9, 0, 0, 0, time.UTC), schedule.NextSendAt)
+}
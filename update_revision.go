@@ -0,0 +1,157 @@
+package malak
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	ErrUpdateRevisionNotFound = MalakError("update revision not found")
+)
+
+// UpdateRevision is an immutable copy of the content of an update. One is
+// stored every time the content is saved or restored and when the update
+// is sent to investors
+type UpdateRevision struct {
+	ID          uuid.UUID `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	Reference   Reference `json:"reference,omitempty"`
+	UpdateID    uuid.UUID `json:"update_id,omitempty"`
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+
+	Title   string        `json:"title,omitempty"`
+	Content BlockContents `bun:"type:jsonb" json:"content,omitempty"`
+
+	// IsSent marks the exact version sent to investors. ScheduleID is the
+	// live schedule it was sent with
+	IsSent     bool      `json:"is_sent"`
+	ScheduleID uuid.UUID `bun:",nullzero" json:"schedule_id,omitempty"`
+	// Metrics is the text of the inline metrics when the update was sent
+	Metrics ResolvedMetrics `bun:"type:jsonb" json:"metrics,omitempty"`
+
+	// RestoredFrom is the revision this one was restored from
+	RestoredFrom uuid.UUID `bun:",nullzero" json:"restored_from,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+
+	bun.BaseModel `bun:"table:update_revisions,alias:update_revision" json:"-"`
+}
+
+type FetchUpdateRevisionOptions struct {
+	UpdateID  uuid.UUID
+	Reference Reference
+}
+
+// ENUM(added,removed,modified,unchanged)
+type BlockDiffStatus string
+
+// BlockDiff is the change of a single block between two versions of an
+// update. Before is empty for added blocks and After for removed ones
+type BlockDiff struct {
+	BlockID string          `json:"block_id,omitempty"`
+	Status  BlockDiffStatus `json:"status,omitempty"`
+	Before  *Block          `json:"before,omitempty"`
+	After   *Block          `json:"after,omitempty"`
+}
+
+// DiffBlocks compares two versions of the content block by block. Blocks
+// are matched by their id and nested blocks are compared on their own.
+// Blocks are listed in the order of the new version, followed by the
+// removed ones
+func DiffBlocks(before, after BlockContents) []BlockDiff {
+	oldBlocks := flattenBlocks(before)
+	newBlocks := flattenBlocks(after)
+
+	previous := make(map[string]Block, len(oldBlocks))
+	for _, block := range oldBlocks {
+		previous[block.ID] = block
+	}
+
+	current := make(map[string]bool, len(newBlocks))
+
+	diff := make([]BlockDiff, 0, len(newBlocks))
+
+	for _, block := range newBlocks {
+		current[block.ID] = true
+
+		block := block
+
+		old, ok := previous[block.ID]
+		if !ok {
+			diff = append(diff, BlockDiff{
+				BlockID: block.ID,
+				Status:  BlockDiffStatusAdded,
+				After:   &block,
+			})
+			continue
+		}
+
+		status := BlockDiffStatusUnchanged
+		if !sameBlock(old, block) {
+			status = BlockDiffStatusModified
+		}
+
+		diff = append(diff, BlockDiff{
+			BlockID: block.ID,
+			Status:  status,
+			Before:  &old,
+			After:   &block,
+		})
+	}
+
+	for _, block := range oldBlocks {
+		if current[block.ID] {
+			continue
+		}
+
+		block := block
+
+		diff = append(diff, BlockDiff{
+			BlockID: block.ID,
+			Status:  BlockDiffStatusRemoved,
+			Before:  &block,
+		})
+	}
+
+	return diff
+}
+
+// flattenBlocks lists the blocks depth first without their children
+func flattenBlocks(blocks BlockContents) []Block {
+	flattened := make([]Block, 0, len(blocks))
+
+	for _, block := range blocks {
+		children := block.Children
+
+		block.Children = nil
+		flattened = append(flattened, block)
+		flattened = append(flattened, flattenBlocks(children)...)
+	}
+
+	return flattened
+}
+
+// IsSameContent reports if both versions have the same blocks in the same
+// order
+func IsSameContent(a, b BlockContents) bool {
+	first, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	second, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(first) == string(second)
+}
+
+// sameBlock compares blocks through their json encoding since the content
+// can either be decoded from the database or built in code
+func sameBlock(a, b Block) bool {
+	return IsSameContent(BlockContents{a}, BlockContents{b})
+}
@@ -0,0 +1,70 @@
+package malak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffBlocks(t *testing.T) {
+	paragraph := func(id, text string, children ...Block) Block {
+		return Block{
+			ID:   id,
+			Type: "paragraph",
+			Content: []interface{}{
+				map[string]interface{}{"type": "text", "text": text},
+			},
+			Children: children,
+		}
+	}
+
+	before := BlockContents{
+		paragraph("intro", "Hello investors"),
+		paragraph("revenue", "Revenue grew by 10%",
+			paragraph("revenue-note", "mostly from new customers")),
+		paragraph("hiring", "We are hiring"),
+	}
+
+	after := BlockContents{
+		paragraph("intro", "Hello investors"),
+		paragraph("revenue", "Revenue grew by 20%",
+			paragraph("revenue-note", "mostly from new customers")),
+		paragraph("runway", "We have 18 months of runway"),
+	}
+
+	diff := DiffBlocks(before, after)
+
+	statuses := make(map[string]BlockDiffStatus, len(diff))
+	order := make([]string, 0, len(diff))
+
+	for _, d := range diff {
+		statuses[d.BlockID] = d.Status
+		order = append(order, d.BlockID)
+	}
+
+	require.Equal(t, []string{"intro", "revenue", "revenue-note", "runway", "hiring"}, order)
+	require.Equal(t, map[string]BlockDiffStatus{
+		"intro":        BlockDiffStatusUnchanged,
+		"revenue":      BlockDiffStatusModified,
+		"revenue-note": BlockDiffStatusUnchanged,
+		"runway":       BlockDiffStatusAdded,
+		"hiring":       BlockDiffStatusRemoved,
+	}, statuses)
+
+	require.Nil(t, diff[3].Before)
+	require.NotNil(t, diff[3].After)
+	require.Nil(t, diff[4].After)
+	require.NotNil(t, diff[4].Before)
+
+	// children are compared on their own
+	require.Nil(t, diff[1].After.Children)
+}
+
+func TestIsSameContent(t *testing.T) {
+	first := Block{ID: "first", Type: "paragraph"}
+	second := Block{ID: "second", Type: "paragraph"}
+
+	require.True(t, IsSameContent(BlockContents{first, second}, BlockContents{first, second}))
+	require.False(t, IsSameContent(BlockContents{first, second}, BlockContents{second, first}))
+	require.False(t, IsSameContent(BlockContents{first}, BlockContents{first, second}))
+}
@@ -29,6 +29,9 @@ type UserRole struct {
 	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
 	UserID      uuid.UUID `json:"user_id,omitempty"`
 
+	// Only loaded when listing the members of a workspace
+	User *User `json:"user,omitempty" bun:"rel:belongs-to,join:user_id=id"`
+
 	bun.BaseModel `bun:"table:roles" json:"-"`
 }
 
@@ -64,6 +67,13 @@ type User struct {
 	// log you in. Else if you have password
 	Password *string `json:"-" bun:"password,nullzero"`
 
+	// TwoFactorSecret is set as soon as the user starts setting up 2FA but
+	// it is only enforced once TwoFactorEnabledAt is set.
+	// It holds the reference from the secrets provider and never the raw
+	// totp secret
+	TwoFactorSecret    *string    `json:"-" bun:"two_factor_secret,nullzero"`
+	TwoFactorEnabledAt *time.Time `json:"two_factor_enabled_at,omitempty" bun:"two_factor_enabled_at,nullzero"`
+
 	FullName string        `json:"full_name"`
 	Metadata *UserMetadata `json:"metadata" `
 
@@ -80,6 +90,10 @@ func (u *User) HasWorkspace() bool { return u.Metadata.CurrentWorkspace != uuid.
 
 func (u *User) HasPassword() bool { return u.Password != nil }
 
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TwoFactorEnabledAt != nil && u.TwoFactorSecret != nil
+}
+
 func (u *User) CanAccessWorkspace(id uuid.UUID) bool {
 	for _, role := range u.Roles {
 		if role.WorkspaceID.String() == id.String() {
@@ -498,6 +498,7 @@ export enum MalakRecipientStatus {
   RecipientStatusPending = "pending",
   RecipientStatusSent = "sent",
   RecipientStatusFailed = "failed",
+  RecipientStatusSkipped = "skipped",
 }
 
 export enum MalakRevocationType {
@@ -0,0 +1,349 @@
+package malak
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotDue   = errors.New("webhook delivery is not due or has been claimed already")
+	ErrWebhookMaxLimit         = errors.New("you can only have a maximum of 10 webhooks")
+)
+
+const (
+	// MaxWebhookAttempts is how many times a delivery is tried before it is
+	// marked as failed. Failed deliveries can still be redelivered manually
+	MaxWebhookAttempts = 6
+
+	// WebhookSignatureHeader contains the time the payload was signed and the
+	// signature itself. e.g t=1700000000,v1=5257a869...
+	WebhookSignatureHeader = "Malak-Signature"
+	WebhookEventHeader     = "Malak-Event"
+	WebhookDeliveryHeader  = "Malak-Delivery"
+
+	// WebhookDeliveryClaimDuration is how long a queued delivery has to be
+	// attempted before it is considered due again
+	WebhookDeliveryClaimDuration = time.Minute * 5
+)
+
+// webhookBackoff is how long to wait before retrying a failed delivery. The
+// index is the number of attempts already made
+var webhookBackoff = []time.Duration{
+	time.Minute,
+	time.Minute * 5,
+	time.Minute * 30,
+	time.Hour * 2,
+	time.Hour * 6,
+}
+
+// ENUM(
+// update_opened,deck_viewer_session_created,
+// fundraising_contact_moved,integration_sync_failed)
+type WebhookEvent string
+
+// ENUM(pending,succeeded,failed)
+type WebhookDeliveryStatus string
+
+type Webhook struct {
+	ID          uuid.UUID      `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	WorkspaceID uuid.UUID      `json:"workspace_id,omitempty"`
+	CreatedBy   uuid.UUID      `json:"created_by,omitempty"`
+	Reference   Reference      `json:"reference,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Events      []WebhookEvent `bun:"type:jsonb" json:"events,omitempty"`
+	IsActive    bool           `json:"is_active"`
+
+	// Secret is the key the signing secret is stored under in the secrets
+	// provider. The signing secret is used to sign every payload so
+	// receivers can verify it came from us
+	Secret string `json:"-"`
+
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+	DeletedAt *time.Time `bun:",soft_delete,nullzero" json:"-,omitempty"`
+
+	bun.BaseModel `json:"-"`
+}
+
+// IsSubscribed reports if the webhook should receive the event
+func (w *Webhook) IsSubscribed(event WebhookEvent) bool {
+	if !w.IsActive {
+		return false
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookPayload is the body sent to webhook endpoints
+type WebhookPayload struct {
+	ID          Reference    `json:"id"`
+	Event       WebhookEvent `json:"event"`
+	WorkspaceID uuid.UUID    `json:"workspace_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Data        any          `json:"data"`
+}
+
+type WebhookUpdateOpenedData struct {
+	UpdateReference    Reference `json:"update_reference"`
+	RecipientReference Reference `json:"recipient_reference"`
+	Email              Email     `json:"email"`
+	OpenedAt           time.Time `json:"opened_at"`
+}
+
+type WebhookDeckViewerSessionCreatedData struct {
+	DeckReference    Reference `json:"deck_reference"`
+	SessionReference Reference `json:"session_reference"`
+	Country          string    `json:"country,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type WebhookFundraisingContactMovedData struct {
+	PipelineReference Reference `json:"pipeline_reference"`
+	ContactReference  Reference `json:"contact_reference"`
+	FromColumnID      uuid.UUID `json:"from_column_id"`
+	ToColumnID        uuid.UUID `json:"to_column_id"`
+	ToColumnTitle     string    `json:"to_column_title"`
+}
+
+type WebhookIntegrationSyncFailedData struct {
+	IntegrationReference Reference `json:"integration_reference"`
+	IntegrationName      string    `json:"integration_name"`
+	Error                string    `json:"error"`
+}
+
+type WebhookDelivery struct {
+	ID          uuid.UUID             `bun:"type:uuid,default:uuid_generate_v4(),pk" json:"id,omitempty"`
+	WebhookID   uuid.UUID             `json:"webhook_id,omitempty"`
+	WorkspaceID uuid.UUID             `json:"workspace_id,omitempty"`
+	Reference   Reference             `json:"reference,omitempty"`
+	Event       WebhookEvent          `json:"event,omitempty"`
+	Payload     json.RawMessage       `bun:"type:jsonb" json:"payload,omitempty" swaggertype:"object"`
+	Status      WebhookDeliveryStatus `json:"status,omitempty"`
+	Attempts    int                   `json:"attempts"`
+
+	ResponseStatusCode int    `json:"response_status_code,omitempty"`
+	ResponseBody       string `json:"response_body,omitempty"`
+	LastError          string `json:"last_error,omitempty"`
+
+	NextAttemptAt *time.Time `bun:",nullzero" json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time `bun:",nullzero" json:"delivered_at,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at,omitempty"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at,omitempty"`
+
+	bun.BaseModel `json:"-"`
+}
+
+// NewWebhookDeliveries builds a pending delivery of the event for every
+// webhook subscribed to it. They are due immediately
+func NewWebhookDeliveries(generator ReferenceGeneratorOperation,
+	webhooks []Webhook, event WebhookEvent, data any) ([]*WebhookDelivery, error) {
+
+	deliveries := make([]*WebhookDelivery, 0, len(webhooks))
+
+	now := time.Now()
+
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribed(event) {
+			continue
+		}
+
+		reference := generator.Generate(EntityTypeWebhookDelivery)
+
+		payload, err := json.Marshal(WebhookPayload{
+			ID:          reference,
+			Event:       event,
+			WorkspaceID: webhook.WorkspaceID,
+			CreatedAt:   now,
+			Data:        data,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			WorkspaceID:   webhook.WorkspaceID,
+			Reference:     reference,
+			Event:         event,
+			Payload:       payload,
+			Status:        WebhookDeliveryStatusPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt updates the delivery with the outcome of an attempt. Failed
+// attempts are retried with an increasing backoff until MaxWebhookAttempts
+// is reached
+func (d *WebhookDelivery) RecordAttempt(statusCode int, body string, err error, now time.Time) {
+	d.Attempts++
+	d.ResponseStatusCode = statusCode
+	d.ResponseBody = ""
+	d.LastError = ""
+	d.UpdatedAt = now
+
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		// the body of failed responses is never kept as it could be the
+		// error page of a service the endpoint was not meant to reach
+		d.ResponseBody = body
+		d.Status = WebhookDeliveryStatusSucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		return
+	}
+
+	if err != nil {
+		d.LastError = err.Error()
+	} else {
+		d.LastError = fmt.Sprintf("endpoint responded with status code %d", statusCode)
+	}
+
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = WebhookDeliveryStatusFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	backoff := webhookBackoff[len(webhookBackoff)-1]
+	if d.Attempts-1 < len(webhookBackoff) {
+		backoff = webhookBackoff[d.Attempts-1]
+	}
+
+	next := now.Add(backoff)
+
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = &next
+}
+
+// Redeliver queues the delivery again with a fresh set of attempts
+func (d *WebhookDelivery) Redeliver(now time.Time) {
+	d.Attempts = 0
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = &now
+	d.UpdatedAt = now
+}
+
+// Cancel marks the delivery as failed without making another attempt
+func (d *WebhookDelivery) Cancel(reason string) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.NextAttemptAt = nil
+	d.LastError = reason
+}
+
+// SignWebhookPayload signs the payload alongside the time it was sent to
+// prevent replays. The value is used as the WebhookSignatureHeader
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeWebhookSignature(secret, ts, payload))
+}
+
+// VerifyWebhookSignature checks a signature created by SignWebhookPayload.
+// Signatures older than tolerance are rejected
+func VerifyWebhookSignature(secret, signature string, payload []byte,
+	tolerance time.Duration, now time.Time) error {
+
+	var ts, v1 string
+
+	for _, part := range strings.Split(signature, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || v1 == "" {
+		return errors.New("invalid signature header")
+	}
+
+	if now.Sub(time.Unix(unix, 0)) > tolerance {
+		return errors.New("signature has expired")
+	}
+
+	if !hmac.Equal([]byte(v1), []byte(computeWebhookSignature(secret, ts, payload))) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
+
+func computeWebhookSignature(secret, timestamp string, payload []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	_, _ = h.Write([]byte(timestamp))
+	_, _ = h.Write([]byte("."))
+	_, _ = h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type FetchWebhookOptions struct {
+	WorkspaceID uuid.UUID
+	Reference   Reference
+}
+
+type FetchWebhookDeliveryOptions struct {
+	WebhookID uuid.UUID
+	Reference Reference
+}
+
+type ListWebhookDeliveryOptions struct {
+	Paginator Paginator
+	WebhookID uuid.UUID
+}
+
+type WebhookRepository interface {
+	Create(context.Context, *Webhook) error
+	Update(context.Context, *Webhook) error
+	Delete(context.Context, *Webhook) error
+	Get(context.Context, FetchWebhookOptions) (*Webhook, error)
+	List(context.Context, uuid.UUID) ([]Webhook, error)
+	// ListSubscribed returns the active webhooks of the workspace that
+	// receive the event
+	ListSubscribed(context.Context, uuid.UUID, WebhookEvent) ([]Webhook, error)
+
+	CreateDeliveries(context.Context, []*WebhookDelivery) error
+	UpdateDelivery(context.Context, *WebhookDelivery) error
+	GetDelivery(context.Context, FetchWebhookDeliveryOptions) (*WebhookDelivery, error)
+	// GetDeliveryByID also returns the webhook the delivery belongs to
+	GetDeliveryByID(context.Context, uuid.UUID) (*Webhook, *WebhookDelivery, error)
+	ListDeliveries(context.Context, ListWebhookDeliveryOptions) ([]WebhookDelivery, int64, error)
+	// ClaimDueDeliveries returns pending deliveries that should be
+	// attempted at the given time. They are not returned again until
+	// WebhookDeliveryClaimDuration has passed so they are not queued twice
+	ClaimDueDeliveries(context.Context, time.Time, int) ([]WebhookDelivery, error)
+	// ClaimDelivery claims a single pending delivery the same way
+	// ClaimDueDeliveries does. ErrWebhookDeliveryNotDue is returned if it
+	// is not due or someone else claimed it first
+	ClaimDelivery(context.Context, uuid.UUID, time.Time) error
+}
@@ -0,0 +1,120 @@
+package malak
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_IsSubscribed(t *testing.T) {
+	webhook := &Webhook{
+		IsActive: true,
+		Events:   []WebhookEvent{WebhookEventUpdateOpened},
+	}
+
+	require.True(t, webhook.IsSubscribed(WebhookEventUpdateOpened))
+	require.False(t, webhook.IsSubscribed(WebhookEventIntegrationSyncFailed))
+
+	webhook.IsActive = false
+
+	require.False(t, webhook.IsSubscribed(WebhookEventUpdateOpened))
+}
+
+func TestNewWebhookDeliveries(t *testing.T) {
+	workspaceID := uuid.New()
+
+	webhooks := []Webhook{
+		{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceID,
+			IsActive:    true,
+			Events:      []WebhookEvent{WebhookEventUpdateOpened},
+		},
+		{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceID,
+			IsActive:    true,
+			Events:      []WebhookEvent{WebhookEventDeckViewerSessionCreated},
+		},
+	}
+
+	deliveries, err := NewWebhookDeliveries(NewReferenceGenerator(), webhooks,
+		WebhookEventUpdateOpened, map[string]string{"update_reference": "update_123"})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	delivery := deliveries[0]
+
+	require.Equal(t, webhooks[0].ID, delivery.WebhookID)
+	require.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	require.NotEqual(t, uuid.Nil, delivery.ID)
+	require.NotNil(t, delivery.NextAttemptAt)
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+
+	require.Equal(t, delivery.Reference, payload.ID)
+	require.Equal(t, WebhookEventUpdateOpened, payload.Event)
+	require.Equal(t, workspaceID, payload.WorkspaceID)
+	require.Equal(t, map[string]any{"update_reference": "update_123"}, payload.Data)
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	now := time.Now()
+
+	delivery := &WebhookDelivery{
+		Status: WebhookDeliveryStatusPending,
+	}
+
+	delivery.RecordAttempt(http.StatusInternalServerError, "oops", nil, now)
+
+	require.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, "endpoint responded with status code 500", delivery.LastError)
+	require.Equal(t, now.Add(time.Minute), *delivery.NextAttemptAt)
+	require.Empty(t, delivery.ResponseBody)
+
+	delivery.RecordAttempt(0, "", errors.New("connection refused"), now)
+
+	require.Equal(t, "connection refused", delivery.LastError)
+	require.Equal(t, now.Add(time.Minute*5), *delivery.NextAttemptAt)
+
+	for delivery.Attempts < MaxWebhookAttempts {
+		delivery.RecordAttempt(http.StatusBadGateway, "", nil, now)
+	}
+
+	require.Equal(t, WebhookDeliveryStatusFailed, delivery.Status)
+	require.Nil(t, delivery.NextAttemptAt)
+
+	delivery.Redeliver(now)
+
+	require.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	require.Zero(t, delivery.Attempts)
+
+	delivery.RecordAttempt(http.StatusNoContent, "ok", nil, now)
+
+	require.Equal(t, WebhookDeliveryStatusSucceeded, delivery.Status)
+	require.Equal(t, "ok", delivery.ResponseBody)
+	require.Empty(t, delivery.LastError)
+	require.Nil(t, delivery.NextAttemptAt)
+	require.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestWebhookSignature(t *testing.T) {
+	now := time.Now()
+	payload := []byte(`{"event":"update_opened"}`)
+
+	signature := SignWebhookPayload("whsec_secret", now, payload)
+
+	require.NoError(t, VerifyWebhookSignature("whsec_secret", signature, payload, time.Minute, now))
+
+	require.Error(t, VerifyWebhookSignature("whsec_other", signature, payload, time.Minute, now))
+	require.Error(t, VerifyWebhookSignature("whsec_secret", signature, []byte(`{}`), time.Minute, now))
+	require.Error(t, VerifyWebhookSignature("whsec_secret", signature, payload, time.Minute, now.Add(time.Hour)))
+	require.Error(t, VerifyWebhookSignature("whsec_secret", "v1=abc", payload, time.Minute, now))
+}
@@ -14,6 +14,9 @@ var (
 )
 
 type WorkspaceMetadata struct {
+	// RequireTwoFactor blocks members without 2FA from accessing the
+	// workspace until they set it up
+	RequireTwoFactor bool `json:"require_two_factor,omitempty"`
 }
 
 type Workspace struct {